@@ -0,0 +1,73 @@
+package analyzer
+
+import "go/ast"
+
+// Identifiers of ACME clients issuing certificates, of the certificate
+// signing requests they submit, and of the settings choosing their account
+// and certificate keys. Automated issuance is where
+// key types are chosen in most deployments, and every ACME CA issues RSA and
+// ECDSA certificates only.
+var acmeIdentifiers = []QvFunction{
+	{"CertificateRequest", "crypto/x509"},
+	{"CreateCertificateRequest", "crypto/x509"},
+	{"Client", "golang.org/x/crypto/acme"},
+	{"Manager", "golang.org/x/crypto/acme/autocert"},
+	{"NewListener", "golang.org/x/crypto/acme/autocert"},
+	{"NewConfig", "github.com/go-acme/lego/v4/lego"},
+	{"GeneratePrivateKey", "github.com/go-acme/lego/v4/certcrypto"},
+	{"NewDefault", "github.com/caddyserver/certmagic"},
+	{"HTTPS", "github.com/caddyserver/certmagic"},
+	{"ManageSync", "github.com/caddyserver/certmagic"},
+	{"ManageAsync", "github.com/caddyserver/certmagic"},
+}
+
+// Fields of ACME clients choosing account and certificate keys.
+var acmeFields = []QvField{
+	{"Key", "Client", "golang.org/x/crypto/acme"},
+	{"KeyType", "CertificateConfig", "github.com/go-acme/lego/v4/lego"},
+	{"KeyType", "StandardKeyGenerator", "github.com/caddyserver/certmagic"},
+	{"KeySource", "Config", "github.com/caddyserver/certmagic"},
+}
+
+// Key type constants of ACME clients, with the classical algorithm they
+// select.
+var acmeKeyTypes = map[QvFunction]string{
+	{"RSA2048", "github.com/go-acme/lego/v4/certcrypto"}: "RSA-2048",
+	{"RSA3072", "github.com/go-acme/lego/v4/certcrypto"}: "RSA-3072",
+	{"RSA4096", "github.com/go-acme/lego/v4/certcrypto"}: "RSA-4096",
+	{"RSA8192", "github.com/go-acme/lego/v4/certcrypto"}: "RSA-8192",
+	{"EC256", "github.com/go-acme/lego/v4/certcrypto"}:   "ECDSA P-256",
+	{"EC384", "github.com/go-acme/lego/v4/certcrypto"}:   "ECDSA P-384",
+	{"RSA2048", "github.com/caddyserver/certmagic"}:      "RSA-2048",
+	{"RSA4096", "github.com/caddyserver/certmagic"}:      "RSA-4096",
+	{"RSA8192", "github.com/caddyserver/certmagic"}:      "RSA-8192",
+	{"P256", "github.com/caddyserver/certmagic"}:         "ECDSA P-256",
+	{"P384", "github.com/caddyserver/certmagic"}:         "ECDSA P-384",
+	{"ED25519", "github.com/caddyserver/certmagic"}:      "Ed25519",
+}
+
+// Reports the key type constants of ACME clients, naming the classical
+// algorithm the issued certificates will carry.
+func reportACMEKeyTypes(r *reporter, file *ast.File) {
+	reportConstants(r, file, acmeKeyTypes, ruleACMEKeyType, `key type "%s.%s" issues certificates with quantum-vulnerable %s keys`)
+}
+
+// Reports the uses of the package-level constants, variables and functions in
+// the table, with the rule and a format taking the package name, the name and
+// the classical algorithm the table maps it to.
+func reportConstants(r *reporter, file *ast.File, table map[QvFunction]string, rule Rule, format string) {
+	ast.Inspect(file, func(node ast.Node) bool {
+		selector, ok := node.(*ast.SelectorExpr)
+		if !ok {
+			return true
+		}
+		obj := r.pass.TypesInfo.Uses[selector.Sel]
+		if obj == nil || obj.Pkg() == nil {
+			return true
+		}
+		if algorithm, ok := table[QvFunction{obj.Name(), obj.Pkg().Path()}]; ok {
+			r.report(selector.Sel.Pos(), rule, format, obj.Pkg().Name(), obj.Name(), algorithm)
+		}
+		return true
+	})
+}
@@ -0,0 +1,80 @@
+package analyzer
+
+import (
+	"go/ast"
+	"go/token"
+	"go/types"
+	"slices"
+	"strings"
+)
+
+// Packages implementing symmetric, hash-based and password-hashing
+// cryptography, none of which Shor's algorithm breaks. Heuristic rules never
+// report findings inside calls into them, so a key derivation salt or an HMAC
+// key is not mistaken for classical key material. Patterns ending in "/..."
+// match every package below the prefix.
+var safePackages = []string{
+	"crypto/aes",
+	"crypto/cipher",
+	"crypto/hkdf",
+	"crypto/hmac",
+	"crypto/mlkem",
+	"crypto/pbkdf2",
+	"crypto/rand",
+	"crypto/sha256",
+	"crypto/sha3",
+	"crypto/sha512",
+	"crypto/subtle",
+	"hash/...",
+	"golang.org/x/crypto/argon2",
+	"golang.org/x/crypto/bcrypt",
+	"golang.org/x/crypto/blake2b",
+	"golang.org/x/crypto/blake2s",
+	"golang.org/x/crypto/chacha20",
+	"golang.org/x/crypto/chacha20poly1305",
+	"golang.org/x/crypto/hkdf",
+	"golang.org/x/crypto/pbkdf2",
+	"golang.org/x/crypto/scrypt",
+	"golang.org/x/crypto/sha3",
+	"github.com/pquerna/otp/...",
+	"github.com/hako/branca",
+	"github.com/essentialkaos/branca/...",
+	"gopkg.in/macaroon.v2",
+}
+
+// Reports whether the package path matches any of the patterns.
+func matchPackage(patterns []string, pkgPath string) bool {
+	return slices.ContainsFunc(patterns, func(pattern string) bool {
+		if prefix, ok := strings.CutSuffix(pattern, "/..."); ok {
+			return pkgPath == prefix || strings.HasPrefix(pkgPath, prefix+"/")
+		}
+		return pkgPath == pattern
+	})
+}
+
+// A span of source positions.
+type span struct {
+	pos, end token.Pos
+}
+
+// Returns the spans of the calls in the file to functions and methods of
+// packages matching the allowlist.
+func allowedCalls(info *types.Info, file *ast.File, allow []string) []span {
+	var spans []span
+	ast.Inspect(file, func(node ast.Node) bool {
+		call, ok := node.(*ast.CallExpr)
+		if !ok {
+			return true
+		}
+		selector, ok := call.Fun.(*ast.SelectorExpr)
+		if !ok {
+			return true
+		}
+		if obj := info.Uses[selector.Sel]; obj != nil && obj.Pkg() != nil && matchPackage(allow, obj.Pkg().Path()) {
+			spans = append(spans, span{call.Pos(), call.End()})
+			return false
+		}
+		return true
+	})
+	return spans
+}
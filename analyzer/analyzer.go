@@ -1,14 +1,23 @@
 // Package analyzer implements the static analysis tool for quantum-vulnerable
 // dependency detection.
+//
+// The rule tables of the package are never modified after initialization,
+// the registry of custom detectors is guarded by a mutex, and all state of a
+// pass is local to it, so passes can safely run concurrently across packages,
+// as they do under multichecker and gopls, even while detectors register.
 package analyzer
 
 import (
 	"flag"
 	"fmt"
 	"go/ast"
+	"go/types"
+	"reflect"
+	"runtime"
 	"slices"
 	"strconv"
 	"strings"
+	"sync"
 
 	"golang.org/x/tools/go/analysis"
 )
@@ -18,7 +27,6 @@ import (
 var ecImportPaths = []string{
 	"crypto/ecdh",
 	"crypto/ecdsa",
-	"crypto/ed25519",
 	"crypto/elliptic",
 }
 
@@ -64,106 +72,646 @@ var fnIdentifiers = []QvFunction{
 	{"GenerateKey", "crypto/dsa"},
 }
 
+// Identifiers of low-level crypto/elliptic functions and curve methods, most of
+// them deprecated since Go 1.21 in favor of crypto/ecdh.
+var ellipticIdentifiers = []QvFunction{
+	{"GenerateKey", "crypto/elliptic"},
+	{"Marshal", "crypto/elliptic"},
+	{"MarshalCompressed", "crypto/elliptic"},
+	{"Unmarshal", "crypto/elliptic"},
+	{"UnmarshalCompressed", "crypto/elliptic"},
+	{"ScalarMult", "crypto/elliptic"},
+	{"ScalarBaseMult", "crypto/elliptic"},
+	{"Add", "crypto/elliptic"},
+	{"Double", "crypto/elliptic"},
+}
+
+// Identifiers of functions, types and methods that make up SSH certificate
+// authority infrastructure. These are reported separately from ordinary SSH
+// keys, since rotating a CA key means reissuing every certificate it signed.
+var sshCAIdentifiers = []QvFunction{
+	{"NewCertSigner", "golang.org/x/crypto/ssh"},
+	{"CertChecker", "golang.org/x/crypto/ssh"},
+	{"SignCert", "golang.org/x/crypto/ssh"},
+}
+
+// Identifiers of SSH host key verification and agent functions. These accept
+// whatever key types the hosts and agents offer, which today means RSA, ECDSA
+// and Ed25519, so bastion and agent tooling must be upgraded alongside servers.
+var sshHostTrustAndAgentIdentifiers = []QvFunction{
+	{"New", "golang.org/x/crypto/ssh/knownhosts"},
+	{"NewClient", "golang.org/x/crypto/ssh/agent"},
+	{"List", "golang.org/x/crypto/ssh/agent"},
+	{"Signers", "golang.org/x/crypto/ssh/agent"},
+	{"ForwardToAgent", "golang.org/x/crypto/ssh/agent"},
+	{"ForwardToRemote", "golang.org/x/crypto/ssh/agent"},
+	{"RequestAgentForwarding", "golang.org/x/crypto/ssh/agent"},
+}
+
+// Identifiers of release signing and self-update verification helpers, all
+// relying on Ed25519, ECDSA or RSA signatures. The verification keys are baked
+// into shipped binaries, making them among the hardest keys to rotate.
+var softwareUpdateIdentifiers = []QvFunction{
+	{"NewRSAVerifier", "github.com/inconshreveable/go-update"},
+	{"NewECDSAVerifier", "github.com/inconshreveable/go-update"},
+	{"SetPublicKeyPEM", "github.com/inconshreveable/go-update"},
+	{"NewECDSAValidator", "github.com/creativeprojects/go-selfupdate"},
+	{"NewChecksumWithECDSAValidator", "github.com/creativeprojects/go-selfupdate"},
+	{"NewPGPValidator", "github.com/creativeprojects/go-selfupdate"},
+	{"NewChecksumWithPGPValidator", "github.com/creativeprojects/go-selfupdate"},
+	{"ECDSAValidator", "github.com/creativeprojects/go-selfupdate"},
+	{"ECDSAValidator", "github.com/rhysd/go-github-selfupdate/selfupdate"},
+	{"Sign", "aead.dev/minisign"},
+	{"Verify", "aead.dev/minisign"},
+	{"GenerateKey", "aead.dev/minisign"},
+	{"NewPublicKey", "github.com/jedisct1/go-minisign"},
+	{"NewPublicKeyFromFile", "github.com/jedisct1/go-minisign"},
+	{"DecodePublicKey", "github.com/jedisct1/go-minisign"},
+	{"Verify", "github.com/jedisct1/go-minisign"},
+	{"VerifyFromFile", "github.com/jedisct1/go-minisign"},
+}
+
+// Struct fields configuring update verification keys.
+var softwareUpdateFields = []QvField{
+	{"PublicKey", "Options", "github.com/inconshreveable/go-update"},
+	{"Verifier", "Options", "github.com/inconshreveable/go-update"},
+}
+
+// Identifiers of cloud SDK helpers that encrypt data client-side before it is
+// stored, wrapping the data keys with a key-encryption key. When that key is
+// RSA, every stored object is a harvest-now-decrypt-later target.
+var cloudEncryptionIdentifiers = []QvFunction{
+	{"NewEncryptionClient", "github.com/aws/aws-sdk-go/service/s3/s3crypto"},
+	{"NewEncryptionClientV2", "github.com/aws/aws-sdk-go/service/s3/s3crypto"},
+	{"NewDecryptionClientV2", "github.com/aws/aws-sdk-go/service/s3/s3crypto"},
+	{"NewKMSKeyGenerator", "github.com/aws/aws-sdk-go/service/s3/s3crypto"},
+	{"NewKMSContextKeyGenerator", "github.com/aws/aws-sdk-go/service/s3/s3crypto"},
+	{"New", "github.com/aws/amazon-s3-encryption-client-go/v3/client"},
+	{"NewKmsKeyring", "github.com/aws/amazon-s3-encryption-client-go/v3/materials"},
+	{"NewCryptographicMaterialsManager", "github.com/aws/amazon-s3-encryption-client-go/v3/materials"},
+}
+
+type QvField struct {
+	FieldName string
+	TypeName  string
+	Package   string
+}
+
+// Struct fields that hold customer-supplied encryption keys wrapped with RSA.
+var cloudEncryptionFields = []QvField{
+	{"RsaEncryptedKey", "CustomerEncryptionKey", "google.golang.org/api/compute/v1"},
+	{"RsaEncryptedKey", "CustomerEncryptionKey", "google.golang.org/api/compute/v0.beta"},
+}
+
+// An importRule reports imports of any of its packages.
+type importRule struct {
+	rule    Rule
+	paths   []string
+	message string
+}
+
+var importRules = []importRule{
+	{ruleEllipticCurveImport, ecImportPaths, "uses quantum-vulnerable elliptic curve cryptography"},
+	{ruleIntegerFactorizationImport, ifImportPaths, "uses quantum-vulnerable integer factorization cryptography"},
+	{ruleEd25519Verification, ed25519ImportPaths, "uses quantum-vulnerable Ed25519 signatures; plan for ML-DSA or SLH-DSA"},
+	{ruleFIPSMode, fipsImportPaths, "depends on FIPS 140 mode; " + fipsGuidance},
+}
+
+// A symbolRule reports uses of its functions, types, methods and struct fields.
+type symbolRule struct {
+	rule    Rule
+	symbols []QvFunction
+	fields  []QvField
+	message string
+	// Operation of every symbol, if not classified by their names.
+	operation Operation
+}
+
+// Returns the operation of the symbol with the given name.
+func (s symbolRule) symbolOperation(name string) Operation {
+	if s.operation != OperationUnknown {
+		return s.operation
+	}
+	return classifyOperation(name)
+}
+
+var symbolRules = []symbolRule{
+	{
+		rule:    ruleVulnerableFunction,
+		symbols: fnIdentifiers,
+		message: "implements quantum-vulnerable cryptography",
+	},
+	{
+		rule:    ruleVulnerableFunction,
+		symbols: ellipticIdentifiers,
+		message: "performs low-level quantum-vulnerable elliptic curve operations; use crypto/ecdh, and plan for ML-KEM",
+	},
+	{
+		rule:    ruleEd25519Signing,
+		symbols: ed25519SigningIdentifiers,
+		message: "generates keys or signs with quantum-vulnerable Ed25519; sign with ML-DSA or SLH-DSA, or a hybrid Ed25519 and ML-DSA signature while verifiers catch up",
+	},
+	{
+		rule:    ruleEd25519Verification,
+		symbols: ed25519VerificationIdentifiers,
+		message: "verifies quantum-vulnerable Ed25519 signatures; verification may have to outlive signing, but should also accept ML-DSA or hybrid signatures",
+	},
+	{
+		rule:    ruleSSHCertificateAuthority,
+		symbols: sshCAIdentifiers,
+		message: "is part of quantum-vulnerable SSH certificate authority infrastructure; CA keys are significantly harder to rotate than client keys",
+	},
+	{
+		rule:    ruleSSHHostTrustAndAgent,
+		symbols: sshHostTrustAndAgentIdentifiers,
+		message: "accepts quantum-vulnerable RSA, ECDSA and Ed25519 SSH keys; bastion and agent tooling must be upgraded alongside servers",
+	},
+	{
+		rule:    ruleFiniteFieldDH,
+		symbols: finiteFieldDHIdentifiers,
+		message: "implements quantum-vulnerable finite-field Diffie-Hellman or ElGamal; use ML-KEM instead",
+	},
+	{
+		rule:    ruleSoftwareUpdateSigning,
+		symbols: softwareUpdateIdentifiers,
+		fields:  softwareUpdateFields,
+		message: "signs or verifies software updates with quantum-vulnerable signatures; update verification keys ship inside binaries and are among the hardest keys to rotate",
+	},
+	{
+		rule:    ruleTUFRootOfTrust,
+		symbols: tufIdentifiers,
+		message: "handles TUF or Notary root-of-trust keys, Ed25519, ECDSA or RSA; clients pin the root keys until a root rotation signed by the current root keys reaches them, so rotation ceremonies need years of lead time",
+	},
+	{
+		rule:    ruleDocumentSigning,
+		symbols: documentSigningIdentifiers,
+		fields:  documentSigningFields,
+		message: documentSigningMessage,
+	},
+	{
+		rule:    ruleSAMLFederation,
+		symbols: samlIdentifiers,
+		message: "federates identities over SAML, whose assertions and metadata are signed with classical RSA or ECDSA keys; every partner of a federation has to migrate together, so inventory them early",
+	},
+	{
+		rule:    ruleSAMLFederation,
+		symbols: samlSignatureIdentifiers,
+		fields:  samlSignatureFields,
+		message: "configures a classical XML signature algorithm, such as RSA-SHA256, for SAML messages; federation partners have to support its post-quantum replacement before it can change",
+	},
+	{
+		rule:    ruleKubernetesPKI,
+		symbols: kubernetesPKIIdentifiers,
+		message: "creates or rotates Kubernetes certificates with classical ECDSA or RSA keys; cluster PKI has to migrate together with the API server and CA",
+	},
+	{
+		rule:    ruleTokenSigning,
+		symbols: tokenSigningIdentifiers,
+		message: "signs or verifies tokens with quantum-vulnerable Ed25519, ECDSA or Curve25519 keys; symmetric local-mode tokens are not affected",
+	},
+	{
+		rule:    ruleTLSSessionResumption,
+		symbols: tlsSessionIdentifiers,
+		fields:  tlsSessionFields,
+		message: "handles TLS session tickets or resumption state; resumed sessions skip the key exchange, so they keep the confidentiality of the original handshake and of the ticket keys through a post-quantum migration",
+	},
+	{
+		rule:    ruleExternalTrustAnchor,
+		symbols: externalTrustIdentifiers,
+		message: "verifies artifacts signed by another organization with classical keys; the counterparty has to migrate its signing keys with you",
+	},
+	{
+		rule:    ruleTLSKeyPair,
+		symbols: tlsKeyPairIdentifiers,
+		message: "loads a TLS certificate and private key pair, whose key is classical until the issuing CA offers post-quantum certificates",
+	},
+	{
+		rule:    ruleACMEKeyType,
+		symbols: acmeIdentifiers,
+		fields:  acmeFields,
+		message: "issues certificates through ACME with classical RSA or ECDSA keys; keep the key type configurable until CAs issue post-quantum certificates",
+	},
+	{
+		rule:    ruleTink,
+		symbols: tinkIdentifiers,
+		message: "uses a Tink keyset of quantum-vulnerable hybrid encryption or signatures; rotate the keyset to a post-quantum template",
+	},
+	{
+		rule:    ruleCryptoCommand,
+		symbols: gpgmeIdentifiers,
+		message: "runs a quantum-vulnerable OpenPGP operation through GnuPG",
+	},
+	{
+		rule:    ruleEd25519Signing,
+		symbols: libsodiumSigningIdentifiers,
+		message: "generates keys or signs with quantum-vulnerable Ed25519 through libsodium (crypto_sign); plan for ML-DSA or SLH-DSA signatures",
+	},
+	{
+		rule:    ruleEd25519Verification,
+		symbols: libsodiumVerificationIdentifiers,
+		message: "verifies quantum-vulnerable Ed25519 signatures through libsodium (crypto_sign)",
+	},
+	{
+		rule:    ruleVulnerableFunction,
+		symbols: libsodiumKeyExchangeIdentifiers,
+		message: "performs quantum-vulnerable X25519 key exchange through libsodium (crypto_box, crypto_kx); encrypted data is a harvest-now-decrypt-later risk",
+	},
+	{
+		rule:    ruleDNSSEC,
+		symbols: dnssecIdentifiers,
+		message: "signs or validates DNSSEC records with classical RSA, ECDSA or EdDSA keys; DNSSEC has no standardized post-quantum algorithm yet, so inventory it until one is assigned",
+	},
+	{
+		rule:    ruleCustomCertificateVerification,
+		symbols: signatureCheckIdentifiers,
+		fields:  verificationCallbackFields,
+		message: "customizes certificate chain verification; custom checks are where post-quantum and hybrid chains break, so they must accept ML-DSA and composite signatures",
+	},
+	{
+		rule:    ruleCustomCertificateVerification,
+		fields:  rootPoolFields,
+		message: "pins certificate verification to a custom root pool, which has to gain post-quantum roots alongside the classical ones before peers move to post-quantum chains",
+	},
+	{
+		rule:    rulePIVKey,
+		symbols: pivIdentifiers,
+		message: "generates or uses a classical RSA or ECDSA key in a PIV smart card slot, such as a YubiKey's; card-bound keys can only move to post-quantum algorithms with new devices",
+	},
+	{
+		rule:    ruleE2EE,
+		symbols: e2eeIdentifiers,
+		message: "sets up or runs a double-ratchet end-to-end encryption session keyed with X25519 and Ed25519; recorded messages are a harvest-now-decrypt-later target until the protocol moves to PQXDH-style post-quantum key agreement",
+	},
+	{
+		rule:    rulePairing,
+		symbols: pairingIdentifiers,
+		message: "signs, verifies or aggregates BLS signatures or computes elliptic curve pairings, which are quantum-vulnerable like every discrete-logarithm scheme; there is no drop-in post-quantum replacement with aggregation, so protocols built on it, such as Ethereum consensus, need protocol-level migration",
+	},
+	{
+		rule:    ruleMPC,
+		symbols: mpcIdentifiers,
+		message: "runs a threshold signing or other multi-party computation protocol built on elliptic curve discrete logarithms and Paillier proofs; there is no drop-in post-quantum replacement, so the protocol needs specialist cryptographic review",
+	},
+	{
+		rule:    ruleMPC,
+		symbols: paillierIdentifiers,
+		message: "uses Paillier homomorphic encryption, built on integer factorization; there is no drop-in post-quantum additively homomorphic scheme, so its use needs specialist cryptographic review",
+	},
+	{
+		rule:    ruleWebPush,
+		symbols: webPushIdentifiers,
+		fields:  webPushFields,
+		message: "generates or uses the ECDSA P-256 keys push notifications are sent with, or encrypts their payloads with ECDH P-256; VAPID and APNs keys are registered with third-party push services, so rotating them depends on those services",
+	},
+	{
+		rule:    ruleServiceIdentity,
+		symbols: meshIdentifiers,
+		message: "fetches or configures service mesh mTLS identity with classical X.509 SVIDs, through SPIFFE, Envoy or Istio; " + meshMessage,
+	},
+	{
+		rule:    ruleCloudEncryption,
+		symbols: cloudEncryptionIdentifiers,
+		fields:  cloudEncryptionFields,
+		message: "wraps client-side encryption keys that are quantum-vulnerable when wrapped with RSA; stored data is a harvest-now-decrypt-later risk",
+	},
+}
+
+// Options configures an analyzer created with New.
+type Options struct {
+	// Import path patterns of packages that are never reported, extending
+	// the built-in allowlist of symmetric and hash-based crypto packages.
+	// Patterns ending in "/..." match every package below the prefix. Safe
+	// internal wrappers can be listed here so neither they nor calls into
+	// them are ever reported.
+	Allow []string
+
+	// Import path patterns of the approved crypto providers, such as
+	// "crypto/..." and "github.com/cloudflare/circl/...". If set, every
+	// import of a crypto implementation outside them is reported, whether or
+	// not it is quantum-vulnerable.
+	Providers []string
+
+	// Functions of internal crypto wrapper packages reported like the
+	// quantum-vulnerable functions they wrap. Wrapper packages must not be
+	// allowlisted, as calls into allowlisted packages are never reported.
+	Wrappers []Wrapper
+
+	// Wording of the messages of findings. If nil, findings keep the
+	// messages of their rules.
+	Messages *Catalog
+
+	// Whether every file with findings gets a diagnostic on its package
+	// clause counting them by severity, listing them as related information,
+	// for editors to show the risk of a file at a glance.
+	FileSummaries bool
+}
+
+// New returns an analyzer configured by opts.
+func New(opts Options) *analysis.Analyzer {
+	opts.Allow = slices.Concat(safePackages, opts.Allow)
+	a := PqcAnalyzer
+	a.Run = func(pass *analysis.Pass) (any, error) {
+		return analyze(pass, opts)
+	}
+	return &a
+}
+
 func pqcAnalyze(pass *analysis.Pass) (any, error) {
+	return analyze(pass, Options{Allow: safePackages, FileSummaries: fileSummaries})
+}
+
+func analyze(pass *analysis.Pass, opts Options) (any, error) {
+	r := newReporter(pass, opts.Allow)
+	r.messages = opts.Messages
+	if matchPackage(opts.Allow, pass.Pkg.Path()) {
+		return r.result, nil
+	}
+	symbolRules := slices.Concat(symbolRules, wrapperSymbolRules(opts.Wrappers))
+	reportIgnoredCryptoVariants(r)
+	reportClassicalKeyDerivation(r)
+	reportIdPTokenSigning(r)
+	reportTLSToolchainDefaults(r)
+	reportUnanalyzableCrypto(r)
+	reportDeterministicKeyGeneration(r)
+	if err := reportDetectors(r); err != nil {
+		return nil, err
+	}
+	var files []*ast.File
 	for _, file := range pass.Files {
-		if file.Name != nil && strings.HasSuffix(file.Name.Name, "_test") {
-			continue
+		if file.Name == nil || !strings.HasSuffix(file.Name.Name, "_test") {
+			files = append(files, file)
 		}
-		for _, currImport := range file.Imports {
-			importPath, err := strconv.Unquote(currImport.Path.Value)
-			if err != nil {
-				return nil, fmt.Errorf("failed to analyze package %s: %s", currImport.Path.Value, err.Error())
-			}
-			if slices.Contains(ecImportPaths, importPath) {
-				pass.Reportf(currImport.Pos(), "%s uses quantum-vulnerable elliptic curve cryptography", currImport.Path.Value)
-			}
-			if slices.Contains(ifImportPaths, importPath) {
-				pass.Reportf(currImport.Pos(), "%s uses quantum-vulnerable integer factorization cryptography", currImport.Path.Value)
+	}
+	// Files are analyzed by a pool of workers, and their findings reported
+	// in file order, so packages with many large files are analyzed faster
+	// without changing the order of diagnostics.
+	fileReporters := make([]*reporter, len(files))
+	errs := make([]error, len(files))
+	next := make(chan int)
+	var wg sync.WaitGroup
+	for range min(runtime.GOMAXPROCS(0), len(files)) {
+		wg.Go(func() {
+			for i := range next {
+				fileReporters[i] = r.forFile()
+				errs[i] = analyzeFile(fileReporters[i], files[i], symbolRules, opts)
 			}
+		})
+	}
+	for i := range files {
+		next <- i
+	}
+	close(next)
+	wg.Wait()
+	for i := range files {
+		if errs[i] != nil {
+			return nil, errs[i]
+		}
+		r.merge(fileReporters[i])
+		if opts.FileSummaries {
+			reportFileSummary(pass, files[i], fileReporters[i].result.Findings)
 		}
+	}
 
-		for _, decl := range file.Decls {
-			funcDecl, ok := decl.(*ast.FuncDecl)
-			if !ok {
-				continue
-			}
+	return r.result, nil
+}
 
-			if funcDecl.Body == nil {
+// Analyzes a file of the pass with the per-file rules and the symbol rules.
+func analyzeFile(r *reporter, file *ast.File, symbolRules []symbolRule, opts Options) error {
+	pass := r.pass
+	for _, currImport := range file.Imports {
+		importPath, err := strconv.Unquote(currImport.Path.Value)
+		if err != nil {
+			return fmt.Errorf("failed to analyze package %s: %s", currImport.Path.Value, err.Error())
+		}
+		// Key exchange only configuring TLS is downgraded, as TLS is
+		// already hybrid.
+		tlsOnly := importPath == "crypto/ecdh" && tlsHybridByDefault(pass, file) && tlsOnlyECDH(pass.TypesInfo, file)
+		if tlsOnly {
+			r.report(currImport.Pos(), ruleTLSOnlyECDH, "%s is only used to configure crypto/tls key exchange, which negotiates hybrid post-quantum X25519MLKEM768 key exchange by default since %s; its classical key exchange is a fallback for peers without ML-KEM support", currImport.Path.Value, goVersionPQ)
+		}
+		for _, importRule := range importRules {
+			if tlsOnly && importRule.rule == ruleEllipticCurveImport {
 				continue
 			}
+			if slices.Contains(importRule.paths, importPath) {
+				r.report(currImport.Pos(), importRule.rule, "%s %s", currImport.Path.Value, importRule.message)
+			}
+		}
+		if len(opts.Providers) > 0 && isCryptoImport(importPath) && !matchPackage(opts.Providers, importPath) {
+			r.report(currImport.Pos(), ruleCryptoProvider, "%s is a crypto implementation outside the approved providers", currImport.Path.Value)
+		}
+	}
 
-			for _, token := range funcDecl.Body.List {
-				switch tokenStmt := token.(type) {
-				case *ast.AssignStmt:
-					for _, expr := range tokenStmt.Rhs {
-						if callExpr, ok := expr.(*ast.CallExpr); ok {
-							if selector, ok := callExpr.Fun.(*ast.SelectorExpr); ok {
-								if localImportName, ok := selector.X.(*ast.Ident); ok {
-									if fnName, vulnerable := vulnerableFunction(file.Imports, localImportName.Name, selector.Sel); vulnerable {
-										pass.Reportf(selector.X.Pos(), `function "%s" implements quantum-vulnerable cryptography`, fnName)
-									}
-								}
+	reportFIPSConfiguration(r, file)
+	reportClassicalOIDs(r, file)
+	reportFiniteFieldDH(r, file)
+	reportKeyLogging(r, file)
+	reportGenericInstantiations(r, file)
+	reportAlgorithmDispatch(r, file)
+	reportGoVersion(r, file)
+	reportCustomAsymmetric(r, file)
+	reportKeyPairFiles(r, file)
+	reportSSHCAKeys(r, file)
+	reportACMEKeyTypes(r, file)
+	reportTinkTypeURLs(r, file)
+	reportTLSVersions(r, file)
+	reportCryptoCommands(r, file)
+	reportExportedKeyTypes(r, file)
+	reportBuildGatedCrypto(r, file)
+	reportUnhandledCertificateAlgorithms(r, file)
+	reportJWKs(r, file)
+	reportTPMKeyTemplates(r, file)
+	reportDNSSECAlgorithms(r, file)
+	reportCertificateAlgorithmChecks(r, file)
+	reportPIVKeyAlgorithms(r, file)
+	reportCertManagerKeyAlgorithms(r, file)
+	reportKeySerialization(r, file)
+	reportCustomSigning(r, file)
+	reportWeakCurves(r, file)
+	reportPKCS12Bundles(r, file)
+	reportSVIDMinting(r, file)
+	reportRevocation(r, file)
+	reportSSHTunnelKeys(r, file)
+	reportImportedKeyMaterial(r, file)
+	reportAlgorithmEnums(r, file, opts.Wrappers)
+	reportDatabaseEncryption(r, file)
+
+	for _, decl := range file.Decls {
+		funcDecl, ok := decl.(*ast.FuncDecl)
+		if !ok {
+			continue
+		}
+
+		if funcDecl.Body == nil {
+			continue
+		}
+
+		ast.Inspect(funcDecl.Body, func(node ast.Node) bool {
+			switch node := node.(type) {
+			case *ast.CallExpr:
+				selector, ok := node.Fun.(*ast.SelectorExpr)
+				if !ok {
+					return true
+				}
+				for _, symbolRule := range symbolRules {
+					if localImportName, ok := selector.X.(*ast.Ident); ok {
+						if fnName, vulnerable := vulnerableFunction(pass.TypesInfo, localImportName, selector.Sel, symbolRule.symbols); vulnerable {
+							r.reportOperation(selector.X.Pos(), symbolRule.rule, symbolRule.symbolOperation(selector.Sel.Name), `function "%s" %s`, fnName, symbolRule.message)
+						}
+					}
+					if methodName, vulnerable := vulnerableMethod(pass.TypesInfo, selector, symbolRule.symbols); vulnerable {
+						r.reportOperation(selector.Sel.Pos(), symbolRule.rule, symbolRule.symbolOperation(selector.Sel.Name), `method "%s" %s`, methodName, symbolRule.message)
+					}
+				}
+			case *ast.AssignStmt:
+				for _, lhs := range node.Lhs {
+					selector, ok := lhs.(*ast.SelectorExpr)
+					if !ok {
+						continue
+					}
+					for _, symbolRule := range symbolRules {
+						if fieldName, vulnerable := vulnerableFieldSelection(pass.TypesInfo, selector, symbolRule.fields); vulnerable {
+							r.report(selector.Sel.Pos(), symbolRule.rule, `field "%s" %s`, fieldName, symbolRule.message)
+						}
+					}
+				}
+			case *ast.CompositeLit:
+				for _, symbolRule := range symbolRules {
+					for _, elt := range node.Elts {
+						if keyValue, ok := elt.(*ast.KeyValueExpr); ok {
+							if fieldName, vulnerable := vulnerableField(pass.TypesInfo, node, keyValue.Key, symbolRule.fields); vulnerable {
+								r.report(keyValue.Key.Pos(), symbolRule.rule, `field "%s" %s`, fieldName, symbolRule.message)
 							}
 						}
 					}
-				case *ast.ExprStmt:
-					if callExpr, ok := tokenStmt.X.(*ast.CallExpr); ok {
-						if selector, ok := callExpr.Fun.(*ast.SelectorExpr); ok {
-							if localImportName, ok := selector.X.(*ast.Ident); ok {
-								if fnName, vulnerable := vulnerableFunction(file.Imports, localImportName.Name, selector.Sel); vulnerable {
-									pass.Reportf(selector.X.Pos(), `function "%s" implements quantum-vulnerable cryptography`, fnName)
-								}
+
+					if selector, ok := node.Type.(*ast.SelectorExpr); ok {
+						if localImportName, ok := selector.X.(*ast.Ident); ok {
+							if typeName, vulnerable := vulnerableFunction(pass.TypesInfo, localImportName, selector.Sel, symbolRule.symbols); vulnerable {
+								r.reportOperation(selector.X.Pos(), symbolRule.rule, symbolRule.symbolOperation(selector.Sel.Name), `type "%s" %s`, typeName, symbolRule.message)
 							}
 						}
 					}
 				}
 			}
-		}
+			return true
+		})
 	}
-
-	return nil, nil
+	return nil
 }
 
-func getLocalImportName(importSpec *ast.ImportSpec) string {
-	if importSpec.Name != nil {
-		return importSpec.Name.Name
+// Returns the name of the function (including its package specifier) if true.
+func vulnerableFunction(info *types.Info, localImportName *ast.Ident, fn ast.Expr, identifiers []QvFunction) (string, bool) {
+	pkgName, ok := info.Uses[localImportName].(*types.PkgName)
+	if !ok {
+		return "", false
 	}
 
-	importPath, _ := strconv.Unquote(importSpec.Path.Value)
-	importPathComponents := strings.Split(importPath, "/")
-	return importPathComponents[len(importPathComponents)-1]
-}
+	importPath := pkgName.Imported().Path()
+	fnIdent, ok := fn.(*ast.Ident)
+	if !ok {
+		return "", false
+	}
+	functionName := fnIdent.Name
 
-// Returns the name of the function (including its package specifier) if true.
-func vulnerableFunction(imports []*ast.ImportSpec, localImportName string, fn ast.Expr) (string, bool) {
-	idx := slices.IndexFunc(imports, func(importSpec *ast.ImportSpec) bool {
-		return getLocalImportName(importSpec) == localImportName
+	idx := slices.IndexFunc(identifiers, func(qvFunc QvFunction) bool {
+		return qvFunc.FnName == functionName && importPath == qvFunc.Package
 	})
 
 	if idx == -1 {
 		return "", false
 	}
 
-	importPath, err := strconv.Unquote(imports[idx].Path.Value)
-	if err != nil {
+	return localImportName.Name + "." + functionName, true
+}
+
+// Returns the name of the method (including its receiver type) if true.
+func vulnerableMethod(info *types.Info, selector *ast.SelectorExpr, identifiers []QvFunction) (string, bool) {
+	selection, ok := info.Selections[selector]
+	if !ok || selection.Kind() != types.MethodVal {
 		return "", false
 	}
-	importName := getLocalImportName(imports[idx])
-	fnIdent, ok := fn.(*ast.Ident)
-	if !ok {
+
+	method := selection.Obj()
+	if method.Pkg() == nil {
 		return "", false
 	}
-	functionName := fnIdent.Name
 
-	idx = slices.IndexFunc(fnIdentifiers, func(qvFunc QvFunction) bool {
-		return qvFunc.FnName == functionName && importPath == qvFunc.Package
+	idx := slices.IndexFunc(identifiers, func(qvFunc QvFunction) bool {
+		return qvFunc.FnName == method.Name() && qvFunc.Package == method.Pkg().Path()
 	})
 
 	if idx == -1 {
 		return "", false
 	}
 
-	return importName + "." + functionName, fnIdentifiers[idx].FnName == functionName && fnIdentifiers[idx].Package == importPath
+	recv := types.Unalias(selection.Recv())
+	if ptr, ok := recv.(*types.Pointer); ok {
+		recv = ptr.Elem()
+	}
+	if named, ok := recv.(*types.Named); ok {
+		return method.Pkg().Name() + "." + named.Obj().Name() + "." + method.Name(), true
+	}
+	return method.Pkg().Name() + "." + method.Name(), true
+}
+
+// Returns the name of the field (including its struct type) if true.
+func vulnerableField(info *types.Info, lit *ast.CompositeLit, key ast.Expr, fields []QvField) (string, bool) {
+	keyIdent, ok := key.(*ast.Ident)
+	if !ok {
+		return "", false
+	}
+
+	litType := info.TypeOf(lit)
+	if litType == nil {
+		return "", false
+	}
+	if ptr, ok := types.Unalias(litType).(*types.Pointer); ok {
+		litType = ptr.Elem()
+	}
+	named, ok := types.Unalias(litType).(*types.Named)
+	if !ok || named.Obj().Pkg() == nil {
+		return "", false
+	}
+
+	typeName := named.Obj().Name()
+	pkg := named.Obj().Pkg()
+	if !slices.ContainsFunc(fields, func(qvField QvField) bool {
+		return qvField.FieldName == keyIdent.Name && qvField.TypeName == typeName && qvField.Package == pkg.Path()
+	}) {
+		return "", false
+	}
+
+	return pkg.Name() + "." + typeName + "." + keyIdent.Name, true
+}
+
+// Returns the name of the field (including its struct type) if the selector
+// selects one of the fields.
+func vulnerableFieldSelection(info *types.Info, selector *ast.SelectorExpr, fields []QvField) (string, bool) {
+	selection, ok := info.Selections[selector]
+	if !ok || selection.Kind() != types.FieldVal {
+		return "", false
+	}
+
+	recv := types.Unalias(selection.Recv())
+	if ptr, ok := recv.(*types.Pointer); ok {
+		recv = ptr.Elem()
+	}
+	named, ok := types.Unalias(recv).(*types.Named)
+	if !ok || named.Obj().Pkg() == nil {
+		return "", false
+	}
+
+	typeName := named.Obj().Name()
+	pkg := named.Obj().Pkg()
+	fieldName := selection.Obj().Name()
+	if !slices.ContainsFunc(fields, func(qvField QvField) bool {
+		return qvField.FieldName == fieldName && qvField.TypeName == typeName && qvField.Package == pkg.Path()
+	}) {
+		return "", false
+	}
+
+	return pkg.Name() + "." + typeName + "." + fieldName, true
 }
 
 var PqcAnalyzer = analysis.Analyzer{
@@ -174,6 +722,8 @@ var PqcAnalyzer = analysis.Analyzer{
 PQC Analyzer looks for instances of quantum-vulnerable functions/libraries being
 called/used in a Go codebase, warning of them and potentially suggesting alternatives.
 	`,
-	Flags: flag.FlagSet{},
-	Run:   pqcAnalyze,
+	Flags:      flag.FlagSet{},
+	Run:        pqcAnalyze,
+	Requires:   []*analysis.Analyzer{provenanceAnalyzer},
+	ResultType: reflect.TypeFor[*Result](),
 }
@@ -1,10 +1,20 @@
 package analyzer_test
 
 import (
+	"fmt"
+	"go/ast"
+	"go/types"
+	"maps"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
 	"testing"
 
 	"github.com/ahan-adelaide/pqc-analyzer/analyzer"
 	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/analysis/analysistest"
+	"golang.org/x/tools/go/types/typeutil"
 )
 
 func TestAnalyzer(t *testing.T) {
@@ -13,3 +23,607 @@ func TestAnalyzer(t *testing.T) {
 		t.Errorf("invalid analyzer: %s", err.Error())
 	}
 }
+
+// Analyzes every test package at once, several times in parallel, as drivers
+// like gopls do. Run with -race to check the analyzer shares no mutable state
+// between passes.
+func TestConcurrentPasses(t *testing.T) {
+	entries, err := os.ReadDir(filepath.Join(analysistest.TestData(), "src"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Stubs of third-party packages live under their domain names, and some
+	// test packages expect diagnostics only from a configured analyzer.
+	configured := []string{"providers", "wrappers", "messages", "enums", "summary", "detector", "allowlist"}
+	var pkgs []string
+	for _, entry := range entries {
+		if entry.IsDir() && !strings.Contains(entry.Name(), ".") && !slices.Contains(configured, entry.Name()) {
+			pkgs = append(pkgs, entry.Name())
+		}
+	}
+
+	for i := range 4 {
+		t.Run(fmt.Sprint(i), func(t *testing.T) {
+			t.Parallel()
+			analysistest.Run(t, analysistest.TestData(), &analyzer.PqcAnalyzer, pkgs...)
+		})
+	}
+}
+
+func TestRuleDocs(t *testing.T) {
+	seen := make(map[string]bool)
+	for _, rule := range analyzer.Rules() {
+		if seen[rule.ID] {
+			t.Errorf("duplicate rule ID %s", rule.ID)
+		}
+		seen[rule.ID] = true
+
+		if rule.Detector != "" {
+			continue
+		}
+		if _, err := analyzer.RuleDoc(rule.ID); err != nil {
+			t.Errorf("rule %s (%s): %s", rule.ID, rule.Name, err.Error())
+		}
+	}
+}
+
+func TestSSHCertificateAuthority(t *testing.T) {
+	analysistest.Run(t, analysistest.TestData(), &analyzer.PqcAnalyzer, "sshca")
+}
+
+func TestFIPS(t *testing.T) {
+	analysistest.Run(t, analysistest.TestData(), &analyzer.PqcAnalyzer, "fips")
+}
+
+func TestCloudEncryption(t *testing.T) {
+	analysistest.Run(t, analysistest.TestData(), &analyzer.PqcAnalyzer, "cloudencryption")
+}
+
+func TestSSHHostTrustAndAgent(t *testing.T) {
+	analysistest.Run(t, analysistest.TestData(), &analyzer.PqcAnalyzer, "sshagent")
+}
+
+func TestCompatAnnotation(t *testing.T) {
+	results := analysistest.Run(t, analysistest.TestData(), &analyzer.PqcAnalyzer, "compat")
+	for _, result := range results {
+		for _, finding := range result.Result.(*analyzer.Result).Findings {
+			if finding.Compat && finding.CompatReason != "partner gateway cannot verify ML-DSA yet" {
+				t.Errorf("unexpected compat reason %q", finding.CompatReason)
+			}
+		}
+	}
+}
+
+func TestIgnoreUntilAnnotation(t *testing.T) {
+	results := analysistest.Run(t, analysistest.TestData(), &analyzer.PqcAnalyzer, "ignoreuntil")
+	accepted := 0
+	for _, result := range results {
+		for _, finding := range result.Result.(*analyzer.Result).Findings {
+			if finding.AcceptedUntil == "" {
+				continue
+			}
+			accepted++
+			if finding.AcceptedUntil != "2026-06-30" || finding.AcceptedReason != "vendor SDK upgrade in Q2" {
+				t.Errorf("unexpected exception until %q: %q", finding.AcceptedUntil, finding.AcceptedReason)
+			}
+		}
+	}
+	if accepted != 1 {
+		t.Errorf("expected 1 finding inside an exception, got %d", accepted)
+	}
+}
+
+func TestClassicalOIDs(t *testing.T) {
+	analysistest.Run(t, analysistest.TestData(), &analyzer.PqcAnalyzer, "oids")
+}
+
+func TestFiniteFieldDH(t *testing.T) {
+	analysistest.Run(t, analysistest.TestData(), &analyzer.PqcAnalyzer, "ffdh")
+}
+
+func TestSoftwareUpdateSigning(t *testing.T) {
+	analysistest.Run(t, analysistest.TestData(), &analyzer.PqcAnalyzer, "selfupdate")
+}
+
+func TestPrivateKeyLogging(t *testing.T) {
+	analysistest.Run(t, analysistest.TestData(), &analyzer.PqcAnalyzer, "keylogging")
+}
+
+func TestEd25519(t *testing.T) {
+	results := analysistest.Run(t, analysistest.TestData(), &analyzer.PqcAnalyzer, "ed25519")
+	for _, result := range results {
+		for _, finding := range result.Result.(*analyzer.Result).Findings {
+			want := analyzer.SeverityHigh
+			if strings.Contains(finding.Diagnostic.Message, "Verify") || strings.HasPrefix(finding.Diagnostic.Message, `"crypto/ed25519"`) {
+				want = analyzer.SeverityLow
+			}
+			if finding.Rule.Severity != want {
+				t.Errorf("finding %q has severity %s, want %s", finding.Diagnostic.Message, finding.Rule.Severity, want)
+			}
+		}
+	}
+}
+
+func TestOperations(t *testing.T) {
+	results := analysistest.Run(t, analysistest.TestData(), &analyzer.PqcAnalyzer, "operations")
+	got := make(map[string]analyzer.Operation)
+	for _, result := range results {
+		for _, finding := range result.Result.(*analyzer.Result).Findings {
+			got[finding.Diagnostic.Message] = finding.Operation
+		}
+	}
+	want := map[string]analyzer.Operation{
+		`"crypto/rsa" uses quantum-vulnerable integer factorization cryptography`:           analyzer.OperationUnknown,
+		`function "rsa.SignPSS" implements quantum-vulnerable cryptography`:                 analyzer.OperationPrivate,
+		`function "x509.MarshalPKCS1PrivateKey" implements quantum-vulnerable cryptography`: analyzer.OperationPrivate,
+		`function "rsa.VerifyPSS" implements quantum-vulnerable cryptography`:               analyzer.OperationPublic,
+	}
+	for message, operation := range want {
+		if got[message] != operation {
+			t.Errorf("finding %q has operation %s, want %s", message, got[message], operation)
+		}
+	}
+}
+
+func TestAllowlist(t *testing.T) {
+	a := analyzer.New(analyzer.Options{Allow: []string{"allowlist/wrapper"}})
+	analysistest.Run(t, analysistest.TestData(), a, "allowlist/...")
+}
+
+func TestKubernetesPKI(t *testing.T) {
+	analysistest.Run(t, analysistest.TestData(), &analyzer.PqcAnalyzer, "kubernetespki")
+}
+
+func TestTokenSigning(t *testing.T) {
+	analysistest.Run(t, analysistest.TestData(), &analyzer.PqcAnalyzer, "tokens")
+}
+
+func TestCryptoProviders(t *testing.T) {
+	a := analyzer.New(analyzer.Options{Providers: []string{"crypto/...", "providers/internal/crypto"}})
+	analysistest.Run(t, analysistest.TestData(), a, "providers/...")
+}
+
+func TestTLSSessionResumption(t *testing.T) {
+	analysistest.Run(t, analysistest.TestData(), &analyzer.PqcAnalyzer, "tlssession")
+}
+
+func TestGenericInstantiations(t *testing.T) {
+	analysistest.Run(t, analysistest.TestData(), &analyzer.PqcAnalyzer, "generics")
+}
+
+func TestAlgorithmDispatch(t *testing.T) {
+	analysistest.Run(t, analysistest.TestData(), &analyzer.PqcAnalyzer, "dispatch")
+}
+
+func TestGoVersion(t *testing.T) {
+	analysistest.Run(t, filepath.Join(analysistest.TestData(), "goversion"), &analyzer.PqcAnalyzer, "./...")
+}
+
+// Only the package reaching crypto/tls through net/http reports, at the
+// package clause of its first file that is neither generated nor a test, and
+// nothing is reported once the go directive gets hybrid key exchange.
+func TestTLSToolchainDefaults(t *testing.T) {
+	analysistest.Run(t, filepath.Join(analysistest.TestData(), "tlsdefaults"), &analyzer.PqcAnalyzer, "./...")
+	analysistest.Run(t, filepath.Join(analysistest.TestData(), "tlshybrid"), &analyzer.PqcAnalyzer, "./...")
+}
+
+func TestCustomAsymmetric(t *testing.T) {
+	analysistest.Run(t, analysistest.TestData(), &analyzer.PqcAnalyzer, "bigint")
+}
+
+func TestExternalTrustAnchors(t *testing.T) {
+	analysistest.Run(t, analysistest.TestData(), &analyzer.PqcAnalyzer, "trustanchors")
+}
+
+func TestEllipticFunctions(t *testing.T) {
+	analysistest.Run(t, analysistest.TestData(), &analyzer.PqcAnalyzer, "elliptic")
+}
+
+func TestTLSKeyPair(t *testing.T) {
+	analysistest.Run(t, analysistest.TestData(), &analyzer.PqcAnalyzer, "keypair")
+}
+
+// Only relative paths staying inside the module are resolved.
+func TestResolvePath(t *testing.T) {
+	root := filepath.FromSlash("/src/module")
+	dir := filepath.Join(root, "server")
+	for _, tt := range []struct {
+		path, want string
+	}{
+		{"certs/server.key", filepath.Join(dir, "certs", "server.key")},
+		{"../certs/server.key", filepath.Join(root, "certs", "server.key")},
+		{"../../other/server.key", ""},
+		{"certs/../../../etc/ssl/private/server.key", ""},
+		{"../..", ""},
+		{filepath.FromSlash("/etc/ssl/private/server.key"), ""},
+	} {
+		name, ok := analyzer.ResolvePath(root, dir, tt.path)
+		if name != tt.want || ok != (tt.want != "") {
+			t.Errorf("ResolvePath(%q) = %q, %t, want %q", tt.path, name, ok, tt.want)
+		}
+	}
+}
+
+func TestWrappers(t *testing.T) {
+	wrapper, err := analyzer.ParseWrapper("wrappers/internal/crypto.Sign", "crypto/rsa.SignPSS")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if wrapper.Function != (analyzer.QvFunction{FnName: "Sign", Package: "wrappers/internal/crypto"}) {
+		t.Errorf("unexpected wrapper function %+v", wrapper.Function)
+	}
+	if _, err := analyzer.ParseWrapper("wrappers/internal/crypto.Hash", "crypto/sha256.Sum256"); err == nil {
+		t.Error("wrapper of a function the analyzer does not report was accepted")
+	}
+
+	a := analyzer.New(analyzer.Options{Wrappers: []analyzer.Wrapper{wrapper}})
+	results := analysistest.Run(t, analysistest.TestData(), a, "wrappers/...")
+	for _, result := range results {
+		for _, finding := range result.Result.(*analyzer.Result).Findings {
+			if finding.Operation != analyzer.OperationPrivate && strings.Contains(finding.Diagnostic.Message, "Sign") {
+				t.Errorf("finding %q has operation %s, want private", finding.Diagnostic.Message, finding.Operation)
+			}
+		}
+	}
+}
+
+func TestACME(t *testing.T) {
+	analysistest.Run(t, analysistest.TestData(), &analyzer.PqcAnalyzer, "acme")
+}
+
+func TestTink(t *testing.T) {
+	analysistest.Run(t, analysistest.TestData(), &analyzer.PqcAnalyzer, "tink")
+}
+
+func TestTLSVersions(t *testing.T) {
+	analysistest.Run(t, analysistest.TestData(), &analyzer.PqcAnalyzer, "tlsversion")
+}
+
+func TestCryptoCommands(t *testing.T) {
+	analysistest.Run(t, analysistest.TestData(), &analyzer.PqcAnalyzer, "commands")
+}
+
+func TestLibsodium(t *testing.T) {
+	analysistest.Run(t, analysistest.TestData(), &analyzer.PqcAnalyzer, "libsodium")
+}
+
+func TestExportedKeyTypes(t *testing.T) {
+	analysistest.Run(t, analysistest.TestData(), &analyzer.PqcAnalyzer, "apisurface/...")
+}
+
+func TestBuildGatedCrypto(t *testing.T) {
+	analysistest.Run(t, analysistest.TestData(), &analyzer.PqcAnalyzer, "buildtags")
+}
+
+func TestUnhandledCertificateAlgorithms(t *testing.T) {
+	analysistest.Run(t, analysistest.TestData(), &analyzer.PqcAnalyzer, "certswitch")
+}
+
+func TestJWK(t *testing.T) {
+	analysistest.Run(t, analysistest.TestData(), &analyzer.PqcAnalyzer, "jwk")
+}
+
+func TestTPM(t *testing.T) {
+	analysistest.Run(t, analysistest.TestData(), &analyzer.PqcAnalyzer, "tpm")
+}
+
+func TestDNSSEC(t *testing.T) {
+	analysistest.Run(t, analysistest.TestData(), &analyzer.PqcAnalyzer, "dnssec")
+}
+
+func TestCustomCertificateVerification(t *testing.T) {
+	analysistest.Run(t, analysistest.TestData(), &analyzer.PqcAnalyzer, "verification")
+}
+
+func TestPIV(t *testing.T) {
+	analysistest.Run(t, analysistest.TestData(), &analyzer.PqcAnalyzer, "piv")
+}
+
+func TestE2EE(t *testing.T) {
+	analysistest.Run(t, analysistest.TestData(), &analyzer.PqcAnalyzer, "e2ee")
+}
+
+func TestCertManager(t *testing.T) {
+	analysistest.Run(t, analysistest.TestData(), &analyzer.PqcAnalyzer, "certmanager")
+}
+
+func TestTLSOnlyECDH(t *testing.T) {
+	analysistest.Run(t, analysistest.TestData(), &analyzer.PqcAnalyzer, "tlsecdh")
+}
+
+func TestClassicalKeyDerivation(t *testing.T) {
+	analysistest.Run(t, analysistest.TestData(), &analyzer.PqcAnalyzer, "kdf")
+}
+
+func TestKeySerialization(t *testing.T) {
+	analysistest.Run(t, analysistest.TestData(), &analyzer.PqcAnalyzer, "serialization")
+}
+
+func TestCustomSigning(t *testing.T) {
+	analysistest.Run(t, analysistest.TestData(), &analyzer.PqcAnalyzer, "nonce")
+}
+
+func TestMessages(t *testing.T) {
+	for _, tt := range []struct {
+		templates    map[string]string
+		replacements map[string]string
+	}{
+		{map[string]string{"PQC999": "{{.Message}}"}, nil},
+		{map[string]string{"*": "{{.Message"}, nil},
+		{map[string]string{"*": "{{.Owner}}"}, nil},
+		{nil, map[string]string{"lattice": "ML-KEM"}},
+	} {
+		if _, err := analyzer.NewCatalog(tt.templates, tt.replacements, ""); err == nil {
+			t.Errorf("NewCatalog(%v, %v) succeeded, want error", tt.templates, tt.replacements)
+		}
+	}
+
+	catalog, err := analyzer.NewCatalog(map[string]string{
+		"*":                   "Der Import {{.Symbol}} ({{.Rule}}) muss bis {{.Deadline}} durch {{.Replacement}} ersetzt werden",
+		"vulnerable-function": "{{.Symbol}}: replace with ML-DSA by {{.Deadline}}",
+		"interop-debt":        "{{.Message}} (akzeptierte Interop-Schuld)",
+	}, map[string]string{"PQC002": "ML-KEM"}, "2030-12-31")
+	if err != nil {
+		t.Fatal(err)
+	}
+	a := analyzer.New(analyzer.Options{Messages: catalog})
+	analysistest.Run(t, analysistest.TestData(), a, "messages")
+
+	// Statuses are worded by the catalog, or by default.
+	if got, want := catalog.Status(analyzer.StatusAcceptedUntil, "rsa.SignPSS", "2026-06-30"), "rsa.SignPSS (accepted until 2026-06-30)"; got != want {
+		t.Errorf("got status %q, want %q", got, want)
+	}
+}
+
+func TestIdPTokenSigning(t *testing.T) {
+	analysistest.Run(t, analysistest.TestData(), &analyzer.PqcAnalyzer, "idp/...")
+}
+
+func TestWeakCurves(t *testing.T) {
+	analysistest.Run(t, analysistest.TestData(), &analyzer.PqcAnalyzer, "curves")
+}
+
+func TestWebPush(t *testing.T) {
+	analysistest.Run(t, analysistest.TestData(), &analyzer.PqcAnalyzer, "webpush")
+}
+
+// Files are analyzed concurrently, but their findings must be reported in
+// file order, each file's in the order its rules found them.
+func TestFileOrder(t *testing.T) {
+	results := analysistest.Run(t, analysistest.TestData(), &analyzer.PqcAnalyzer, "parallel")
+	for _, result := range results {
+		var got []string
+		for _, finding := range result.Result.(*analyzer.Result).Findings {
+			posn := result.Pass.Fset.Position(finding.Diagnostic.Pos)
+			got = append(got, fmt.Sprintf("%s:%d", filepath.Base(posn.Filename), posn.Line))
+		}
+		want := []string{"a.go:3", "a.go:6", "b.go:3", "b.go:6", "c.go:3", "c.go:6", "d.go:3", "d.go:6"}
+		if !slices.Equal(got, want) {
+			t.Errorf("got findings at %v, want %v", got, want)
+		}
+	}
+}
+
+func TestPKCS12(t *testing.T) {
+	analysistest.Run(t, analysistest.TestData(), &analyzer.PqcAnalyzer, "pkcs12")
+}
+
+func TestServiceMesh(t *testing.T) {
+	analysistest.Run(t, analysistest.TestData(), &analyzer.PqcAnalyzer, "mesh")
+}
+
+func TestUnanalyzableCrypto(t *testing.T) {
+	analysistest.Run(t, analysistest.TestData(), &analyzer.PqcAnalyzer, "blindspots", "blindspots/curve25519")
+}
+
+func TestTUFRootOfTrust(t *testing.T) {
+	analysistest.Run(t, analysistest.TestData(), &analyzer.PqcAnalyzer, "tuf")
+}
+
+func TestSSHTunnelKeys(t *testing.T) {
+	analysistest.Run(t, analysistest.TestData(), &analyzer.PqcAnalyzer, "sshtunnel", "sshtunnel/client")
+}
+
+// Call-site findings span the called function and relate to its import and
+// the generation of the keys passed to it.
+func TestRelatedInformation(t *testing.T) {
+	results := analysistest.Run(t, analysistest.TestData(), &analyzer.PqcAnalyzer, "related")
+	for _, result := range results {
+		got := make(map[string][]string)
+		for _, finding := range result.Result.(*analyzer.Result).Findings {
+			diag := finding.Diagnostic
+			start, end := result.Pass.Fset.Position(diag.Pos), result.Pass.Fset.Position(diag.End)
+			span := fmt.Sprintf("%d:%d-%d", start.Line, start.Column, end.Column)
+			for _, related := range diag.Related {
+				got[span] = append(got[span], fmt.Sprintf("%d: %s", result.Pass.Fset.Position(related.Pos).Line, related.Message))
+			}
+			if diag.Related == nil {
+				got[span] = nil
+			}
+		}
+		want := map[string][]string{
+			"6:2-14":  nil,
+			"14:9-20": {`6: "crypto/rsa" imported here`, "10: key generated here by rsa.GenerateKey"},
+			"18:9-22": {`6: "crypto/rsa" imported here`},
+		}
+		if !maps.EqualFunc(got, want, slices.Equal) {
+			t.Errorf("got spans and related information %v, want %v", got, want)
+		}
+	}
+}
+
+func TestDocumentSigning(t *testing.T) {
+	analysistest.Run(t, analysistest.TestData(), &analyzer.PqcAnalyzer, "documents")
+}
+
+func TestSAMLFederation(t *testing.T) {
+	analysistest.Run(t, analysistest.TestData(), &analyzer.PqcAnalyzer, "saml")
+}
+
+func TestImportedKeyMaterial(t *testing.T) {
+	analysistest.Run(t, analysistest.TestData(), &analyzer.PqcAnalyzer, "provenance")
+}
+
+func TestAlgorithmEnums(t *testing.T) {
+	var wrappers []analyzer.Wrapper
+	for _, function := range []string{"enums/internal/signing.Sign", "enums/internal/signing.SignWith"} {
+		wrapper, err := analyzer.ParseWrapper(function, "crypto/rsa.SignPSS")
+		if err != nil {
+			t.Fatal(err)
+		}
+		wrappers = append(wrappers, wrapper)
+	}
+	a := analyzer.New(analyzer.Options{Wrappers: wrappers})
+	analysistest.Run(t, analysistest.TestData(), a, "enums/...")
+}
+
+func TestRevocation(t *testing.T) {
+	analysistest.Run(t, analysistest.TestData(), &analyzer.PqcAnalyzer, "revocation")
+}
+
+func TestDatabaseEncryption(t *testing.T) {
+	analysistest.Run(t, analysistest.TestData(), &analyzer.PqcAnalyzer, "dbcrypt")
+}
+
+func TestPairing(t *testing.T) {
+	analysistest.Run(t, analysistest.TestData(), &analyzer.PqcAnalyzer, "pairing")
+}
+
+func TestMPC(t *testing.T) {
+	analysistest.Run(t, analysistest.TestData(), &analyzer.PqcAnalyzer, "mpc")
+}
+
+func TestDeterministicKeyGeneration(t *testing.T) {
+	analysistest.Run(t, analysistest.TestData(), &analyzer.PqcAnalyzer, "detkeys")
+}
+
+func TestHighVolume(t *testing.T) {
+	results := analysistest.Run(t, analysistest.TestData(), &analyzer.PqcAnalyzer, "highvolume")
+	var got []string
+	for _, result := range results {
+		for _, finding := range result.Result.(*analyzer.Result).Findings {
+			if finding.HighVolume {
+				posn := result.Pass.Fset.Position(finding.Diagnostic.Pos)
+				got = append(got, fmt.Sprintf("%d %s", posn.Line, finding.Rule.ID))
+			}
+		}
+	}
+	if want := []string{"18 PQC003", "34 PQC003", "46 PQC003"}; !slices.Equal(got, want) {
+		t.Errorf("got high-volume findings %q, want %q", got, want)
+	}
+}
+
+func TestFileSummaries(t *testing.T) {
+	a := analyzer.New(analyzer.Options{FileSummaries: true})
+	results := analysistest.Run(t, analysistest.TestData(), a, "summary")
+	for _, result := range results {
+		for _, diagnostic := range result.Diagnostics {
+			if diagnostic.Category == analyzer.SummaryCategory && len(diagnostic.Related) != 2 {
+				t.Errorf("summary lists %d findings, want 2", len(diagnostic.Related))
+			}
+		}
+		if findings := result.Result.(*analyzer.Result).Findings; len(findings) != 2 {
+			t.Errorf("result has %d findings, want 2", len(findings))
+		}
+	}
+}
+
+// Reports the calls of NewRSAKey of a stub internal crypto SDK.
+type sdkDetector struct{}
+
+var ruleSDKKey = analyzer.Rule{
+	ID:       "ACME001",
+	Name:     "acme-sdk-rsa-key",
+	Category: "internal-sdk",
+	Severity: analyzer.SeverityHigh,
+	Summary:  "RSA key generated with the internal crypto SDK",
+	HelpURI:  "https://docs.example.com/acme001",
+}
+
+func (sdkDetector) Name() string { return "acme-sdk" }
+
+func (sdkDetector) Rules() []analyzer.Rule { return []analyzer.Rule{ruleSDKKey} }
+
+func (sdkDetector) Match(pass *analysis.Pass) []analyzer.Finding {
+	var findings []analyzer.Finding
+	for _, file := range pass.Files {
+		ast.Inspect(file, func(n ast.Node) bool {
+			call, ok := n.(*ast.CallExpr)
+			if !ok {
+				return true
+			}
+			fn, ok := typeutil.Callee(pass.TypesInfo, call).(*types.Func)
+			if ok && fn.Pkg() != nil && fn.Pkg().Path() == "example.com/cryptosdk" && fn.Name() == "NewRSAKey" {
+				findings = append(findings, analyzer.Finding{
+					Diagnostic: analysis.Diagnostic{
+						Pos:     call.Pos(),
+						Message: "cryptosdk.NewRSAKey generates an RSA key",
+					},
+					Rule: ruleSDKKey,
+				})
+			}
+			return true
+		})
+	}
+	return findings
+}
+
+func TestDetector(t *testing.T) {
+	analyzer.RegisterDetector(sdkDetector{})
+	t.Cleanup(func() { analyzer.UnregisterDetector(sdkDetector{}.Name()) })
+
+	rule, ok := analyzer.LookupRule("ACME001")
+	if !ok || rule.Detector != "acme-sdk" || rule.DocURL() != "https://docs.example.com/acme001" {
+		t.Errorf("got rule %+v", rule)
+	}
+	if !slices.ContainsFunc(analyzer.Rules(), func(rule analyzer.Rule) bool { return rule.ID == "ACME001" }) {
+		t.Error("rules do not include ACME001")
+	}
+
+	results := analysistest.Run(t, analysistest.TestData(), &analyzer.PqcAnalyzer, "detector")
+	compat := 0
+	for _, result := range results {
+		for _, finding := range result.Result.(*analyzer.Result).Findings {
+			if finding.Diagnostic.Category != "ACME001" || finding.Diagnostic.URL != "https://docs.example.com/acme001" {
+				t.Errorf("unexpected diagnostic %+v", finding.Diagnostic)
+			}
+			if finding.Compat {
+				compat++
+			}
+		}
+	}
+	if compat != 1 {
+		t.Errorf("expected 1 compat finding, got %d", compat)
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Error("registering a detector twice did not panic")
+		}
+	}()
+	analyzer.RegisterDetector(sdkDetector{})
+}
+
+// Reports nothing, under two rules with the same ID.
+type duplicateDetector struct{}
+
+func (duplicateDetector) Name() string { return "duplicate" }
+
+func (duplicateDetector) Rules() []analyzer.Rule {
+	return []analyzer.Rule{{ID: "DUP001", Name: "first"}, {ID: "DUP001", Name: "second"}}
+}
+
+func (duplicateDetector) Match(pass *analysis.Pass) []analyzer.Finding { return nil }
+
+func TestDetectorDuplicateRules(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			analyzer.UnregisterDetector(duplicateDetector{}.Name())
+			t.Error("registering a detector repeating a rule ID did not panic")
+		}
+		if _, ok := analyzer.LookupRule("DUP001"); ok {
+			t.Error("rule of the rejected detector registered")
+		}
+	}()
+	analyzer.RegisterDetector(duplicateDetector{})
+}
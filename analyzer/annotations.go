@@ -0,0 +1,66 @@
+package analyzer
+
+import (
+	"go/ast"
+	"go/token"
+	"strings"
+)
+
+// Prefix of the directives that annotate declarations for the analyzer.
+const annotationPrefix = "//pqc:"
+
+// Annotation marking a function as a deliberate classical-compat shim, kept
+// for interoperability with systems that cannot use post-quantum cryptography
+// yet. Findings inside it are accepted interop debt rather than failures.
+const compatAnnotation = "compat"
+
+// Annotation accepting the findings inside a declaration until a date, such
+// as //pqc:ignore-until=2026-06-30 reason=vendor SDK upgrade in Q2. Findings
+// inside it are accepted until the end of that day and fail scans after it,
+// so exceptions are time-boxed in the code itself.
+const ignoreUntilAnnotation = "ignore-until"
+
+// An annotation is a //pqc: directive in the doc comment of a declaration. It
+// applies to every finding inside the declaration.
+type annotation struct {
+	name string
+	// Value following "=" after the directive name, such as a date.
+	value string
+	// Text following the directive name, such as a justification.
+	text     string
+	pos, end token.Pos
+}
+
+// Returns the annotations of the top-level declarations of the file.
+func fileAnnotations(file *ast.File) []annotation {
+	var annotations []annotation
+	for _, decl := range file.Decls {
+		var doc *ast.CommentGroup
+		switch decl := decl.(type) {
+		case *ast.FuncDecl:
+			doc = decl.Doc
+		case *ast.GenDecl:
+			doc = decl.Doc
+		}
+		if doc == nil {
+			continue
+		}
+
+		for _, comment := range doc.List {
+			directive, ok := strings.CutPrefix(comment.Text, annotationPrefix)
+			if !ok {
+				continue
+			}
+			name, text, _ := strings.Cut(directive, " ")
+			name, value, _ := strings.Cut(name, "=")
+			annotations = append(annotations, annotation{
+				name:  name,
+				value: value,
+				text:  strings.TrimSpace(text),
+				pos:   decl.Pos(),
+				end:   decl.End(),
+			})
+		}
+	}
+	return annotations
+}
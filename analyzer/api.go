@@ -0,0 +1,163 @@
+package analyzer
+
+import (
+	"go/ast"
+	"go/token"
+	"go/types"
+	"slices"
+	"strings"
+)
+
+// Reports exported functions, methods, types, fields and variables whose
+// signatures expose quantum-vulnerable key types, such as a function taking
+// an *rsa.PrivateKey. Replacing those key types is a breaking change for the
+// importers of the package, which needs semver planning of its own, unlike
+// internal call sites. Main and internal packages have no such importers.
+func reportExportedKeyTypes(r *reporter, file *ast.File) {
+	pkg := r.pass.Pkg
+	if pkg.Name() == "main" || isInternalPackage(pkg.Path()) {
+		return
+	}
+	if strings.HasSuffix(r.pass.Fset.File(file.Pos()).Name(), "_test.go") {
+		return
+	}
+	info := r.pass.TypesInfo
+	keyTypes := slices.Concat(privateKeyTypes, publicKeyTypes)
+	report := func(name *ast.Ident, kind string, t types.Type) {
+		if keyType, ok := exposedKeyType(t, keyTypes, nil); ok {
+			r.report(name.Pos(), ruleExportedKeyType, `exported %s "%s" exposes quantum-vulnerable key type %s; replacing it is a breaking API change`, kind, name.Name, types.TypeString(keyType, (*types.Package).Name))
+		}
+	}
+
+	for _, decl := range file.Decls {
+		switch decl := decl.(type) {
+		case *ast.FuncDecl:
+			fn, ok := info.Defs[decl.Name].(*types.Func)
+			if !ok || !fn.Exported() {
+				continue
+			}
+			kind := "function"
+			if recv := fn.Signature().Recv(); recv != nil {
+				if !isExportedReceiver(recv.Type()) {
+					continue
+				}
+				kind = "method"
+			}
+			report(decl.Name, kind, fn.Signature())
+		case *ast.GenDecl:
+			for _, spec := range decl.Specs {
+				switch spec := spec.(type) {
+				case *ast.TypeSpec:
+					if !spec.Name.IsExported() {
+						continue
+					}
+					reportExportedTypeSpec(info, spec, report)
+				case *ast.ValueSpec:
+					if decl.Tok != token.VAR {
+						continue
+					}
+					for _, name := range spec.Names {
+						if name.IsExported() {
+							report(name, "variable", info.TypeOf(name))
+						}
+					}
+				}
+			}
+		}
+	}
+}
+
+// Reports the exported fields and methods of an exported type, or the type
+// itself if it is defined over a key type.
+func reportExportedTypeSpec(info *types.Info, spec *ast.TypeSpec, report func(*ast.Ident, string, types.Type)) {
+	switch specType := spec.Type.(type) {
+	case *ast.StructType:
+		for _, field := range specType.Fields.List {
+			for _, name := range field.Names {
+				if name.IsExported() {
+					report(name, "field", info.TypeOf(field.Type))
+				}
+			}
+			if len(field.Names) == 0 {
+				// Embedded key types are exposed under their type name.
+				if ident := embeddedName(field.Type); ident != nil && ident.IsExported() {
+					report(ident, "field", info.TypeOf(field.Type))
+				}
+			}
+		}
+	case *ast.InterfaceType:
+		for _, method := range specType.Methods.List {
+			for _, name := range method.Names {
+				if name.IsExported() {
+					report(name, "method", info.TypeOf(method.Type))
+				}
+			}
+		}
+	default:
+		report(spec.Name, "type", info.TypeOf(spec.Type))
+	}
+}
+
+// Returns the name of an embedded field type.
+func embeddedName(expr ast.Expr) *ast.Ident {
+	switch expr := expr.(type) {
+	case *ast.StarExpr:
+		return embeddedName(expr.X)
+	case *ast.SelectorExpr:
+		return expr.Sel
+	case *ast.Ident:
+		return expr
+	}
+	return nil
+}
+
+// Returns the first key type in t, looking through pointers, containers and
+// function signatures, but not into other named types.
+func exposedKeyType(t types.Type, keyTypes []QvFunction, seen []types.Type) (types.Type, bool) {
+	if t == nil || slices.Contains(seen, t) {
+		return nil, false
+	}
+	if isNamedType(t, keyTypes) {
+		return t, true
+	}
+	seen = append(seen, t)
+	switch t := types.Unalias(t).(type) {
+	case *types.Pointer:
+		return exposedKeyType(t.Elem(), keyTypes, seen)
+	case *types.Slice:
+		return exposedKeyType(t.Elem(), keyTypes, seen)
+	case *types.Array:
+		return exposedKeyType(t.Elem(), keyTypes, seen)
+	case *types.Chan:
+		return exposedKeyType(t.Elem(), keyTypes, seen)
+	case *types.Map:
+		if keyType, ok := exposedKeyType(t.Key(), keyTypes, seen); ok {
+			return keyType, true
+		}
+		return exposedKeyType(t.Elem(), keyTypes, seen)
+	case *types.Signature:
+		for _, tuple := range []*types.Tuple{t.Params(), t.Results()} {
+			for v := range tuple.Variables() {
+				if keyType, ok := exposedKeyType(v.Type(), keyTypes, seen); ok {
+					return keyType, true
+				}
+			}
+		}
+	}
+	return nil, false
+}
+
+// Reports whether the receiver type of a method is exported.
+func isExportedReceiver(t types.Type) bool {
+	if ptr, ok := types.Unalias(t).(*types.Pointer); ok {
+		t = ptr.Elem()
+	}
+	named, ok := types.Unalias(t).(*types.Named)
+	return ok && named.Obj().Exported()
+}
+
+// Reports whether the import path is of an internal package, which cannot be
+// imported from outside its parent.
+func isInternalPackage(path string) bool {
+	return path == "internal" || strings.HasPrefix(path, "internal/") || strings.HasSuffix(path, "/internal") || strings.Contains(path, "/internal/")
+}
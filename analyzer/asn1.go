@@ -0,0 +1,114 @@
+package analyzer
+
+import (
+	"go/ast"
+	"go/constant"
+	"go/token"
+	"go/types"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// An oidArc is an ASN.1 object identifier arc under which every OID
+// identifies a quantum-vulnerable algorithm.
+type oidArc struct {
+	arc       []int
+	algorithm string
+}
+
+var classicalOIDArcs = []oidArc{
+	{[]int{1, 2, 840, 113549, 1, 1}, "RSA (PKCS #1)"},
+	{[]int{1, 2, 840, 10045}, "elliptic curve (ANSI X9.62)"},
+	{[]int{1, 2, 840, 10040, 4}, "DSA"},
+	{[]int{1, 3, 132, 0}, "elliptic curve (SEC 2)"},
+	{[]int{1, 3, 101, 110}, "X25519"},
+	{[]int{1, 3, 101, 111}, "X448"},
+	{[]int{1, 3, 101, 112}, "Ed25519"},
+	{[]int{1, 3, 101, 113}, "Ed448"},
+}
+
+// Reports encoding/asn1 object identifier literals and OID strings that
+// identify classical public-key algorithms. Hand-rolled ASN.1 handling is
+// exactly the code that breaks when certificates move to PQC algorithms.
+func reportClassicalOIDs(r *reporter, file *ast.File) {
+	ast.Inspect(file, func(node ast.Node) bool {
+		switch node := node.(type) {
+		case *ast.CompositeLit:
+			if !isObjectIdentifier(r, node) {
+				return true
+			}
+			var oid []int
+			for _, elt := range node.Elts {
+				value := r.pass.TypesInfo.Types[elt].Value
+				if value == nil || value.Kind() != constant.Int {
+					return true
+				}
+				arc, ok := constant.Int64Val(value)
+				if !ok {
+					return true
+				}
+				oid = append(oid, int(arc))
+			}
+			reportClassicalOID(r, node.Pos(), "object identifier", oid)
+		case *ast.BasicLit:
+			if node.Kind != token.STRING {
+				return true
+			}
+			value, err := strconv.Unquote(node.Value)
+			if err != nil {
+				return true
+			}
+			if oid, ok := parseOID(value); ok {
+				reportClassicalOID(r, node.Pos(), "OID string", oid)
+			}
+		}
+		return true
+	})
+}
+
+func reportClassicalOID(r *reporter, pos token.Pos, kind string, oid []int) {
+	for _, arc := range classicalOIDArcs {
+		if len(oid) >= len(arc.arc) && slices.Equal(oid[:len(arc.arc)], arc.arc) {
+			r.report(pos, ruleClassicalOID, "ASN.1 %s %s identifies quantum-vulnerable %s; hand-rolled ASN.1 handling breaks during PQC certificate migration", kind, formatOID(oid), arc.algorithm)
+			return
+		}
+	}
+}
+
+func isObjectIdentifier(r *reporter, lit *ast.CompositeLit) bool {
+	litType := r.pass.TypesInfo.TypeOf(lit)
+	if litType == nil {
+		return false
+	}
+	named, ok := types.Unalias(litType).(*types.Named)
+	if !ok || named.Obj().Pkg() == nil {
+		return false
+	}
+	return named.Obj().Pkg().Path() == "encoding/asn1" && named.Obj().Name() == "ObjectIdentifier"
+}
+
+// Parses a dotted OID string such as "1.2.840.113549.1.1.1".
+func parseOID(value string) ([]int, bool) {
+	components := strings.Split(value, ".")
+	if len(components) < 3 {
+		return nil, false
+	}
+	oid := make([]int, 0, len(components))
+	for _, component := range components {
+		arc, err := strconv.Atoi(component)
+		if err != nil || arc < 0 || strings.HasPrefix(component, "+") {
+			return nil, false
+		}
+		oid = append(oid, arc)
+	}
+	return oid, true
+}
+
+func formatOID(oid []int) string {
+	components := make([]string, len(oid))
+	for i, arc := range oid {
+		components[i] = strconv.Itoa(arc)
+	}
+	return strings.Join(components, ".")
+}
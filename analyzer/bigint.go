@@ -0,0 +1,149 @@
+package analyzer
+
+import (
+	"go/ast"
+	"go/token"
+	"go/types"
+	"slices"
+	"strconv"
+	"strings"
+
+	"golang.org/x/tools/go/analysis"
+)
+
+// Names of variables holding RSA parameters.
+var rsaParameterNames = []string{"n", "e", "d", "pub", "priv", "publicexponent", "privateexponent"}
+
+// Smallest integer constant, in bits, considered an asymmetric key parameter.
+const minAsymmetricConstantBits = 2048
+
+const customAsymmetricMessage = "possible custom asymmetric implementation, manual review required"
+
+// Reports hand-rolled asymmetric cryptography on math/big: RSA-style modular
+// exponentiation, 2048-bit or larger integer constants, elliptic curve point
+// arithmetic and Miller-Rabin prime generation.
+func reportCustomAsymmetric(r *reporter, file *ast.File) {
+	info := r.pass.TypesInfo
+	var loops []ast.Node
+	ast.Inspect(file, func(node ast.Node) bool {
+		switch node := node.(type) {
+		case *ast.ForStmt, *ast.RangeStmt:
+			loops = append(loops, node)
+		case *ast.FuncDecl:
+			if node.Body != nil && isPointArithmetic(info, node) {
+				r.report(node.Name.Pos(), ruleCustomAsymmetric, "function %s computes elliptic curve points on big.Int coordinates: %s", node.Name.Name, customAsymmetricMessage)
+			}
+		case *ast.CallExpr:
+			selector, ok := node.Fun.(*ast.SelectorExpr)
+			if !ok {
+				return true
+			}
+			if _, ok := vulnerableMethod(info, selector, []QvFunction{{"Exp", "math/big"}}); ok && len(node.Args) == 3 {
+				// Diffie-Hellman parameters are reported by reportFiniteFieldDH.
+				if !isDHParameter(node.Args[0]) && !isDHParameter(node.Args[2]) && (isRSAParameter(node.Args[1]) || isRSAParameter(node.Args[2])) {
+					r.report(selector.Sel.Pos(), ruleCustomAsymmetric, "modular exponentiation of RSA parameters: %s", customAsymmetricMessage)
+				}
+			}
+			if _, ok := vulnerableMethod(info, selector, []QvFunction{{"SetString", "math/big"}}); ok && len(node.Args) == 2 {
+				if bits, ok := integerConstantBits(info, node.Args[0], node.Args[1]); ok && bits >= minAsymmetricConstantBits {
+					r.report(node.Args[0].Pos(), ruleCustomAsymmetric, "%d-bit integer constant: %s", bits, customAsymmetricMessage)
+				}
+			}
+			if _, ok := vulnerableMethod(info, selector, []QvFunction{{"ProbablyPrime", "math/big"}}); ok && slices.ContainsFunc(loops, func(loop ast.Node) bool {
+				return loop.Pos() <= node.Pos() && node.End() <= loop.End()
+			}) {
+				r.report(selector.Sel.Pos(), ruleCustomAsymmetric, "Miller-Rabin primality testing in a loop generates primes: %s", customAsymmetricMessage)
+			}
+			if localImportName, ok := selector.X.(*ast.Ident); ok {
+				if _, ok := vulnerableFunction(info, localImportName, selector.Sel, []QvFunction{{"Prime", "crypto/rand"}}); ok {
+					// crypto/rand is allowlisted, so the call does not
+					// exclude the finding.
+					r.add(Finding{
+						Diagnostic: analysis.Diagnostic{
+							Pos:     selector.X.Pos(),
+							Message: "random prime generation: " + customAsymmetricMessage,
+						},
+						Rule:   ruleCustomAsymmetric,
+						inside: span{node.Pos(), node.End()},
+					})
+				}
+			}
+		}
+		return true
+	})
+}
+
+// Reports whether expr is a variable or field named like an RSA parameter.
+func isRSAParameter(expr ast.Expr) bool {
+	var name string
+	switch expr := expr.(type) {
+	case *ast.Ident:
+		name = expr.Name
+	case *ast.SelectorExpr:
+		name = expr.Sel.Name
+	default:
+		return false
+	}
+	return slices.Contains(rsaParameterNames, strings.ToLower(name))
+}
+
+// Returns the size in bits of the integer constant a big.Int is set to from
+// a string, unless it is a well-known Diffie-Hellman group prime.
+func integerConstantBits(info *types.Info, value, base ast.Expr) (int, bool) {
+	lit, ok := value.(*ast.BasicLit)
+	if !ok || lit.Kind != token.STRING {
+		return 0, false
+	}
+	digits, err := strconv.Unquote(lit.Value)
+	if err != nil {
+		return 0, false
+	}
+	digits = strings.ToUpper(strings.Join(strings.Fields(digits), ""))
+	if slices.ContainsFunc(dhGroupPrimePrefixes, func(prefix string) bool {
+		return strings.HasPrefix(digits, prefix)
+	}) {
+		return 0, false
+	}
+
+	tv, ok := info.Types[base]
+	if !ok || tv.Value == nil {
+		return 0, false
+	}
+	switch tv.Value.ExactString() {
+	case "16":
+		return len(digits) * 4, true
+	case "10":
+		// Each decimal digit carries log2(10) bits.
+		return len(digits) * 3322 / 1000, true
+	}
+	return 0, false
+}
+
+// Reports whether the function looks like elliptic curve point arithmetic: it
+// returns a pair of big.Int coordinates and computes modular inverses.
+func isPointArithmetic(info *types.Info, funcDecl *ast.FuncDecl) bool {
+	obj, ok := info.Defs[funcDecl.Name].(*types.Func)
+	if !ok {
+		return false
+	}
+	results := obj.Signature().Results()
+	if results.Len() != 2 {
+		return false
+	}
+	for v := range results.Variables() {
+		if !isNamedType(v.Type(), []QvFunction{{"Int", "math/big"}}) {
+			return false
+		}
+	}
+
+	found := false
+	ast.Inspect(funcDecl.Body, func(node ast.Node) bool {
+		if selector, ok := node.(*ast.SelectorExpr); ok {
+			if _, ok := vulnerableMethod(info, selector, []QvFunction{{"ModInverse", "math/big"}}); ok {
+				found = true
+			}
+		}
+		return !found
+	})
+	return found
+}
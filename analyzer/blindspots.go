@@ -0,0 +1,66 @@
+package analyzer
+
+import (
+	"path"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// Names suggesting cryptography when they make up a whole word of a file or
+// package name, alone or followed by digits, such as p256 in p256_asm_amd64.s
+// or curve25519 in curve25519.s, but not aes in aesthetic.go.
+var cryptoNames = []string{
+	"aes", "bls", "bls12381", "bn256", "chacha", "chacha20poly1305", "crypto", "curve", "ecc", "ecdh",
+	"ecdsa", "ed25519", "ed448", "fiat", "gcm", "kyber", "mlkem", "mldsa", "dilithium", "montgomery",
+	"nistec", "p224", "p256", "p384", "p521", "poly1305", "rsa", "salsa", "secp256k1", "secp256r1",
+	"secp384r1", "x25519", "x448", "xchacha",
+}
+
+const unanalyzableMessage = "unanalyzable custom crypto, manual review required"
+
+// Reports the crypto implementations of the package the analyzer cannot see
+// into: assembly files, including those of other architectures, and files
+// importing "unsafe", whose name or package name suggests cryptography.
+// Findings in them are missed, so they are reported instead of leaving the
+// report silently clean. Assembly files are reported at the package clause of
+// the first file of the package.
+func reportUnanalyzableCrypto(r *reporter) {
+	first, ok := firstFile(r.pass)
+	if !ok {
+		return
+	}
+	cryptoPackage := cryptoName(path.Base(r.pass.Pkg.Path())) || cryptoName(first.Name.Name)
+	for _, name := range slices.Concat(r.pass.OtherFiles, r.pass.IgnoredFiles) {
+		if ext := filepath.Ext(name); ext != ".s" && ext != ".S" {
+			continue
+		}
+		if cryptoPackage || cryptoName(filepath.Base(name)) {
+			r.report(first.Name.Pos(), ruleUnanalyzableCrypto, "assembly file %s implements what looks like cryptography the analyzer cannot inspect: %s", filepath.Base(name), unanalyzableMessage)
+		}
+	}
+	for _, file := range r.pass.Files {
+		filename := filepath.Base(r.pass.Fset.File(file.Pos()).Name())
+		if strings.HasSuffix(filename, "_test.go") || !cryptoPackage && !cryptoName(filename) {
+			continue
+		}
+		for _, currImport := range file.Imports {
+			if importPath, err := strconv.Unquote(currImport.Path.Value); err == nil && importPath == "unsafe" {
+				r.report(currImport.Pos(), ruleUnanalyzableCrypto, `file %s imports "unsafe" in what looks like a crypto implementation, whose memory accesses the analyzer cannot follow: %s`, filename, unanalyzableMessage)
+			}
+		}
+	}
+}
+
+// Reports whether a file or package name suggests cryptography: whether one
+// of its words, separated by underscores, dashes or dots, is a crypto name,
+// alone or followed by digits.
+func cryptoName(name string) bool {
+	words := strings.FieldsFunc(strings.ToLower(name), func(r rune) bool {
+		return r == '_' || r == '-' || r == '.'
+	})
+	return slices.ContainsFunc(words, func(word string) bool {
+		return slices.Contains(cryptoNames, word) || slices.Contains(cryptoNames, strings.TrimRight(word, "0123456789"))
+	})
+}
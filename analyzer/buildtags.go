@@ -0,0 +1,125 @@
+package analyzer
+
+import (
+	"go/ast"
+	"go/build/constraint"
+	"go/parser"
+	"go/token"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+
+	"golang.org/x/tools/go/analysis"
+)
+
+// Imports of quantum-vulnerable public-key algorithms.
+var classicalImportPaths = slices.Concat(ecImportPaths, ifImportPaths, ed25519ImportPaths)
+
+// Reports files built only under a build constraint that import
+// quantum-vulnerable crypto, such as crypto_legacy.go with
+// "//go:build legacy". Which builds ship classical crypto then depends on the
+// release configuration rather than the code.
+func reportBuildGatedCrypto(r *reporter, file *ast.File) {
+	expr := fileConstraint(file)
+	if expr == nil || onlyGoVersions(expr) {
+		return
+	}
+	if importPath, ok := classicalImport(file); ok {
+		r.report(file.Package, ruleBuildGatedCrypto, `file is only built when "%s" holds, and imports quantum-vulnerable "%s"; check which release builds include it`, expr, importPath)
+	}
+}
+
+// Reports the files of the package excluded from the analyzed build by their
+// build constraints that import quantum-vulnerable crypto, as alternative
+// crypto implementations no finding of this build covers. They are reported
+// at the package clause of the first file of the package.
+func reportIgnoredCryptoVariants(r *reporter) {
+	first, ok := firstFile(r.pass)
+	if !ok {
+		return
+	}
+	for _, name := range r.pass.IgnoredFiles {
+		if strings.HasSuffix(name, "_test.go") {
+			continue
+		}
+		src, err := r.pass.ReadFile(name)
+		if err != nil {
+			continue
+		}
+		file, err := parser.ParseFile(token.NewFileSet(), name, src, parser.ImportsOnly|parser.ParseComments)
+		if err != nil || file.Name.Name != first.Name.Name {
+			continue
+		}
+		importPath, ok := classicalImport(file)
+		if !ok {
+			continue
+		}
+		condition := "its file name"
+		if expr := fileConstraint(file); expr != nil {
+			if onlyGoVersions(expr) {
+				continue
+			}
+			condition = `"` + expr.String() + `"`
+		}
+		r.report(first.Name.Pos(), ruleBuildGatedCrypto, `crypto variant %s is excluded from this build by %s, and imports quantum-vulnerable "%s"; analyze the builds including it with -matrix`, filepath.Base(name), condition, importPath)
+	}
+}
+
+// Returns the first file of the package by file name, whose package clause
+// findings about the package as a whole are reported at.
+func firstFile(pass *analysis.Pass) (*ast.File, bool) {
+	if len(pass.Files) == 0 {
+		return nil, false
+	}
+	return slices.MinFunc(pass.Files, func(a, b *ast.File) int {
+		return strings.Compare(pass.Fset.File(a.Pos()).Name(), pass.Fset.File(b.Pos()).Name())
+	}), true
+}
+
+// Returns the //go:build constraint of the file, if any.
+func fileConstraint(file *ast.File) constraint.Expr {
+	for _, group := range file.Comments {
+		if group.Pos() > file.Package {
+			break
+		}
+		for _, comment := range group.List {
+			if !constraint.IsGoBuild(comment.Text) {
+				continue
+			}
+			expr, err := constraint.Parse(comment.Text)
+			if err != nil {
+				return nil
+			}
+			return expr
+		}
+	}
+	return nil
+}
+
+// Reports whether the constraint only selects Go versions, such as "go1.24",
+// which pick language features rather than alternative implementations.
+func onlyGoVersions(expr constraint.Expr) bool {
+	switch expr := expr.(type) {
+	case *constraint.TagExpr:
+		return constraint.GoVersion(expr) != ""
+	case *constraint.NotExpr:
+		return onlyGoVersions(expr.X)
+	case *constraint.AndExpr:
+		return onlyGoVersions(expr.X) && onlyGoVersions(expr.Y)
+	case *constraint.OrExpr:
+		return onlyGoVersions(expr.X) && onlyGoVersions(expr.Y)
+	}
+	return false
+}
+
+// Returns the first import of the file of a quantum-vulnerable algorithm.
+func classicalImport(file *ast.File) (string, bool) {
+	for _, currImport := range file.Imports {
+		importPath, err := strconv.Unquote(currImport.Path.Value)
+		if err == nil && slices.Contains(classicalImportPaths, importPath) {
+			return importPath, true
+		}
+	}
+	return "", false
+}
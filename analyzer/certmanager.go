@@ -0,0 +1,195 @@
+package analyzer
+
+import (
+	"go/ast"
+	"go/constant"
+	"go/token"
+	"go/types"
+	"reflect"
+	"regexp"
+	"strings"
+)
+
+// Private key algorithm constants of the cert-manager API, with the classical
+// algorithm they select.
+var certManagerKeyAlgorithms = map[QvFunction]string{
+	{"RSAKeyAlgorithm", "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"}:     "RSA",
+	{"ECDSAKeyAlgorithm", "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"}:   "ECDSA",
+	{"Ed25519KeyAlgorithm", "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"}: "Ed25519",
+	{"RSAKeyAlgorithm", "github.com/jetstack/cert-manager/pkg/apis/certmanager/v1"}:         "RSA",
+	{"ECDSAKeyAlgorithm", "github.com/jetstack/cert-manager/pkg/apis/certmanager/v1"}:       "ECDSA",
+	{"Ed25519KeyAlgorithm", "github.com/jetstack/cert-manager/pkg/apis/certmanager/v1"}:     "Ed25519",
+}
+
+// Values of the privateKey.algorithm field of cert-manager Certificates, by
+// lowercase value, with the classical algorithm they select.
+var certManagerAlgorithmValues = map[string]string{
+	"rsa":     "RSA",
+	"ecdsa":   "ECDSA",
+	"ed25519": "Ed25519",
+}
+
+// Matches the private key algorithm of a Certificate in a YAML template.
+var certManagerYAMLAlgorithm = regexp.MustCompile(`(?i)\balgorithm:\s*"?(rsa|ecdsa|ed25519)\b`)
+
+// Reports cert-manager Certificates requesting classical private keys: the
+// algorithm constants of the cert-manager API, the algorithm fields of Go
+// types mirroring its CertificatePrivateKey, and the Certificate resources
+// operators template as unstructured maps or YAML. The algorithm of these
+// resources is cluster certificate policy, which source review rarely sees.
+func reportCertManagerKeyAlgorithms(r *reporter, file *ast.File) {
+	info := r.pass.TypesInfo
+	ast.Inspect(file, func(node ast.Node) bool {
+		switch node := node.(type) {
+		case *ast.SelectorExpr:
+			constant, ok := info.Uses[node.Sel].(*types.Const)
+			if !ok || constant.Pkg() == nil {
+				return true
+			}
+			if algorithm, ok := certManagerKeyAlgorithms[QvFunction{constant.Name(), constant.Pkg().Path()}]; ok {
+				r.report(node.Sel.Pos(), ruleCertManagerKey, `key algorithm "%s.%s" issues cert-manager certificates with quantum-vulnerable %s keys`, constant.Pkg().Name(), constant.Name(), algorithm)
+			}
+		case *ast.CompositeLit:
+			litType := info.TypeOf(node)
+			if litType == nil {
+				return true
+			}
+			if _, ok := litType.Underlying().(*types.Map); ok {
+				reportCertManagerMap(r, node)
+				return true
+			}
+			for _, elt := range node.Elts {
+				keyValue, ok := elt.(*ast.KeyValueExpr)
+				if !ok {
+					continue
+				}
+				key, ok := keyValue.Key.(*ast.Ident)
+				if !ok {
+					continue
+				}
+				if fieldName, ok := privateKeyAlgorithmField(litType, key.Name); ok {
+					reportCertManagerValue(r, fieldName, keyValue.Value)
+				}
+			}
+		case *ast.AssignStmt:
+			if len(node.Lhs) != len(node.Rhs) {
+				return true
+			}
+			for i, lhs := range node.Lhs {
+				selector, ok := lhs.(*ast.SelectorExpr)
+				if !ok {
+					continue
+				}
+				selection, ok := info.Selections[selector]
+				if !ok || selection.Kind() != types.FieldVal {
+					continue
+				}
+				if fieldName, ok := privateKeyAlgorithmField(selection.Recv(), selector.Sel.Name); ok {
+					reportCertManagerValue(r, fieldName, node.Rhs[i])
+				}
+			}
+		case *ast.BasicLit:
+			if node.Kind != token.STRING {
+				return true
+			}
+			value, ok := stringConstant(info, node)
+			if !ok || !strings.Contains(value, "cert-manager.io/") || !strings.Contains(value, "kind: Certificate") {
+				return true
+			}
+			if match := certManagerYAMLAlgorithm.FindStringSubmatch(value); match != nil {
+				r.report(node.Pos(), ruleCertManagerKey, `cert-manager Certificate template requests a quantum-vulnerable %s private key`, certManagerAlgorithmValues[strings.ToLower(match[1])])
+			}
+		}
+		return true
+	})
+}
+
+// Returns the name of the field of a struct type mirroring the
+// CertificatePrivateKey of cert-manager, that is a type whose name mentions
+// a private key, if the field is its algorithm, tagged json:"algorithm".
+func privateKeyAlgorithmField(t types.Type, name string) (string, bool) {
+	if ptr, ok := types.Unalias(t).(*types.Pointer); ok {
+		t = ptr.Elem()
+	}
+	named, ok := types.Unalias(t).(*types.Named)
+	if !ok || named.Obj().Pkg() == nil || !strings.Contains(strings.ToLower(named.Obj().Name()), "privatekey") {
+		return "", false
+	}
+	structType, ok := named.Underlying().(*types.Struct)
+	if !ok {
+		return "", false
+	}
+	for i := range structType.NumFields() {
+		if structType.Field(i).Name() != name {
+			continue
+		}
+		tag, _, _ := strings.Cut(reflect.StructTag(structType.Tag(i)).Get("json"), ",")
+		if tag != "algorithm" {
+			return "", false
+		}
+		return named.Obj().Pkg().Name() + "." + named.Obj().Name() + "." + name, true
+	}
+	return "", false
+}
+
+// Reports the value of a private key algorithm field if it is a classical
+// algorithm. The constants of the cert-manager API are reported on their own.
+func reportCertManagerValue(r *reporter, fieldName string, value ast.Expr) {
+	if selector, ok := ast.Unparen(value).(*ast.SelectorExpr); ok {
+		if constant, ok := r.pass.TypesInfo.Uses[selector.Sel].(*types.Const); ok && constant.Pkg() != nil {
+			if _, ok := certManagerKeyAlgorithms[QvFunction{constant.Name(), constant.Pkg().Path()}]; ok {
+				return
+			}
+		}
+	}
+	algorithm, ok := stringConstant(r.pass.TypesInfo, value)
+	if !ok {
+		return
+	}
+	if algorithm, ok := certManagerAlgorithmValues[strings.ToLower(algorithm)]; ok {
+		r.report(value.Pos(), ruleCertManagerKey, `field "%s" requests a quantum-vulnerable %s private key for a cert-manager certificate`, fieldName, algorithm)
+	}
+}
+
+// Reports the algorithm of the private key of an unstructured Certificate,
+// that is the "algorithm" entry of a map under a "privateKey" key.
+func reportCertManagerMap(r *reporter, lit *ast.CompositeLit) {
+	for _, elt := range lit.Elts {
+		keyValue, ok := elt.(*ast.KeyValueExpr)
+		if !ok {
+			continue
+		}
+		if key, _ := stringConstant(r.pass.TypesInfo, keyValue.Key); key != "privateKey" {
+			continue
+		}
+		privateKey, ok := ast.Unparen(keyValue.Value).(*ast.CompositeLit)
+		if !ok {
+			continue
+		}
+		for _, elt := range privateKey.Elts {
+			keyValue, ok := elt.(*ast.KeyValueExpr)
+			if !ok {
+				continue
+			}
+			if key, _ := stringConstant(r.pass.TypesInfo, keyValue.Key); key != "algorithm" {
+				continue
+			}
+			algorithm, ok := stringConstant(r.pass.TypesInfo, keyValue.Value)
+			if !ok {
+				continue
+			}
+			if algorithm, ok := certManagerAlgorithmValues[strings.ToLower(algorithm)]; ok {
+				r.report(keyValue.Value.Pos(), ruleCertManagerKey, `cert-manager Certificate template requests a quantum-vulnerable %s private key`, algorithm)
+			}
+		}
+	}
+}
+
+// Returns the value of expr if it is a constant string.
+func stringConstant(info *types.Info, expr ast.Expr) (string, bool) {
+	tv, ok := info.Types[expr]
+	if !ok || tv.Value == nil || tv.Value.Kind() != constant.String {
+		return "", false
+	}
+	return constant.StringVal(tv.Value), true
+}
@@ -0,0 +1,119 @@
+package analyzer
+
+import (
+	"go/ast"
+	"go/constant"
+	"path"
+	"slices"
+	"strings"
+)
+
+// Functions of os/exec starting commands, with the index of the command name
+// in their arguments.
+var commandFunctions = map[QvFunction]int{
+	{"Command", "os/exec"}:        0,
+	{"CommandContext", "os/exec"}: 1,
+}
+
+// GnuPG arguments signing, encrypting, verifying or generating OpenPGP keys,
+// which are RSA, DSA, ElGamal, ECDSA, EdDSA or ECDH keys in current GnuPG.
+var gpgArguments = []string{
+	"-s", "--sign", "-b", "--detach-sign", "--clear-sign", "--clearsign",
+	"-e", "--encrypt", "-d", "--decrypt", "--verify",
+	"--gen-key", "--generate-key", "--full-gen-key", "--full-generate-key",
+	"--quick-gen-key", "--quick-generate-key", "--quick-add-key",
+}
+
+// OpenSSL subcommands of classical public-key algorithms.
+var opensslCommands = []string{
+	"genrsa", "rsa", "rsautl", "ecparam", "ec", "gendsa", "dsa", "dsaparam",
+	"dhparam", "req", "ca", "x509", "pkeyutl", "smime", "cms", "pkcs12",
+}
+
+// OpenSSL subcommands that only use public-key algorithms with some
+// arguments, and those arguments.
+var opensslArguments = map[string][]string{
+	"dgst":    {"-sign", "-verify", "-prverify"},
+	"genpkey": {"RSA", "RSA-PSS", "EC", "DSA", "DH", "X25519", "X448", "ED25519", "ED448"},
+}
+
+// Identifiers of GPGME bindings signing, encrypting and verifying with
+// OpenPGP keys.
+var gpgmeIdentifiers = functionsOf("github.com/proglottis/gpgme",
+	"Sign", "Encrypt", "Decrypt", "DecryptVerify", "Verify",
+)
+
+// Reports os/exec commands running gpg or openssl with arguments of
+// classical public-key operations. Shelling out to crypto binaries hides the
+// algorithms from source analysis.
+func reportCryptoCommands(r *reporter, file *ast.File) {
+	info := r.pass.TypesInfo
+	ast.Inspect(file, func(node ast.Node) bool {
+		call, ok := node.(*ast.CallExpr)
+		if !ok {
+			return true
+		}
+		selector, ok := call.Fun.(*ast.SelectorExpr)
+		if !ok {
+			return true
+		}
+		localImportName, ok := selector.X.(*ast.Ident)
+		if !ok {
+			return true
+		}
+		for function, nameIndex := range commandFunctions {
+			fnName, ok := vulnerableFunction(info, localImportName, selector.Sel, []QvFunction{function})
+			if !ok || len(call.Args) <= nameIndex {
+				continue
+			}
+			args := constantStrings(r, call.Args[nameIndex:])
+			if len(args) == 0 {
+				continue
+			}
+			if operation, ok := classicalCommand(path.Base(args[0]), args[1:]); ok {
+				r.report(call.Args[nameIndex].Pos(), ruleCryptoCommand, `function "%s" runs "%s", a quantum-vulnerable public-key operation hidden from source analysis`, fnName, operation)
+			}
+		}
+		return true
+	})
+}
+
+// Returns the leading arguments that are string constants.
+func constantStrings(r *reporter, exprs []ast.Expr) []string {
+	var values []string
+	for _, expr := range exprs {
+		value := r.pass.TypesInfo.Types[expr].Value
+		if value == nil || value.Kind() != constant.String {
+			break
+		}
+		values = append(values, constant.StringVal(value))
+	}
+	return values
+}
+
+// Returns the classical operation run by the command with the given binary
+// name and arguments, such as "gpg --sign" or "openssl genrsa".
+func classicalCommand(name string, args []string) (string, bool) {
+	name = strings.TrimSuffix(name, ".exe")
+	switch name {
+	case "gpg", "gpg2":
+		for _, arg := range args {
+			if slices.Contains(gpgArguments, arg) {
+				return name + " " + arg, true
+			}
+		}
+	case "openssl":
+		if len(args) == 0 {
+			return "", false
+		}
+		if slices.Contains(opensslCommands, args[0]) {
+			return name + " " + args[0], true
+		}
+		for _, arg := range args[1:] {
+			if slices.Contains(opensslArguments[args[0]], arg) || slices.Contains(opensslArguments[args[0]], strings.ToUpper(arg)) {
+				return name + " " + args[0] + " " + arg, true
+			}
+		}
+	}
+	return "", false
+}
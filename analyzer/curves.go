@@ -0,0 +1,117 @@
+package analyzer
+
+import (
+	"fmt"
+	"go/ast"
+	"go/constant"
+	"strings"
+)
+
+// A named elliptic curve and its size in bits.
+type namedCurve struct {
+	name string
+	bits int
+}
+
+// Functions and variables selecting curves other than P-256, P-384, P-521 and
+// Curve25519, or generating keys on them: crypto/elliptic's P-224, the
+// secp256k1 implementations of decred, btcd and go-ethereum, and the
+// Brainpool curves.
+var weakCurveIdentifiers = map[QvFunction]namedCurve{
+	{"P224", "crypto/elliptic"}: {"P-224", 224},
+
+	{"S256", "github.com/decred/dcrd/dcrec/secp256k1/v4"}:               {"secp256k1", 256},
+	{"GeneratePrivateKey", "github.com/decred/dcrd/dcrec/secp256k1/v4"}: {"secp256k1", 256},
+	{"S256", "github.com/btcsuite/btcd/btcec/v2"}:                       {"secp256k1", 256},
+	{"NewPrivateKey", "github.com/btcsuite/btcd/btcec/v2"}:              {"secp256k1", 256},
+	{"S256", "github.com/btcsuite/btcd/btcec"}:                          {"secp256k1", 256},
+	{"NewPrivateKey", "github.com/btcsuite/btcd/btcec"}:                 {"secp256k1", 256},
+	{"S256", "github.com/ethereum/go-ethereum/crypto"}:                  {"secp256k1", 256},
+	{"GenerateKey", "github.com/ethereum/go-ethereum/crypto"}:           {"secp256k1", 256},
+	{"S256", "github.com/ethereum/go-ethereum/crypto/secp256k1"}:        {"secp256k1", 256},
+
+	{"P160r1", "github.com/ebfe/brainpool"}:              {"brainpoolP160r1", 160},
+	{"P160t1", "github.com/ebfe/brainpool"}:              {"brainpoolP160t1", 160},
+	{"P192r1", "github.com/ebfe/brainpool"}:              {"brainpoolP192r1", 192},
+	{"P192t1", "github.com/ebfe/brainpool"}:              {"brainpoolP192t1", 192},
+	{"P224r1", "github.com/ebfe/brainpool"}:              {"brainpoolP224r1", 224},
+	{"P224t1", "github.com/ebfe/brainpool"}:              {"brainpoolP224t1", 224},
+	{"P256r1", "github.com/ebfe/brainpool"}:              {"brainpoolP256r1", 256},
+	{"P256t1", "github.com/ebfe/brainpool"}:              {"brainpoolP256t1", 256},
+	{"P320r1", "github.com/ebfe/brainpool"}:              {"brainpoolP320r1", 320},
+	{"P320t1", "github.com/ebfe/brainpool"}:              {"brainpoolP320t1", 320},
+	{"P384r1", "github.com/ebfe/brainpool"}:              {"brainpoolP384r1", 384},
+	{"P384t1", "github.com/ebfe/brainpool"}:              {"brainpoolP384t1", 384},
+	{"P512r1", "github.com/ebfe/brainpool"}:              {"brainpoolP512r1", 512},
+	{"P512t1", "github.com/ebfe/brainpool"}:              {"brainpoolP512t1", 512},
+	{"P256r1", "github.com/keybase/go-crypto/brainpool"}: {"brainpoolP256r1", 256},
+	{"P384r1", "github.com/keybase/go-crypto/brainpool"}: {"brainpoolP384r1", 384},
+	{"P512r1", "github.com/keybase/go-crypto/brainpool"}: {"brainpoolP512r1", 512},
+}
+
+// Reports curve choices that compliance regimes treat differently from the
+// NIST curves P-256, P-384 and P-521: curves below 128-bit classical security,
+// such as P-224, secp256k1, the Brainpool curves, and custom curves defined
+// with elliptic.CurveParams. Every elliptic curve is quantum-vulnerable, but
+// these are rejected by audits long before.
+func reportWeakCurves(r *reporter, file *ast.File) {
+	info := r.pass.TypesInfo
+	ast.Inspect(file, func(node ast.Node) bool {
+		switch node := node.(type) {
+		case *ast.SelectorExpr:
+			obj := info.Uses[node.Sel]
+			if obj == nil || obj.Pkg() == nil {
+				return true
+			}
+			curve, ok := weakCurveIdentifiers[QvFunction{obj.Name(), obj.Pkg().Path()}]
+			if !ok {
+				return true
+			}
+			r.report(node.Sel.Pos(), ruleWeakCurve, `"%s.%s" selects %s, which %s; %s`, obj.Pkg().Name(), obj.Name(), curve.name, curveWeakness(curve), weakCurveMessage)
+		case *ast.CompositeLit:
+			if !isNamedType(info.TypeOf(node), []QvFunction{{"CurveParams", "crypto/elliptic"}}) {
+				return true
+			}
+			curve := namedCurve{name: "a custom curve"}
+			for _, elt := range node.Elts {
+				keyValue, ok := elt.(*ast.KeyValueExpr)
+				if !ok {
+					continue
+				}
+				key, ok := keyValue.Key.(*ast.Ident)
+				if !ok {
+					continue
+				}
+				switch key.Name {
+				case "Name":
+					if name, ok := stringConstant(info, keyValue.Value); ok && name != "" {
+						curve.name = fmt.Sprintf("the custom curve %q", name)
+					}
+				case "BitSize":
+					if tv, ok := info.Types[keyValue.Value]; ok && tv.Value != nil && tv.Value.Kind() == constant.Int {
+						bits, _ := constant.Int64Val(tv.Value)
+						curve.bits = int(bits)
+					}
+				}
+			}
+			r.report(node.Pos(), ruleWeakCurve, `elliptic.CurveParams literal defines %s, which %s; %s`, curve.name, curveWeakness(curve), weakCurveMessage)
+		}
+		return true
+	})
+}
+
+const weakCurveMessage = "compliance regimes can reject it long before quantum computers break any curve"
+
+// Returns why compliance regimes treat the curve differently from the NIST
+// curves.
+func curveWeakness(curve namedCurve) string {
+	switch {
+	case curve.bits > 0 && curve.bits < 256:
+		return fmt.Sprintf("offers only %d-bit classical security, below the 128 bits most regimes require", curve.bits/2)
+	case curve.name == "secp256k1":
+		return "is not a NIST curve and is not approved for FIPS 140-3 validated modules or CNSA"
+	case strings.HasPrefix(curve.name, "brainpool"):
+		return "is approved by BSI TR-02102 but not by CNSA, and by NIST SP 800-186 only for interoperability"
+	}
+	return "is evaluated by the deprecated generic crypto/elliptic implementation, outside any vetted curve implementation"
+}
@@ -0,0 +1,99 @@
+package analyzer
+
+import (
+	"go/ast"
+	"go/token"
+	"go/types"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// pgcrypto functions encrypting and decrypting with OpenPGP public keys,
+// which are RSA or ElGamal keys, in SQL.
+var pgcryptoPattern = regexp.MustCompile(`(?i)\b(pgp_pub_(?:en|de)crypt(?:_bytea)?)\s*\(`)
+
+// Path prefixes and fragments of ORM packages, whose field encryption
+// plugins are configured with keys.
+var (
+	ormPathPrefixes  = []string{"gorm.io/", "xorm.io/", "github.com/uptrace/bun", "entgo.io/"}
+	ormPathFragments = []string{"gorm"}
+)
+
+const databaseMessage = "encrypted columns are stored for years, which makes them a prime harvest-now-decrypt-later target"
+
+// Reports column-level encryption with classical public keys: SQL string
+// literals calling the pgcrypto functions pgp_pub_encrypt and
+// pgp_pub_decrypt, and ORM field encryption plugins, such as GORM
+// serializers, configured with RSA or other classical keys passed to their
+// constructors or set in their option structs.
+func reportDatabaseEncryption(r *reporter, file *ast.File) {
+	info := r.pass.TypesInfo
+	ast.Inspect(file, func(node ast.Node) bool {
+		switch node := node.(type) {
+		case *ast.BasicLit:
+			if node.Kind != token.STRING {
+				return true
+			}
+			sql, err := strconv.Unquote(node.Value)
+			if err != nil {
+				return true
+			}
+			if match := pgcryptoPattern.FindStringSubmatch(sql); match != nil {
+				function := strings.ToLower(match[1])
+				operation := OperationPublic
+				if strings.Contains(function, "decrypt") {
+					operation = OperationPrivate
+				}
+				r.reportOperation(node.Pos(), ruleDatabaseEncryption, operation, "SQL calls pgcrypto %s, which encrypts or decrypts columns with OpenPGP RSA or ElGamal keys; %s", function, databaseMessage)
+			}
+		case *ast.CallExpr:
+			fn, _ := callee(info, node)
+			if fn == nil || fn.Pkg() == nil || !isORMPackage(fn.Pkg().Path()) {
+				return true
+			}
+			for _, arg := range node.Args {
+				if algorithm, keyType, ok := classicalKeyType(info.TypeOf(arg)); ok {
+					r.reportOperation(arg.Pos(), ruleDatabaseEncryption, OperationUnknown, `"%s" configures ORM field encryption with a classical %s key (%s); %s`, types.ExprString(node.Fun), algorithm, keyType, databaseMessage)
+				}
+			}
+		case *ast.CompositeLit:
+			t := info.TypeOf(node)
+			if t == nil {
+				return true
+			}
+			if ptr, ok := types.Unalias(t).(*types.Pointer); ok {
+				t = ptr.Elem()
+			}
+			named, ok := types.Unalias(t).(*types.Named)
+			if !ok || named.Obj().Pkg() == nil || !isORMPackage(named.Obj().Pkg().Path()) {
+				return true
+			}
+			for _, elt := range node.Elts {
+				keyValue, ok := elt.(*ast.KeyValueExpr)
+				if !ok {
+					continue
+				}
+				if algorithm, keyType, ok := classicalKeyType(info.TypeOf(keyValue.Value)); ok {
+					r.reportOperation(keyValue.Key.Pos(), ruleDatabaseEncryption, OperationUnknown, `option "%s.%s" configures ORM field encryption with a classical %s key (%s); %s`, named.Obj().Name(), types.ExprString(keyValue.Key), algorithm, keyType, databaseMessage)
+				}
+			}
+		}
+		return true
+	})
+}
+
+// Reports whether a package belongs to an ORM or one of its plugins.
+func isORMPackage(path string) bool {
+	for _, prefix := range ormPathPrefixes {
+		if strings.HasPrefix(path, prefix) {
+			return true
+		}
+	}
+	for _, fragment := range ormPathFragments {
+		if strings.Contains(path, fragment) {
+			return true
+		}
+	}
+	return false
+}
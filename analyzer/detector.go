@@ -0,0 +1,104 @@
+package analyzer
+
+import (
+	"fmt"
+	"slices"
+	"sync"
+
+	"golang.org/x/tools/go/analysis"
+)
+
+// Detector is a custom detector compiled into the analyzer, such as one for
+// the calls of an organization's internal crypto SDK. Its findings are
+// reported like those of the built-in rules: annotations, allowlists, message
+// templates, suppressions and severity overrides apply to them, and they are
+// written in every output format.
+type Detector interface {
+	// Name identifies the detector in errors.
+	Name() string
+	// Rules returns the rules the detector reports findings under. Their IDs
+	// must differ from those of the built-in rules and of other detectors,
+	// for example by a prefix of their own, such as "ACME001".
+	Rules() []Rule
+	// Match returns the findings of the detector in the package of the pass,
+	// each with one of its rules and the position and message of its
+	// diagnostic. The category and URL of the diagnostic are set from the
+	// rule, and its end and related information filled in when missing.
+	// Match is called concurrently for different packages.
+	Match(pass *analysis.Pass) []Finding
+}
+
+var (
+	detectorsMu sync.RWMutex
+	detectors   []Detector
+)
+
+// RegisterDetector adds a detector to every analyzer, usually from the init
+// function of the detector's package, imported by the main package of a
+// custom build. Its rules are returned by Rules and LookupRule, with their
+// Detector set to its name. It panics if the name of the detector or the ID
+// of one of its rules is registered already, or if its rules repeat an ID.
+func RegisterDetector(d Detector) {
+	detectorsMu.Lock()
+	defer detectorsMu.Unlock()
+	for _, registered := range detectors {
+		if registered.Name() == d.Name() {
+			panic("analyzer: detector " + d.Name() + " registered twice")
+		}
+	}
+	rules := d.Rules()
+	for i, rule := range rules {
+		if _, ok := lookupRule(rule.ID); ok {
+			panic("analyzer: rule " + rule.ID + " of detector " + d.Name() + " registered twice")
+		}
+		if slices.ContainsFunc(rules[:i], func(other Rule) bool { return other.ID == rule.ID }) {
+			panic("analyzer: rule " + rule.ID + " of detector " + d.Name() + " declared twice")
+		}
+	}
+	detectors = append(detectors, d)
+}
+
+// Removes the registered detector with the given name, for tests.
+func unregisterDetector(name string) {
+	detectorsMu.Lock()
+	defer detectorsMu.Unlock()
+	detectors = slices.DeleteFunc(detectors, func(d Detector) bool {
+		return d.Name() == name
+	})
+}
+
+// Returns the registered detectors.
+func registeredDetectors() []Detector {
+	detectorsMu.RLock()
+	defer detectorsMu.RUnlock()
+	return slices.Clone(detectors)
+}
+
+// Returns the rules of the registered detectors. The caller must hold
+// detectorsMu.
+func detectorRules() []Rule {
+	var rules []Rule
+	for _, d := range detectors {
+		for _, rule := range d.Rules() {
+			rule.Detector = d.Name()
+			rules = append(rules, rule)
+		}
+	}
+	return rules
+}
+
+// Reports the findings of the registered detectors in the package of the
+// pass.
+func reportDetectors(r *reporter) error {
+	for _, d := range registeredDetectors() {
+		rules := d.Rules()
+		for _, finding := range d.Match(r.pass) {
+			if !slices.ContainsFunc(rules, func(rule Rule) bool { return rule.ID == finding.Rule.ID }) {
+				return fmt.Errorf("detector %s reported a finding of rule %q, which is not one of its rules", d.Name(), finding.Rule.ID)
+			}
+			finding.Rule.Detector = d.Name()
+			r.add(finding)
+		}
+	}
+	return nil
+}
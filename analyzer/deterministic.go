@@ -0,0 +1,192 @@
+package analyzer
+
+import (
+	"go/ast"
+	"go/types"
+	"slices"
+	"strings"
+)
+
+// Functions and methods generating classical keys from the randomness of an
+// io.Reader, by the index of the reader among their arguments.
+var keyGenerationReaders = map[QvFunction]int{
+	{"GenerateKey", "crypto/rsa"}:           0,
+	{"GenerateMultiPrimeKey", "crypto/rsa"}: 0,
+	{"GenerateKey", "crypto/ecdsa"}:         1,
+	{"GenerateKey", "crypto/ed25519"}:       0,
+	{"GenerateKey", "crypto/ecdh"}:          0,
+	{"GenerateKey", "crypto/dsa"}:           1,
+}
+
+// Reader types returning the same bytes on every run: in-memory readers and
+// seeded pseudo-random generators.
+var deterministicReaderTypes = slices.Concat(
+	functionsOf("bytes", "Reader", "Buffer"),
+	functionsOf("strings", "Reader"),
+	functionsOf("math/rand", "Rand"),
+	functionsOf("math/rand/v2", "ChaCha8"),
+)
+
+const deterministicKeyMessage = "keys generated from a fixed reader are the same on every run, and deterministic test keys in non-test files ship with the binary"
+
+// Reports classical keys generated from deterministic readers in non-test
+// files, such as rsa.GenerateKey(bytes.NewReader(seed), 2048), directly or
+// through a key generation helper of the package passing one of its io.Reader
+// parameters on. Test fixtures in non-test files, such as testutil.go or files
+// behind a build tag, are built into whatever imports them. Deterministic
+// readers are bytes and strings readers, seeded math/rand generators, and
+// readers of the package filling buffers without calling anything, such as
+// a zeroReader.
+func reportDeterministicKeyGeneration(r *reporter) {
+	info := r.pass.TypesInfo
+	var files []*ast.File
+	for _, file := range r.pass.Files {
+		if file.Name != nil && strings.HasSuffix(file.Name.Name, "_test") {
+			continue
+		}
+		if !strings.HasSuffix(r.pass.Fset.File(file.Pos()).Name(), "_test.go") {
+			files = append(files, file)
+		}
+	}
+	helpers := keyGenerationHelpers(info, files)
+	zeroReaders := zeroReaderTypes(info, r.pass.Files)
+
+	for _, file := range files {
+		ast.Inspect(file, func(node ast.Node) bool {
+			call, ok := node.(*ast.CallExpr)
+			if !ok {
+				return true
+			}
+			fn, _ := callee(info, call)
+			if fn == nil || fn.Pkg() == nil {
+				return true
+			}
+			arg, ok := helpers[fn]
+			if !ok {
+				if arg, ok = keyGenerationReaders[QvFunction{fn.Name(), fn.Pkg().Path()}]; !ok {
+					return true
+				}
+			}
+			if arg >= len(call.Args) || !deterministicReader(info, call.Args[arg], zeroReaders) {
+				return true
+			}
+			where := ""
+			if expr := fileConstraint(file); expr != nil && !onlyGoVersions(expr) {
+				where = ` in a file built when "` + expr.String() + `" holds`
+			}
+			r.reportOperation(call.Args[arg].Pos(), ruleDeterministicKeyGeneration, OperationPrivate, `"%s" generates a classical key from deterministic reader %s%s; %s`, types.ExprString(call.Fun), types.ExprString(call.Args[arg]), where, deterministicKeyMessage)
+			return true
+		})
+	}
+}
+
+// Returns the functions of the files generating classical keys from one of
+// their io.Reader parameters, with the index of the parameter.
+func keyGenerationHelpers(info *types.Info, files []*ast.File) map[*types.Func]int {
+	helpers := make(map[*types.Func]int)
+	for _, file := range files {
+		for _, decl := range file.Decls {
+			funcDecl, ok := decl.(*ast.FuncDecl)
+			if !ok || funcDecl.Body == nil {
+				continue
+			}
+			fn, ok := info.Defs[funcDecl.Name].(*types.Func)
+			if !ok {
+				continue
+			}
+			params := fn.Signature().Params()
+			ast.Inspect(funcDecl.Body, func(node ast.Node) bool {
+				call, ok := node.(*ast.CallExpr)
+				if !ok {
+					return true
+				}
+				generator, _ := callee(info, call)
+				if generator == nil || generator.Pkg() == nil {
+					return true
+				}
+				arg, ok := keyGenerationReaders[QvFunction{generator.Name(), generator.Pkg().Path()}]
+				if !ok || arg >= len(call.Args) {
+					return true
+				}
+				ident, ok := ast.Unparen(call.Args[arg]).(*ast.Ident)
+				if !ok {
+					return true
+				}
+				for i := range params.Len() {
+					if params.At(i) == info.Uses[ident] && types.TypeString(params.At(i).Type(), nil) == "io.Reader" {
+						helpers[fn] = i
+					}
+				}
+				return true
+			})
+		}
+	}
+	return helpers
+}
+
+// Returns the types of the files whose Read method fills the buffer without
+// calling anything but builtins and conversions, so reads return the same
+// bytes every time.
+func zeroReaderTypes(info *types.Info, files []*ast.File) map[types.Object]bool {
+	zeroReaders := make(map[types.Object]bool)
+	for _, file := range files {
+		for _, decl := range file.Decls {
+			funcDecl, ok := decl.(*ast.FuncDecl)
+			if !ok || funcDecl.Recv == nil || funcDecl.Name.Name != "Read" || funcDecl.Body == nil {
+				continue
+			}
+			fn, ok := info.Defs[funcDecl.Name].(*types.Func)
+			if !ok {
+				continue
+			}
+			recv := fn.Signature().Recv().Type()
+			if ptr, ok := recv.(*types.Pointer); ok {
+				recv = ptr.Elem()
+			}
+			named, ok := recv.(*types.Named)
+			if !ok {
+				continue
+			}
+			calls := false
+			ast.Inspect(funcDecl.Body, func(node ast.Node) bool {
+				call, ok := node.(*ast.CallExpr)
+				if !ok {
+					return !calls
+				}
+				if tv, ok := info.Types[call.Fun]; ok && tv.IsType() {
+					return true
+				}
+				if ident, ok := ast.Unparen(call.Fun).(*ast.Ident); ok {
+					if _, ok := info.Uses[ident].(*types.Builtin); ok {
+						return true
+					}
+				}
+				calls = true
+				return false
+			})
+			if !calls {
+				zeroReaders[named.Obj()] = true
+			}
+		}
+	}
+	return zeroReaders
+}
+
+// Reports whether a reader returns the same bytes on every run, by its type.
+func deterministicReader(info *types.Info, reader ast.Expr, zeroReaders map[types.Object]bool) bool {
+	t := info.TypeOf(reader)
+	if t == nil {
+		return false
+	}
+	if ptr, ok := types.Unalias(t).(*types.Pointer); ok {
+		t = ptr.Elem()
+	}
+	named, ok := types.Unalias(t).(*types.Named)
+	if !ok || named.Obj().Pkg() == nil {
+		return false
+	}
+	obj := named.Obj()
+	return zeroReaders[obj] || slices.ContainsFunc(deterministicReaderTypes, func(qvFunc QvFunction) bool {
+		return qvFunc.FnName == obj.Name() && qvFunc.Package == obj.Pkg().Path()
+	})
+}
@@ -0,0 +1,90 @@
+package analyzer
+
+import (
+	"go/ast"
+	"go/token"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// Identifiers of finite-field Diffie-Hellman and ElGamal implementations.
+var finiteFieldDHIdentifiers = []QvFunction{
+	{"GetGroup", "github.com/monnand/dhkx"},
+	{"CreateGroup", "github.com/monnand/dhkx"},
+	{"GeneratePrivateKey", "github.com/monnand/dhkx"},
+	{"ComputeKey", "github.com/monnand/dhkx"},
+	{"Encrypt", "golang.org/x/crypto/openpgp/elgamal"},
+	{"Decrypt", "golang.org/x/crypto/openpgp/elgamal"},
+	{"Encrypt", "github.com/ProtonMail/go-crypto/openpgp/elgamal"},
+	{"Decrypt", "github.com/ProtonMail/go-crypto/openpgp/elgamal"},
+}
+
+// Names of variables holding Diffie-Hellman group parameters.
+var dhParameterNames = []string{"g", "gen", "generator", "p", "prime", "modulus", "dhprime", "dhgenerator"}
+
+// Hex prefixes of the well-known finite-field Diffie-Hellman group primes.
+// The MODP groups of RFC 2409 and RFC 3526 share the first 64 bits of pi,
+// and the FFDHE groups of RFC 7919 the first 64 bits of e.
+var dhGroupPrimePrefixes = []string{
+	"FFFFFFFFFFFFFFFFC90FDAA22168C234C4C6628B80DC1CD1",
+	"FFFFFFFFFFFFFFFFADF85458A2BB4A9AAFDC5620273D3CF1",
+}
+
+var hexDigitsPattern = regexp.MustCompile(`^[0-9A-Fa-f\s]+$`)
+
+// Reports hand-rolled finite-field Diffie-Hellman: modular exponentiation of
+// group parameters with math/big, and well-known DH group primes.
+func reportFiniteFieldDH(r *reporter, file *ast.File) {
+	ast.Inspect(file, func(node ast.Node) bool {
+		switch node := node.(type) {
+		case *ast.CallExpr:
+			selector, ok := node.Fun.(*ast.SelectorExpr)
+			if !ok || len(node.Args) != 3 {
+				return true
+			}
+			if _, ok := vulnerableMethod(r.pass.TypesInfo, selector, []QvFunction{{"Exp", "math/big"}}); !ok {
+				return true
+			}
+			if isDHParameter(node.Args[0]) || isDHParameter(node.Args[2]) {
+				r.report(selector.Sel.Pos(), ruleFiniteFieldDH, "modular exponentiation of Diffie-Hellman group parameters implements quantum-vulnerable finite-field key exchange; use ML-KEM instead")
+			}
+		case *ast.BasicLit:
+			if node.Kind != token.STRING {
+				return true
+			}
+			value, err := strconv.Unquote(node.Value)
+			if err != nil || !hexDigitsPattern.MatchString(value) {
+				return true
+			}
+			digits := strings.ToUpper(strings.Join(strings.Fields(value), ""))
+			if !slices.ContainsFunc(dhGroupPrimePrefixes, func(prefix string) bool {
+				return strings.HasPrefix(digits, prefix)
+			}) {
+				return true
+			}
+			bits := len(digits) * 4
+			if bits < 2048 {
+				r.report(node.Pos(), ruleFiniteFieldDH, "%d-bit Diffie-Hellman group prime is weak today and quantum-vulnerable; use ML-KEM instead", bits)
+			} else {
+				r.report(node.Pos(), ruleFiniteFieldDH, "%d-bit Diffie-Hellman group prime is used for quantum-vulnerable finite-field key exchange; use ML-KEM instead", bits)
+			}
+		}
+		return true
+	})
+}
+
+// Reports whether expr is a variable or field named like a DH group parameter.
+func isDHParameter(expr ast.Expr) bool {
+	var name string
+	switch expr := expr.(type) {
+	case *ast.Ident:
+		name = expr.Name
+	case *ast.SelectorExpr:
+		name = expr.Sel.Name
+	default:
+		return false
+	}
+	return slices.Contains(dhParameterNames, strings.ToLower(name))
+}
@@ -0,0 +1,98 @@
+package analyzer
+
+import (
+	"go/ast"
+	"go/constant"
+	"go/types"
+	"slices"
+	"strings"
+)
+
+// Names of classical public-key algorithms, as dispatched on in code: generic
+// names, JOSE algorithm identifiers, curve names and SSH key types. They are
+// matched case-insensitively.
+var algorithmNames = []string{
+	"rsa", "ecdsa", "ed25519", "eddsa", "dsa", "ecdh", "x25519", "dh",
+	"rs256", "rs384", "rs512", "ps256", "ps384", "ps512", "es256", "es384", "es512",
+	"p-256", "p-384", "p-521", "p256", "p384", "p521", "secp256k1",
+	"ssh-rsa", "ssh-ed25519", "ecdsa-sha2-nistp256", "ecdsa-sha2-nistp384",
+}
+
+// Algorithm identifier types that switches dispatch on.
+var algorithmTypes = []QvFunction{
+	{"Hash", "crypto"},
+	{"PublicKeyAlgorithm", "crypto/x509"},
+	{"SignatureAlgorithm", "crypto/x509"},
+	{"SignatureScheme", "crypto/tls"},
+	{"CurveID", "crypto/tls"},
+}
+
+// Reports algorithm agility choke points: switches and maps dispatching on
+// algorithm names, algorithm identifier types or key types. They are not
+// vulnerable by themselves, but each has to be extended for post-quantum
+// algorithms.
+func reportAlgorithmDispatch(r *reporter, file *ast.File) {
+	info := r.pass.TypesInfo
+	keyTypes := slices.Concat(privateKeyTypes, publicKeyTypes)
+	ast.Inspect(file, func(node ast.Node) bool {
+		switch node := node.(type) {
+		case *ast.SwitchStmt:
+			if node.Tag != nil && isNamedType(info.TypeOf(node.Tag), algorithmTypes) {
+				r.report(node.Pos(), ruleAlgorithmDispatch, "switch on %s dispatches on algorithm identifiers; it must be extended for post-quantum algorithms", types.TypeString(info.TypeOf(node.Tag), (*types.Package).Name))
+				return true
+			}
+			var names []string
+			for _, stmt := range node.Body.List {
+				for _, expr := range stmt.(*ast.CaseClause).List {
+					if name, ok := algorithmName(info, expr); ok {
+						names = append(names, name)
+					}
+				}
+			}
+			if len(names) > 0 {
+				r.report(node.Pos(), ruleAlgorithmDispatch, "switch dispatches on algorithm names %s; it must be extended for post-quantum algorithms", strings.Join(names, ", "))
+			}
+		case *ast.TypeSwitchStmt:
+			var names []string
+			for _, stmt := range node.Body.List {
+				for _, expr := range stmt.(*ast.CaseClause).List {
+					if t := info.TypeOf(expr); isNamedType(t, keyTypes) {
+						names = append(names, types.TypeString(t, (*types.Package).Name))
+					}
+				}
+			}
+			if len(names) > 0 {
+				r.report(node.Pos(), ruleAlgorithmDispatch, "type switch dispatches on key types %s; it must be extended for post-quantum algorithms", strings.Join(names, ", "))
+			}
+		case *ast.CompositeLit:
+			if _, ok := types.Unalias(info.TypeOf(node)).Underlying().(*types.Map); !ok {
+				return true
+			}
+			var names []string
+			for _, elt := range node.Elts {
+				if keyValue, ok := elt.(*ast.KeyValueExpr); ok {
+					if name, ok := algorithmName(info, keyValue.Key); ok {
+						names = append(names, name)
+					}
+				}
+			}
+			if len(names) > 0 {
+				r.report(node.Pos(), ruleAlgorithmDispatch, "map dispatches on algorithm names %s; it must be extended for post-quantum algorithms", strings.Join(names, ", "))
+			}
+		}
+		return true
+	})
+}
+
+// Returns the quoted algorithm name if expr is a constant string naming one.
+func algorithmName(info *types.Info, expr ast.Expr) (string, bool) {
+	tv, ok := info.Types[expr]
+	if !ok || tv.Value == nil || tv.Value.Kind() != constant.String {
+		return "", false
+	}
+	value := constant.StringVal(tv.Value)
+	if !slices.Contains(algorithmNames, strings.ToLower(value)) {
+		return "", false
+	}
+	return tv.Value.ExactString(), true
+}
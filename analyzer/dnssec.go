@@ -0,0 +1,30 @@
+package analyzer
+
+import "go/ast"
+
+// Methods of miekg/dns generating DNSSEC keys, and signing and validating
+// resource record sets and SIG(0) messages with them.
+var dnssecIdentifiers = functionsOf("github.com/miekg/dns", "Generate", "NewPrivateKey", "Sign", "Verify")
+
+// DNSSEC algorithm numbers of miekg/dns, with the classical algorithm they
+// select.
+var dnssecAlgorithms = map[QvFunction]string{
+	{"RSAMD5", "github.com/miekg/dns"}:           "RSA",
+	{"DSA", "github.com/miekg/dns"}:              "DSA",
+	{"RSASHA1", "github.com/miekg/dns"}:          "RSA",
+	{"DSANSEC3SHA1", "github.com/miekg/dns"}:     "DSA",
+	{"RSASHA1NSEC3SHA1", "github.com/miekg/dns"}: "RSA",
+	{"RSASHA256", "github.com/miekg/dns"}:        "RSA",
+	{"RSASHA512", "github.com/miekg/dns"}:        "RSA",
+	{"ECCGOST", "github.com/miekg/dns"}:          "GOST R 34.10-2001",
+	{"ECDSAP256SHA256", "github.com/miekg/dns"}:  "ECDSA P-256",
+	{"ECDSAP384SHA384", "github.com/miekg/dns"}:  "ECDSA P-384",
+	{"ED25519", "github.com/miekg/dns"}:          "Ed25519",
+	{"ED448", "github.com/miekg/dns"}:            "Ed448",
+}
+
+// Reports the DNSSEC algorithm constants of miekg/dns, naming the classical
+// algorithm of the zone or SIG(0) keys they select.
+func reportDNSSECAlgorithms(r *reporter, file *ast.File) {
+	reportConstants(r, file, dnssecAlgorithms, ruleDNSSEC, `DNSSEC algorithm "%s.%s" signs zones with quantum-vulnerable %s keys; DNSSEC has no standardized post-quantum algorithm yet, so inventory it until one is assigned`)
+}
@@ -0,0 +1,27 @@
+package analyzer
+
+import "slices"
+
+const pdfsignImportPath = "github.com/digitorus/pdfsign/sign"
+
+// Functions and types of document signing: PDF signatures created with
+// digitorus/pdfsign or validated with pdfcpu, XML signatures (XMLDSig) of
+// goxmldsig, and the RFC 3161 timestamps of digitorus/timestamp that keep
+// signatures verifiable after their certificates expire. The signatures are
+// RSA or ECDSA and documents are expected to stay verifiable for decades.
+var documentSigningIdentifiers = slices.Concat(
+	functionsOf(pdfsignImportPath, "Sign", "SignFile"),
+	functionsOf("github.com/digitorus/pdfsign/verify", "File", "Reader"),
+	functionsOf("github.com/pdfcpu/pdfcpu/pkg/api", "ValidateSignatures", "ValidateSignaturesFile"),
+	functionsOf("github.com/russellhaering/goxmldsig", "NewDefaultSigningContext", "NewSigningContext", "SignEnveloped", "SignEnvelopedReader", "SignString", "NewDefaultValidationContext", "Validate"),
+	functionsOf("github.com/digitorus/timestamp", "CreateRequest", "ParseResponse", "Parse"),
+)
+
+// Fields of pdfsign configuring the signing key and the timestamp authority
+// of a signature.
+var documentSigningFields = []QvField{
+	{"Signer", "SignData", pdfsignImportPath},
+	{"TSA", "SignData", pdfsignImportPath},
+}
+
+const documentSigningMessage = "signs, timestamps or verifies documents with RSA or ECDSA signatures that must stay verifiable for decades; plan archival timestamps (PAdES-LTA, XAdES-A) renewed with post-quantum algorithms, or re-signing, before the classical ones are broken"
@@ -0,0 +1,16 @@
+package analyzer
+
+import "slices"
+
+// Functions and methods of Go implementations of the Signal protocol and of
+// Matrix's Olm and Megolm, setting up and running double-ratchet sessions.
+// Their key agreement is X3DH over X25519, with Ed25519 identity keys, so
+// recorded messages can be decrypted once either is broken.
+var e2eeIdentifiers = slices.Concat(
+	functionsOf("go.mau.fi/libsignal/session", "NewBuilder", "NewBuilderFromSignal", "NewCipher", "NewCipherFromSession", "ProcessBundle", "Encrypt", "Decrypt"),
+	functionsOf("go.mau.fi/libsignal/util/keyhelper", "GenerateIdentityKeyPair", "GeneratePreKeys", "GenerateSignedPreKey"),
+	functionsOf("github.com/RadicalApp/libsignal-protocol-go/session", "NewBuilder", "NewBuilderFromSignal", "NewCipher", "NewCipherFromSession", "ProcessBundle", "Encrypt", "Decrypt"),
+	functionsOf("github.com/RadicalApp/libsignal-protocol-go/util/keyhelper", "GenerateIdentityKeyPair", "GeneratePreKeys", "GenerateSignedPreKey"),
+	functionsOf("maunium.net/go/mautrix/crypto", "NewOlmMachine", "ShareGroupSession", "EncryptMegolmEvent", "DecryptMegolmEvent"),
+	functionsOf("maunium.net/go/mautrix/crypto/olm", "NewAccount", "AccountFromPickled", "NewOutboundGroupSession", "NewInboundGroupSession", "NewOutboundSession", "NewInboundSession"),
+)
@@ -0,0 +1,27 @@
+package analyzer
+
+// Imports implementing Ed25519 signatures. These are reported at the low
+// severity of verification; generating keys and signing escalate at the call
+// site.
+var ed25519ImportPaths = []string{
+	"crypto/ed25519",
+	"golang.org/x/crypto/ed25519",
+}
+
+// Identifiers of functions and methods generating Ed25519 keys or signing.
+var ed25519SigningIdentifiers = []QvFunction{
+	{"GenerateKey", "crypto/ed25519"},
+	{"NewKeyFromSeed", "crypto/ed25519"},
+	{"Sign", "crypto/ed25519"},
+	{"GenerateKey", "golang.org/x/crypto/ed25519"},
+	{"NewKeyFromSeed", "golang.org/x/crypto/ed25519"},
+	{"Sign", "golang.org/x/crypto/ed25519"},
+}
+
+// Identifiers of functions verifying Ed25519 signatures. Verification of
+// existing signatures often has to continue long after new signing stops.
+var ed25519VerificationIdentifiers = []QvFunction{
+	{"Verify", "crypto/ed25519"},
+	{"VerifyWithOptions", "crypto/ed25519"},
+	{"Verify", "golang.org/x/crypto/ed25519"},
+}
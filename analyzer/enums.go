@@ -0,0 +1,216 @@
+package analyzer
+
+import (
+	"go/ast"
+	"go/constant"
+	"go/types"
+	"slices"
+	"strings"
+	"unicode"
+)
+
+// Classical algorithm families named by enum constants with a key size or
+// curve appended, such as AlgRSA2048 or DH4096.
+var sizedAlgorithmNames = []string{"rsa", "dsa", "ecdsa", "ecdh", "dh"}
+
+// Reports the enum constants naming classical algorithms, such as AlgRSA2048
+// or KeyTypeECDSA, that flow into the configured crypto wrappers: passed to
+// them, set in the fields of struct literals passed to them, or assigned to
+// the variables and fields passed to them earlier in the function. Policy
+// constants pick the algorithm the wrapper runs, so they have to change along
+// with it.
+func reportAlgorithmEnums(r *reporter, file *ast.File, wrappers []Wrapper) {
+	if len(wrappers) == 0 {
+		return
+	}
+	info := r.pass.TypesInfo
+	for _, decl := range file.Decls {
+		funcDecl, ok := decl.(*ast.FuncDecl)
+		if !ok || funcDecl.Body == nil {
+			continue
+		}
+		// Enum constants assigned to each variable of the function or to
+		// its fields, in source order.
+		var assigned map[types.Object][]ast.Expr
+		assign := func(lhs, rhs ast.Expr) {
+			obj := rootVariable(info, lhs)
+			if obj == nil {
+				return
+			}
+			if enums := algorithmEnums(info, rhs, nil); len(enums) > 0 {
+				if assigned == nil {
+					assigned = make(map[types.Object][]ast.Expr)
+				}
+				assigned[obj] = append(assigned[obj], enums...)
+			}
+		}
+		ast.Inspect(funcDecl.Body, func(node ast.Node) bool {
+			switch node := node.(type) {
+			case *ast.AssignStmt:
+				if len(node.Lhs) == len(node.Rhs) {
+					for i, lhs := range node.Lhs {
+						assign(lhs, node.Rhs[i])
+					}
+				}
+			case *ast.ValueSpec:
+				if len(node.Names) == len(node.Values) {
+					for i, name := range node.Names {
+						assign(name, node.Values[i])
+					}
+				}
+			case *ast.CallExpr:
+				wrapper, ok := calledWrapper(info, node, wrappers)
+				if !ok {
+					return true
+				}
+				for _, arg := range node.Args {
+					enums := algorithmEnums(info, arg, nil)
+					if obj := rootVariable(info, arg); obj != nil {
+						enums = append(enums, assigned[obj]...)
+					}
+					for _, enum := range enums {
+						constant := enumConstant(info, enum)
+						algorithm, _ := classicalEnum(constant)
+						r.report(enum.Pos(), ruleAlgorithmEnum, `enum "%s.%s" selects classical %s for wrapper "%s.%s", which wraps "%s.%s"; the policy constant has to change along with the wrapper`, constant.Pkg().Name(), constant.Name(), algorithm, wrapper.Function.Package, wrapper.Function.FnName, wrapper.Wraps.Package, wrapper.Wraps.FnName)
+					}
+				}
+			}
+			return true
+		})
+	}
+}
+
+// Returns the configured wrapper a call calls.
+func calledWrapper(info *types.Info, call *ast.CallExpr, wrappers []Wrapper) (Wrapper, bool) {
+	fn, recv := callee(info, call)
+	if fn == nil || recv != nil || fn.Pkg() == nil {
+		return Wrapper{}, false
+	}
+	idx := slices.IndexFunc(wrappers, func(wrapper Wrapper) bool {
+		return wrapper.Function == QvFunction{fn.Name(), fn.Pkg().Path()}
+	})
+	if idx == -1 {
+		return Wrapper{}, false
+	}
+	return wrappers[idx], true
+}
+
+// Returns the variable an expression refers to, or whose field or element it
+// refers to, such as cfg in &cfg or cfg.Key.Algorithm.
+func rootVariable(info *types.Info, expr ast.Expr) types.Object {
+	for {
+		switch e := ast.Unparen(expr).(type) {
+		case *ast.Ident:
+			v, ok := info.ObjectOf(e).(*types.Var)
+			if !ok || v.IsField() {
+				return nil
+			}
+			return v
+		case *ast.SelectorExpr:
+			if _, ok := info.Selections[e]; !ok {
+				return nil
+			}
+			expr = e.X
+		case *ast.IndexExpr:
+			expr = e.X
+		case *ast.StarExpr:
+			expr = e.X
+		case *ast.UnaryExpr:
+			expr = e.X
+		default:
+			return nil
+		}
+	}
+}
+
+// Appends to enums the classical algorithm enum constants of an expression,
+// itself or nested in the fields and elements of its composite literals.
+func algorithmEnums(info *types.Info, expr ast.Expr, enums []ast.Expr) []ast.Expr {
+	switch e := ast.Unparen(expr).(type) {
+	case *ast.Ident, *ast.SelectorExpr:
+		if _, ok := classicalEnum(enumConstant(info, e)); ok {
+			enums = append(enums, e)
+		}
+	case *ast.UnaryExpr:
+		enums = algorithmEnums(info, e.X, enums)
+	case *ast.KeyValueExpr:
+		enums = algorithmEnums(info, e.Value, enums)
+	case *ast.CompositeLit:
+		for _, elt := range e.Elts {
+			enums = algorithmEnums(info, elt, enums)
+		}
+	}
+	return enums
+}
+
+// Returns the constant of a named type an identifier or qualified identifier
+// refers to.
+func enumConstant(info *types.Info, expr ast.Expr) *types.Const {
+	var ident *ast.Ident
+	switch e := expr.(type) {
+	case *ast.Ident:
+		ident = e
+	case *ast.SelectorExpr:
+		ident = e.Sel
+	default:
+		return nil
+	}
+	c, ok := info.Uses[ident].(*types.Const)
+	if !ok || c.Pkg() == nil {
+		return nil
+	}
+	if _, ok := types.Unalias(c.Type()).(*types.Named); !ok {
+		return nil
+	}
+	return c
+}
+
+// Returns the classical algorithm an enum constant names, by a word of its
+// name, such as RSA2048 in AlgRSA2048, or by its string value, such as
+// "ecdsa".
+func classicalEnum(c *types.Const) (string, bool) {
+	if c == nil {
+		return "", false
+	}
+	for _, word := range nameWords(c.Name()) {
+		lower := strings.ToLower(word)
+		if slices.Contains(algorithmNames, lower) || slices.Contains(sizedAlgorithmNames, strings.TrimRightFunc(lower, unicode.IsDigit)) {
+			return word, true
+		}
+	}
+	if c.Val().Kind() == constant.String {
+		value := constant.StringVal(c.Val())
+		if slices.Contains(algorithmNames, strings.ToLower(value)) {
+			return value, true
+		}
+	}
+	return "", false
+}
+
+// Splits a Go identifier into its words, at underscores and case changes,
+// keeping acronyms and the digits following a word together, such as Alg and
+// RSA2048 in AlgRSA2048 or Key, Type and ECDSA in KeyTypeECDSA.
+func nameWords(name string) []string {
+	var words []string
+	runes := []rune(name)
+	start := 0
+	for i := 1; i <= len(runes); i++ {
+		boundary := i == len(runes) || runes[i] == '_'
+		if !boundary && unicode.IsUpper(runes[i]) {
+			prev := runes[i-1]
+			boundary = unicode.IsLower(prev) || unicode.IsDigit(prev) ||
+				unicode.IsUpper(prev) && i+1 < len(runes) && unicode.IsLower(runes[i+1])
+		}
+		if !boundary {
+			continue
+		}
+		if start < i && runes[start] != '_' {
+			words = append(words, string(runes[start:i]))
+		}
+		start = i
+		if i < len(runes) && runes[i] == '_' {
+			start = i + 1
+		}
+	}
+	return words
+}
@@ -0,0 +1,172 @@
+package analyzer
+
+import (
+	"fmt"
+	"go/ast"
+	"go/constant"
+	"go/token"
+	"go/types"
+	"slices"
+	"strings"
+)
+
+// Enum types of crypto/x509 identifying the algorithms of certificates.
+var certificateAlgorithmTypes = []QvFunction{
+	{"PublicKeyAlgorithm", "crypto/x509"},
+	{"SignatureAlgorithm", "crypto/x509"},
+}
+
+// Number of unhandled algorithms listed by name in findings.
+const maxUnhandledAlgorithms = 3
+
+// Reports switches on the certificate algorithm enums of crypto/x509 without
+// a default case. Certificates with algorithms the switch does not handle,
+// such as ML-DSA or composite certificates, silently fall through it; what
+// follows the switch tells whether they are then accepted or rejected.
+func reportUnhandledCertificateAlgorithms(r *reporter, file *ast.File) {
+	info := r.pass.TypesInfo
+	ast.Inspect(file, func(node ast.Node) bool {
+		switch node := node.(type) {
+		case *ast.FuncDecl:
+			if fn, ok := info.Defs[node.Name].(*types.Func); ok && node.Body != nil {
+				reportUnhandledAlgorithmSwitches(r, node.Body, fn.Type().(*types.Signature))
+			}
+		case *ast.FuncLit:
+			if signature, ok := info.TypeOf(node).(*types.Signature); ok {
+				reportUnhandledAlgorithmSwitches(r, node.Body, signature)
+			}
+		}
+		return true
+	})
+}
+
+// Reports the switches without a default case in the body of a function
+// with the signature, leaving nested function literals to their own pass.
+func reportUnhandledAlgorithmSwitches(r *reporter, body *ast.BlockStmt, signature *types.Signature) {
+	info := r.pass.TypesInfo
+	ast.Inspect(body, func(node ast.Node) bool {
+		var stmts []ast.Stmt
+		switch node := node.(type) {
+		case *ast.FuncLit:
+			return false
+		case *ast.BlockStmt:
+			stmts = node.List
+		case *ast.CaseClause:
+			stmts = node.Body
+		case *ast.CommClause:
+			stmts = node.Body
+		}
+		for i, stmt := range stmts {
+			switchStmt, ok := stmt.(*ast.SwitchStmt)
+			if !ok || switchStmt.Tag == nil {
+				continue
+			}
+			t := info.TypeOf(switchStmt.Tag)
+			if !isNamedType(t, certificateAlgorithmTypes) || hasDefaultCase(switchStmt) {
+				continue
+			}
+			var next ast.Stmt
+			if i+1 < len(stmts) {
+				next = stmts[i+1]
+			}
+			typeName := types.TypeString(t, (*types.Package).Name)
+			outcome := fallThroughOutcome(info, signature, next)
+			unhandled := unhandledConstants(info, switchStmt, t)
+			if len(unhandled) == 0 {
+				r.report(switchStmt.Pos(), ruleUnhandledCertificateAlgorithm, "switch on %s handles every algorithm known today but has no default case; composite certificates and algorithms added later fall through it %s", typeName, outcome)
+				continue
+			}
+			r.report(switchStmt.Pos(), ruleUnhandledCertificateAlgorithm, "switch on %s has no default case and does not handle %s; certificates with those algorithms, such as post-quantum and composite ones, fall through it %s", typeName, describeUnhandled(unhandled), outcome)
+		}
+		return true
+	})
+}
+
+func hasDefaultCase(stmt *ast.SwitchStmt) bool {
+	return slices.ContainsFunc(stmt.Body.List, func(clause ast.Stmt) bool {
+		return clause.(*ast.CaseClause).List == nil
+	})
+}
+
+// Returns the names of the constants of the enum type t, ordered by value,
+// that no case of the switch handles. The zero "Unknown" values are left out.
+func unhandledConstants(info *types.Info, stmt *ast.SwitchStmt, t types.Type) []string {
+	named, ok := types.Unalias(t).(*types.Named)
+	if !ok || named.Obj().Pkg() == nil {
+		return nil
+	}
+	handled := make(map[string]bool)
+	for _, clause := range stmt.Body.List {
+		for _, expr := range clause.(*ast.CaseClause).List {
+			if tv, ok := info.Types[expr]; ok && tv.Value != nil {
+				handled[tv.Value.ExactString()] = true
+			}
+		}
+	}
+	var unhandled []*types.Const
+	scope := named.Obj().Pkg().Scope()
+	for _, name := range scope.Names() {
+		c, ok := scope.Lookup(name).(*types.Const)
+		if !ok || !c.Exported() || !types.Identical(c.Type(), t) || strings.HasPrefix(name, "Unknown") || handled[c.Val().ExactString()] {
+			continue
+		}
+		unhandled = append(unhandled, c)
+	}
+	slices.SortFunc(unhandled, func(a, b *types.Const) int {
+		if constant.Compare(a.Val(), token.LSS, b.Val()) {
+			return -1
+		}
+		return 1
+	})
+	names := make([]string, len(unhandled))
+	for i, c := range unhandled {
+		names[i] = c.Name()
+	}
+	return names
+}
+
+// Lists the unhandled algorithms, naming only the first few.
+func describeUnhandled(names []string) string {
+	if len(names) <= maxUnhandledAlgorithms+1 {
+		return strings.Join(names, ", ")
+	}
+	return fmt.Sprintf("%s and %d more", strings.Join(names[:maxUnhandledAlgorithms], ", "), len(names)-maxUnhandledAlgorithms)
+}
+
+// Describes what becomes of the values falling through a switch, judging by
+// the statement following it in a function with the signature.
+func fallThroughOutcome(info *types.Info, signature *types.Signature, next ast.Stmt) string {
+	const (
+		accepted  = "and are accepted, failing open"
+		rejected  = "and are rejected, failing closed"
+		unhandled = "unhandled"
+	)
+	switch next := next.(type) {
+	case *ast.ExprStmt:
+		if call, ok := next.X.(*ast.CallExpr); ok {
+			if ident, ok := ast.Unparen(call.Fun).(*ast.Ident); ok && info.Uses[ident] == types.Universe.Lookup("panic") {
+				return rejected
+			}
+		}
+	case *ast.ReturnStmt:
+		results := signature.Results()
+		if len(next.Results) == 0 || results.Len() != len(next.Results) {
+			return unhandled
+		}
+		last := next.Results[len(next.Results)-1]
+		tv := info.Types[last]
+		switch {
+		case types.Identical(results.At(results.Len()-1).Type(), types.Universe.Lookup("error").Type()):
+			if tv.IsNil() {
+				return accepted
+			}
+			return rejected
+		case tv.Value != nil && tv.Value.Kind() == constant.Bool:
+			if constant.BoolVal(tv.Value) {
+				return accepted
+			}
+			return rejected
+		}
+	}
+	return unhandled
+}
@@ -0,0 +1,7 @@
+package analyzer
+
+// UnregisterDetector removes a detector registered by a test.
+var UnregisterDetector = unregisterDetector
+
+// ResolvePath resolves the constant paths of key files.
+var ResolvePath = resolvePath
@@ -0,0 +1,88 @@
+package analyzer
+
+import (
+	"go/ast"
+	"go/build/constraint"
+	"go/token"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// Imports that only exist to enforce or query FIPS 140 mode.
+var fipsImportPaths = []string{
+	"crypto/tls/fipsonly",
+	"crypto/fips140",
+}
+
+// Build tags that select the BoringCrypto FIPS module.
+var boringCryptoTags = []string{
+	"boringcrypto",
+	"goexperiment.boringcrypto",
+}
+
+const fipsGuidance = "classical FIPS 140-2 configurations will need a FIPS 203/204/205 module plan"
+
+// The GODEBUG setting controlling the Go Cryptographic Module's FIPS 140 mode.
+const fipsGODEBUG = "fips140"
+
+// Reports build constraints selecting BoringCrypto and go:debug directives
+// setting the fips140 GODEBUG setting, at the package clause of their file,
+// and string literals handling the setting. These are informational: they
+// are not vulnerable by themselves, but FIPS 140-2 validated configurations
+// only approve classical algorithms.
+func reportFIPSConfiguration(r *reporter, file *ast.File) {
+	for _, group := range file.Comments {
+		for _, comment := range group.List {
+			switch {
+			case constraint.IsGoBuild(comment.Text):
+				expr, err := constraint.Parse(comment.Text)
+				if err != nil {
+					continue
+				}
+				if tag, ok := boringCryptoTag(expr); ok {
+					reportFIPS(r, file.Package, "file is built conditionally on the %s tag", tag)
+				}
+			case strings.HasPrefix(comment.Text, "//go:debug ") && strings.Contains(comment.Text, fipsGODEBUG):
+				reportFIPS(r, file.Package, "go:debug directive sets the %s GODEBUG setting", fipsGODEBUG)
+			}
+		}
+	}
+
+	ast.Inspect(file, func(node ast.Node) bool {
+		lit, ok := node.(*ast.BasicLit)
+		if !ok || lit.Kind != token.STRING {
+			return true
+		}
+		value, err := strconv.Unquote(lit.Value)
+		if err == nil && strings.Contains(value, fipsGODEBUG+"=") {
+			reportFIPS(r, lit.Pos(), "string %s handles the %s GODEBUG setting", lit.Value, fipsGODEBUG)
+		}
+		return true
+	})
+}
+
+func reportFIPS(r *reporter, pos token.Pos, format string, args ...any) {
+	r.report(pos, ruleFIPSMode, format+"; "+fipsGuidance, args...)
+}
+
+// Returns the BoringCrypto tag the build constraint refers to, if any.
+func boringCryptoTag(expr constraint.Expr) (string, bool) {
+	switch expr := expr.(type) {
+	case *constraint.TagExpr:
+		return expr.Tag, slices.Contains(boringCryptoTags, expr.Tag)
+	case *constraint.NotExpr:
+		return boringCryptoTag(expr.X)
+	case *constraint.AndExpr:
+		if tag, ok := boringCryptoTag(expr.X); ok {
+			return tag, true
+		}
+		return boringCryptoTag(expr.Y)
+	case *constraint.OrExpr:
+		if tag, ok := boringCryptoTag(expr.X); ok {
+			return tag, true
+		}
+		return boringCryptoTag(expr.Y)
+	}
+	return "", false
+}
@@ -0,0 +1,47 @@
+package analyzer
+
+import (
+	"go/ast"
+	"go/types"
+	"slices"
+)
+
+// Types holding public key material.
+var publicKeyTypes = []QvFunction{
+	{"PublicKey", "crypto/rsa"},
+	{"PublicKey", "crypto/ecdsa"},
+	{"PublicKey", "crypto/ed25519"},
+	{"PublicKey", "crypto/ecdh"},
+	{"PublicKey", "crypto/dsa"},
+	{"PublicKey", "golang.org/x/crypto/ed25519"},
+}
+
+// Reports generic functions and types instantiated with quantum-vulnerable
+// key types, such as sign[K crypto.Signer] instantiated with
+// *ecdsa.PrivateKey. The instantiation is often inferred, so it never appears
+// in the source.
+func reportGenericInstantiations(r *reporter, file *ast.File) {
+	info := r.pass.TypesInfo
+	keyTypes := slices.Concat(privateKeyTypes, publicKeyTypes)
+	ast.Inspect(file, func(node ast.Node) bool {
+		ident, ok := node.(*ast.Ident)
+		if !ok {
+			return true
+		}
+		instance, ok := info.Instances[ident]
+		if !ok {
+			return true
+		}
+
+		kind := "function"
+		if _, ok := info.Uses[ident].(*types.TypeName); ok {
+			kind = "type"
+		}
+		for typeArg := range instance.TypeArgs.Types() {
+			if isNamedType(typeArg, keyTypes) {
+				r.reportOperation(ident.Pos(), ruleGenericKeyInstantiation, classifyOperation(ident.Name), "generic %s \"%s\" is instantiated with quantum-vulnerable key type %s", kind, ident.Name, types.TypeString(typeArg, (*types.Package).Name))
+			}
+		}
+		return true
+	})
+}
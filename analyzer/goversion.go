@@ -0,0 +1,134 @@
+package analyzer
+
+import (
+	"go/ast"
+	"go/types"
+	"go/version"
+	"slices"
+	"strconv"
+	"strings"
+
+	"golang.org/x/tools/go/analysis"
+)
+
+// Go version that added crypto/mlkem, crypto/hkdf, crypto/pbkdf2 and
+// crypto/sha3 to the standard library, and made crypto/tls negotiate the
+// hybrid X25519MLKEM768 key exchange by default. The TLS default is a GODEBUG
+// setting (tlsmlkem) tied to the go directive of the main module, so it stays
+// off for modules declaring an older version, even when built with a newer
+// toolchain.
+const goVersionPQ = "go1.24"
+
+// Standard library replacements of x/crypto packages added in goVersionPQ.
+var stdlibReplacements = map[string]string{
+	"golang.org/x/crypto/hkdf":   "crypto/hkdf",
+	"golang.org/x/crypto/pbkdf2": "crypto/pbkdf2",
+	"golang.org/x/crypto/sha3":   "crypto/sha3",
+}
+
+// Reports suggestions that depend on the Go version of the file: whether
+// crypto/mlkem is available to replace crypto/ecdh, which x/crypto packages
+// moved to the standard library, and whether crypto/tls defaults to hybrid
+// post-quantum key exchange.
+func reportGoVersion(r *reporter, file *ast.File) {
+	goVersion := fileGoVersion(r.pass, file)
+	// Without a known version, the file is built with the current toolchain.
+	if goVersion == "" {
+		return
+	}
+	available := version.Compare(goVersion, goVersionPQ) >= 0
+
+	for _, currImport := range file.Imports {
+		importPath, err := strconv.Unquote(currImport.Path.Value)
+		if err != nil {
+			continue
+		}
+		switch {
+		case importPath == "crypto/ecdh" && available && tlsOnlyECDH(r.pass.TypesInfo, file):
+			// ML-KEM does not replace the key exchange of TLS configuration,
+			// which crypto/tls already makes hybrid.
+		case importPath == "crypto/ecdh" && available:
+			r.report(currImport.Pos(), ruleToolchainReplacements, `"crypto/mlkem" is available to replace "crypto/ecdh" key exchange with ML-KEM`)
+		case importPath == "crypto/ecdh":
+			r.report(currImport.Pos(), ruleToolchainReplacements, `"crypto/mlkem", the replacement of "crypto/ecdh" key exchange, requires %s, but the file targets %s; use github.com/cloudflare/circl/kem/mlkem or raise the go directive`, goVersionPQ, goVersion)
+		case stdlibReplacements[importPath] != "" && available:
+			r.report(currImport.Pos(), ruleToolchainReplacements, `%s is available in the standard library as "%s" since %s`, currImport.Path.Value, stdlibReplacements[importPath], goVersionPQ)
+		case importPath == "crypto/tls" && !available:
+			r.report(currImport.Pos(), ruleTLSHybridDefault, `"crypto/tls" does not negotiate hybrid post-quantum X25519MLKEM768 key exchange by default, as the file targets %s; raise the go directive to %s or later`, goVersion, goVersionPQ)
+		}
+	}
+}
+
+// Returns the Go version the file is built for, from its build constraints or
+// the go directive of its module, or "" if unknown.
+func fileGoVersion(pass *analysis.Pass, file *ast.File) string {
+	goVersion := pass.TypesInfo.FileVersions[file]
+	if goVersion == "" && pass.Module != nil && pass.Module.GoVersion != "" {
+		goVersion = "go" + pass.Module.GoVersion
+	}
+	return goVersion
+}
+
+// Reports, at the package clause of packages of the main module reaching
+// crypto/tls, such as through net/http, the key exchange and certificate
+// defaults crypto/tls gets from a go directive older than goVersionPQ, which
+// leaves hybrid post-quantum key exchange off. The GODEBUG defaults of a
+// binary follow the go directive of its main module, not the toolchain
+// building it, so the finding is the same for every package of the module,
+// and scans keep one per module. Test mains and other generated files are
+// skipped.
+func reportTLSToolchainDefaults(r *reporter) {
+	pass := r.pass
+	if pass.Module == nil || pass.Module.GoVersion == "" || pass.Module.Version != "" {
+		return
+	}
+	goVersion := "go" + pass.Module.GoVersion
+	if version.Compare(goVersion, goVersionPQ) >= 0 {
+		return
+	}
+	i := slices.IndexFunc(pass.Files, func(file *ast.File) bool {
+		return !ast.IsGenerated(file) && !strings.HasSuffix(pass.Fset.File(file.Pos()).Name(), "_test.go")
+	})
+	if i < 0 || !reachesPackage(pass.Pkg, "crypto/tls", make(map[*types.Package]bool)) {
+		return
+	}
+
+	message := goVersion + ": crypto/tls " + tlsKeyExchangeDefaults(goVersion) + ", and " + tlsCertificateDefaults +
+		"; raise the go directive to " + goVersionPQ + " or later for hybrid X25519MLKEM768 key exchange by default"
+	r.report(pass.Files[i].Package, ruleTLSToolchainDefaults, "%s", message)
+}
+
+// Returns the key exchange crypto/tls negotiates by default at a Go version
+// older than goVersionPQ.
+func tlsKeyExchangeDefaults(goVersion string) string {
+	var defaults string
+	switch {
+	case version.Compare(goVersion, "go1.23") >= 0:
+		defaults = "negotiates the pre-standard X25519Kyber768Draft00 hybrid key exchange by default, which " + goVersionPQ + " replaces with X25519MLKEM768"
+	default:
+		defaults = "negotiates classical ECDHE key exchange only"
+	}
+	if version.Compare(goVersion, "go1.22") < 0 {
+		defaults += ", and offers RSA key exchange cipher suites without forward secrecy"
+	}
+	return defaults
+}
+
+// The certificates crypto/tls and crypto/x509 accept by default at a Go
+// version older than goVersionPQ.
+const tlsCertificateDefaults = "verifies classical RSA, ECDSA and Ed25519 certificates, accepting SHA-1 signatures in TLS 1.2 handshakes and RSA keys under 1024 bits"
+
+// Reports whether pkg is, or transitively imports, the package with the
+// given path.
+func reachesPackage(pkg *types.Package, path string, visited map[*types.Package]bool) bool {
+	if pkg.Path() == path {
+		return true
+	}
+	visited[pkg] = true
+	for _, imported := range pkg.Imports() {
+		if !visited[imported] && reachesPackage(imported, path, visited) {
+			return true
+		}
+	}
+	return false
+}
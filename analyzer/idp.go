@@ -0,0 +1,148 @@
+package analyzer
+
+import (
+	"go/ast"
+	"go/types"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// Functions configuring the token signing of an OAuth2 or OpenID Connect
+// provider: fosite's composers and strategies taking the signing key, and
+// the zitadel/oidc provider constructors signing with the keys of their
+// storage.
+var idpProviderIdentifiers = slices.Concat(
+	functionsOf("github.com/ory/fosite/compose", "ComposeAllEnabled", "NewOAuth2JWTStrategy", "NewOpenIDConnectStrategy"),
+	functionsOf("github.com/zitadel/oidc/v2/pkg/op", "NewOpenIDProvider", "NewProvider"),
+	functionsOf("github.com/zitadel/oidc/v3/pkg/op", "NewOpenIDProvider", "NewProvider"),
+)
+
+// Imports marking a package as identity provider code.
+var idpImportPaths = []string{
+	"github.com/ory/fosite",
+	"github.com/zitadel/oidc/v2/pkg/op",
+	"github.com/zitadel/oidc/v3/pkg/op",
+}
+
+// Fields of fosite signers returning the private key tokens are signed with.
+var idpSignerFields = []QvField{
+	{"GetPrivateKey", "DefaultSigner", "github.com/ory/fosite/token/jwt"},
+}
+
+// Functions of go-jose building a signer from the SigningKey passed as their
+// first argument.
+var joseSignerConstructors = slices.Concat(
+	functionsOf("github.com/go-jose/go-jose/v3", "NewSigner"),
+	functionsOf("github.com/go-jose/go-jose/v4", "NewSigner"),
+	functionsOf("gopkg.in/square/go-jose.v2", "NewSigner"),
+	functionsOf("gopkg.in/go-jose/go-jose.v2", "NewSigner"),
+)
+
+// Substrings of string literals marking a package as identity provider code:
+// the discovery document and token endpoints it serves.
+var idpMarkers = []string{"/.well-known/openid-configuration", "/oauth2/token", "id_token"}
+
+const idpSigningMessage = "a compromise of an identity provider's signing key affects every relying party, so its keys and JWKS have to be migrated first"
+
+// Reports token signing in OAuth2 and OpenID Connect provider code: fosite
+// and zitadel/oidc providers, fosite signers, and go-jose signers built with
+// classical algorithms in packages serving as an identity provider. Unlike
+// other token signing, every relying party trusts these keys.
+func reportIdPTokenSigning(r *reporter) {
+	info := r.pass.TypesInfo
+	var files []*ast.File
+	for _, file := range r.pass.Files {
+		if file.Name == nil || !strings.HasSuffix(file.Name.Name, "_test") {
+			files = append(files, file)
+		}
+	}
+	provider := slices.ContainsFunc(files, isIdentityProviderFile)
+
+	for _, file := range files {
+		ast.Inspect(file, func(node ast.Node) bool {
+			switch node := node.(type) {
+			case *ast.CallExpr:
+				selector, ok := node.Fun.(*ast.SelectorExpr)
+				if !ok {
+					return true
+				}
+				localImportName, ok := selector.X.(*ast.Ident)
+				if !ok {
+					return true
+				}
+				if fnName, ok := vulnerableFunction(info, localImportName, selector.Sel, idpProviderIdentifiers); ok {
+					r.reportOperation(selector.Sel.Pos(), ruleIdPTokenSigning, OperationPrivate, `"%s" configures an OAuth2 or OpenID Connect provider signing tokens with classical keys; %s`, fnName, idpSigningMessage)
+					return true
+				}
+				if fnName, ok := vulnerableFunction(info, localImportName, selector.Sel, joseSignerConstructors); ok && provider && len(node.Args) > 0 {
+					algorithm, known := signingKeyAlgorithm(info, node.Args[0])
+					if known && strings.HasPrefix(algorithm, "HS") {
+						// HMAC tokens are symmetric.
+						return true
+					}
+					if !known {
+						algorithm = "classical"
+					}
+					r.reportOperation(selector.Sel.Pos(), ruleIdPTokenSigning, OperationPrivate, `"%s" builds a %s token signer in an identity provider; %s`, fnName, algorithm, idpSigningMessage)
+				}
+			case *ast.CompositeLit:
+				for _, elt := range node.Elts {
+					keyValue, ok := elt.(*ast.KeyValueExpr)
+					if !ok {
+						continue
+					}
+					if fieldName, ok := vulnerableField(info, node, keyValue.Key, idpSignerFields); ok {
+						r.reportOperation(keyValue.Key.Pos(), ruleIdPTokenSigning, OperationPrivate, `field "%s" sets the key an OAuth2 or OpenID Connect provider signs tokens with; %s`, fieldName, idpSigningMessage)
+					}
+				}
+			}
+			return true
+		})
+	}
+}
+
+// Reports whether the file imports an identity provider framework, or
+// mentions the endpoints of one.
+func isIdentityProviderFile(file *ast.File) bool {
+	for _, spec := range file.Imports {
+		importPath, err := strconv.Unquote(spec.Path.Value)
+		if err == nil && slices.ContainsFunc(idpImportPaths, func(prefix string) bool {
+			return importPath == prefix || strings.HasPrefix(importPath, prefix+"/")
+		}) {
+			return true
+		}
+	}
+	found := false
+	ast.Inspect(file, func(node ast.Node) bool {
+		if lit, ok := node.(*ast.BasicLit); ok {
+			found = found || slices.ContainsFunc(idpMarkers, func(marker string) bool {
+				return strings.Contains(lit.Value, marker)
+			})
+		}
+		return !found
+	})
+	return found
+}
+
+// Returns the algorithm of a go-jose SigningKey literal, if it is a constant.
+func signingKeyAlgorithm(info *types.Info, key ast.Expr) (string, bool) {
+	if unary, ok := key.(*ast.UnaryExpr); ok {
+		key = unary.X
+	}
+	lit, ok := key.(*ast.CompositeLit)
+	if !ok {
+		return "", false
+	}
+	for _, elt := range lit.Elts {
+		keyValue, ok := elt.(*ast.KeyValueExpr)
+		if !ok {
+			continue
+		}
+		if ident, ok := keyValue.Key.(*ast.Ident); !ok || ident.Name != "Algorithm" {
+			continue
+		}
+		return stringConstant(info, keyValue.Value)
+	}
+	return "", false
+}
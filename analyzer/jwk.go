@@ -0,0 +1,103 @@
+package analyzer
+
+import (
+	"go/ast"
+	"go/constant"
+	"go/types"
+	"slices"
+	"strings"
+)
+
+// Functions of the jwx packages building a JWK from the raw key passed as
+// their first argument: jwk.New in v1, jwk.FromRaw in v2 and jwk.Import in v3.
+var jwkConstructors = []QvFunction{
+	{"New", "github.com/lestrrat-go/jwx/jwk"},
+	{"FromRaw", "github.com/lestrrat-go/jwx/v2/jwk"},
+	{"Import", "github.com/lestrrat-go/jwx/v3/jwk"},
+}
+
+// Fields of the go-jose JSONWebKey holding its raw key.
+var jwkKeyFields = []QvField{
+	{"Key", "JSONWebKey", "github.com/go-jose/go-jose/v3"},
+	{"Key", "JSONWebKey", "github.com/go-jose/go-jose/v4"},
+	{"Key", "JSONWebKey", "gopkg.in/square/go-jose.v2"},
+	{"Key", "JSONWebKey", "gopkg.in/go-jose/go-jose.v2"},
+}
+
+// Functions and methods registering HTTP handlers for the route pattern passed
+// as their first argument.
+var routeRegistrations = slices.Concat(
+	functionsOf("net/http", "Handle", "HandleFunc"),
+	functionsOf("github.com/gorilla/mux", "Handle", "HandleFunc"),
+	functionsOf("github.com/go-chi/chi/v5", "Get", "Handle", "HandleFunc", "Method"),
+	functionsOf("github.com/gin-gonic/gin", "GET", "Handle", "Any"),
+)
+
+// Reports JWKs built from classical keys with jwx or go-jose, and the JWKS
+// endpoints serving them. A JWKS encodes the organization's public-key
+// algorithm choices for every relying party, so changing them needs
+// coordinated migration rather than a local code change.
+func reportJWKs(r *reporter, file *ast.File) {
+	info := r.pass.TypesInfo
+	keyTypes := slices.Concat(privateKeyTypes, publicKeyTypes)
+	ast.Inspect(file, func(node ast.Node) bool {
+		switch node := node.(type) {
+		case *ast.CallExpr:
+			selector, ok := node.Fun.(*ast.SelectorExpr)
+			if !ok || len(node.Args) == 0 {
+				return true
+			}
+			if localImportName, ok := selector.X.(*ast.Ident); ok {
+				if fnName, ok := vulnerableFunction(info, localImportName, selector.Sel, jwkConstructors); ok {
+					reportJWKKey(r, fnName, "function", node.Args[0], keyTypes)
+					return true
+				}
+				if fnName, ok := vulnerableFunction(info, localImportName, selector.Sel, routeRegistrations); ok {
+					reportJWKSRoute(r, fnName, "function", node.Args[0])
+					return true
+				}
+			}
+			if methodName, ok := vulnerableMethod(info, selector, routeRegistrations); ok {
+				reportJWKSRoute(r, methodName, "method", node.Args[0])
+			}
+		case *ast.CompositeLit:
+			for _, elt := range node.Elts {
+				keyValue, ok := elt.(*ast.KeyValueExpr)
+				if !ok {
+					continue
+				}
+				if fieldName, ok := vulnerableField(info, node, keyValue.Key, jwkKeyFields); ok {
+					reportJWKKey(r, fieldName, "field", keyValue.Value, keyTypes)
+				}
+			}
+		}
+		return true
+	})
+}
+
+// Reports the key of a JWK if its type is a classical key type.
+func reportJWKKey(r *reporter, name, kind string, key ast.Expr, keyTypes []QvFunction) {
+	t := r.pass.TypesInfo.TypeOf(key)
+	if !isNamedType(t, keyTypes) {
+		return
+	}
+	operation := OperationPublic
+	if isNamedType(t, privateKeyTypes) {
+		operation = OperationPrivate
+	}
+	r.reportOperation(key.Pos(), ruleJWK, operation, `%s "%s" builds a JWK from quantum-vulnerable key type %s; JWKS consumers are pinned to its algorithm until every relying party migrates`, kind, name, types.TypeString(t, (*types.Package).Name))
+}
+
+// Reports the registration of a handler if its route pattern is that of a
+// JWKS endpoint, such as "/.well-known/jwks.json".
+func reportJWKSRoute(r *reporter, name, kind string, pattern ast.Expr) {
+	tv := r.pass.TypesInfo.Types[pattern]
+	if tv.Value == nil || tv.Value.Kind() != constant.String {
+		return
+	}
+	route := constant.StringVal(tv.Value)
+	if !strings.Contains(strings.ToLower(route), "jwks") {
+		return
+	}
+	r.report(pattern.Pos(), ruleJWK, `%s "%s" serves the JWKS endpoint %q, which publishes the organization's public-key algorithms; migrating them needs coordination with every relying party`, kind, name, route)
+}
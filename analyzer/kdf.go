@@ -0,0 +1,240 @@
+package analyzer
+
+import (
+	"fmt"
+	"go/ast"
+	"go/types"
+	"slices"
+	"strings"
+
+	"golang.org/x/tools/go/analysis"
+)
+
+// Functions and methods returning the shared secret of a classical key
+// agreement as their first result.
+var classicalSecretSources = slices.Concat(
+	functionsOf("crypto/ecdh", "ECDH"),
+	functionsOf("golang.org/x/crypto/curve25519", "X25519"),
+)
+
+// Methods returning a post-quantum shared secret as their first result. Key
+// derivation mixing one in is already hybrid.
+var postQuantumSecretSources = functionsOf("crypto/mlkem", "Encapsulate", "Decapsulate")
+
+// Key derivation functions taking their input keying material as their
+// second argument.
+var kdfIdentifiers = slices.Concat(
+	functionsOf("crypto/hkdf", "Key", "Extract"),
+	functionsOf("golang.org/x/crypto/hkdf", "New", "Extract"),
+)
+
+// Reports key derivation functions keyed by the shared secret of a classical
+// key agreement, such as hkdf.New(sha256.New, secret, salt, info) where secret
+// comes from an ecdh.PrivateKey.ECDH call. Secrets are tracked through the
+// variables of each function, and through the functions of the package
+// returning them. These calls are the seam where an ML-KEM encapsulation has to
+// be added to make the key exchange hybrid.
+func reportClassicalKeyDerivation(r *reporter) {
+	info := r.pass.TypesInfo
+	var funcDecls []*ast.FuncDecl
+	for _, file := range r.pass.Files {
+		if file.Name != nil && strings.HasSuffix(file.Name.Name, "_test") {
+			continue
+		}
+		for _, decl := range file.Decls {
+			if funcDecl, ok := decl.(*ast.FuncDecl); ok && funcDecl.Body != nil {
+				funcDecls = append(funcDecls, funcDecl)
+			}
+		}
+	}
+
+	// Functions of the package returning classical shared secrets, found
+	// until no more are.
+	helpers := make(map[types.Object]bool)
+	for changed := true; changed; {
+		changed = false
+		for _, funcDecl := range funcDecls {
+			obj := info.Defs[funcDecl.Name]
+			if obj == nil || helpers[obj] {
+				continue
+			}
+			tracker := newSecretTracker(info, helpers)
+			tracker.track(funcDecl.Body, func(node ast.Node) {
+				ret, ok := node.(*ast.ReturnStmt)
+				if !ok || len(ret.Results) == 0 || helpers[obj] {
+					return
+				}
+				if secret, hybrid := tracker.origin(ret.Results[0]); secret != nil && !hybrid {
+					helpers[obj] = true
+					changed = true
+				}
+			})
+		}
+	}
+
+	for _, funcDecl := range funcDecls {
+		tracker := newSecretTracker(info, helpers)
+		tracker.track(funcDecl.Body, func(node ast.Node) {
+			call, ok := node.(*ast.CallExpr)
+			if !ok {
+				return
+			}
+			kdf, ok := kdfCall(info, call)
+			if !ok {
+				return
+			}
+			if secret, hybrid := tracker.origin(call.Args[1]); secret != nil && !hybrid {
+				// The key derivation functions are allowlisted, so the call
+				// does not exclude the finding.
+				r.add(Finding{
+					Diagnostic: analysis.Diagnostic{
+						Pos:     call.Args[1].Pos(),
+						Message: fmt.Sprintf(`function "%s" derives keys from the classical shared secret %s; insert an ML-KEM encapsulation here and derive the keys from both shared secrets`, kdf, types.ExprString(secret)),
+					},
+					Rule:   ruleClassicalKeyDerivation,
+					inside: span{call.Pos(), call.End()},
+				})
+			}
+		})
+	}
+}
+
+// Returns the name of the key derivation function if the call is one.
+func kdfCall(info *types.Info, call *ast.CallExpr) (string, bool) {
+	if len(call.Args) < 2 {
+		return "", false
+	}
+	selector, ok := call.Fun.(*ast.SelectorExpr)
+	if !ok {
+		return "", false
+	}
+	localImportName, ok := selector.X.(*ast.Ident)
+	if !ok {
+		return "", false
+	}
+	return vulnerableFunction(info, localImportName, selector.Sel, kdfIdentifiers)
+}
+
+// A secretTracker tracks the variables of a function holding classical and
+// post-quantum shared secrets: a variable assigned from an expression
+// containing a shared secret holds it too.
+type secretTracker struct {
+	info *types.Info
+	// Functions of the package returning classical shared secrets.
+	helpers                map[types.Object]bool
+	classical, postQuantum map[types.Object]bool
+}
+
+func newSecretTracker(info *types.Info, helpers map[types.Object]bool) *secretTracker {
+	return &secretTracker{
+		info:        info,
+		helpers:     helpers,
+		classical:   make(map[types.Object]bool),
+		postQuantum: make(map[types.Object]bool),
+	}
+}
+
+// Walks body in source order, tracking its assignments, and calls visit with
+// every node. Function literals are not walked, as they may run at any time.
+func (t *secretTracker) track(body *ast.BlockStmt, visit func(ast.Node)) {
+	ast.Inspect(body, func(node ast.Node) bool {
+		switch node := node.(type) {
+		case *ast.FuncLit:
+			return false
+		case *ast.AssignStmt:
+			t.assign(node.Lhs, node.Rhs)
+		case *ast.ValueSpec:
+			lhs := make([]ast.Expr, len(node.Names))
+			for i, name := range node.Names {
+				lhs[i] = name
+			}
+			t.assign(lhs, node.Values)
+		}
+		if node != nil {
+			visit(node)
+		}
+		return true
+	})
+}
+
+// Records the secrets assigned to variables. A call assigned to several
+// variables returns the secret in its first result only.
+func (t *secretTracker) assign(lhs, rhs []ast.Expr) {
+	for i, expr := range lhs {
+		var value ast.Expr
+		switch {
+		case len(rhs) == len(lhs):
+			value = rhs[i]
+		case len(rhs) == 1 && i == 0:
+			value = rhs[0]
+		default:
+			continue
+		}
+		ident, ok := expr.(*ast.Ident)
+		if !ok {
+			continue
+		}
+		obj := t.info.ObjectOf(ident)
+		if obj == nil {
+			continue
+		}
+		secret, hybrid := t.origin(value)
+		t.classical[obj] = secret != nil
+		t.postQuantum[obj] = hybrid
+	}
+}
+
+// Returns the first subexpression of expr holding a classical shared secret,
+// and whether expr also holds a post-quantum one.
+func (t *secretTracker) origin(expr ast.Expr) (ast.Expr, bool) {
+	var secret ast.Expr
+	hybrid := false
+	ast.Inspect(expr, func(node ast.Node) bool {
+		switch node := node.(type) {
+		case *ast.Ident:
+			obj := t.info.ObjectOf(node)
+			if t.classical[obj] && secret == nil {
+				secret = node
+			}
+			hybrid = hybrid || t.postQuantum[obj]
+		case *ast.CallExpr:
+			// Derived keys are not the shared secret itself.
+			if _, ok := kdfCall(t.info, node); ok {
+				return false
+			}
+			classical, postQuantum := t.source(node)
+			if classical && secret == nil {
+				secret = node
+			}
+			hybrid = hybrid || postQuantum
+		case *ast.FuncLit:
+			return false
+		}
+		return true
+	})
+	return secret, hybrid
+}
+
+// Reports whether the call returns a classical or a post-quantum shared
+// secret.
+func (t *secretTracker) source(call *ast.CallExpr) (bool, bool) {
+	switch fun := ast.Unparen(call.Fun).(type) {
+	case *ast.Ident:
+		return t.helpers[t.info.Uses[fun]], false
+	case *ast.SelectorExpr:
+		if t.helpers[t.info.Uses[fun.Sel]] {
+			return true, false
+		}
+		if localImportName, ok := fun.X.(*ast.Ident); ok {
+			if _, ok := vulnerableFunction(t.info, localImportName, fun.Sel, classicalSecretSources); ok {
+				return true, false
+			}
+		}
+		if _, ok := vulnerableMethod(t.info, fun, classicalSecretSources); ok {
+			return true, false
+		}
+		_, ok := vulnerableMethod(t.info, fun, postQuantumSecretSources)
+		return false, ok
+	}
+	return false, false
+}
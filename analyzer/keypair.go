@@ -0,0 +1,162 @@
+package analyzer
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"fmt"
+	"go/ast"
+	"go/constant"
+	"os"
+	"path/filepath"
+)
+
+// Identifiers of functions loading a TLS certificate and private key pair.
+var tlsKeyPairIdentifiers = []QvFunction{
+	{"LoadX509KeyPair", "crypto/tls"},
+	{"X509KeyPair", "crypto/tls"},
+}
+
+// Reports the certificate and key files passed to tls.LoadX509KeyPair as
+// constant paths, with the classical key they hold, when they can be found
+// relative to the directory of the file or the root of the module. Absolute
+// paths and paths leaving the module are never read, so scans only read files
+// of the module.
+func reportKeyPairFiles(r *reporter, file *ast.File) {
+	dir := filepath.Dir(r.pass.Fset.File(file.Pos()).Name())
+	root, ok := moduleRoot(dir)
+	if !ok {
+		return
+	}
+	dirs := []string{dir}
+	if root != dir {
+		dirs = append(dirs, root)
+	}
+
+	ast.Inspect(file, func(node ast.Node) bool {
+		call, ok := node.(*ast.CallExpr)
+		if !ok || len(call.Args) != 2 {
+			return true
+		}
+		selector, ok := call.Fun.(*ast.SelectorExpr)
+		if !ok {
+			return true
+		}
+		localImportName, ok := selector.X.(*ast.Ident)
+		if !ok {
+			return true
+		}
+		if _, ok := vulnerableFunction(r.pass.TypesInfo, localImportName, selector.Sel, []QvFunction{{"LoadX509KeyPair", "crypto/tls"}}); !ok {
+			return true
+		}
+		for _, arg := range call.Args {
+			tv, ok := r.pass.TypesInfo.Types[arg]
+			if !ok || tv.Value == nil || tv.Value.Kind() != constant.String {
+				continue
+			}
+			path := constant.StringVal(tv.Value)
+			for _, dir := range dirs {
+				name, ok := resolvePath(root, dir, path)
+				if !ok {
+					continue
+				}
+				r.result.DataFiles = append(r.result.DataFiles, name)
+				data, err := os.ReadFile(name)
+				if err != nil {
+					continue
+				}
+				if kind, algorithm := describePEM(data); algorithm != "" {
+					r.report(arg.Pos(), ruleTLSKeyPair, "%s file %q holds a quantum-vulnerable %s key", kind, path, algorithm)
+				}
+				break
+			}
+		}
+		return true
+	})
+}
+
+// Returns the path relative to dir, if it is relative and stays inside root
+// once cleaned.
+func resolvePath(root, dir, path string) (string, bool) {
+	if filepath.IsAbs(path) {
+		return "", false
+	}
+	name := filepath.Join(dir, path)
+	rel, err := filepath.Rel(root, name)
+	if err != nil || !filepath.IsLocal(rel) {
+		return "", false
+	}
+	return name, true
+}
+
+// Returns the closest directory at or above dir containing a go.mod file.
+func moduleRoot(dir string) (string, bool) {
+	for {
+		if _, err := os.Stat(filepath.Join(dir, "go.mod")); err == nil {
+			return dir, true
+		}
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return "", false
+		}
+		dir = parent
+	}
+}
+
+// Returns the kind of the first certificate or private key in the PEM data,
+// and the classical algorithm of its key, or an empty algorithm if there is
+// none.
+func describePEM(data []byte) (kind, algorithm string) {
+	for {
+		var block *pem.Block
+		block, data = pem.Decode(data)
+		if block == nil {
+			return "", ""
+		}
+		switch block.Type {
+		case "CERTIFICATE":
+			cert, err := x509.ParseCertificate(block.Bytes)
+			if err != nil {
+				continue
+			}
+			return "certificate", describeKey(cert.PublicKey)
+		case "RSA PRIVATE KEY":
+			key, err := x509.ParsePKCS1PrivateKey(block.Bytes)
+			if err != nil {
+				continue
+			}
+			return "private key", describeKey(key.Public())
+		case "EC PRIVATE KEY":
+			key, err := x509.ParseECPrivateKey(block.Bytes)
+			if err != nil {
+				continue
+			}
+			return "private key", describeKey(key.Public())
+		case "PRIVATE KEY":
+			key, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+			if err != nil {
+				continue
+			}
+			if signer, ok := key.(crypto.Signer); ok {
+				return "private key", describeKey(signer.Public())
+			}
+		}
+	}
+}
+
+// Returns the classical algorithm and size of a public key, or an empty
+// string for other keys.
+func describeKey(pub any) string {
+	switch pub := pub.(type) {
+	case *rsa.PublicKey:
+		return fmt.Sprintf("RSA-%d", pub.N.BitLen())
+	case *ecdsa.PublicKey:
+		return "ECDSA " + pub.Curve.Params().Name
+	case ed25519.PublicKey:
+		return "Ed25519"
+	}
+	return ""
+}
@@ -0,0 +1,19 @@
+package analyzer
+
+// Identifiers of Kubernetes client-go certificate rotation, CSR creation and
+// key bootstrapping helpers. Kubelets and operators using them generate
+// ECDSA P-256 or RSA keys and have them signed by the cluster CA, making them
+// part of the cluster PKI that has to migrate as a whole.
+var kubernetesPKIIdentifiers = []QvFunction{
+	{"NewManager", "k8s.io/client-go/util/certificate"},
+	{"Config", "k8s.io/client-go/util/certificate"},
+	{"RequestCertificate", "k8s.io/client-go/util/certificate/csr"},
+	{"MakeCSR", "k8s.io/client-go/util/cert"},
+	{"MakeCSRFromTemplate", "k8s.io/client-go/util/cert"},
+	{"GenerateSelfSignedCertKey", "k8s.io/client-go/util/cert"},
+	{"MakeEllipticPrivateKeyPEM", "k8s.io/client-go/util/keyutil"},
+	{"LoadOrGenerateKeyFile", "k8s.io/client-go/util/keyutil"},
+	{"CertificateSigningRequest", "k8s.io/api/certificates/v1"},
+	{"CertificateSigningRequest", "k8s.io/api/certificates/v1beta1"},
+	{"LoadClientCert", "k8s.io/kubernetes/pkg/kubelet/certificate/bootstrap"},
+}
@@ -0,0 +1,47 @@
+package analyzer
+
+import "slices"
+
+// Identifiers of libsodium bindings generating Ed25519 signing keys or
+// signing (crypto_sign). Bindings wrap all of libsodium, so their asymmetric
+// APIs are told apart from the symmetric ones (crypto_secretbox,
+// crypto_aead, crypto_generichash) symbol by symbol.
+var libsodiumSigningIdentifiers = slices.Concat(
+	functionsOf("github.com/GoKillers/libsodium-go/cryptosign",
+		"CryptoSign", "CryptoSignDetached", "CryptoSignKeyPair", "CryptoSignSeedKeyPair",
+	),
+	functionsOf("github.com/jamesruan/sodium",
+		"MakeSignKP", "SeedSignKP", "Sign", "SignDetached",
+	),
+)
+
+// Identifiers of libsodium bindings verifying Ed25519 signatures.
+var libsodiumVerificationIdentifiers = slices.Concat(
+	functionsOf("github.com/GoKillers/libsodium-go/cryptosign",
+		"CryptoSignOpen", "CryptoSignVerifyDetached",
+	),
+	functionsOf("github.com/jamesruan/sodium",
+		"SignOpen", "SignVerifyDetached",
+	),
+)
+
+// Identifiers of libsodium bindings performing X25519 key exchange: public-key
+// authenticated and sealed boxes (crypto_box), key exchange (crypto_kx) and
+// raw scalar multiplication (crypto_scalarmult).
+var libsodiumKeyExchangeIdentifiers = slices.Concat(
+	functionsOf("github.com/GoKillers/libsodium-go/cryptobox",
+		"CryptoBox", "CryptoBoxOpen", "CryptoBoxEasy", "CryptoBoxOpenEasy",
+		"CryptoBoxDetached", "CryptoBoxOpenDetached", "CryptoBoxKeyPair",
+		"CryptoBoxSeedKeyPair", "CryptoBoxBeforeNm", "CryptoBoxSeal", "CryptoBoxSealOpen",
+	),
+	functionsOf("github.com/GoKillers/libsodium-go/cryptokx",
+		"CryptoKXKeyPair", "CryptoKXSeedKeyPair", "CryptoKXClientSessionKeys", "CryptoKXServerSessionKeys",
+	),
+	functionsOf("github.com/GoKillers/libsodium-go/scalarmult",
+		"CryptoScalarmult", "CryptoScalarmultBase",
+	),
+	functionsOf("github.com/jamesruan/sodium",
+		"MakeBoxKP", "SeedBoxKP", "Box", "BoxOpen", "BoxDetached", "BoxOpenDetached",
+		"SealedBox", "SealedBoxOpen", "MakeKXKP", "SeedKXKP", "ClientSessionKeys", "ServerSessionKeys",
+	),
+)
@@ -0,0 +1,155 @@
+package analyzer
+
+import (
+	"go/ast"
+	"go/types"
+	"slices"
+)
+
+// Types holding private key material.
+var privateKeyTypes = []QvFunction{
+	{"PrivateKey", "crypto/rsa"},
+	{"PrivateKey", "crypto/ecdsa"},
+	{"PrivateKey", "crypto/ed25519"},
+	{"PrivateKey", "crypto/ecdh"},
+	{"PrivateKey", "crypto/dsa"},
+	{"PrivateKey", "golang.org/x/crypto/ed25519"},
+}
+
+// Functions and methods that write their arguments to logs or output.
+var logSinkIdentifiers = slices.Concat(
+	functionsOf("fmt", "Print", "Printf", "Println", "Fprint", "Fprintf", "Fprintln"),
+	functionsOf("log", "Print", "Printf", "Println", "Fatal", "Fatalf", "Fatalln", "Panic", "Panicf", "Panicln"),
+	functionsOf("log/slog", "Debug", "Info", "Warn", "Error", "Log", "DebugContext", "InfoContext", "WarnContext", "ErrorContext", "Any", "String"),
+	functionsOf("github.com/sirupsen/logrus", "Print", "Printf", "Println", "Debug", "Debugf", "Info", "Infof", "Warn", "Warnf", "Error", "Errorf", "Fatal", "Fatalf", "WithField", "WithFields"),
+	functionsOf("go.uber.org/zap", "Debug", "Info", "Warn", "Error", "Fatal", "Debugf", "Infof", "Warnf", "Errorf", "Debugw", "Infow", "Warnw", "Errorw", "Any", "String", "Binary", "ByteString", "Stringer", "Reflect"),
+)
+
+func functionsOf(pkg string, names ...string) []QvFunction {
+	functions := make([]QvFunction, len(names))
+	for i, name := range names {
+		functions[i] = QvFunction{name, pkg}
+	}
+	return functions
+}
+
+// Reports private key values flowing into logging and printing sinks. Taint
+// is tracked within each function: a variable assigned from an expression
+// containing private key material is itself private key material.
+func reportKeyLogging(r *reporter, file *ast.File) {
+	info := r.pass.TypesInfo
+	for _, decl := range file.Decls {
+		funcDecl, ok := decl.(*ast.FuncDecl)
+		if !ok || funcDecl.Body == nil {
+			continue
+		}
+
+		tainted := make(map[types.Object]bool)
+		// Returns the first subexpression of expr holding private key material.
+		source := func(expr ast.Expr) ast.Expr {
+			var found ast.Expr
+			ast.Inspect(expr, func(node ast.Node) bool {
+				if found != nil {
+					return false
+				}
+				sub, ok := node.(ast.Expr)
+				if !ok {
+					return true
+				}
+				if selector, ok := sub.(*ast.SelectorExpr); ok && publicKeyPart(info, selector) {
+					return false
+				}
+				if ident, ok := sub.(*ast.Ident); ok && tainted[info.ObjectOf(ident)] {
+					found = sub
+				} else if isNamedType(info.TypeOf(sub), privateKeyTypes) {
+					found = sub
+				}
+				return found == nil
+			})
+			return found
+		}
+
+		ast.Inspect(funcDecl.Body, func(node ast.Node) bool {
+			switch node := node.(type) {
+			case *ast.AssignStmt:
+				for i, lhs := range node.Lhs {
+					rhs := node.Rhs[0]
+					if len(node.Rhs) == len(node.Lhs) {
+						rhs = node.Rhs[i]
+					}
+					if ident, ok := lhs.(*ast.Ident); ok && source(rhs) != nil {
+						if obj := info.ObjectOf(ident); obj != nil {
+							tainted[obj] = true
+						}
+					}
+				}
+			case *ast.ValueSpec:
+				for i, name := range node.Names {
+					if i < len(node.Values) && source(node.Values[i]) != nil {
+						tainted[info.ObjectOf(name)] = true
+					}
+				}
+			case *ast.CallExpr:
+				selector, ok := node.Fun.(*ast.SelectorExpr)
+				if !ok {
+					return true
+				}
+				sink, ok := "", false
+				if localImportName, isIdent := selector.X.(*ast.Ident); isIdent {
+					sink, ok = vulnerableFunction(info, localImportName, selector.Sel, logSinkIdentifiers)
+				}
+				if !ok {
+					sink, ok = vulnerableMethod(info, selector, logSinkIdentifiers)
+				}
+				if !ok {
+					return true
+				}
+				for _, arg := range node.Args {
+					if key := source(arg); key != nil {
+						r.report(key.Pos(), rulePrivateKeyLogging, "private key material %s flows into logging sink \"%s\"; key material must never be logged", types.ExprString(key), sink)
+						break
+					}
+				}
+			}
+			return true
+		})
+	}
+}
+
+// Reports whether the selector picks the public half of a private key, which
+// is not secret: its PublicKey field or method, its Public method, or a field
+// promoted from its embedded public key, such as the Curve or X of an
+// ecdsa.PrivateKey or the N of an rsa.PrivateKey.
+func publicKeyPart(info *types.Info, selector *ast.SelectorExpr) bool {
+	if !isNamedType(info.TypeOf(selector.X), privateKeyTypes) {
+		return false
+	}
+	if selector.Sel.Name == "Public" || selector.Sel.Name == "PublicKey" {
+		return true
+	}
+	selection, ok := info.Selections[selector]
+	if !ok || selection.Kind() != types.FieldVal || len(selection.Index()) < 2 {
+		return false
+	}
+	recv := selection.Recv()
+	if ptr, ok := recv.(*types.Pointer); ok {
+		recv = ptr.Elem()
+	}
+	st, ok := recv.Underlying().(*types.Struct)
+	return ok && st.Field(selection.Index()[0]).Name() == "PublicKey"
+}
+
+// Reports whether t is, or points to, one of the named types.
+func isNamedType(t types.Type, namedTypes []QvFunction) bool {
+	if t == nil {
+		return false
+	}
+	if ptr, ok := types.Unalias(t).(*types.Pointer); ok {
+		t = ptr.Elem()
+	}
+	named, ok := types.Unalias(t).(*types.Named)
+	if !ok || named.Obj().Pkg() == nil {
+		return false
+	}
+	return slices.Contains(namedTypes, QvFunction{named.Obj().Name(), named.Obj().Pkg().Path()})
+}
@@ -0,0 +1,79 @@
+package analyzer
+
+import (
+	"go/ast"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// Functions and types of service mesh identity: the SPIFFE Workload API and
+// the X.509 SVIDs it serves, mTLS configuration built from them, and the TLS
+// contexts of Envoy and Istio configuration generated by control planes.
+// SVIDs are issued by the mesh CA with ECDSA P-256 or RSA keys.
+var meshIdentifiers = slices.Concat(
+	functionsOf("github.com/spiffe/go-spiffe/v2/workloadapi", "NewClient", "NewX509Source", "NewJWTSource", "FetchX509SVID", "FetchX509SVIDs", "FetchX509Context", "FetchJWTSVID", "WatchX509Context"),
+	functionsOf("github.com/spiffe/go-spiffe/v2/spiffetls", "Listen", "ListenWithMode", "Dial", "DialWithMode"),
+	functionsOf("github.com/spiffe/go-spiffe/v2/spiffetls/tlsconfig", "MTLSServerConfig", "MTLSClientConfig", "TLSServerConfig", "TLSClientConfig", "MTLSWebServerConfig"),
+	functionsOf("github.com/spiffe/go-spiffe/v2/svid/x509svid", "Load", "Parse", "ParseRaw"),
+	functionsOf("github.com/envoyproxy/go-control-plane/envoy/extensions/transport_sockets/tls/v3", "DownstreamTlsContext", "UpstreamTlsContext", "CommonTlsContext", "TlsCertificate", "Secret"),
+	functionsOf("istio.io/api/security/v1beta1", "PeerAuthentication", "PeerAuthentication_MutualTLS"),
+	functionsOf("istio.io/api/networking/v1alpha3", "ClientTLSSettings", "ServerTLSSettings"),
+)
+
+// Packages of SPIFFE IDs, whose use next to certificate creation marks SVID
+// minting.
+var spiffeIDImportPaths = []string{
+	"github.com/spiffe/go-spiffe/v2/spiffeid",
+}
+
+const meshMessage = "mesh identities are issued and rotated centrally by the mesh CA, which makes them an early migration candidate once it issues post-quantum certificates"
+
+// Reports functions minting X.509 SVIDs themselves: functions creating
+// certificates with crypto/x509 for SPIFFE IDs, written as spiffe:// URIs or
+// built with the spiffeid package, as custom SPIRE plugins and mesh CAs do,
+// noting ECDSA keys generated in the same function.
+func reportSVIDMinting(r *reporter, file *ast.File) {
+	info := r.pass.TypesInfo
+	for _, decl := range file.Decls {
+		funcDecl, ok := decl.(*ast.FuncDecl)
+		if !ok || funcDecl.Body == nil {
+			continue
+		}
+		var createCertificate []*ast.SelectorExpr
+		spiffeID, ecdsaKey := false, false
+		ast.Inspect(funcDecl.Body, func(node ast.Node) bool {
+			switch node := node.(type) {
+			case *ast.BasicLit:
+				if value, err := strconv.Unquote(node.Value); err == nil && strings.HasPrefix(value, "spiffe://") {
+					spiffeID = true
+				}
+			case *ast.SelectorExpr:
+				localImportName, ok := node.X.(*ast.Ident)
+				if !ok {
+					return true
+				}
+				if _, ok := vulnerableFunction(info, localImportName, node.Sel, functionsOf("crypto/x509", "CreateCertificate")); ok {
+					createCertificate = append(createCertificate, node)
+				}
+				if _, ok := vulnerableFunction(info, localImportName, node.Sel, functionsOf("crypto/ecdsa", "GenerateKey")); ok {
+					ecdsaKey = true
+				}
+				if obj := info.Uses[node.Sel]; obj != nil && obj.Pkg() != nil && slices.Contains(spiffeIDImportPaths, obj.Pkg().Path()) {
+					spiffeID = true
+				}
+			}
+			return true
+		})
+		if !spiffeID {
+			continue
+		}
+		keys := "classical keys"
+		if ecdsaKey {
+			keys = "ECDSA keys"
+		}
+		for _, selector := range createCertificate {
+			r.reportOperation(selector.Sel.Pos(), ruleServiceIdentity, OperationPrivate, "function %s mints X.509 SVIDs for SPIFFE IDs with %s; %s", funcDecl.Name.Name, keys, meshMessage)
+		}
+	}
+}
@@ -0,0 +1,215 @@
+package analyzer
+
+import (
+	"fmt"
+	"go/ast"
+	"go/token"
+	"go/types"
+	"maps"
+	"slices"
+	"strconv"
+	"strings"
+	"text/template"
+)
+
+// Post-quantum replacements of the findings of each category, the default
+// Replacement of message templates.
+var categoryReplacements = map[string]string{
+	CategoryEllipticCurve:        "ML-KEM (crypto/mlkem) for key exchange and ML-DSA for signatures",
+	CategoryIntegerFactorization: "ML-KEM (crypto/mlkem) for encryption and ML-DSA for signatures",
+	CategoryFunction:             "ML-KEM (crypto/mlkem) for key exchange and ML-DSA for signatures",
+	CategorySSH:                  "hybrid mlkem768x25519-sha256 key exchange and post-quantum host and user keys",
+	CategoryFIPS:                 "FIPS 140-3 validated ML-KEM and ML-DSA",
+	CategoryCloudEncryption:      "data keys wrapped by the KMS with symmetric or ML-KEM keys",
+	CategoryPKI:                  "ML-DSA or composite certificates",
+	CategoryKeyExchange:          "hybrid ML-KEM key exchange",
+	CategorySoftwareUpdate:       "ML-DSA or SLH-DSA release signatures",
+	CategoryHygiene:              "keys kept in a key store and referenced by ID",
+	CategoryTokens:               "ML-DSA token signatures",
+	CategoryGovernance:           "an approved crypto provider",
+	CategoryTransport:            "TLS 1.3 with hybrid X25519MLKEM768 key exchange",
+	CategoryAgility:              "a dispatch extended with post-quantum algorithms",
+	CategoryToolchain:            goVersionPQ + " or later, with crypto/mlkem and hybrid TLS by default",
+	CategoryCustomCrypto:         "vetted ML-KEM and ML-DSA implementations",
+	CategorySchema:               "fields carrying an algorithm identifier next to the key",
+	CategoryExternalTrust:        "post-quantum signatures agreed with the issuing organization",
+	CategoryCommands:             "ML-KEM and ML-DSA keys in gpg or openssl",
+	CategoryAPI:                  "algorithm-agnostic key types, such as crypto.Signer",
+	CategoryBuild:                "post-quantum algorithms in every build configuration",
+	CategoryHardware:             "post-quantum capable hardware",
+	CategoryDNSSEC:               "a post-quantum DNSSEC algorithm, once one is standardized",
+	CategoryE2EE:                 "a post-quantum ratchet, such as PQXDH",
+	CategoryMessaging:            "post-quantum push keys, once push services accept them",
+	CategoryServiceIdentity:      "ML-DSA or composite SVIDs issued by the mesh CA",
+	CategoryTooling:              "hybrid mlkem768x25519-sha256 SSH key exchange, and keys loaded at runtime rather than embedded",
+	CategoryLongLivedSignatures:  "ML-DSA or SLH-DSA signatures and archival timestamps, renewed before the classical ones are broken",
+	CategoryPairing:              "hash-based or lattice-based signatures, with aggregation redesigned at the protocol level",
+	CategoryDatabaseEncryption:   "columns encrypted with symmetric data keys wrapped by a KMS or ML-KEM",
+	CategoryAdvanced:             "a post-quantum protocol chosen with specialist cryptographic review, as there is no drop-in replacement",
+}
+
+// Keys of the templates wording the status of accepted findings, which
+// catalogs can override like the templates of rules. Their Message is the
+// message of the finding and their Deadline the date of its exception.
+const (
+	// Findings annotated with //pqc:compat.
+	StatusInteropDebt = "interop-debt"
+	// Findings inside an exception still in effect.
+	StatusAcceptedUntil = "accepted-until"
+	// Findings inside an exception that has expired.
+	StatusExceptionExpired = "exception-expired"
+	// Findings suppressed by the configuration without a date.
+	StatusSuppressed = "suppressed"
+)
+
+// Default templates of the statuses of accepted findings.
+var statusTemplates = map[string]*template.Template{
+	StatusInteropDebt:      template.Must(template.New(StatusInteropDebt).Parse("{{.Message}} (accepted interop debt)")),
+	StatusAcceptedUntil:    template.Must(template.New(StatusAcceptedUntil).Parse("{{.Message}} (accepted until {{.Deadline}})")),
+	StatusExceptionExpired: template.Must(template.New(StatusExceptionExpired).Parse("{{.Message}} (exception expired on {{.Deadline}})")),
+	StatusSuppressed:       template.Must(template.New(StatusSuppressed).Parse("{{.Message}} (suppressed)")),
+}
+
+// MessageData are the variables of message templates.
+type MessageData struct {
+	// Message reported by the rule, in English.
+	Message string
+	// Source text of what the finding is about, such as an import path or a
+	// called function.
+	Symbol string
+	// Post-quantum replacement of the findings of the rule.
+	Replacement string
+	// Date, as YYYY-MM-DD, the finding has to be migrated by: the end of the
+	// time-boxed exception it is inside, or the deadline of the catalog.
+	Deadline string
+	Rule     string
+	Name     string
+	Category string
+	Severity string
+}
+
+// Catalog is the wording of the messages of findings, so organizations can
+// localize or vet the text shown to developers. Templates use the
+// text/template syntax over MessageData, such as
+//
+//	{{.Symbol}} must be replaced by {{.Replacement}} before {{.Deadline}}
+//
+// Create catalogs with NewCatalog.
+type Catalog struct {
+	// Templates by rule ID, category, or "*" for every rule. The most
+	// specific one applies; findings without one keep the message of their
+	// rule. Templates keyed by a status, such as StatusAcceptedUntil, word
+	// the status of accepted findings instead.
+	Templates map[string]string
+	// Replacements by rule ID or category, overriding the built-in ones.
+	Replacements map[string]string
+	// Migration deadline of findings outside time-boxed exceptions.
+	Deadline string
+
+	parsed map[string]*template.Template
+}
+
+// NewCatalog returns the catalog of the templates and replacements, keyed by
+// rule ID or category, with the given deadline.
+func NewCatalog(templates, replacements map[string]string, deadline string) (*Catalog, error) {
+	c := &Catalog{Templates: templates, Replacements: replacements, Deadline: deadline, parsed: make(map[string]*template.Template)}
+	for _, key := range slices.Sorted(maps.Keys(templates)) {
+		if key != "*" && !isRuleOrCategory(key) && statusTemplates[key] == nil {
+			return nil, fmt.Errorf("unknown rule or category %q", key)
+		}
+		tmpl, err := template.New(key).Parse(templates[key])
+		if err != nil {
+			return nil, fmt.Errorf("invalid template of %s: %s", key, err.Error())
+		}
+		// Referencing unknown variables only fails on execution.
+		if err := tmpl.Execute(new(strings.Builder), MessageData{}); err != nil {
+			return nil, fmt.Errorf("invalid template of %s: %s", key, err.Error())
+		}
+		c.parsed[key] = tmpl
+	}
+	for _, key := range slices.Sorted(maps.Keys(replacements)) {
+		if !isRuleOrCategory(key) {
+			return nil, fmt.Errorf("unknown rule or category %q", key)
+		}
+	}
+	return c, nil
+}
+
+// Reports whether key is the ID or category of a rule.
+func isRuleOrCategory(key string) bool {
+	return slices.ContainsFunc(rules, func(rule Rule) bool {
+		return rule.ID == key || rule.Category == key
+	})
+}
+
+// Renders the message of a finding of the rule, whose data are filled in but
+// for the rule and replacement. Findings without a template, or whose
+// template fails, keep the message of their rule.
+func (c *Catalog) render(rule Rule, data MessageData) string {
+	tmpl, ok := c.parsed[rule.ID]
+	if !ok {
+		tmpl, ok = c.parsed[rule.Category]
+	}
+	if !ok {
+		tmpl, ok = c.parsed["*"]
+	}
+	if !ok {
+		return data.Message
+	}
+	data.Rule, data.Name, data.Category, data.Severity = rule.ID, rule.Name, rule.Category, rule.Severity.String()
+	data.Replacement, ok = c.Replacements[rule.ID]
+	if !ok {
+		data.Replacement, ok = c.Replacements[rule.Category]
+	}
+	if !ok {
+		data.Replacement = categoryReplacements[rule.Category]
+	}
+	if data.Deadline == "" {
+		data.Deadline = c.Deadline
+	}
+	var message strings.Builder
+	if err := tmpl.Execute(&message, data); err != nil {
+		return data.Message
+	}
+	return message.String()
+}
+
+// Status returns the message of an accepted finding with its status, such as
+// StatusAcceptedUntil, and the date of its exception, if any, worded by the
+// template of the status in the catalog or by default. A nil catalog words
+// every status by default.
+func (c *Catalog) Status(status, message, date string) string {
+	tmpl := statusTemplates[status]
+	if c != nil && c.parsed[status] != nil {
+		tmpl = c.parsed[status]
+	}
+	var b strings.Builder
+	if tmpl == nil || tmpl.Execute(&b, MessageData{Message: message, Deadline: date}) != nil {
+		return message
+	}
+	return b.String()
+}
+
+// Returns the source text of the outermost identifier, selector or literal
+// starting at pos, unquoted, which names what a finding there is about.
+func (r *reporter) symbol(pos token.Pos) string {
+	node, _ := r.nodeAt(pos)
+	switch node := node.(type) {
+	case *ast.ImportSpec:
+		if node.Name != nil {
+			return node.Name.Name
+		}
+		if unquoted, err := strconv.Unquote(node.Path.Value); err == nil {
+			return unquoted
+		}
+		return node.Path.Value
+	case *ast.Ident, *ast.SelectorExpr:
+		return types.ExprString(node.(ast.Expr))
+	case *ast.BasicLit:
+		if unquoted, err := strconv.Unquote(node.Value); err == nil {
+			return unquoted
+		}
+		return node.Value
+	}
+	return ""
+}
@@ -0,0 +1,33 @@
+package analyzer
+
+import "slices"
+
+// Functions of threshold signature and other multi-party computation
+// libraries: the ECDSA and EdDSA key generation, signing and resharing parties
+// of tss-lib, and the CMP and FROST protocols of multi-party-sig. The
+// protocols produce elliptic curve signatures from discrete logarithm shares,
+// with proofs over Paillier ciphertexts and RSA moduli between the parties,
+// all of which Shor's algorithm breaks.
+var mpcIdentifiers = slices.Concat(
+	functionsOf("github.com/bnb-chain/tss-lib/v2/ecdsa/keygen", "NewLocalParty", "GeneratePreParams", "GeneratePreParamsWithContext"),
+	functionsOf("github.com/bnb-chain/tss-lib/v2/ecdsa/signing", "NewLocalParty"),
+	functionsOf("github.com/bnb-chain/tss-lib/v2/ecdsa/resharing", "NewLocalParty"),
+	functionsOf("github.com/bnb-chain/tss-lib/v2/eddsa/keygen", "NewLocalParty"),
+	functionsOf("github.com/bnb-chain/tss-lib/v2/eddsa/signing", "NewLocalParty"),
+	functionsOf("github.com/bnb-chain/tss-lib/ecdsa/keygen", "NewLocalParty", "GeneratePreParams"),
+	functionsOf("github.com/bnb-chain/tss-lib/ecdsa/signing", "NewLocalParty"),
+	functionsOf("github.com/binance-chain/tss-lib/ecdsa/keygen", "NewLocalParty", "GeneratePreParams"),
+	functionsOf("github.com/binance-chain/tss-lib/ecdsa/signing", "NewLocalParty"),
+	functionsOf("github.com/taurusgroup/multi-party-sig/protocols/cmp", "Keygen", "Refresh", "Sign", "Presign", "PresignOnline"),
+	functionsOf("github.com/taurusgroup/multi-party-sig/protocols/frost", "Keygen", "KeygenTaproot", "Sign", "SignTaproot"),
+)
+
+// Functions and methods of Paillier homomorphic encryption: the Paillier keys
+// of tss-lib and multi-party-sig, and the implementations of kryptology and
+// go-go-gadget-paillier. Paillier rests on integer factorization, like RSA.
+var paillierIdentifiers = slices.Concat(
+	functionsOf("github.com/bnb-chain/tss-lib/v2/crypto/paillier", "GenerateKeyPair", "Encrypt", "EncryptAndReturnRandomness", "Decrypt", "HomoAdd", "HomoMult"),
+	functionsOf("github.com/taurusgroup/multi-party-sig/pkg/paillier", "NewSecretKey", "NewPublicKey", "Enc", "Dec"),
+	functionsOf("github.com/coinbase/kryptology/pkg/paillier", "NewKeys", "NewSecretKey", "NewPubkey", "Encrypt", "Decrypt", "Add", "Mul"),
+	functionsOf("github.com/roasbeef/go-go-gadget-paillier", "GenerateKey", "Encrypt", "Decrypt", "AddCipher", "Add", "Mul"),
+)
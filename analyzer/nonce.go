@@ -0,0 +1,103 @@
+package analyzer
+
+import (
+	"go/ast"
+	"go/types"
+	"slices"
+)
+
+// Third-party functions signing with deterministic ECDSA nonces (RFC 6979),
+// or deriving such nonces, outside the standard library.
+var deterministicECDSAIdentifiers = slices.Concat(
+	functionsOf("github.com/codahale/rfc6979", "SignECDSA", "SignDSA"),
+	functionsOf("github.com/decred/dcrd/dcrec/secp256k1/v4", "NonceRFC6979"),
+	functionsOf("github.com/decred/dcrd/dcrec/secp256k1/v4/ecdsa", "Sign", "SignCompact"),
+	functionsOf("github.com/btcsuite/btcd/btcec/v2", "NonceRFC6979"),
+	functionsOf("github.com/btcsuite/btcd/btcec/v2/ecdsa", "Sign", "SignCompact"),
+	functionsOf("github.com/ethereum/go-ethereum/crypto", "Sign"),
+	functionsOf("github.com/ethereum/go-ethereum/crypto/secp256k1", "Sign"),
+)
+
+// Functions and methods of crypto/ecdsa signing with the nonce randomness
+// read from their first argument.
+var ecdsaSigningIdentifiers = functionsOf("crypto/ecdsa", "Sign", "SignASN1")
+
+const customSigningMessage = "custom signing pipelines have no drop-in post-quantum replacement and must be rebuilt around ML-DSA"
+
+// Reports hand-rolled ECDSA signing pipelines: third-party deterministic
+// ECDSA implementations, crypto/ecdsa signing with nonce randomness from a
+// reader other than crypto/rand.Reader, such as a DRBG seeded from the
+// message, and functions computing signatures from their own nonces with
+// elliptic curve scalar multiplication and math/big modular inverses.
+func reportCustomSigning(r *reporter, file *ast.File) {
+	info := r.pass.TypesInfo
+	ast.Inspect(file, func(node ast.Node) bool {
+		switch node := node.(type) {
+		case *ast.FuncDecl:
+			if node.Body != nil && computesSignatureNonces(info, node.Body) {
+				r.reportOperation(node.Name.Pos(), ruleCustomSigning, OperationPrivate, "function %s computes ECDSA signatures from its own nonces; %s", node.Name.Name, customSigningMessage)
+			}
+		case *ast.CallExpr:
+			selector, ok := node.Fun.(*ast.SelectorExpr)
+			if !ok {
+				return true
+			}
+			if localImportName, ok := selector.X.(*ast.Ident); ok {
+				if name, ok := vulnerableFunction(info, localImportName, selector.Sel, deterministicECDSAIdentifiers); ok {
+					r.reportOperation(selector.Sel.Pos(), ruleCustomSigning, OperationPrivate, `"%s" is a third-party deterministic ECDSA (RFC 6979) implementation; %s`, name, customSigningMessage)
+					return true
+				}
+			}
+			name, ok := "", false
+			if localImportName, isIdent := selector.X.(*ast.Ident); isIdent {
+				name, ok = vulnerableFunction(info, localImportName, selector.Sel, ecdsaSigningIdentifiers)
+			}
+			if !ok {
+				name, ok = vulnerableMethod(info, selector, ecdsaSigningIdentifiers)
+			}
+			if ok && len(node.Args) > 0 && isCustomNonceSource(info, node.Args[0]) {
+				r.reportOperation(node.Args[0].Pos(), ruleCustomSigning, OperationPrivate, `"%s" draws its nonce from %s instead of crypto/rand.Reader; %s`, name, types.ExprString(node.Args[0]), customSigningMessage)
+			}
+		}
+		return true
+	})
+}
+
+// Reports whether the reader passed to an ECDSA signing function is built
+// for it, rather than crypto/rand.Reader or an io.Reader handed down by the
+// caller: a call constructing a reader, or a value of a concrete type.
+func isCustomNonceSource(info *types.Info, reader ast.Expr) bool {
+	if _, ok := ast.Unparen(reader).(*ast.CallExpr); ok {
+		return true
+	}
+	t := info.TypeOf(reader)
+	if t == nil {
+		return false
+	}
+	if basic, ok := t.(*types.Basic); ok && basic.Kind() == types.UntypedNil {
+		return false
+	}
+	return !types.IsInterface(t)
+}
+
+// Reports whether the function body multiplies the curve base point by a
+// scalar and inverts one modulo the curve order, the two steps of computing
+// an ECDSA signature from a nonce.
+func computesSignatureNonces(info *types.Info, body *ast.BlockStmt) bool {
+	var scalarBaseMult, modInverse bool
+	ast.Inspect(body, func(node ast.Node) bool {
+		if _, ok := node.(*ast.FuncLit); ok {
+			return false
+		}
+		if selector, ok := node.(*ast.SelectorExpr); ok {
+			if _, ok := vulnerableMethod(info, selector, []QvFunction{{"ScalarBaseMult", "crypto/elliptic"}}); ok {
+				scalarBaseMult = true
+			}
+			if _, ok := vulnerableMethod(info, selector, []QvFunction{{"ModInverse", "math/big"}}); ok {
+				modInverse = true
+			}
+		}
+		return true
+	})
+	return scalarBaseMult && modInverse
+}
@@ -0,0 +1,113 @@
+package analyzer
+
+import (
+	"fmt"
+	"go/ast"
+	"slices"
+	"strings"
+)
+
+// Operation classifies which side of a public-key algorithm a call site is
+// on. Verification of existing classical signatures often has to continue
+// long after new signing stops, so policies may weight the sides differently.
+type Operation int
+
+const (
+	// The side of the call could not be determined.
+	OperationUnknown Operation = iota
+	// Verifying signatures, encrypting or handling public keys.
+	OperationPublic
+	// Signing, decrypting, generating or handling private keys.
+	OperationPrivate
+)
+
+var operationNames = []string{"unknown", "public", "private"}
+
+func (o Operation) String() string {
+	if o < OperationUnknown || o > OperationPrivate {
+		return fmt.Sprintf("Operation(%d)", int(o))
+	}
+	return operationNames[o]
+}
+
+// ParseOperation returns the operation with the given name.
+func ParseOperation(name string) (Operation, error) {
+	for i, operationName := range operationNames {
+		if operationName == name {
+			return Operation(i), nil
+		}
+	}
+	return OperationUnknown, fmt.Errorf("unknown operation %q", name)
+}
+
+// Name fragments of functions and methods on the private side, checked before
+// those of the public side: ParsePKCS1PrivateKey is private-side, though it
+// starts like a public-side parser.
+var privateOperationNames = []string{"Sign", "Decrypt", "Generate", "NewKeyFromSeed", "PrivateKey"}
+
+// Name fragments of functions and methods on the public side.
+var publicOperationNames = []string{"Verif", "Encrypt", "Validator", "Checker", "PublicKey"}
+
+// Classifies a function or method by its name.
+func classifyOperation(name string) Operation {
+	for _, fragment := range privateOperationNames {
+		if strings.Contains(name, fragment) {
+			return OperationPrivate
+		}
+	}
+	for _, fragment := range publicOperationNames {
+		if strings.Contains(name, fragment) {
+			return OperationPublic
+		}
+	}
+	return OperationUnknown
+}
+
+// Words of the private-side calls whose throughput matters in loops: signing
+// and decryption themselves, rather than the signers, signatures and keys
+// other private-side names, such as NewSignerFromKey, mention.
+var highVolumeOperationWords = []string{"Sign", "Decrypt"}
+
+// Reports whether the node is the function of a signing or decryption call
+// made in the body of a loop, directly or in a closure the loop starts, such
+// as a goroutine or errgroup task per item. The path leads from the file to
+// the node.
+func highVolume(node ast.Node, path []ast.Node) bool {
+	var call *ast.CallExpr
+	for _, enclosing := range slices.Backward(path) {
+		var body *ast.BlockStmt
+		switch enclosing := enclosing.(type) {
+		case *ast.CallExpr:
+			if call == nil && enclosing.Fun.Pos() <= node.Pos() && node.End() <= enclosing.Fun.End() {
+				call = enclosing
+				name := calledName(call)
+				if classifyOperation(name) != OperationPrivate || !slices.ContainsFunc(nameWords(name), func(word string) bool {
+					return slices.Contains(highVolumeOperationWords, word)
+				}) {
+					return false
+				}
+			}
+		case *ast.ForStmt:
+			body = enclosing.Body
+		case *ast.RangeStmt:
+			body = enclosing.Body
+		case *ast.FuncDecl:
+			return false
+		}
+		if call != nil && body != nil && body.Pos() <= call.Pos() && call.End() <= body.End() {
+			return true
+		}
+	}
+	return false
+}
+
+// Returns the name of the function or method a call calls, if it is named.
+func calledName(call *ast.CallExpr) string {
+	switch fun := ast.Unparen(call.Fun).(type) {
+	case *ast.Ident:
+		return fun.Name
+	case *ast.SelectorExpr:
+		return fun.Sel.Name
+	}
+	return ""
+}
@@ -0,0 +1,25 @@
+package analyzer
+
+import "slices"
+
+// Functions and methods of BLS signature and pairing libraries: herumi's BLS
+// bindings, kilic/bls12-381, Prysm's and blst's Ethereum BLS signatures,
+// gnark-crypto and CIRCL pairings, and golang.org/x/crypto/bn256. Pairings
+// are bilinear maps between elliptic curve groups, and BLS signatures and
+// their aggregation rest on the discrete logarithm problem in these groups,
+// which Shor's algorithm solves like that of any other curve.
+var pairingIdentifiers = slices.Concat(
+	functionsOf("github.com/herumi/bls-eth-go-binary/bls", "SetByCSPRNG", "Sign", "SignByte", "SignHash", "Verify", "VerifyByte", "VerifyHash", "Aggregate", "FastAggregateVerify", "AggregateVerify", "GetPublicKey"),
+	functionsOf("github.com/herumi/bls-go-binary/bls", "SetByCSPRNG", "Sign", "SignByte", "SignHash", "Verify", "VerifyByte", "VerifyHash", "Aggregate", "FastAggregateVerify", "AggregateVerify", "GetPublicKey"),
+	functionsOf("github.com/kilic/bls12-381", "NewEngine", "NewG1", "NewG2", "AddPair", "AddPairInv", "Check", "Result"),
+	functionsOf("github.com/prysmaticlabs/prysm/v5/crypto/bls", "RandKey", "SecretKeyFromBytes", "PublicKeyFromBytes", "SignatureFromBytes", "AggregateSignatures", "AggregatePublicKeys", "VerifySignature", "VerifyMultipleSignatures"),
+	functionsOf("github.com/prysmaticlabs/prysm/v5/crypto/bls/common", "Sign", "Verify", "FastAggregateVerify", "Eth2FastAggregateVerify", "AggregateVerify", "PublicKey"),
+	functionsOf("github.com/prysmaticlabs/prysm/v4/crypto/bls", "RandKey", "SecretKeyFromBytes", "PublicKeyFromBytes", "SignatureFromBytes", "AggregateSignatures", "AggregatePublicKeys", "VerifySignature", "VerifyMultipleSignatures"),
+	functionsOf("github.com/prysmaticlabs/prysm/v4/crypto/bls/common", "Sign", "Verify", "FastAggregateVerify", "Eth2FastAggregateVerify", "AggregateVerify", "PublicKey"),
+	functionsOf("github.com/supranational/blst/bindings/go", "KeyGen", "Sign", "Verify", "AggregateVerify", "FastAggregateVerify", "AggregateVerifyCompressed"),
+	functionsOf("github.com/consensys/gnark-crypto/ecc/bls12-381", "Pair", "PairingCheck", "MillerLoop"),
+	functionsOf("github.com/consensys/gnark-crypto/ecc/bn254", "Pair", "PairingCheck", "MillerLoop"),
+	functionsOf("github.com/cloudflare/circl/sign/bls", "KeyGen", "Sign", "Verify", "Aggregate", "VerifyAggregate"),
+	functionsOf("github.com/cloudflare/circl/ecc/bls12381", "Pair", "ProdPair", "ProdPairFrac"),
+	functionsOf("golang.org/x/crypto/bn256", "Pair", "RandomG1", "RandomG2"),
+)
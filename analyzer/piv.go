@@ -0,0 +1,38 @@
+package analyzer
+
+import (
+	"go/ast"
+	"slices"
+)
+
+// Methods of PIV smart card libraries generating, importing and using keys in
+// the slots of cards such as YubiKeys: piv-go, in its v1 and v2 modules, and
+// go-ykpiv.
+var pivIdentifiers = slices.Concat(
+	functionsOf("github.com/go-piv/piv-go/piv", "GenerateKey", "PrivateKey", "SetPrivateKeyInsecure"),
+	functionsOf("github.com/go-piv/piv-go/v2/piv", "GenerateKey", "PrivateKey", "SetPrivateKeyInsecure"),
+	functionsOf("github.com/paultag/go-ykpiv", "GenerateRSA", "GenerateEC", "GenerateRSAWithPolicies", "GenerateECWithPolicies", "ImportKey", "Sign", "Decrypt"),
+)
+
+// Algorithm constants of piv-go, with the classical algorithm they select.
+var pivKeyAlgorithms = map[QvFunction]string{
+	{"AlgorithmEC256", "github.com/go-piv/piv-go/piv"}:      "ECDSA P-256",
+	{"AlgorithmEC384", "github.com/go-piv/piv-go/piv"}:      "ECDSA P-384",
+	{"AlgorithmEd25519", "github.com/go-piv/piv-go/piv"}:    "Ed25519",
+	{"AlgorithmRSA1024", "github.com/go-piv/piv-go/piv"}:    "RSA-1024",
+	{"AlgorithmRSA2048", "github.com/go-piv/piv-go/piv"}:    "RSA-2048",
+	{"AlgorithmEC256", "github.com/go-piv/piv-go/v2/piv"}:   "ECDSA P-256",
+	{"AlgorithmEC384", "github.com/go-piv/piv-go/v2/piv"}:   "ECDSA P-384",
+	{"AlgorithmEd25519", "github.com/go-piv/piv-go/v2/piv"}: "Ed25519",
+	{"AlgorithmX25519", "github.com/go-piv/piv-go/v2/piv"}:  "X25519",
+	{"AlgorithmRSA1024", "github.com/go-piv/piv-go/v2/piv"}: "RSA-1024",
+	{"AlgorithmRSA2048", "github.com/go-piv/piv-go/v2/piv"}: "RSA-2048",
+	{"AlgorithmRSA3072", "github.com/go-piv/piv-go/v2/piv"}: "RSA-3072",
+	{"AlgorithmRSA4096", "github.com/go-piv/piv-go/v2/piv"}: "RSA-4096",
+}
+
+// Reports the algorithm constants of piv-go, naming the classical algorithm of
+// the key generated or imported on the card.
+func reportPIVKeyAlgorithms(r *reporter, file *ast.File) {
+	reportConstants(r, file, pivKeyAlgorithms, rulePIVKey, `algorithm "%s.%s" puts a quantum-vulnerable %s key on a PIV smart card, which can only be replaced with new hardware`)
+}
@@ -0,0 +1,122 @@
+package analyzer
+
+import (
+	"go/ast"
+	"go/types"
+	"slices"
+)
+
+const (
+	sslmatePKCS12 = "software.sslmate.com/src/go-pkcs12"
+	xPKCS12       = "golang.org/x/crypto/pkcs12"
+)
+
+// Functions and encoder methods writing a private key and its certificates
+// to a PKCS#12 file. The key is the second argument of the function, after
+// the random source, and the first of the methods.
+var pkcs12KeyEncoders = functionsOf(sslmatePKCS12, "Encode")
+
+// Functions and encoder methods writing certificates to a PKCS#12 trust
+// store.
+var pkcs12TrustStoreEncoders = functionsOf(sslmatePKCS12, "EncodeTrustStore", "EncodeTrustStoreEntries")
+
+// Functions reading the private key and certificates of a PKCS#12 file.
+var pkcs12Decoders = slices.Concat(
+	functionsOf(sslmatePKCS12, "Decode", "DecodeChain", "DecodeTrustStore"),
+	functionsOf(xPKCS12, "Decode", "ToPEM"),
+)
+
+// Encryption of the encoders of go-pkcs12. The package-level functions use
+// the legacy RC2 and 3DES password-based encryption of classic PKCS#12.
+var pkcs12Encryption = map[string]string{
+	"Legacy":       "encrypted with legacy RC2 and 3DES",
+	"LegacyRC2":    "encrypted with legacy RC2 and 3DES",
+	"LegacyDES":    "encrypted with legacy 3DES",
+	"Modern":       "encrypted with AES-256",
+	"Modern2023":   "encrypted with AES-256",
+	"Passwordless": "unencrypted",
+}
+
+const pkcs12Message = "PKCS#12 files distribute classical keys and certificates outside any key store, and have to be reissued with post-quantum ones"
+
+// Reports PKCS#12 bundles created or parsed with go-pkcs12 or
+// golang.org/x/crypto/pkcs12: the classical key type bundled, when known,
+// and the encryption of the encoder, legacy RC2 and 3DES unless an encoder
+// such as Modern is chosen.
+func reportPKCS12Bundles(r *reporter, file *ast.File) {
+	info := r.pass.TypesInfo
+	ast.Inspect(file, func(node ast.Node) bool {
+		call, ok := node.(*ast.CallExpr)
+		if !ok {
+			return true
+		}
+		selector, ok := call.Fun.(*ast.SelectorExpr)
+		if !ok {
+			return true
+		}
+		if localImportName, ok := selector.X.(*ast.Ident); ok {
+			if pkgName, ok := info.Uses[localImportName].(*types.PkgName); ok {
+				if name, ok := vulnerableFunction(info, localImportName, selector.Sel, pkcs12KeyEncoders); ok {
+					r.reportOperation(selector.Sel.Pos(), rulePKCS12, OperationPrivate, `"%s" bundles %s in a PKCS#12 file, encrypted with legacy RC2 and 3DES; %s`, name, signerKeyType(info, call.Args, 1), pkcs12Message)
+				} else if name, ok := vulnerableFunction(info, localImportName, selector.Sel, pkcs12TrustStoreEncoders); ok {
+					r.reportOperation(selector.Sel.Pos(), rulePKCS12, OperationPublic, `"%s" bundles classical certificates in a PKCS#12 trust store; %s`, name, pkcs12Message)
+				} else if name, ok := vulnerableFunction(info, localImportName, selector.Sel, pkcs12Decoders); ok {
+					legacy := ""
+					if pkgName.Imported().Path() == xPKCS12 {
+						legacy = ", which only reads files encrypted with legacy RC2 and 3DES"
+					}
+					r.reportOperation(selector.Sel.Pos(), rulePKCS12, OperationPrivate, `"%s" reads classical keys or certificates from a PKCS#12 file%s; %s`, name, legacy, pkcs12Message)
+				}
+				return true
+			}
+		}
+
+		encryption := ""
+		if encoder, ok := pkcs12Encoder(info, selector.X); ok && pkcs12Encryption[encoder] != "" {
+			encryption = ", " + pkcs12Encryption[encoder]
+		}
+		if name, ok := vulnerableMethod(info, selector, pkcs12KeyEncoders); ok {
+			r.reportOperation(selector.Sel.Pos(), rulePKCS12, OperationPrivate, `"%s" bundles %s in a PKCS#12 file%s; %s`, name, signerKeyType(info, call.Args, 0), encryption, pkcs12Message)
+		} else if name, ok := vulnerableMethod(info, selector, pkcs12TrustStoreEncoders); ok {
+			r.reportOperation(selector.Sel.Pos(), rulePKCS12, OperationPublic, `"%s" bundles classical certificates in a PKCS#12 trust store; %s`, name, pkcs12Message)
+		}
+		return true
+	})
+}
+
+// Returns the key type bundled or signed with by a call, the type of the
+// argument at the given index, or "a classical private key" if it is not a
+// known private key type.
+func signerKeyType(info *types.Info, args []ast.Expr, index int) string {
+	if len(args) <= index {
+		return "a classical private key"
+	}
+	t := info.TypeOf(args[index])
+	if !isNamedType(t, privateKeyTypes) {
+		return "a classical private key"
+	}
+	return "a " + types.TypeString(t, (*types.Package).Name)
+}
+
+// Returns the name of the go-pkcs12 encoder variable an encoder expression
+// starts from, such as "LegacyDES" for pkcs12.LegacyDES.WithRand(r).
+func pkcs12Encoder(info *types.Info, expr ast.Expr) (string, bool) {
+	for {
+		switch e := ast.Unparen(expr).(type) {
+		case *ast.CallExpr:
+			selector, ok := e.Fun.(*ast.SelectorExpr)
+			if !ok {
+				return "", false
+			}
+			expr = selector.X
+		case *ast.SelectorExpr:
+			obj, ok := info.Uses[e.Sel].(*types.Var)
+			if !ok || obj.Pkg() == nil || obj.Pkg().Path() != sslmatePKCS12 {
+				return "", false
+			}
+			return obj.Name(), true
+		default:
+			return "", false
+		}
+	}
+}
@@ -0,0 +1,489 @@
+package analyzer
+
+import (
+	"fmt"
+	"go/ast"
+	"go/types"
+	"maps"
+	"path"
+	"reflect"
+	"slices"
+	"strings"
+
+	"golang.org/x/tools/go/analysis"
+)
+
+// KeyProvenance is the fact of an exported function or method outside the
+// standard library that returns classical key material, such as a
+// crypto.Signer backed by an ECDSA key, or uses the key material passed to it
+// or held by its receiver with a classical algorithm. Packages calling the
+// function are analyzed with the facts of their dependencies, so a signer
+// built in another package is reported where it signs, without whole-program
+// analysis.
+type KeyProvenance struct {
+	// Algorithm of the key material the function returns, such as "ECDSA",
+	// or empty.
+	Returns string
+	// Function or key type the returned key material comes from, such as
+	// "ecdsa.GenerateKey".
+	ReturnedBy string
+	// Index of the result holding the returned key material.
+	Result int
+	// Algorithm the function uses the key material passed to it or held by
+	// its receiver with, or empty.
+	Uses string
+	// Function the key material is used by, such as "rsa.SignPKCS1v15".
+	UsedBy string
+}
+
+func (*KeyProvenance) AFact() {}
+
+func (p *KeyProvenance) String() string {
+	var parts []string
+	if p.Returns != "" {
+		parts = append(parts, fmt.Sprintf("returns %s key material from %s", p.Returns, p.ReturnedBy))
+	}
+	if p.Uses != "" {
+		parts = append(parts, fmt.Sprintf("uses key material with %s in %s", p.Uses, p.UsedBy))
+	}
+	return strings.Join(parts, ", ")
+}
+
+// Analyzer recording the KeyProvenance facts of the functions of a package,
+// required by the PQC analyzer. Its result holds the facts of the functions
+// of the dependencies of the package.
+var provenanceAnalyzer = &analysis.Analyzer{
+	Name:       "pqcKeyProvenance",
+	Doc:        "records the classical key material functions return or use, for the analysis of the packages calling them",
+	Run:        recordKeyProvenance,
+	FactTypes:  []analysis.Fact{new(KeyProvenance)},
+	ResultType: reflect.TypeFor[keyProvenances](),
+}
+
+// The key provenance of functions, by function.
+type keyProvenances map[*types.Func]*KeyProvenance
+
+// Algorithms of the packages of classical keys.
+var classicalKeyPackages = map[string]string{
+	"crypto/rsa":                  "RSA",
+	"crypto/ecdsa":                "ECDSA",
+	"crypto/ed25519":              "Ed25519",
+	"crypto/ecdh":                 "ECDH",
+	"crypto/dsa":                  "DSA",
+	"golang.org/x/crypto/ed25519": "Ed25519",
+}
+
+// Functions parsing the keys of a single classical algorithm.
+var singleAlgorithmKeyParsers = map[QvFunction]string{
+	{"ParsePKCS1PrivateKey", "crypto/x509"}: "RSA",
+	{"ParsePKCS1PublicKey", "crypto/x509"}:  "RSA",
+	{"ParseECPrivateKey", "crypto/x509"}:    "ECDSA",
+}
+
+// Functions and methods of the packages of classical keys using the key
+// material passed to them or held by their receiver.
+var keyUses = slices.Concat(
+	functionsOf("crypto/rsa", "SignPKCS1v15", "SignPSS", "VerifyPKCS1v15", "VerifyPSS", "EncryptOAEP", "EncryptPKCS1v15", "DecryptOAEP", "DecryptPKCS1v15", "DecryptPKCS1v15SessionKey", "Sign", "Decrypt"),
+	functionsOf("crypto/ecdsa", "Sign", "SignASN1", "Verify", "VerifyASN1", "ECDH"),
+	functionsOf("crypto/ed25519", "Sign", "Verify", "VerifyWithOptions"),
+	functionsOf("crypto/ecdh", "ECDH"),
+	functionsOf("crypto/dsa", "Sign", "Verify"),
+)
+
+// Qualified names of the functions generating, parsing and using keys, such
+// as "ecdsa.GenerateKey", as recorded in the facts.
+var keyFunctionNames = func() map[QvFunction]string {
+	names := make(map[QvFunction]string)
+	for _, qvFunc := range slices.Concat(keyUses, slices.Collect(maps.Keys(keyGenerators)), slices.Collect(maps.Keys(singleAlgorithmKeyParsers))) {
+		names[qvFunc] = path.Base(qvFunc.Package) + "." + qvFunc.FnName
+	}
+	return names
+}()
+
+// Records the key provenance of the functions of the package, and exports
+// that of its exported functions and methods. Packages of the standard
+// library, whose crypto is reported by the other rules, and packages neither
+// importing classical keys nor calling functions with key provenance are
+// skipped.
+func recordKeyProvenance(pass *analysis.Pass) (any, error) {
+	var imported keyProvenances
+	for _, fact := range pass.AllObjectFacts() {
+		if fn, ok := fact.Object.(*types.Func); ok {
+			if imported == nil {
+				imported = make(keyProvenances)
+			}
+			imported[fn] = fact.Fact.(*KeyProvenance)
+		}
+	}
+	first, _, _ := strings.Cut(pass.Pkg.Path(), "/")
+	if (pass.Module == nil || pass.Module.Path == "") && !strings.Contains(first, ".") {
+		return imported, nil
+	}
+	if imported == nil && !slices.ContainsFunc(pass.Pkg.Imports(), func(pkg *types.Package) bool {
+		_, ok := classicalKeyPackages[pkg.Path()]
+		return ok || pkg.Path() == "crypto/x509"
+	}) {
+		return imported, nil
+	}
+
+	var decls []*ast.FuncDecl
+	for _, file := range pass.Files {
+		for _, decl := range file.Decls {
+			if funcDecl, ok := decl.(*ast.FuncDecl); ok && funcDecl.Body != nil {
+				decls = append(decls, funcDecl)
+			}
+		}
+	}
+	provenances := maps.Clone(imported)
+	if provenances == nil {
+		provenances = make(keyProvenances)
+	}
+	// Functions of the package calling each other are resolved to a fixed
+	// point, as a function may be declared before the one generating its
+	// keys.
+	finder := &provenanceFinder{info: pass.TypesInfo, provenances: provenances}
+	for range len(decls) + 1 {
+		changed := false
+		for _, decl := range decls {
+			fn, ok := pass.TypesInfo.Defs[decl.Name].(*types.Func)
+			if !ok {
+				continue
+			}
+			if provenance, ok := finder.find(decl); ok && (provenances[fn] == nil || *provenances[fn] != provenance) {
+				found := provenance
+				provenances[fn] = &found
+				changed = true
+			}
+		}
+		if !changed {
+			break
+		}
+	}
+	for _, decl := range decls {
+		if fn, ok := pass.TypesInfo.Defs[decl.Name].(*types.Func); ok && fn.Exported() && provenances[fn] != nil {
+			pass.ExportObjectFact(fn, provenances[fn])
+		}
+	}
+	return imported, nil
+}
+
+// An ast.Visitor finding the key provenance of the functions it is reused
+// for: the first key material a function returns, and the first classical
+// algorithm it uses its parameters or receiver with.
+type provenanceFinder struct {
+	info        *types.Info
+	provenances keyProvenances
+	body        *ast.BlockStmt
+	provenance  KeyProvenance
+	// Parameters and the receiver of the function, and the variables
+	// assigned from them, such as type assertions to key types.
+	params []types.Object
+}
+
+// Returns the key provenance of a function, if any.
+func (f *provenanceFinder) find(decl *ast.FuncDecl) (KeyProvenance, bool) {
+	f.body, f.provenance, f.params = decl.Body, KeyProvenance{}, f.params[:0]
+	for _, fields := range []*ast.FieldList{decl.Recv, decl.Type.Params} {
+		if fields == nil {
+			continue
+		}
+		for _, field := range fields.List {
+			for _, name := range field.Names {
+				if obj := f.info.Defs[name]; obj != nil {
+					f.params = append(f.params, obj)
+				}
+			}
+		}
+	}
+	ast.Walk(f, decl.Body)
+	return f.provenance, f.provenance != KeyProvenance{}
+}
+
+// Reports whether an expression takes key material from a parameter.
+func (f *provenanceFinder) param(expr ast.Expr) bool {
+	obj := f.info.Uses[keyOperand(expr)]
+	return obj != nil && slices.Contains(f.params, obj)
+}
+
+func (f *provenanceFinder) Visit(node ast.Node) ast.Visitor {
+	switch node := node.(type) {
+	case *ast.FuncLit:
+		return nil
+	case *ast.AssignStmt:
+		if len(node.Lhs) == len(node.Rhs) {
+			for i, rhs := range node.Rhs {
+				if ident, ok := node.Lhs[i].(*ast.Ident); ok && f.info.ObjectOf(ident) != nil && f.param(rhs) {
+					f.params = append(f.params, f.info.ObjectOf(ident))
+				}
+			}
+		}
+	case *ast.ReturnStmt:
+		for i, result := range node.Results {
+			if f.provenance.Returns != "" {
+				break
+			}
+			if algorithm, origin, ok := keyMaterial(f.info, f.body, result, f.provenances, 0); ok {
+				f.provenance.Returns, f.provenance.ReturnedBy, f.provenance.Result = algorithm, origin, i
+			}
+		}
+	case *ast.CallExpr:
+		if f.provenance.Uses != "" {
+			return f
+		}
+		fn, receiver := callee(f.info, node)
+		if fn == nil || fn.Pkg() == nil {
+			return f
+		}
+		// Only the keys among the arguments of the functions of the
+		// packages of classical keys are key material, not the messages
+		// they sign; the arguments of other functions are unknown.
+		keysOnly := slices.Contains(keyUses, QvFunction{fn.Name(), fn.Pkg().Path()})
+		called := f.provenances[fn]
+		if !keysOnly && (called == nil || called.Uses == "") {
+			return f
+		}
+		uses := receiver != nil && f.param(receiver)
+		for _, arg := range node.Args {
+			if _, _, ok := classicalKeyType(f.info.TypeOf(arg)); (ok || !keysOnly) && f.param(arg) {
+				uses = true
+			}
+		}
+		switch {
+		case !uses:
+		case keysOnly:
+			qvFunc := QvFunction{fn.Name(), fn.Pkg().Path()}
+			f.provenance.Uses, f.provenance.UsedBy = classicalKeyPackages[qvFunc.Package], keyFunctionNames[qvFunc]
+		default:
+			f.provenance.Uses, f.provenance.UsedBy = called.Uses, called.UsedBy
+		}
+	}
+	return f
+}
+
+// Returns the identifier of the variable an expression such as key,
+// &key, key.PublicKey or priv.(*rsa.PrivateKey) takes key material from.
+func keyOperand(expr ast.Expr) *ast.Ident {
+	if assertion, ok := ast.Unparen(expr).(*ast.TypeAssertExpr); ok {
+		expr = assertion.X
+	}
+	return baseIdent(expr)
+}
+
+// Returns the called function or method of a call, and the receiver of a
+// method call.
+func callee(info *types.Info, call *ast.CallExpr) (*types.Func, ast.Expr) {
+	switch fun := ast.Unparen(call.Fun).(type) {
+	case *ast.Ident:
+		fn, _ := info.Uses[fun].(*types.Func)
+		return fn, nil
+	case *ast.SelectorExpr:
+		fn, ok := info.Uses[fun.Sel].(*types.Func)
+		if !ok || fn.Pkg() == nil {
+			return nil, nil
+		}
+		if selection, ok := info.Selections[fun]; ok && selection.Kind() == types.MethodVal {
+			return fn, fun.X
+		}
+		return fn, nil
+	}
+	return nil, nil
+}
+
+// Returns the algorithm of the classical key material an expression of the
+// body holds, and the function or key type it comes from: a key, a value
+// built from one, such as a signer wrapping it, or the result of a function
+// generating, parsing or returning keys, possibly through variables.
+func keyMaterial(info *types.Info, body *ast.BlockStmt, expr ast.Expr, provenances keyProvenances, depth int) (string, string, bool) {
+	expr = ast.Unparen(expr)
+	switch e := expr.(type) {
+	case *ast.Ident:
+		if v, ok := info.Uses[e].(*types.Var); ok && depth < 4 && mayHoldKey(v.Type()) {
+			if algorithm, origin, ok := assignedKeyMaterial(info, body, v, provenances, depth); ok {
+				return algorithm, origin, true
+			}
+		}
+	case *ast.UnaryExpr:
+		return keyMaterial(info, body, e.X, provenances, depth)
+	case *ast.CompositeLit:
+		for _, elt := range e.Elts {
+			if keyValue, ok := elt.(*ast.KeyValueExpr); ok {
+				elt = keyValue.Value
+			}
+			if algorithm, origin, ok := keyMaterial(info, body, elt, provenances, depth); ok {
+				return algorithm, origin, true
+			}
+		}
+	case *ast.CallExpr:
+		if algorithm, origin, ok := keyResult(info, e, 0, provenances); ok {
+			return algorithm, origin, true
+		}
+	}
+	return classicalKeyType(info.TypeOf(expr))
+}
+
+// Reports whether a variable of the type may hold key material: it is not an
+// error, a string, a number or an unnamed slice such as a message.
+func mayHoldKey(t types.Type) bool {
+	switch t := types.Unalias(t).(type) {
+	case *types.Basic, *types.Slice:
+		return false
+	case *types.Named:
+		return t.Obj() != types.Universe.Lookup("error")
+	}
+	return true
+}
+
+// Returns the algorithm and origin of the key material assigned to a
+// variable in the body.
+func assignedKeyMaterial(info *types.Info, body *ast.BlockStmt, v *types.Var, provenances keyProvenances, depth int) (string, string, bool) {
+	var algorithm, origin string
+	ast.Inspect(body, func(node ast.Node) bool {
+		assign, ok := node.(*ast.AssignStmt)
+		if algorithm != "" || !ok {
+			return algorithm == ""
+		}
+		for i, lhs := range assign.Lhs {
+			if ident, ok := lhs.(*ast.Ident); !ok || info.ObjectOf(ident) != v {
+				continue
+			}
+			found := false
+			if len(assign.Rhs) == len(assign.Lhs) {
+				algorithm, origin, found = keyMaterial(info, body, assign.Rhs[i], provenances, depth+1)
+			} else if call, ok := ast.Unparen(assign.Rhs[0]).(*ast.CallExpr); ok && len(assign.Rhs) == 1 {
+				algorithm, origin, found = keyResult(info, call, i, provenances)
+			}
+			if !found {
+				algorithm, origin = "", ""
+			}
+		}
+		return true
+	})
+	return algorithm, origin, algorithm != ""
+}
+
+// Returns the algorithm and origin of the key material of a result of a
+// call: the first result of functions generating or parsing keys, or the
+// result of a function with key provenance holding its key material.
+func keyResult(info *types.Info, call *ast.CallExpr, result int, provenances keyProvenances) (string, string, bool) {
+	fn, _ := callee(info, call)
+	if fn == nil || fn.Pkg() == nil {
+		return "", "", false
+	}
+	qvFunc := QvFunction{fn.Name(), fn.Pkg().Path()}
+	if _, ok := keyGenerators[qvFunc]; ok && result == 0 {
+		return classicalKeyPackages[qvFunc.Package], keyFunctionNames[qvFunc], true
+	}
+	if algorithm, ok := singleAlgorithmKeyParsers[qvFunc]; ok && result == 0 {
+		return algorithm, keyFunctionNames[qvFunc], true
+	}
+	if provenance := provenances[fn]; provenance != nil && provenance.Returns != "" && provenance.Result == result {
+		return provenance.Returns, provenance.ReturnedBy, true
+	}
+	return "", "", false
+}
+
+// Returns the algorithm of a classical key type, or pointer to one, and its
+// name.
+func classicalKeyType(t types.Type) (string, string, bool) {
+	if ptr, ok := types.Unalias(t).(*types.Pointer); ok {
+		t = ptr.Elem()
+	}
+	named, ok := types.Unalias(t).(*types.Named)
+	if !ok || named.Obj().Pkg() == nil {
+		return "", "", false
+	}
+	algorithm, ok := classicalKeyPackages[named.Obj().Pkg().Path()]
+	if !ok || named.Obj().Name() != "PrivateKey" && named.Obj().Name() != "PublicKey" {
+		return "", "", false
+	}
+	return algorithm, named.Obj().Pkg().Name() + "." + named.Obj().Name(), true
+}
+
+const keyProvenanceMessage = "the classical algorithm is chosen in another package, which has to migrate before this call can"
+
+// Reports the calls of functions of other packages with key provenance:
+// calls of functions using the key material passed to them or held by their
+// receiver with a classical algorithm, and signing, decrypting or other
+// operations on key material returned by such functions, such as the Sign
+// method of a crypto.Signer built by another package.
+func reportImportedKeyMaterial(r *reporter, file *ast.File) {
+	provenances, _ := r.pass.ResultOf[provenanceAnalyzer].(keyProvenances)
+	if len(provenances) == 0 {
+		return
+	}
+	info := r.pass.TypesInfo
+	imported := func(fn *types.Func) *KeyProvenance {
+		if fn == nil {
+			return nil
+		}
+		return provenances[fn]
+	}
+	for _, decl := range file.Decls {
+		funcDecl, ok := decl.(*ast.FuncDecl)
+		if !ok || funcDecl.Body == nil {
+			continue
+		}
+		// Variables holding key material returned by functions of other
+		// packages, and the functions.
+		returned := make(map[types.Object]*types.Func)
+		ast.Inspect(funcDecl.Body, func(node ast.Node) bool {
+			assign, ok := node.(*ast.AssignStmt)
+			if !ok || len(assign.Rhs) != 1 {
+				return true
+			}
+			call, ok := ast.Unparen(assign.Rhs[0]).(*ast.CallExpr)
+			if !ok {
+				return true
+			}
+			fn, _ := callee(info, call)
+			if provenance := imported(fn); provenance != nil && provenance.Returns != "" && provenance.Result < len(assign.Lhs) {
+				if ident, ok := assign.Lhs[provenance.Result].(*ast.Ident); ok && info.ObjectOf(ident) != nil {
+					returned[info.ObjectOf(ident)] = fn
+				}
+			}
+			return true
+		})
+
+		ast.Inspect(funcDecl.Body, func(node ast.Node) bool {
+			call, ok := node.(*ast.CallExpr)
+			if !ok {
+				return true
+			}
+			selector, ok := ast.Unparen(call.Fun).(*ast.SelectorExpr)
+			if !ok {
+				return true
+			}
+			fn, receiver := callee(info, call)
+			if provenance := imported(fn); provenance != nil && provenance.Uses != "" {
+				symbol := []QvFunction{{fn.Name(), fn.Pkg().Path()}}
+				if name, ok := vulnerableMethod(info, selector, symbol); ok {
+					r.reportOperation(selector.Sel.Pos(), ruleImportedKeyMaterial, classifyOperation(fn.Name()), `method "%s" uses the key material of its receiver or arguments with %s in %s; %s`, name, provenance.Uses, provenance.UsedBy, keyProvenanceMessage)
+				} else if localImportName, ok := selector.X.(*ast.Ident); ok {
+					if name, ok := vulnerableFunction(info, localImportName, selector.Sel, symbol); ok {
+						r.reportOperation(selector.X.Pos(), ruleImportedKeyMaterial, classifyOperation(fn.Name()), `function "%s" uses the key material of its arguments with %s in %s; %s`, name, provenance.Uses, provenance.UsedBy, keyProvenanceMessage)
+					}
+				}
+				return true
+			}
+			if receiver == nil || classifyOperation(fn.Name()) == OperationUnknown {
+				return true
+			}
+			var source *types.Func
+			if ident := keyOperand(receiver); ident != nil {
+				source = returned[info.Uses[ident]]
+			} else if inner, ok := ast.Unparen(receiver).(*ast.CallExpr); ok {
+				if innerFn, _ := callee(info, inner); imported(innerFn) != nil && imported(innerFn).Returns != "" && imported(innerFn).Result == 0 {
+					source = innerFn
+				}
+			}
+			if source == nil {
+				return true
+			}
+			provenance := provenances[source]
+			if name, ok := vulnerableMethod(info, selector, []QvFunction{{fn.Name(), fn.Pkg().Path()}}); ok {
+				r.reportOperation(selector.Sel.Pos(), ruleImportedKeyMaterial, classifyOperation(fn.Name()), `method "%s" uses %s key material returned by "%s.%s", from %s; %s`, name, provenance.Returns, source.Pkg().Name(), source.Name(), provenance.ReturnedBy, keyProvenanceMessage)
+			}
+			return true
+		})
+	}
+}
@@ -0,0 +1,24 @@
+package analyzer
+
+import (
+	"strings"
+)
+
+// Keywords in the elements of import paths of crypto implementations, such as
+// "crypto" in golang.org/x/crypto/ssh or "jose" in github.com/go-jose/go-jose.
+var cryptoPathKeywords = []string{
+	"crypto", "circl", "jose", "jwk", "jws", "jwt", "nacl", "openpgp", "paseto",
+	"pgp", "pkcs", "sodium", "ssh", "tink", "tls", "x509",
+}
+
+// Reports whether the import path looks like that of a crypto implementation.
+func isCryptoImport(importPath string) bool {
+	for _, element := range strings.Split(importPath, "/") {
+		for _, keyword := range cryptoPathKeywords {
+			if strings.Contains(strings.ToLower(element), keyword) {
+				return true
+			}
+		}
+	}
+	return false
+}
@@ -0,0 +1,198 @@
+package analyzer
+
+import (
+	"fmt"
+	"go/ast"
+	"go/token"
+	"go/types"
+	"slices"
+	"strconv"
+
+	"golang.org/x/tools/go/analysis"
+)
+
+// Returns the outermost import, selector, identifier or literal starting at
+// pos, the symbol a finding at pos is reported on, and the nodes enclosing
+// it, starting with its file. The path is only valid until the next call.
+func (r *reporter) nodeAt(pos token.Pos) (ast.Node, []ast.Node) {
+	for _, file := range r.pass.Files {
+		if pos < file.FileStart || pos >= file.FileEnd {
+			continue
+		}
+		r.finder = nodeFinder{pos: pos}
+		r.finder.path = r.finder.buf[:0]
+		ast.Walk(&r.finder, file)
+		return r.finder.found, r.finder.path
+	}
+	return nil, nil
+}
+
+// An ast.Visitor descending to the node nodeAt returns, keeping the path to
+// it.
+type nodeFinder struct {
+	pos   token.Pos
+	found ast.Node
+	path  []ast.Node
+	// Backing array of the path, deep enough for most findings.
+	buf [16]ast.Node
+}
+
+func (f *nodeFinder) Visit(node ast.Node) ast.Visitor {
+	if f.found != nil {
+		return nil
+	}
+	if node == nil {
+		f.path = f.path[:len(f.path)-1]
+		return nil
+	}
+	if f.pos < node.Pos() || node.End() <= f.pos {
+		return nil
+	}
+	if node.Pos() == f.pos {
+		switch node.(type) {
+		case *ast.ImportSpec, *ast.SelectorExpr, *ast.Ident, *ast.BasicLit:
+			f.found = node
+			return nil
+		}
+	}
+	f.path = append(f.path, node)
+	return f
+}
+
+// Returns the related information of a finding reported on a call, given
+// the nodes enclosing the node it is reported on: the declaration importing
+// the package of the called function or method, and the calls generating the
+// keys passed to it or it is called on, when they are assigned to variables
+// of the enclosing function.
+func (r *reporter) related(node ast.Node, path []ast.Node) []analysis.RelatedInformation {
+	info := r.pass.TypesInfo
+	var call *ast.CallExpr
+	for _, enclosing := range slices.Backward(path) {
+		if c, ok := enclosing.(*ast.CallExpr); ok && c.Fun.Pos() <= node.Pos() && node.End() <= c.Fun.End() {
+			call = c
+			break
+		}
+	}
+	if call == nil {
+		return nil
+	}
+	file := path[0].(*ast.File)
+
+	var related []analysis.RelatedInformation
+	fun := ast.Unparen(call.Fun)
+	var receiver ast.Expr
+	var obj types.Object
+	switch fun := fun.(type) {
+	case *ast.SelectorExpr:
+		obj = info.Uses[fun.Sel]
+		if _, ok := info.Selections[fun]; ok {
+			receiver = fun.X
+		}
+	case *ast.Ident:
+		obj = info.Uses[fun]
+	}
+	if obj != nil && obj.Pkg() != nil && obj.Pkg() != r.pass.Pkg {
+		for _, spec := range file.Imports {
+			if importPath, err := strconv.Unquote(spec.Path.Value); err == nil && importPath == obj.Pkg().Path() {
+				related = append(related, analysis.RelatedInformation{
+					Pos:     spec.Pos(),
+					End:     spec.End(),
+					Message: spec.Path.Value + " imported here",
+				})
+				break
+			}
+		}
+	}
+
+	var funcDecl *ast.FuncDecl
+	for _, enclosing := range slices.Backward(path) {
+		if decl, ok := enclosing.(*ast.FuncDecl); ok {
+			funcDecl = decl
+			break
+		}
+	}
+	if funcDecl == nil || funcDecl.Body == nil {
+		return related
+	}
+	for i := -1; i < len(call.Args); i++ {
+		key := receiver
+		if i >= 0 {
+			key = call.Args[i]
+		}
+		v, ok := info.Uses[baseIdent(key)].(*types.Var)
+		if !ok || !isNamedType(v.Type(), privateKeyTypes) {
+			continue
+		}
+		if generation, name, ok := keyGeneration(info, funcDecl.Body, v); ok {
+			related = append(related, analysis.RelatedInformation{
+				Pos:     generation.Pos(),
+				End:     generation.End(),
+				Message: fmt.Sprintf("%s generated here by %s", v.Name(), name),
+			})
+		}
+	}
+	return related
+}
+
+// Returns the identifier an expression such as key, &key or key.PublicKey
+// refers to a field or the address of, or nil.
+func baseIdent(expr ast.Expr) *ast.Ident {
+	for expr != nil {
+		switch e := ast.Unparen(expr).(type) {
+		case *ast.Ident:
+			return e
+		case *ast.UnaryExpr:
+			expr = e.X
+		case *ast.SelectorExpr:
+			expr = e.X
+		default:
+			return nil
+		}
+	}
+	return nil
+}
+
+// Returns the call of a key generation function assigned to the variable in
+// the body, and the name of the function.
+func keyGeneration(info *types.Info, body *ast.BlockStmt, v *types.Var) (*ast.CallExpr, string, bool) {
+	return keyAssignment(info, body, v, func(qvFunc QvFunction) bool {
+		_, ok := keyGenerators[qvFunc]
+		return ok
+	})
+}
+
+// Returns the call of a function matching the predicate assigned to the
+// variable in the body, and the name of the function.
+func keyAssignment(info *types.Info, body *ast.BlockStmt, v *types.Var, match func(QvFunction) bool) (*ast.CallExpr, string, bool) {
+	var generation *ast.CallExpr
+	var name string
+	ast.Inspect(body, func(node ast.Node) bool {
+		assign, ok := node.(*ast.AssignStmt)
+		if generation != nil || !ok || len(assign.Rhs) != 1 {
+			return generation == nil
+		}
+		call, ok := ast.Unparen(assign.Rhs[0]).(*ast.CallExpr)
+		if !ok {
+			return true
+		}
+		selector, ok := call.Fun.(*ast.SelectorExpr)
+		if !ok {
+			return true
+		}
+		fn, ok := info.Uses[selector.Sel].(*types.Func)
+		if !ok || fn.Pkg() == nil {
+			return true
+		}
+		if !match(QvFunction{fn.Name(), fn.Pkg().Path()}) {
+			return true
+		}
+		for _, lhs := range assign.Lhs {
+			ident, ok := lhs.(*ast.Ident)
+			if ok && (info.Defs[ident] == v || info.Uses[ident] == v) {
+				generation, name = call, fn.Pkg().Name()+"."+fn.Name()
+			}
+		}
+		return true
+	})
+	return generation, name, generation != nil
+}
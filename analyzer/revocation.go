@@ -0,0 +1,66 @@
+package analyzer
+
+import (
+	"go/ast"
+	"go/types"
+)
+
+const xOCSP = "golang.org/x/crypto/ocsp"
+
+// Functions signing OCSP responses and certificate revocation lists, with the
+// index of the signing key among their arguments.
+var revocationSigners = map[QvFunction]int{
+	{"CreateResponse", xOCSP}:               3,
+	{"CreateRevocationList", "crypto/x509"}: 3,
+}
+
+// Functions creating OCSP requests, which identify certificates by the hash
+// of their issuer's classical key, and parsing OCSP responses and revocation
+// lists, whose signatures are verified against classical issuer keys.
+var revocationCheckers = map[QvFunction]string{
+	{"CreateRequest", xOCSP}:               "creates an OCSP request identifying a certificate by its classical issuer key",
+	{"ParseResponse", xOCSP}:               "parses an OCSP response and verifies its classical signature",
+	{"ParseResponseForCert", xOCSP}:        "parses an OCSP response and verifies its classical signature",
+	{"ParseRevocationList", "crypto/x509"}: "parses a certificate revocation list signed with a classical key",
+	{"ParseCRL", "crypto/x509"}:            "parses a certificate revocation list signed with a classical key",
+	{"ParseDERCRL", "crypto/x509"}:         "parses a certificate revocation list signed with a classical key",
+}
+
+const revocationMessage = "revocation infrastructure has to migrate in lockstep with certificate issuance, as relying parties check revocation against the same issuers"
+
+// Reports OCSP responses and certificate revocation lists signed with the
+// classical key of their issuer, naming its type when known, and the OCSP
+// requests and revocation checks depending on classical issuer keys.
+func reportRevocation(r *reporter, file *ast.File) {
+	info := r.pass.TypesInfo
+	ast.Inspect(file, func(node ast.Node) bool {
+		call, ok := node.(*ast.CallExpr)
+		if !ok {
+			return true
+		}
+		selector, ok := call.Fun.(*ast.SelectorExpr)
+		if !ok {
+			return true
+		}
+		localImportName, ok := selector.X.(*ast.Ident)
+		if !ok {
+			return true
+		}
+		pkgName, ok := info.Uses[localImportName].(*types.PkgName)
+		if !ok {
+			return true
+		}
+		fn := QvFunction{selector.Sel.Name, pkgName.Imported().Path()}
+		name := localImportName.Name + "." + selector.Sel.Name
+		if index, ok := revocationSigners[fn]; ok {
+			document := "an OCSP response"
+			if fn.Package == "crypto/x509" {
+				document = "a certificate revocation list"
+			}
+			r.reportOperation(selector.Sel.Pos(), ruleRevocation, OperationPrivate, `"%s" signs %s with %s; %s`, name, document, signerKeyType(info, call.Args, index), revocationMessage)
+		} else if message, ok := revocationCheckers[fn]; ok {
+			r.reportOperation(selector.Sel.Pos(), ruleRevocation, OperationPublic, `"%s" %s; %s`, name, message, revocationMessage)
+		}
+		return true
+	})
+}
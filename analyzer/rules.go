@@ -0,0 +1,792 @@
+package analyzer
+
+import (
+	"embed"
+	"fmt"
+	"go/token"
+	"slices"
+	"strings"
+	"time"
+
+	"golang.org/x/tools/go/analysis"
+)
+
+// Categories group related rules in reports.
+const (
+	CategoryEllipticCurve        = "elliptic-curve"
+	CategoryIntegerFactorization = "integer-factorization"
+	CategoryFunction             = "vulnerable-function"
+	CategorySSH                  = "ssh"
+	CategoryFIPS                 = "fips"
+	CategoryCloudEncryption      = "cloud-encryption"
+	CategoryPKI                  = "pki-handling"
+	CategoryKeyExchange          = "key-exchange"
+	CategorySoftwareUpdate       = "software-update"
+	CategoryHygiene              = "crypto-hygiene"
+	CategoryTokens               = "token-signing"
+	CategoryGovernance           = "governance"
+	CategoryTransport            = "transport"
+	CategoryAgility              = "algorithm-agility"
+	CategoryToolchain            = "toolchain"
+	CategoryCustomCrypto         = "custom-crypto"
+	CategorySchema               = "wire-format"
+	CategoryExternalTrust        = "external-trust"
+	CategoryCommands             = "crypto-command"
+	CategoryAPI                  = "api-boundary"
+	CategoryBuild                = "build-variant"
+	CategoryHardware             = "hardware-bound-key"
+	CategoryDNSSEC               = "dnssec"
+	CategoryE2EE                 = "end-to-end-encryption"
+	CategoryMessaging            = "messaging"
+	CategoryServiceIdentity      = "service-identity"
+	CategoryTooling              = "tooling"
+	CategoryLongLivedSignatures  = "long-lived-signatures"
+	CategoryPairing              = "pairing-based"
+	CategoryDatabaseEncryption   = "database-encryption"
+	CategoryAdvanced             = "advanced-cryptography"
+)
+
+// DocsBaseURL is the location the embedded rule documentation is published at.
+const DocsBaseURL = "https://github.com/ahan-adelaide/pqc-analyzer/blob/main/analyzer/docs/"
+
+// Rule is a class of finding reported by the analyzer. The rule ID is used
+// as the category of the reported analysis.Diagnostic.
+type Rule struct {
+	// Stable identifier of the rule, e.g. "PQC001".
+	ID string
+	// Short human-readable name of the rule.
+	Name     string
+	Category string
+	Severity Severity
+	// One-line description of what the rule reports.
+	Summary string
+	// Documentation page of the rule, for rules of detectors. Built-in rules
+	// are documented under DocsBaseURL.
+	HelpURI string
+	// Name of the registered Detector reporting the rule, or empty for
+	// built-in rules.
+	Detector string
+	// Findings of the rule are the same for every package of a module, such
+	// as those about its go directive: scans keep the first one per module.
+	PerModule bool
+}
+
+// DocURL returns the canonical documentation page of the rule.
+func (r Rule) DocURL() string {
+	if r.HelpURI != "" || r.Detector != "" {
+		return r.HelpURI
+	}
+	return DocsBaseURL + r.ID + ".md"
+}
+
+var (
+	ruleEllipticCurveImport = Rule{
+		ID:       "PQC001",
+		Name:     "elliptic-curve-import",
+		Category: CategoryEllipticCurve,
+		Severity: SeverityMedium,
+		Summary:  "Import of a package implementing elliptic curve cryptography",
+	}
+	ruleIntegerFactorizationImport = Rule{
+		ID:       "PQC002",
+		Name:     "integer-factorization-import",
+		Category: CategoryIntegerFactorization,
+		Severity: SeverityMedium,
+		Summary:  "Import of a package implementing integer factorization cryptography",
+	}
+	ruleVulnerableFunction = Rule{
+		ID:       "PQC003",
+		Name:     "vulnerable-function",
+		Category: CategoryFunction,
+		Severity: SeverityHigh,
+		Summary:  "Call of a function implementing quantum-vulnerable cryptography",
+	}
+	ruleSSHCertificateAuthority = Rule{
+		ID:       "PQC004",
+		Name:     "ssh-certificate-authority",
+		Category: CategorySSH,
+		Severity: SeverityHigh,
+		Summary:  "SSH certificate authority signing or trust with classical keys",
+	}
+	ruleFIPSMode = Rule{
+		ID:       "PQC005",
+		Name:     "fips-mode",
+		Category: CategoryFIPS,
+		Severity: SeverityInfo,
+		Summary:  "Dependency on FIPS 140 mode or BoringCrypto",
+	}
+	ruleCloudEncryption = Rule{
+		ID:       "PQC006",
+		Name:     "cloud-client-side-encryption",
+		Category: CategoryCloudEncryption,
+		Severity: SeverityHigh,
+		Summary:  "Cloud SDK client-side encryption with wrapped data keys",
+	}
+	ruleSSHHostTrustAndAgent = Rule{
+		ID:       "PQC007",
+		Name:     "ssh-known-hosts-and-agent",
+		Category: CategorySSH,
+		Severity: SeverityMedium,
+		Summary:  "SSH known hosts verification and agent key listing or forwarding",
+	}
+	ruleClassicalOID = Rule{
+		ID:       "PQC008",
+		Name:     "classical-algorithm-oid",
+		Category: CategoryPKI,
+		Severity: SeverityMedium,
+		Summary:  "ASN.1 object identifier of a classical public-key algorithm",
+	}
+	ruleFiniteFieldDH = Rule{
+		ID:       "PQC009",
+		Name:     "finite-field-diffie-hellman",
+		Category: CategoryKeyExchange,
+		Severity: SeverityHigh,
+		Summary:  "Finite-field Diffie-Hellman or ElGamal key exchange",
+	}
+	ruleSoftwareUpdateSigning = Rule{
+		ID:       "PQC010",
+		Name:     "software-update-signing",
+		Category: CategorySoftwareUpdate,
+		Severity: SeverityHigh,
+		Summary:  "Release signing or update verification with classical signatures",
+	}
+	rulePrivateKeyLogging = Rule{
+		ID:       "PQC011",
+		Name:     "private-key-logging",
+		Category: CategoryHygiene,
+		Severity: SeverityHigh,
+		Summary:  "Private key material flowing into logging or printing",
+	}
+	ruleEd25519Signing = Rule{
+		ID:       "PQC012",
+		Name:     "ed25519-signing",
+		Category: CategoryEllipticCurve,
+		Severity: SeverityHigh,
+		Summary:  "Ed25519 key generation or signing",
+	}
+	ruleEd25519Verification = Rule{
+		ID:       "PQC013",
+		Name:     "ed25519-verification",
+		Category: CategoryEllipticCurve,
+		Severity: SeverityLow,
+		Summary:  "Ed25519 signature verification",
+	}
+	ruleKubernetesPKI = Rule{
+		ID:       "PQC014",
+		Name:     "kubernetes-pki",
+		Category: CategoryPKI,
+		Severity: SeverityMedium,
+		Summary:  "Kubernetes client certificate rotation, CSR creation or key bootstrapping",
+	}
+	ruleTokenSigning = Rule{
+		ID:       "PQC015",
+		Name:     "token-public-mode",
+		Category: CategoryTokens,
+		Severity: SeverityHigh,
+		Summary:  "PASETO public mode or macaroon third-party caveat keys",
+	}
+	ruleCryptoProvider = Rule{
+		ID:       "PQC016",
+		Name:     "unapproved-crypto-provider",
+		Category: CategoryGovernance,
+		Severity: SeverityMedium,
+		Summary:  "Import of a crypto implementation outside the approved providers",
+	}
+	ruleTLSSessionResumption = Rule{
+		ID:       "PQC017",
+		Name:     "tls-session-resumption",
+		Category: CategoryTransport,
+		Severity: SeverityInfo,
+		Summary:  "TLS session ticket keys and resumption state handling",
+	}
+	ruleGenericKeyInstantiation = Rule{
+		ID:       "PQC018",
+		Name:     "generic-key-instantiation",
+		Category: CategoryFunction,
+		Severity: SeverityMedium,
+		Summary:  "Generic function or type instantiated with a quantum-vulnerable key type",
+	}
+	ruleAlgorithmDispatch = Rule{
+		ID:       "PQC019",
+		Name:     "algorithm-dispatch",
+		Category: CategoryAgility,
+		Severity: SeverityInfo,
+		Summary:  "Switch or map dispatching on algorithm names, identifiers or key types",
+	}
+	ruleToolchainReplacements = Rule{
+		ID:       "PQC020",
+		Name:     "toolchain-replacements",
+		Category: CategoryToolchain,
+		Severity: SeverityInfo,
+		Summary:  "Replacements available, or not, at the Go version of the module",
+	}
+	ruleTLSHybridDefault = Rule{
+		ID:       "PQC021",
+		Name:     "tls-hybrid-default",
+		Category: CategoryToolchain,
+		Severity: SeverityMedium,
+		Summary:  "Go version of the module predates hybrid post-quantum TLS by default",
+	}
+	ruleCustomAsymmetric = Rule{
+		ID:       "PQC022",
+		Name:     "custom-asymmetric-implementation",
+		Category: CategoryCustomCrypto,
+		Severity: SeverityHigh,
+		Summary:  "Hand-rolled RSA or elliptic curve cryptography on math/big",
+	}
+	// Reported by the schema scanner rather than the analyzer.
+	ruleSchemaKeyField = Rule{
+		ID:       "PQC023",
+		Name:     "schema-key-field",
+		Category: CategorySchema,
+		Severity: SeverityMedium,
+		Summary:  "Protobuf or OpenAPI field carrying classical keys, signatures or certificates",
+	}
+	ruleExternalTrustAnchor = Rule{
+		ID:       "PQC024",
+		Name:     "external-trust-anchor",
+		Category: CategoryExternalTrust,
+		Severity: SeverityMedium,
+		Summary:  "Verification of webhooks, license keys or JWTs signed by another organization",
+	}
+	ruleTLSKeyPair = Rule{
+		ID:       "PQC025",
+		Name:     "tls-key-pair",
+		Category: CategoryPKI,
+		Severity: SeverityMedium,
+		Summary:  "Loading of a TLS certificate and private key pair",
+	}
+	ruleACMEKeyType = Rule{
+		ID:       "PQC026",
+		Name:     "acme-key-type",
+		Category: CategoryPKI,
+		Severity: SeverityMedium,
+		Summary:  "ACME certificate issuance choosing classical account or certificate keys",
+	}
+	ruleTink = Rule{
+		ID:       "PQC027",
+		Name:     "tink-classical-template",
+		Category: CategoryFunction,
+		Severity: SeverityHigh,
+		Summary:  "Tink key template or key type of classical hybrid encryption or signatures",
+	}
+	ruleTLSVersion = Rule{
+		ID:       "PQC028",
+		Name:     "tls-version",
+		Category: CategoryTransport,
+		Severity: SeverityMedium,
+		Summary:  "TLS version bounds ruling out hybrid post-quantum key exchange",
+	}
+	ruleCryptoCommand = Rule{
+		ID:       "PQC029",
+		Name:     "crypto-command",
+		Category: CategoryCommands,
+		Severity: SeverityMedium,
+		Summary:  "Classical public-key operation run by gpg or openssl, or through GPGME",
+	}
+	ruleExportedKeyType = Rule{
+		ID:       "PQC030",
+		Name:     "exported-key-type",
+		Category: CategoryAPI,
+		Severity: SeverityInfo,
+		Summary:  "Exported API exposing a quantum-vulnerable key type",
+	}
+	ruleBuildGatedCrypto = Rule{
+		ID:       "PQC031",
+		Name:     "build-gated-crypto",
+		Category: CategoryBuild,
+		Severity: SeverityInfo,
+		Summary:  "Quantum-vulnerable crypto selected by build constraints",
+	}
+	ruleUnhandledCertificateAlgorithm = Rule{
+		ID:       "PQC032",
+		Name:     "unhandled-certificate-algorithm",
+		Category: CategoryPKI,
+		Severity: SeverityInfo,
+		Summary:  "Switch on x509 algorithm identifiers without a default case",
+	}
+	ruleJWK = Rule{
+		ID:       "PQC033",
+		Name:     "jwk-classical-key",
+		Category: CategoryTokens,
+		Severity: SeverityMedium,
+		Summary:  "JWK built from a classical key, or JWKS endpoint publishing the algorithm choice",
+	}
+	ruleTPMKeyTemplate = Rule{
+		ID:       "PQC034",
+		Name:     "tpm-key-template",
+		Category: CategoryHardware,
+		Severity: SeverityHigh,
+		Summary:  "TPM or attestation key template selecting a classical algorithm",
+	}
+	ruleDNSSEC = Rule{
+		ID:       "PQC035",
+		Name:     "dnssec-signing",
+		Category: CategoryDNSSEC,
+		Severity: SeverityMedium,
+		Summary:  "DNSSEC signing or validation with a classical algorithm",
+	}
+	ruleCustomCertificateVerification = Rule{
+		ID:       "PQC036",
+		Name:     "custom-certificate-verification",
+		Category: CategoryPKI,
+		Severity: SeverityMedium,
+		Summary:  "Custom certificate verification callback, signature check or pinned root pool",
+	}
+	rulePIVKey = Rule{
+		ID:       "PQC037",
+		Name:     "piv-key",
+		Category: CategoryHardware,
+		Severity: SeverityHigh,
+		Summary:  "Classical key generated, imported or used in a PIV smart card slot",
+	}
+	ruleE2EE = Rule{
+		ID:       "PQC038",
+		Name:     "e2ee-ratchet",
+		Category: CategoryE2EE,
+		Severity: SeverityCritical,
+		Summary:  "Signal protocol or Olm/Megolm end-to-end encryption session",
+	}
+	ruleCertManagerKey = Rule{
+		ID:       "PQC039",
+		Name:     "cert-manager-key-algorithm",
+		Category: CategoryPKI,
+		Severity: SeverityMedium,
+		Summary:  "cert-manager Certificate requesting a classical private key algorithm",
+	}
+	ruleTLSOnlyECDH = Rule{
+		ID:       "PQC040",
+		Name:     "tls-only-ecdh",
+		Category: CategoryTransport,
+		Severity: SeverityLow,
+		Summary:  "crypto/ecdh used only to configure TLS, which already negotiates hybrid key exchange",
+	}
+	ruleClassicalKeyDerivation = Rule{
+		ID:       "PQC041",
+		Name:     "classical-key-derivation",
+		Category: CategoryKeyExchange,
+		Severity: SeverityHigh,
+		Summary:  "HKDF keyed by the shared secret of a classical key agreement",
+	}
+	ruleKeySerialization = Rule{
+		ID:       "PQC042",
+		Name:     "private-key-serialization",
+		Category: CategoryHygiene,
+		Severity: SeverityMedium,
+		Summary:  "Classical private key serialized with encoding/json, encoding/gob or encoding/xml",
+	}
+	ruleCustomSigning = Rule{
+		ID:       "PQC043",
+		Name:     "custom-signing-pipeline",
+		Category: CategoryCustomCrypto,
+		Severity: SeverityHigh,
+		Summary:  "Deterministic ECDSA implementation or hand-rolled nonce generation feeding ECDSA signing",
+	}
+	ruleIdPTokenSigning = Rule{
+		ID:       "PQC044",
+		Name:     "idp-token-signing",
+		Category: CategoryTokens,
+		Severity: SeverityCritical,
+		Summary:  "OAuth2 or OpenID Connect provider signing tokens with classical keys",
+	}
+	ruleWeakCurve = Rule{
+		ID:       "PQC045",
+		Name:     "weak-curve",
+		Category: CategoryEllipticCurve,
+		Severity: SeverityHigh,
+		Summary:  "Elliptic curve other than P-256, P-384, P-521 or Curve25519",
+	}
+	ruleTLSToolchainDefaults = Rule{
+		ID:        "PQC046",
+		Name:      "tls-toolchain-defaults",
+		Category:  CategoryToolchain,
+		Severity:  SeverityInfo,
+		Summary:   "Key exchange and certificate defaults crypto/tls gets from a Go version of the module without hybrid post-quantum key exchange",
+		PerModule: true,
+	}
+	ruleWebPush = Rule{
+		ID:       "PQC047",
+		Name:     "push-notification-keys",
+		Category: CategoryMessaging,
+		Severity: SeverityHigh,
+		Summary:  "Web push (VAPID) or APNs keys and payload encryption of push notifications",
+	}
+	rulePKCS12 = Rule{
+		ID:       "PQC048",
+		Name:     "pkcs12-bundle",
+		Category: CategoryPKI,
+		Severity: SeverityHigh,
+		Summary:  "PKCS#12 bundle of classical keys and certificates created or parsed",
+	}
+	ruleServiceIdentity = Rule{
+		ID:       "PQC049",
+		Name:     "service-mesh-identity",
+		Category: CategoryServiceIdentity,
+		Severity: SeverityHigh,
+		Summary:  "SPIFFE, Envoy or Istio service mesh identity with classical X.509 SVIDs",
+	}
+	ruleUnanalyzableCrypto = Rule{
+		ID:       "PQC050",
+		Name:     "unanalyzable-crypto",
+		Category: CategoryCustomCrypto,
+		Severity: SeverityMedium,
+		Summary:  "Assembly or unsafe-based crypto implementation the analyzer cannot inspect",
+	}
+	ruleTUFRootOfTrust = Rule{
+		ID:       "PQC051",
+		Name:     "tuf-root-of-trust",
+		Category: CategorySoftwareUpdate,
+		Severity: SeverityHigh,
+		Summary:  "TUF or Notary root-of-trust keys generated, added or trusted with classical signatures",
+	}
+	ruleSSHTunnelKeys = Rule{
+		ID:       "PQC052",
+		Name:     "ssh-tunnel-keys",
+		Category: CategoryTooling,
+		Severity: SeverityLow,
+		Summary:  "Embedded or generated classical keys of SSH tunnels in internal tools and test helpers",
+	}
+	ruleDocumentSigning = Rule{
+		ID:       "PQC053",
+		Name:     "document-signing",
+		Category: CategoryLongLivedSignatures,
+		Severity: SeverityHigh,
+		Summary:  "PDF or XML document signatures and timestamps with classical keys",
+	}
+	ruleSAMLFederation = Rule{
+		ID:       "PQC054",
+		Name:     "saml-federation",
+		Category: CategoryTokens,
+		Severity: SeverityHigh,
+		Summary:  "SAML service or identity provider, or XML signature algorithm configuration, with classical keys",
+	}
+	ruleImportedKeyMaterial = Rule{
+		ID:       "PQC055",
+		Name:     "imported-key-material",
+		Category: CategoryFunction,
+		Severity: SeverityHigh,
+		Summary:  "Classical key material returned or used by a function of another package",
+	}
+	ruleAlgorithmEnum = Rule{
+		ID:       "PQC056",
+		Name:     "algorithm-enum",
+		Category: CategoryFunction,
+		Severity: SeverityMedium,
+		Summary:  "Project enum constant naming a classical algorithm passed to a configured crypto wrapper",
+	}
+	ruleRevocation = Rule{
+		ID:       "PQC057",
+		Name:     "revocation-signing",
+		Category: CategoryPKI,
+		Severity: SeverityHigh,
+		Summary:  "OCSP response or certificate revocation list signed, requested or checked with classical keys",
+	}
+	ruleCommittedKey = Rule{
+		ID:       "PQC058",
+		Name:     "committed-private-key",
+		Category: CategoryHygiene,
+		Severity: SeverityHigh,
+		Summary:  "Classical private key or JSON Web Key committed in a configuration file",
+	}
+	ruleDeterministicKeyGeneration = Rule{
+		ID:       "PQC059",
+		Name:     "deterministic-key-generation",
+		Category: CategoryHygiene,
+		Severity: SeverityHigh,
+		Summary:  "Classical key generated from a deterministic reader outside test files",
+	}
+	rulePairing = Rule{
+		ID:       "PQC060",
+		Name:     "pairing-based-crypto",
+		Category: CategoryPairing,
+		Severity: SeverityHigh,
+		Summary:  "BLS signature or elliptic curve pairing, such as BLS12-381 in Ethereum consensus",
+	}
+	ruleDatabaseEncryption = Rule{
+		ID:       "PQC061",
+		Name:     "database-column-encryption",
+		Category: CategoryDatabaseEncryption,
+		Severity: SeverityHigh,
+		Summary:  "Database columns encrypted with pgcrypto public-key functions or ORM plugins using classical keys",
+	}
+	ruleMPC = Rule{
+		ID:       "PQC062",
+		Name:     "classical-mpc",
+		Category: CategoryAdvanced,
+		Severity: SeverityHigh,
+		Summary:  "Paillier homomorphic encryption or threshold-ECDSA multi-party computation, requiring specialist review",
+	}
+)
+
+// Every rule the analyzer reports, ordered by ID.
+var rules = []Rule{
+	ruleEllipticCurveImport,
+	ruleIntegerFactorizationImport,
+	ruleVulnerableFunction,
+	ruleSSHCertificateAuthority,
+	ruleFIPSMode,
+	ruleCloudEncryption,
+	ruleSSHHostTrustAndAgent,
+	ruleClassicalOID,
+	ruleFiniteFieldDH,
+	ruleSoftwareUpdateSigning,
+	rulePrivateKeyLogging,
+	ruleEd25519Signing,
+	ruleEd25519Verification,
+	ruleKubernetesPKI,
+	ruleTokenSigning,
+	ruleCryptoProvider,
+	ruleTLSSessionResumption,
+	ruleGenericKeyInstantiation,
+	ruleAlgorithmDispatch,
+	ruleToolchainReplacements,
+	ruleTLSHybridDefault,
+	ruleCustomAsymmetric,
+	ruleSchemaKeyField,
+	ruleExternalTrustAnchor,
+	ruleTLSKeyPair,
+	ruleACMEKeyType,
+	ruleTink,
+	ruleTLSVersion,
+	ruleCryptoCommand,
+	ruleExportedKeyType,
+	ruleBuildGatedCrypto,
+	ruleUnhandledCertificateAlgorithm,
+	ruleJWK,
+	ruleTPMKeyTemplate,
+	ruleDNSSEC,
+	ruleCustomCertificateVerification,
+	rulePIVKey,
+	ruleE2EE,
+	ruleCertManagerKey,
+	ruleTLSOnlyECDH,
+	ruleClassicalKeyDerivation,
+	ruleKeySerialization,
+	ruleCustomSigning,
+	ruleIdPTokenSigning,
+	ruleWeakCurve,
+	ruleTLSToolchainDefaults,
+	ruleWebPush,
+	rulePKCS12,
+	ruleServiceIdentity,
+	ruleUnanalyzableCrypto,
+	ruleTUFRootOfTrust,
+	ruleSSHTunnelKeys,
+	ruleDocumentSigning,
+	ruleSAMLFederation,
+	ruleImportedKeyMaterial,
+	ruleAlgorithmEnum,
+	ruleRevocation,
+	ruleCommittedKey,
+	ruleDeterministicKeyGeneration,
+	rulePairing,
+	ruleDatabaseEncryption,
+	ruleMPC,
+}
+
+// Rules returns every rule the analyzer reports, including those of the
+// registered detectors, ordered by ID.
+func Rules() []Rule {
+	detectorsMu.RLock()
+	defer detectorsMu.RUnlock()
+	all := slices.Concat(rules, detectorRules())
+	slices.SortFunc(all, func(a, b Rule) int {
+		return strings.Compare(a.ID, b.ID)
+	})
+	return all
+}
+
+// LookupRule returns the rule with the given ID, which may be a rule of a
+// registered detector.
+func LookupRule(id string) (Rule, bool) {
+	detectorsMu.RLock()
+	defer detectorsMu.RUnlock()
+	return lookupRule(id)
+}
+
+// Returns the rule with the given ID. The caller must hold detectorsMu.
+func lookupRule(id string) (Rule, bool) {
+	for _, rule := range slices.Concat(rules, detectorRules()) {
+		if rule.ID == id {
+			return rule, true
+		}
+	}
+	return Rule{}, false
+}
+
+//go:embed docs/*.md
+var docs embed.FS
+
+// RuleDoc returns the embedded documentation page of the rule with the given
+// ID, explaining the finding and how to migrate away from it.
+func RuleDoc(id string) (string, error) {
+	doc, err := docs.ReadFile("docs/" + id + ".md")
+	if err != nil {
+		return "", fmt.Errorf("no documentation for rule %s", id)
+	}
+	return string(doc), nil
+}
+
+// Finding is a diagnostic reported by the analyzer, together with the
+// metadata that does not fit in an analysis.Diagnostic.
+type Finding struct {
+	Diagnostic analysis.Diagnostic
+	Rule       Rule
+	// Side of the algorithm the reported call is on, for function and method
+	// calls.
+	Operation Operation
+	// Whether the finding is inside a function annotated as a deliberate
+	// classical-compat shim, and the justification given for it.
+	Compat       bool
+	CompatReason string
+	// Date the finding is accepted until, as YYYY-MM-DD, if it is inside a
+	// declaration annotated with //pqc:ignore-until, and the reason given for
+	// the exception.
+	AcceptedUntil  string
+	AcceptedReason string
+	// Whether the reported call signs or decrypts in a loop, directly or in
+	// a goroutine or task started per iteration, such as a batch or worker
+	// pool. Larger post-quantum signatures and ciphertexts weigh most on
+	// these throughput hot spots.
+	HighVolume bool
+
+	// Allowlisted call the finding is reported inside of, which does not
+	// exclude it, such as the key derivation call of a classical key
+	// derivation finding. Enclosing allowlisted calls still do.
+	inside span
+}
+
+// Result is the result of the analyzer for a package: every finding it
+// reported, in the order they were reported.
+type Result struct {
+	Findings []Finding
+	// Files other than Go files the findings depend on, such as the key files
+	// of constant certificate paths, whether or not they exist, so findings
+	// kept for the package go stale when these files change.
+	DataFiles []string
+}
+
+// A reporter reports the findings of a single pass.
+type reporter struct {
+	pass        *analysis.Pass
+	annotations []annotation
+	// Package patterns findings are never reported in or inside calls to.
+	allow []string
+	// Calls into allowlisted packages.
+	allowed []span
+	// Wording of the messages of findings, if overridden.
+	messages *Catalog
+	result   *Result
+	// Whether findings are only added to the result, to be reported when
+	// merged into the reporter of the pass.
+	buffered bool
+	// Visitor finding the nodes findings are reported on, reused across
+	// findings as every one needs it.
+	finder nodeFinder
+}
+
+func newReporter(pass *analysis.Pass, allow []string) *reporter {
+	r := &reporter{pass: pass, allow: allow, result: &Result{}}
+	for _, file := range pass.Files {
+		r.annotations = append(r.annotations, fileAnnotations(file)...)
+		r.allowed = append(r.allowed, allowedCalls(pass.TypesInfo, file, allow)...)
+	}
+	return r
+}
+
+// Returns a reporter buffering the findings of a single file, so files can be
+// analyzed concurrently. Buffered findings are reported by merge.
+func (r *reporter) forFile() *reporter {
+	fileReporter := *r
+	fileReporter.result = &Result{}
+	fileReporter.buffered = true
+	return &fileReporter
+}
+
+// Reports the findings buffered by a file reporter.
+func (r *reporter) merge(fileReporter *reporter) {
+	for _, finding := range fileReporter.result.Findings {
+		r.result.Findings = append(r.result.Findings, finding)
+		r.pass.Report(finding.Diagnostic)
+	}
+	r.result.DataFiles = append(r.result.DataFiles, fileReporter.result.DataFiles...)
+}
+
+// Returns the innermost annotation with the given name applying to pos.
+func (r *reporter) annotation(pos token.Pos, name string) (annotation, bool) {
+	var found annotation
+	ok := false
+	for _, annotation := range r.annotations {
+		if annotation.name == name && annotation.pos <= pos && pos < annotation.end {
+			if !ok || annotation.pos > found.pos {
+				found, ok = annotation, true
+			}
+		}
+	}
+	return found, ok
+}
+
+func (r *reporter) report(pos token.Pos, rule Rule, format string, args ...any) {
+	r.reportOperation(pos, rule, OperationUnknown, format, args...)
+}
+
+// Reports a finding at a call site on the given side of the algorithm.
+func (r *reporter) reportOperation(pos token.Pos, rule Rule, operation Operation, format string, args ...any) {
+	r.add(Finding{
+		Diagnostic: analysis.Diagnostic{
+			Pos:     pos,
+			Message: fmt.Sprintf(format, args...),
+		},
+		Rule:      rule,
+		Operation: operation,
+	})
+}
+
+// Reports a finding, unless it is inside a call into an allowlisted package.
+// The category and URL of its diagnostic are set from its rule, its end and
+// related information filled in when missing, and its annotations applied.
+func (r *reporter) add(finding Finding) {
+	pos, rule := finding.Diagnostic.Pos, finding.Rule
+	if slices.ContainsFunc(r.allowed, func(call span) bool {
+		return call != finding.inside && call.pos <= pos && pos < call.end
+	}) {
+		return
+	}
+
+	finding.Diagnostic.Category = rule.ID
+	finding.Diagnostic.URL = rule.DocURL()
+	if node, path := r.nodeAt(pos); node != nil {
+		if !finding.Diagnostic.End.IsValid() {
+			finding.Diagnostic.End = node.End()
+		}
+		if finding.Diagnostic.Related == nil {
+			finding.Diagnostic.Related = r.related(node, path)
+		}
+		finding.HighVolume = finding.HighVolume || highVolume(node, path)
+	}
+	// Exceptions with malformed dates are ignored, so their findings fail
+	// rather than being accepted indefinitely.
+	if ignore, ok := r.annotation(pos, ignoreUntilAnnotation); ok {
+		if _, err := time.Parse(time.DateOnly, ignore.value); err == nil {
+			finding.AcceptedUntil = ignore.value
+			finding.AcceptedReason = strings.TrimPrefix(ignore.text, "reason=")
+		}
+	}
+	if r.messages != nil {
+		finding.Diagnostic.Message = r.messages.render(rule, MessageData{
+			Message:  finding.Diagnostic.Message,
+			Symbol:   r.symbol(pos),
+			Deadline: finding.AcceptedUntil,
+		})
+	}
+	if compat, ok := r.annotation(pos, compatAnnotation); ok {
+		finding.Compat = true
+		finding.CompatReason = compat.text
+		finding.Diagnostic.Message = r.messages.Status(StatusInteropDebt, finding.Diagnostic.Message, "")
+	}
+
+	r.result.Findings = append(r.result.Findings, finding)
+	if !r.buffered {
+		r.pass.Report(finding.Diagnostic)
+	}
+}
@@ -0,0 +1,26 @@
+package analyzer
+
+import "slices"
+
+const (
+	samlImportPath      = "github.com/crewjam/saml"
+	goxmldsigImportPath = "github.com/russellhaering/goxmldsig"
+)
+
+// Functions and types of SAML service and identity providers of
+// crewjam/saml, whose assertions, requests and metadata are signed with RSA
+// or ECDSA keys of the federation partners.
+var samlIdentifiers = slices.Concat(
+	functionsOf(samlImportPath, "ServiceProvider", "IdentityProvider"),
+	functionsOf("github.com/crewjam/saml/samlsp", "New", "DefaultServiceProvider", "Options"),
+)
+
+// Configuration of the signature algorithm of SAML messages and XML
+// signatures, such as RSA-SHA256: the SignatureMethod of crewjam/saml
+// providers, and goxmldsig signing contexts.
+var samlSignatureIdentifiers = functionsOf(goxmldsigImportPath, "SetSignatureMethod")
+
+var samlSignatureFields = []QvField{
+	{"SignatureMethod", "ServiceProvider", samlImportPath},
+	{"SignatureMethod", "IdentityProvider", samlImportPath},
+}
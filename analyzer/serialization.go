@@ -0,0 +1,109 @@
+package analyzer
+
+import (
+	"go/ast"
+	"go/types"
+	"reflect"
+	"slices"
+	"strings"
+)
+
+// Functions and methods serializing the value passed as their first argument
+// with reflection-based encoders, which write every exported field of a key,
+// private exponents and scalars included.
+var serializationIdentifiers = slices.Concat(
+	functionsOf("encoding/json", "Marshal", "MarshalIndent", "Encode"),
+	functionsOf("encoding/gob", "Encode"),
+	functionsOf("encoding/xml", "Marshal", "MarshalIndent", "Encode"),
+)
+
+// Struct tag keys of the encoders, whose "-" value excludes a field from the
+// encoding. encoding/gob has no struct tags.
+var serializationTagKeys = map[string]string{
+	"encoding/json": "json",
+	"encoding/xml":  "xml",
+}
+
+// Private key types whose serialization holds the key. The fields of
+// crypto/ecdh keys are unexported, so encoders write no key material.
+var serializableKeyTypes = slices.DeleteFunc(slices.Clone(privateKeyTypes), func(keyType QvFunction) bool {
+	return keyType.Package == "crypto/ecdh"
+})
+
+// Reports values serialized with encoding/json, encoding/gob or encoding/xml
+// whose type is, or contains, a classical private key type, such as a session
+// struct with a *rsa.PrivateKey field. Serialized keys end up in datastores
+// and caches, outside any key store, where a migration has to find them.
+func reportKeySerialization(r *reporter, file *ast.File) {
+	info := r.pass.TypesInfo
+	ast.Inspect(file, func(node ast.Node) bool {
+		call, ok := node.(*ast.CallExpr)
+		if !ok || len(call.Args) == 0 {
+			return true
+		}
+		selector, ok := call.Fun.(*ast.SelectorExpr)
+		if !ok {
+			return true
+		}
+		encoder, ok := "", false
+		if localImportName, isIdent := selector.X.(*ast.Ident); isIdent {
+			encoder, ok = vulnerableFunction(info, localImportName, selector.Sel, serializationIdentifiers)
+		}
+		if !ok {
+			encoder, ok = vulnerableMethod(info, selector, serializationIdentifiers)
+		}
+		if !ok {
+			return true
+		}
+		tagKey := ""
+		if obj := info.Uses[selector.Sel]; obj != nil && obj.Pkg() != nil {
+			tagKey = serializationTagKeys[obj.Pkg().Path()]
+		}
+		arg := call.Args[0]
+		path, keyType, ok := privateKeyPath(info.TypeOf(arg), tagKey, nil)
+		if !ok {
+			return true
+		}
+		subject := types.ExprString(arg)
+		if len(path) > 0 {
+			subject += " field " + strings.Join(path, ".")
+		}
+		r.reportOperation(arg.Pos(), ruleKeySerialization, OperationPrivate, `"%s" serializes %s, of quantum-vulnerable private key type %s; inventory the stored keys, as they must be found and replaced during migration`, encoder, subject, types.TypeString(keyType, (*types.Package).Name))
+		return true
+	})
+}
+
+// Returns the path of fields leading from t to a private key type, and that
+// type, if t is or contains one through exported struct fields, pointers,
+// slices, arrays and maps. Fields whose tag for tagKey is "-" are skipped, as
+// the encoder does. Types already on the way are in seen.
+func privateKeyPath(t types.Type, tagKey string, seen []types.Type) ([]string, types.Type, bool) {
+	if t == nil || slices.ContainsFunc(seen, func(s types.Type) bool { return types.Identical(s, t) }) {
+		return nil, nil, false
+	}
+	if isNamedType(t, serializableKeyTypes) {
+		return nil, t, true
+	}
+	seen = append(seen, t)
+	switch u := types.Unalias(t).Underlying().(type) {
+	case *types.Pointer:
+		return privateKeyPath(u.Elem(), tagKey, seen)
+	case *types.Slice:
+		return privateKeyPath(u.Elem(), tagKey, seen)
+	case *types.Array:
+		return privateKeyPath(u.Elem(), tagKey, seen)
+	case *types.Map:
+		return privateKeyPath(u.Elem(), tagKey, seen)
+	case *types.Struct:
+		for i := range u.NumFields() {
+			field := u.Field(i)
+			if !field.Exported() || tagKey != "" && reflect.StructTag(u.Tag(i)).Get(tagKey) == "-" {
+				continue
+			}
+			if path, keyType, ok := privateKeyPath(field.Type(), tagKey, seen); ok {
+				return append([]string{field.Name()}, path...), keyType, true
+			}
+		}
+	}
+	return nil, nil, false
+}
@@ -0,0 +1,35 @@
+package analyzer
+
+import "fmt"
+
+// Severity ranks how urgently a finding needs migration work.
+type Severity int
+
+const (
+	// Informational findings are inventory items that need no change by
+	// themselves, but must be accounted for in a migration plan.
+	SeverityInfo Severity = iota
+	SeverityLow
+	SeverityMedium
+	SeverityHigh
+	SeverityCritical
+)
+
+var severityNames = []string{"info", "low", "medium", "high", "critical"}
+
+func (s Severity) String() string {
+	if s < SeverityInfo || s > SeverityCritical {
+		return fmt.Sprintf("Severity(%d)", int(s))
+	}
+	return severityNames[s]
+}
+
+// ParseSeverity returns the severity with the given name.
+func ParseSeverity(name string) (Severity, error) {
+	for i, severityName := range severityNames {
+		if severityName == name {
+			return Severity(i), nil
+		}
+	}
+	return SeverityInfo, fmt.Errorf("unknown severity %q", name)
+}
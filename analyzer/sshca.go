@@ -0,0 +1,64 @@
+package analyzer
+
+import (
+	"go/ast"
+	"go/types"
+	"slices"
+
+	"golang.org/x/tools/go/analysis"
+)
+
+// Functions and methods signing SSH certificates with the signer passed as
+// their second argument.
+var sshCertificateSigners = functionsOf(sshImportPath, "SignCert", "NewCertSigner")
+
+// Functions parsing SSH private keys into signers.
+var sshSignerParsers = functionsOf(sshImportPath, "ParsePrivateKey", "ParsePrivateKeyWithPassphrase")
+
+// Reports the SSH private keys parsed into signers that sign certificates in
+// the same function, as the loading of a certificate authority key.
+func reportSSHCAKeys(r *reporter, file *ast.File) {
+	info := r.pass.TypesInfo
+	for _, decl := range file.Decls {
+		funcDecl, ok := decl.(*ast.FuncDecl)
+		if !ok || funcDecl.Body == nil {
+			continue
+		}
+		reported := make(map[*ast.CallExpr]bool)
+		ast.Inspect(funcDecl.Body, func(node ast.Node) bool {
+			call, ok := node.(*ast.CallExpr)
+			if !ok || len(call.Args) != 2 {
+				return true
+			}
+			fn, _ := callee(info, call)
+			if fn == nil || !slices.Contains(sshCertificateSigners, QvFunction{fn.Name(), fn.Pkg().Path()}) {
+				return true
+			}
+			v, ok := info.Uses[baseIdent(call.Args[1])].(*types.Var)
+			if !ok {
+				return true
+			}
+			parse, name, ok := keyAssignment(info, funcDecl.Body, v, func(qvFunc QvFunction) bool {
+				return slices.Contains(sshSignerParsers, qvFunc)
+			})
+			if !ok || reported[parse] {
+				return true
+			}
+			reported[parse] = true
+			r.add(Finding{
+				Diagnostic: analysis.Diagnostic{
+					Pos:     parse.Fun.(*ast.SelectorExpr).Sel.Pos(),
+					Message: `function "` + name + `" loads the key of a quantum-vulnerable SSH certificate authority; CA keys are significantly harder to rotate than client keys`,
+					Related: []analysis.RelatedInformation{{
+						Pos:     call.Pos(),
+						End:     call.End(),
+						Message: v.Name() + " signs certificates here",
+					}},
+				},
+				Rule:      ruleSSHCertificateAuthority,
+				Operation: OperationPrivate,
+			})
+			return true
+		})
+	}
+}
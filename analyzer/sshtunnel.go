@@ -0,0 +1,123 @@
+package analyzer
+
+import (
+	"go/ast"
+	"go/types"
+	"slices"
+	"strings"
+)
+
+const sshImportPath = "golang.org/x/crypto/ssh"
+
+// Methods of ssh.Client forwarding ports through an SSH connection.
+var sshTunnelMethods = functionsOf(sshImportPath, "Listen", "ListenTCP", "ListenUnix", "Dial", "DialTCP", "DialContext")
+
+// Functions parsing SSH private keys.
+var sshKeyParsers = functionsOf(sshImportPath, "ParsePrivateKey", "ParseRawPrivateKey", "ParsePrivateKeyWithPassphrase", "ParseRawPrivateKeyWithPassphrase")
+
+// Functions generating classical keys, by the algorithm they generate.
+var keyGenerators = map[QvFunction]string{
+	{"GenerateKey", "crypto/rsa"}:     "an RSA",
+	{"GenerateKey", "crypto/ecdsa"}:   "an ECDSA",
+	{"GenerateKey", "crypto/ed25519"}: "an Ed25519",
+}
+
+const sshTunnelMessage = "tunnels of internal tools and test helpers authenticate with classical SSH keys; reported as tooling, whose severity can be configured apart from production code"
+
+// Reports the keys of SSH tunnels in files forwarding ports through an
+// ssh.Client: private keys parsed from constants or //go:embed variables,
+// which ship inside the tool, and keys generated with crypto/rsa,
+// crypto/ecdsa or crypto/ed25519.
+func reportSSHTunnelKeys(r *reporter, file *ast.File) {
+	info := r.pass.TypesInfo
+	tunnel := false
+	ast.Inspect(file, func(node ast.Node) bool {
+		if selector, ok := node.(*ast.SelectorExpr); ok && !tunnel {
+			_, tunnel = vulnerableMethod(info, selector, sshTunnelMethods)
+		}
+		return !tunnel
+	})
+	if !tunnel {
+		return
+	}
+
+	ast.Inspect(file, func(node ast.Node) bool {
+		call, ok := node.(*ast.CallExpr)
+		if !ok {
+			return true
+		}
+		selector, ok := call.Fun.(*ast.SelectorExpr)
+		if !ok {
+			return true
+		}
+		localImportName, ok := selector.X.(*ast.Ident)
+		if !ok {
+			return true
+		}
+		if name, ok := vulnerableFunction(info, localImportName, selector.Sel, sshKeyParsers); ok && len(call.Args) > 0 {
+			if key, ok := embeddedKey(info, file, call.Args[0]); ok {
+				r.reportOperation(selector.Sel.Pos(), ruleSSHTunnelKeys, OperationPrivate, `"%s" parses %s embedded in the binary; %s`, name, key, sshTunnelMessage)
+			}
+			return true
+		}
+		if pkgName, ok := info.Uses[localImportName].(*types.PkgName); ok {
+			if algorithm, ok := keyGenerators[QvFunction{selector.Sel.Name, pkgName.Imported().Path()}]; ok {
+				r.reportOperation(selector.Sel.Pos(), ruleSSHTunnelKeys, OperationPrivate, `"%s.%s" generates %s key for SSH tunnels; %s`, localImportName.Name, selector.Sel.Name, algorithm, sshTunnelMessage)
+			}
+		}
+		return true
+	})
+}
+
+// Returns the description of a private key embedded in the binary an
+// expression holds: a string constant, possibly converted to []byte, or a
+// variable of the file initialized with //go:embed. The key type is taken
+// from the PEM header of constants.
+func embeddedKey(info *types.Info, file *ast.File, expr ast.Expr) (string, bool) {
+	expr = ast.Unparen(expr)
+	if conversion, ok := expr.(*ast.CallExpr); ok && len(conversion.Args) == 1 {
+		if tv, ok := info.Types[conversion.Fun]; ok && tv.IsType() {
+			expr = ast.Unparen(conversion.Args[0])
+		}
+	}
+	if value, ok := stringConstant(info, expr); ok {
+		switch {
+		case strings.Contains(value, "BEGIN RSA PRIVATE KEY"):
+			return "an RSA private key", true
+		case strings.Contains(value, "BEGIN EC PRIVATE KEY"):
+			return "an ECDSA private key", true
+		}
+		return "a private key", true
+	}
+	ident, ok := expr.(*ast.Ident)
+	if !ok {
+		return "", false
+	}
+	obj := info.Uses[ident]
+	if obj == nil {
+		return "", false
+	}
+	for _, decl := range file.Decls {
+		genDecl, ok := decl.(*ast.GenDecl)
+		if !ok {
+			continue
+		}
+		for _, spec := range genDecl.Specs {
+			valueSpec, ok := spec.(*ast.ValueSpec)
+			if !ok || !slices.ContainsFunc(valueSpec.Names, func(name *ast.Ident) bool { return info.Defs[name] == obj }) {
+				continue
+			}
+			if goEmbed(genDecl.Doc) || goEmbed(valueSpec.Doc) {
+				return "a private key file", true
+			}
+		}
+	}
+	return "", false
+}
+
+// Reports whether the comments hold a //go:embed directive.
+func goEmbed(group *ast.CommentGroup) bool {
+	return group != nil && slices.ContainsFunc(group.List, func(comment *ast.Comment) bool {
+		return strings.HasPrefix(comment.Text, "//go:embed ")
+	})
+}
@@ -0,0 +1,58 @@
+package analyzer
+
+import (
+	"fmt"
+	"go/ast"
+	"strings"
+
+	"golang.org/x/tools/go/analysis"
+)
+
+// Category of the diagnostics summarizing the findings of a file. Summaries
+// are not findings, so they are never in the result of the analyzer.
+const SummaryCategory = "summary"
+
+// Whether the analyzer reports file summaries, set with its -summary flag.
+var fileSummaries bool
+
+func init() {
+	PqcAnalyzer.Flags.BoolVar(&fileSummaries, "summary", false, "report a summary of the findings of every file on its package clause")
+}
+
+// Reports a diagnostic on the package clause of a file counting its findings
+// by severity, such as "3 critical, 5 info PQC findings in this file", with
+// every finding as related information. Editors show it at the top of the
+// file, and list the findings when it is expanded, so the risk of a file is
+// visible without scrolling through its diagnostics.
+func reportFileSummary(pass *analysis.Pass, file *ast.File, findings []Finding) {
+	if len(findings) == 0 {
+		return
+	}
+	counts := make([]int, SeverityCritical+1)
+	related := make([]analysis.RelatedInformation, 0, len(findings))
+	for _, finding := range findings {
+		counts[finding.Rule.Severity]++
+		related = append(related, analysis.RelatedInformation{
+			Pos:     finding.Diagnostic.Pos,
+			End:     finding.Diagnostic.End,
+			Message: fmt.Sprintf("%s (%s): %s", finding.Rule.ID, finding.Rule.Severity, finding.Diagnostic.Message),
+		})
+	}
+	var parts []string
+	for severity := SeverityCritical; severity >= SeverityInfo; severity-- {
+		if counts[severity] > 0 {
+			parts = append(parts, fmt.Sprintf("%d %s", counts[severity], severity))
+		}
+	}
+	noun := "findings"
+	if len(findings) == 1 {
+		noun = "finding"
+	}
+	pass.Report(analysis.Diagnostic{
+		Pos:      file.Package,
+		End:      file.Name.End(),
+		Category: SummaryCategory,
+		Message:  fmt.Sprintf("%s PQC %s in this file", strings.Join(parts, ", "), noun),
+		Related:  related,
+	})
+}
@@ -0,0 +1,13 @@
+//go:build go1.24
+
+package goversion // want `go1.22: crypto/tls negotiates classical ECDHE key exchange only, and verifies classical RSA, ECDSA and Ed25519 certificates, accepting SHA-1 signatures in TLS 1.2 handshakes and RSA keys under 1024 bits; raise the go directive to go1.24 or later for hybrid X25519MLKEM768 key exchange by default`
+
+import (
+	"crypto/ecdh" // want `"crypto/ecdh" uses quantum-vulnerable elliptic curve cryptography` `"crypto/mlkem" is available to replace "crypto/ecdh" key exchange with ML-KEM`
+	"crypto/tls"
+)
+
+var (
+	_ = ecdh.P256
+	_ tls.Certificate
+)
@@ -0,0 +1,3 @@
+module goversion
+
+go 1.22
@@ -0,0 +1,11 @@
+package goversion
+
+import (
+	"crypto/ecdh" // want `"crypto/ecdh" uses quantum-vulnerable elliptic curve cryptography` `"crypto/mlkem", the replacement of "crypto/ecdh" key exchange, requires go1.24, but the file targets go1.22`
+	"crypto/tls"  // want `"crypto/tls" does not negotiate hybrid post-quantum X25519MLKEM768 key exchange by default, as the file targets go1.22`
+)
+
+var (
+	_ = ecdh.X25519
+	_ tls.Config
+)
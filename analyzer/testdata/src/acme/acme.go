@@ -0,0 +1,42 @@
+package acme
+
+import (
+	"crypto"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+
+	"github.com/caddyserver/certmagic"
+	"github.com/go-acme/lego/v4/certcrypto"
+	"github.com/go-acme/lego/v4/lego"
+	"golang.org/x/crypto/acme/autocert"
+)
+
+func legoConfig(user lego.User) *lego.Config {
+	config := lego.NewConfig(user)                  // want `function "lego.NewConfig" issues certificates through ACME`
+	config.Certificate.KeyType = certcrypto.RSA2048 // want `field "lego.CertificateConfig.KeyType" issues certificates through ACME` `key type "certcrypto.RSA2048" issues certificates with quantum-vulnerable RSA-2048 keys`
+	return config
+}
+
+func autocertManager() *autocert.Manager {
+	return &autocert.Manager{ // want `type "autocert.Manager" issues certificates through ACME`
+		Prompt:     autocert.AcceptTOS,
+		HostPolicy: autocert.HostWhitelist("example.com"),
+	}
+}
+
+func certmagicConfig() error {
+	config := certmagic.NewDefault()                    // want `function "certmagic.NewDefault" issues certificates through ACME`
+	config.KeySource = &certmagic.StandardKeyGenerator{ // want `field "certmagic.Config.KeySource" issues certificates through ACME`
+		KeyType: certmagic.P256, // want `field "certmagic.StandardKeyGenerator.KeyType" issues certificates through ACME` `key type "certmagic.P256" issues certificates with quantum-vulnerable ECDSA P-256 keys`
+	}
+	return config.ManageSync([]string{"example.com"}) // want `method "certmagic.Config.ManageSync" issues certificates through ACME`
+}
+
+func certificateRequest(key crypto.Signer) ([]byte, error) {
+	template := &x509.CertificateRequest{ // want `type "x509.CertificateRequest" issues certificates through ACME`
+		Subject:  pkix.Name{CommonName: "example.com"},
+		DNSNames: []string{"example.com"},
+	}
+	return x509.CreateCertificateRequest(rand.Reader, template, key) // want `function "x509.CreateCertificateRequest" issues certificates through ACME`
+}
@@ -0,0 +1,15 @@
+package allowlist
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+)
+
+// An HMAC key that happens to look like an RSA object identifier.
+func mac(message []byte) []byte {
+	h := hmac.New(sha256.New, []byte("1.2.840.113549.1.1.1"))
+	h.Write(message)
+	return h.Sum(nil)
+}
+
+const rsaEncryption = "1.2.840.113549.1.1.1" // want `ASN.1 OID string 1.2.840.113549.1.1.1 identifies quantum-vulnerable RSA`
@@ -0,0 +1,26 @@
+package allowlist
+
+import (
+	"crypto/ecdh" // want `"crypto/ecdh" uses quantum-vulnerable elliptic curve cryptography`
+	"crypto/hkdf"
+	"crypto/sha256"
+
+	"allowlist/wrapper"
+)
+
+// Key derivation inside a call into the allowlisted wrapper is not reported.
+func wrappedKey(priv *ecdh.PrivateKey, peer *ecdh.PublicKey) ([]byte, error) {
+	shared, err := priv.ECDH(peer)
+	if err != nil {
+		return nil, err
+	}
+	return wrapper.Derive(hkdf.Key(sha256.New, shared, nil, "session", 32))
+}
+
+func sessionKey(priv *ecdh.PrivateKey, peer *ecdh.PublicKey) ([]byte, error) {
+	shared, err := priv.ECDH(peer)
+	if err != nil {
+		return nil, err
+	}
+	return hkdf.Key(sha256.New, shared, nil, "session", 32) // want `function "hkdf.Key" derives keys from the classical shared secret shared`
+}
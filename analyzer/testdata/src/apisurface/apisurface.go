@@ -0,0 +1,38 @@
+package apisurface
+
+import (
+	"crypto"
+	"crypto/ecdsa" // want `"crypto/ecdsa" uses quantum-vulnerable elliptic curve cryptography`
+	"crypto/rsa"   // want `"crypto/rsa" uses quantum-vulnerable integer factorization cryptography`
+)
+
+func Sign(key *rsa.PrivateKey, message []byte) ([]byte, error) { // want `exported function "Sign" exposes quantum-vulnerable key type \*rsa.PrivateKey; replacing it is a breaking API change`
+	return nil, nil
+}
+
+func SignWith(signer crypto.Signer, message []byte) ([]byte, error) {
+	return nil, nil
+}
+
+func sign(key *rsa.PrivateKey) {}
+
+type Verifier struct {
+	Keys    map[string]*ecdsa.PublicKey // want `exported field "Keys" exposes quantum-vulnerable key type \*ecdsa.PublicKey`
+	private *ecdsa.PublicKey
+}
+
+func (v *Verifier) Add(id string, key *ecdsa.PublicKey) { // want `exported method "Add" exposes quantum-vulnerable key type \*ecdsa.PublicKey`
+	v.Keys[id] = key
+}
+
+type KeySource interface {
+	Key() (*rsa.PrivateKey, error) // want `exported method "Key" exposes quantum-vulnerable key type \*rsa.PrivateKey`
+}
+
+type KeyFunc func(id string) *ecdsa.PublicKey // want `exported type "KeyFunc" exposes quantum-vulnerable key type \*ecdsa.PublicKey`
+
+var DefaultKey *rsa.PrivateKey // want `exported variable "DefaultKey" exposes quantum-vulnerable key type \*rsa.PrivateKey`
+
+type verifier struct{}
+
+func (verifier) Add(key *ecdsa.PublicKey) {}
@@ -0,0 +1,7 @@
+package keys
+
+import "crypto/rsa" // want `"crypto/rsa" uses quantum-vulnerable integer factorization cryptography`
+
+func Load() *rsa.PrivateKey {
+	return nil
+}
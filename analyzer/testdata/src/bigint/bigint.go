@@ -0,0 +1,43 @@
+package bigint
+
+import (
+	"crypto/rand"
+	"math/big"
+)
+
+func encrypt(m, e, n *big.Int) *big.Int {
+	return new(big.Int).Exp(m, e, n) // want `modular exponentiation of RSA parameters: possible custom asymmetric implementation, manual review required`
+}
+
+func modulus() *big.Int {
+	n, _ := new(big.Int).SetString("CA4C123B1612DD272D1371C17149D439536B3216FDAEEB975729FAE923D5A4FD12AABFE228F219E9CB0EB53F16947CCF25EC84D8DBC74254770F58904DBA41ECCCC3FC1626E53A13043B026C48BBF33FEFF9243A8F506B40928B5B7A767C76FB008F86BEBB2737F6A6F0FB23C6F5DA2CEC255404E4FB440034D6608697A8D41BED440E50454F31AF3176813E02EA68EF786E4D3CEA27D26934B484E73CF575DCAD6BA2B0AEE0CA923732881584D8C4FA2815D2802827283E0AD84173581569969E58B081006F7E3DFC967A64CB14028D512C9791E558E08BAA7196B50AC2F86702824C1C099724CAF4941D4072014B3CE107F80E222F828767EFC2F91624A894", 16) // want `2048-bit integer constant: possible custom asymmetric implementation`
+	return n
+}
+
+func double(x, y, p *big.Int) (*big.Int, *big.Int) { // want `function double computes elliptic curve points on big.Int coordinates`
+	lambda := new(big.Int).Mul(big.NewInt(3), new(big.Int).Mul(x, x))
+	lambda.Mul(lambda, new(big.Int).ModInverse(new(big.Int).Lsh(y, 1), p))
+	x3 := new(big.Int).Sub(new(big.Int).Mul(lambda, lambda), new(big.Int).Lsh(x, 1))
+	y3 := new(big.Int).Sub(new(big.Int).Mul(lambda, new(big.Int).Sub(x, x3)), y)
+	return x3.Mod(x3, p), y3.Mod(y3, p)
+}
+
+func prime(bits int) (*big.Int, error) {
+	for {
+		candidate, err := rand.Int(rand.Reader, new(big.Int).Lsh(big.NewInt(1), uint(bits)))
+		if err != nil {
+			return nil, err
+		}
+		if candidate.ProbablyPrime(20) { // want `Miller-Rabin primality testing in a loop generates primes`
+			return candidate, nil
+		}
+	}
+}
+
+func generate() (*big.Int, error) {
+	return rand.Prime(rand.Reader, 1024) // want `random prime generation: possible custom asymmetric implementation`
+}
+
+func isPrime(n *big.Int) bool {
+	return n.ProbablyPrime(20)
+}
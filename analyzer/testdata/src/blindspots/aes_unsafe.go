@@ -0,0 +1,9 @@
+package blindspots // want `assembly file p256_asm.s implements what looks like cryptography the analyzer cannot inspect: unanalyzable custom crypto, manual review required` `assembly file p256_asm_arm64.s implements what looks like cryptography`
+
+import "unsafe" // want `file aes_unsafe.go imports "unsafe" in what looks like a crypto implementation, whose memory accesses the analyzer cannot follow: unanalyzable custom crypto, manual review required`
+
+func xorWords(dst, src []byte) {
+	for i := 0; i+8 <= len(src); i += 8 {
+		*(*uint64)(unsafe.Pointer(&dst[i])) ^= *(*uint64)(unsafe.Pointer(&src[i]))
+	}
+}
@@ -0,0 +1,8 @@
+package blindspots
+
+import "unsafe"
+
+// Words only starting like crypto names are not crypto names.
+func themeSize(theme *[4]byte) uintptr {
+	return unsafe.Sizeof(*theme)
+}
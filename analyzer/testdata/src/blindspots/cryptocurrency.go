@@ -0,0 +1,3 @@
+package blindspots
+
+func tickerPrice() uint64
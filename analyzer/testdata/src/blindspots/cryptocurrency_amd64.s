@@ -0,0 +1,4 @@
+#include "textflag.h"
+
+TEXT ·tickerPrice(SB), NOSPLIT, $0
+	RET
@@ -0,0 +1,10 @@
+package curve25519 // want `assembly file mul.s implements what looks like cryptography the analyzer cannot inspect`
+
+import "unsafe" // want `file curve25519.go imports "unsafe" in what looks like a crypto implementation`
+
+//go:noescape
+func feMul(out, a, b *[5]uint64)
+
+func load(b *[32]byte) *[4]uint64 {
+	return (*[4]uint64)(unsafe.Pointer(b))
+}
@@ -0,0 +1,4 @@
+#include "textflag.h"
+
+TEXT ·feMul(SB), NOSPLIT, $0
+	RET
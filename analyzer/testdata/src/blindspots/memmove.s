@@ -0,0 +1,4 @@
+#include "textflag.h"
+
+TEXT ·memmove(SB), NOSPLIT, $0
+	RET
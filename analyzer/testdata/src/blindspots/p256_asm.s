@@ -0,0 +1,4 @@
+#include "textflag.h"
+
+TEXT ·p256Mul(SB), NOSPLIT, $0
+	RET
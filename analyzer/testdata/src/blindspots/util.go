@@ -0,0 +1,10 @@
+package blindspots
+
+import "unsafe"
+
+//go:noescape
+func memmove(dst, src unsafe.Pointer, n uintptr)
+
+func bytesToString(b []byte) string {
+	return unsafe.String(unsafe.SliceData(b), len(b))
+}
@@ -0,0 +1,5 @@
+package buildtags // want `crypto variant legacy.go is excluded from this build by "legacy", and imports quantum-vulnerable "crypto/rsa"; analyze the builds including it with -matrix`
+
+func Sign(message []byte) ([]byte, error) {
+	return sign(message)
+}
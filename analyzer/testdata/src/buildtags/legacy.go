@@ -0,0 +1,11 @@
+//go:build legacy
+
+package buildtags
+
+import "crypto/rsa"
+
+var key *rsa.PrivateKey
+
+func sign(message []byte) ([]byte, error) {
+	return nil, nil
+}
@@ -0,0 +1,11 @@
+//go:build !legacy && !pure
+
+package buildtags // want `file is only built when "!legacy && !pure" holds, and imports quantum-vulnerable "crypto/ecdsa"`
+
+import "crypto/ecdsa" // want `"crypto/ecdsa" uses quantum-vulnerable elliptic curve cryptography`
+
+var key *ecdsa.PrivateKey
+
+func sign(message []byte) ([]byte, error) {
+	return nil, nil
+}
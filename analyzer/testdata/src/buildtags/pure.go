@@ -0,0 +1,7 @@
+//go:build pure && !legacy
+
+package buildtags
+
+import "crypto/sha256"
+
+var _ = sha256.Sum256
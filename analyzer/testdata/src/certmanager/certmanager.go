@@ -0,0 +1,50 @@
+package certmanager
+
+import (
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+)
+
+func certificate(name string) *cmapi.Certificate {
+	return &cmapi.Certificate{Spec: cmapi.CertificateSpec{
+		SecretName: name,
+		PrivateKey: &cmapi.CertificatePrivateKey{
+			Algorithm: cmapi.ECDSAKeyAlgorithm, // want `key algorithm "v1.ECDSAKeyAlgorithm" issues cert-manager certificates with quantum-vulnerable ECDSA keys`
+			Size:      256,
+		},
+	}}
+}
+
+func rotate(cert *cmapi.Certificate) {
+	cert.Spec.PrivateKey.Algorithm = "RSA" // want `field "v1.CertificatePrivateKey.Algorithm" requests a quantum-vulnerable RSA private key for a cert-manager certificate`
+}
+
+// Mirrors the cert-manager API without depending on it.
+type PrivateKeySpec struct {
+	Algorithm string `json:"algorithm"`
+	Size      int    `json:"size"`
+}
+
+type SigningSpec struct {
+	Algorithm string `json:"algorithm"`
+}
+
+func mirrored() (PrivateKeySpec, SigningSpec) {
+	key := PrivateKeySpec{Algorithm: "ECDSA", Size: 384} // want `field "certmanager.PrivateKeySpec.Algorithm" requests a quantum-vulnerable ECDSA private key`
+	return key, SigningSpec{Algorithm: "RSA"}
+}
+
+func unstructured(name string) map[string]any {
+	return map[string]any{
+		"apiVersion": "cert-manager.io/v1",
+		"kind":       "Certificate",
+		"spec": map[string]any{
+			"secretName": name,
+			"privateKey": map[string]any{
+				"algorithm": "RSA", // want `cert-manager Certificate template requests a quantum-vulnerable RSA private key`
+				"size":      2048,
+			},
+		},
+	}
+}
+
+const certificateTemplate = "apiVersion: cert-manager.io/v1\nkind: Certificate\nspec:\n  privateKey:\n    algorithm: Ed25519\n" // want `cert-manager Certificate template requests a quantum-vulnerable Ed25519 private key`
@@ -0,0 +1,48 @@
+package certswitch
+
+import (
+	"crypto/x509"
+	"errors"
+)
+
+func checkKey(cert *x509.Certificate) error {
+	switch cert.PublicKeyAlgorithm { // want `switch on x509.PublicKeyAlgorithm dispatches` `switch on x509.PublicKeyAlgorithm has no default case and does not handle DSA, Ed25519, MLDSA; certificates with those algorithms, such as post-quantum and composite ones, fall through it and are accepted, failing open`
+	case x509.RSA:
+		return errors.New("RSA keys are not allowed")
+	case x509.ECDSA:
+		if cert.PublicKey == nil {
+			return errors.New("missing key")
+		}
+	}
+	return nil
+}
+
+func allowed(cert *x509.Certificate) bool {
+	switch cert.PublicKeyAlgorithm { // want `switch on x509.PublicKeyAlgorithm dispatches` `switch on x509.PublicKeyAlgorithm handles every algorithm known today but has no default case; composite certificates and algorithms added later fall through it and are rejected, failing closed`
+	case x509.RSA, x509.DSA, x509.ECDSA:
+		return true
+	case x509.Ed25519, x509.MLDSA:
+		return true
+	}
+	return false
+}
+
+func name(algorithm x509.SignatureAlgorithm) string {
+	var name string
+	func() {
+		switch algorithm { // want `switch on x509.SignatureAlgorithm dispatches` `switch on x509.SignatureAlgorithm has no default case and does not handle MD2WithRSA, MD5WithRSA, SHA1WithRSA and 15 more; certificates with those algorithms, such as post-quantum and composite ones, fall through it unhandled`
+		case x509.SHA256WithRSA:
+			name = "RS256"
+		}
+	}()
+	return name
+}
+
+func strict(algorithm x509.SignatureAlgorithm) string {
+	switch algorithm { // want `switch on x509.SignatureAlgorithm dispatches`
+	case x509.SHA256WithRSA:
+		return "RS256"
+	default:
+		panic("unsupported algorithm")
+	}
+}
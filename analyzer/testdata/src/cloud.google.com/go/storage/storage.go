@@ -0,0 +1,6 @@
+// Package storage is a minimal stub of the Google Cloud Storage client for analyzer tests.
+package storage
+
+type ObjectHandle struct{}
+
+func (o *ObjectHandle) Key(encryptionKey []byte) *ObjectHandle { return o }
@@ -0,0 +1,26 @@
+package cloudencryption
+
+import (
+	"cloud.google.com/go/storage"
+	"github.com/aws/aws-sdk-go/service/s3/s3crypto"
+	compute "google.golang.org/api/compute/v1"
+)
+
+func s3Client(kms any) (*s3crypto.EncryptionClientV2, error) {
+	gen := s3crypto.NewKMSContextKeyGenerator(kms, "key-id", nil) // want `function "s3crypto.NewKMSContextKeyGenerator" wraps client-side encryption keys`
+	return s3crypto.NewEncryptionClientV2(nil, gen)               // want `function "s3crypto.NewEncryptionClientV2" wraps client-side encryption keys`
+}
+
+func gcsObject(obj *storage.ObjectHandle, key []byte) *storage.ObjectHandle {
+	return obj.Key(key)
+}
+
+func diskKey(wrapped string) *compute.CustomerEncryptionKey {
+	return &compute.CustomerEncryptionKey{
+		RsaEncryptedKey: wrapped, // want `field "compute.CustomerEncryptionKey.RsaEncryptedKey" wraps client-side encryption keys`
+	}
+}
+
+func rawDiskKey(raw string) *compute.CustomerEncryptionKey {
+	return &compute.CustomerEncryptionKey{RawKey: raw}
+}
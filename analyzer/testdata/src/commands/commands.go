@@ -0,0 +1,29 @@
+package commands
+
+import (
+	"context"
+	"os/exec"
+)
+
+func signRelease(artifact string) error {
+	return exec.Command("gpg", "--armor", "--detach-sign", artifact).Run() // want `function "exec.Command" runs "gpg --detach-sign", a quantum-vulnerable public-key operation hidden from source analysis`
+}
+
+func generateKey(ctx context.Context) error {
+	return exec.CommandContext(ctx, "/usr/bin/openssl", "genrsa", "-out", "key.pem", "4096").Run() // want `function "exec.CommandContext" runs "openssl genrsa"`
+}
+
+func generatePostQuantumKey(ctx context.Context) error {
+	if err := exec.Command("openssl", "genpkey", "-algorithm", "ec", "-out", "ec.pem").Run(); err != nil { // want `function "exec.Command" runs "openssl genpkey ec"`
+		return err
+	}
+	return exec.CommandContext(ctx, "openssl", "genpkey", "-algorithm", "ML-DSA-65", "-out", "key.pem").Run()
+}
+
+func checksum(file string) ([]byte, error) {
+	return exec.Command("openssl", "dgst", "-sha256", file).Output()
+}
+
+func listKeys() ([]byte, error) {
+	return exec.Command("gpg", "--list-keys").Output()
+}
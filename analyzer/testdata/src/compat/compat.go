@@ -0,0 +1,18 @@
+package compat
+
+import (
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa" // want `"crypto/rsa" uses quantum-vulnerable integer factorization cryptography`
+)
+
+// legacySign signs for partners that only verify RSA signatures.
+//
+//pqc:compat partner gateway cannot verify ML-DSA yet
+func legacySign(key *rsa.PrivateKey, digest []byte) ([]byte, error) {
+	return rsa.SignPSS(rand.Reader, key, crypto.SHA256, digest, nil) // want `function "rsa.SignPSS" implements quantum-vulnerable cryptography \(accepted interop debt\)`
+}
+
+func sign(key *rsa.PrivateKey, digest []byte) ([]byte, error) {
+	return rsa.SignPSS(rand.Reader, key, crypto.SHA256, digest, nil) // want `function "rsa.SignPSS" implements quantum-vulnerable cryptography$`
+}
@@ -0,0 +1,35 @@
+package curves
+
+import (
+	"crypto/ecdsa"    // want `"crypto/ecdsa" uses quantum-vulnerable elliptic curve cryptography`
+	"crypto/elliptic" // want `"crypto/elliptic" uses quantum-vulnerable elliptic curve cryptography`
+	"crypto/rand"
+	"math/big"
+
+	"github.com/decred/dcrd/dcrec/secp256k1/v4"
+	"github.com/ebfe/brainpool"
+)
+
+func standard() (*ecdsa.PrivateKey, error) {
+	return ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+}
+
+func small() (*ecdsa.PrivateKey, error) {
+	return ecdsa.GenerateKey(elliptic.P224(), rand.Reader) // want `"elliptic.P224" selects P-224, which offers only 112-bit classical security`
+}
+
+func bitcoin() (*secp256k1.PrivateKey, error) {
+	_ = secp256k1.S256()                  // want `"secp256k1.S256" selects secp256k1, which is not a NIST curve`
+	return secp256k1.GeneratePrivateKey() // want `"secp256k1.GeneratePrivateKey" selects secp256k1`
+}
+
+func european() (*ecdsa.PrivateKey, error) {
+	if _, err := ecdsa.GenerateKey(brainpool.P192t1(), rand.Reader); err != nil { // want `"brainpool.P192t1" selects brainpoolP192t1, which offers only 96-bit classical security`
+		return nil, err
+	}
+	return ecdsa.GenerateKey(brainpool.P256r1(), rand.Reader) // want `"brainpool.P256r1" selects brainpoolP256r1, which is approved by BSI TR-02102 but not by CNSA`
+}
+
+var curve = &elliptic.CurveParams{Name: "P-192", BitSize: 192, P: big.NewInt(23)} // want `elliptic.CurveParams literal defines the custom curve "P-192", which offers only 96-bit classical security`
+
+var unnamed = elliptic.CurveParams{BitSize: 384} // want `elliptic.CurveParams literal defines a custom curve, which is evaluated by the deprecated generic crypto/elliptic implementation`
@@ -0,0 +1,42 @@
+package dbcrypt
+
+import (
+	"crypto/rsa" // want `"crypto/rsa" uses quantum-vulnerable integer factorization cryptography`
+	"database/sql"
+
+	"dbcrypt/internal/gormenc"
+	"github.com/pkasila/gorm-crypto/algorithms"
+)
+
+const insertSSN = `INSERT INTO patients (name, ssn) VALUES ($1, pgp_pub_encrypt($2, dearmor($3)))` // want `SQL calls pgcrypto pgp_pub_encrypt, which encrypts or decrypts columns with OpenPGP RSA or ElGamal keys; encrypted columns are stored for years`
+
+func store(db *sql.DB, name, ssn, publicKey string) error {
+	_, err := db.Exec(insertSSN, name, ssn, publicKey)
+	return err
+}
+
+func load(db *sql.DB, name, privateKey string) (string, error) {
+	var ssn string
+	err := db.QueryRow("SELECT PGP_PUB_DECRYPT_BYTEA(ssn, dearmor($2)) FROM patients WHERE name = $1", name, privateKey).Scan(&ssn) // want `SQL calls pgcrypto pgp_pub_decrypt_bytea`
+	return ssn, err
+}
+
+func symmetric(db *sql.DB, name, ssn, key string) error {
+	_, err := db.Exec("INSERT INTO patients (name, ssn) VALUES ($1, pgp_sym_encrypt($2, $3))", name, ssn, key)
+	return err
+}
+
+func fieldEncryption(key *rsa.PrivateKey) algorithms.Algorithm {
+	return algorithms.NewRSA(key, &key.PublicKey) // want `"algorithms.NewRSA" configures ORM field encryption with a classical RSA key \(rsa.PrivateKey\)` `"algorithms.NewRSA" configures ORM field encryption with a classical RSA key \(rsa.PublicKey\)`
+}
+
+func symmetricFieldEncryption(key []byte) (algorithms.Algorithm, error) {
+	return algorithms.NewAES256GCM(key)
+}
+
+func register(key *rsa.PublicKey) {
+	gormenc.Register(gormenc.Options{
+		Column:    "ssn",
+		PublicKey: key, // want `option "Options.PublicKey" configures ORM field encryption with a classical RSA key \(rsa.PublicKey\)`
+	})
+}
@@ -0,0 +1,10 @@
+package gormenc
+
+import "crypto/rsa"
+
+type Options struct {
+	Column    string
+	PublicKey *rsa.PublicKey
+}
+
+func Register(opts Options) {}
@@ -0,0 +1,16 @@
+package detector
+
+import "example.com/cryptosdk"
+
+func sign(msg []byte) ([]byte, error) {
+	key, err := cryptosdk.NewRSAKey(2048) // want `cryptosdk.NewRSAKey generates an RSA key`
+	if err != nil {
+		return nil, err
+	}
+	return key.Sign(msg)
+}
+
+//pqc:compat partner API only accepts RSA
+func legacyKey() (*cryptosdk.Key, error) {
+	return cryptosdk.NewRSAKey(2048) // want `cryptosdk.NewRSAKey generates an RSA key`
+}
@@ -0,0 +1,56 @@
+package detkeys
+
+import (
+	"bytes"
+	"crypto/ecdh"     // want `"crypto/ecdh" uses quantum-vulnerable elliptic curve cryptography`
+	"crypto/ecdsa"    // want `"crypto/ecdsa" uses quantum-vulnerable elliptic curve cryptography`
+	"crypto/elliptic" // want `"crypto/elliptic" uses quantum-vulnerable elliptic curve cryptography`
+	"crypto/rand"
+	"crypto/rsa" // want `"crypto/rsa" uses quantum-vulnerable integer factorization cryptography`
+	"io"
+	mrand "math/rand"
+)
+
+// newKey generates the signing key of a service.
+func newKey(random io.Reader) (*rsa.PrivateKey, error) {
+	return rsa.GenerateKey(random, 2048)
+}
+
+type zeroReader struct{}
+
+func (zeroReader) Read(p []byte) (int, error) {
+	clear(p)
+	return len(p), nil
+}
+
+type countingReader struct {
+	r io.Reader
+	n int
+}
+
+func (c *countingReader) Read(p []byte) (int, error) {
+	n, err := c.r.Read(p)
+	c.n += n
+	return n, err
+}
+
+func serviceKey() (*rsa.PrivateKey, error) {
+	return newKey(rand.Reader)
+}
+
+func fixtureKey(seed []byte) (*rsa.PrivateKey, error) {
+	return rsa.GenerateKey(bytes.NewReader(seed), 2048) // want `"rsa.GenerateKey" generates a classical key from deterministic reader bytes.NewReader\(seed\); keys generated from a fixed reader are the same on every run`
+}
+
+func zeroKey() (*rsa.PrivateKey, error) {
+	return newKey(zeroReader{}) // want `"newKey" generates a classical key from deterministic reader zeroReader\{\}`
+}
+
+func seededKey() (*ecdsa.PrivateKey, error) {
+	random := mrand.New(mrand.NewSource(1))
+	return ecdsa.GenerateKey(elliptic.P256(), random) // want `"ecdsa.GenerateKey" generates a classical key from deterministic reader random`
+}
+
+func countedKey() (*ecdh.PrivateKey, error) {
+	return ecdh.X25519().GenerateKey(&countingReader{r: rand.Reader})
+}
@@ -0,0 +1,11 @@
+package detkeys
+
+import (
+	"crypto/ed25519" // want `"crypto/ed25519" uses quantum-vulnerable Ed25519 signatures`
+	"strings"
+)
+
+func testKey() ed25519.PrivateKey {
+	_, key, _ := ed25519.GenerateKey(strings.NewReader(strings.Repeat("seed", 8))) // want `function "ed25519.GenerateKey" generates keys or signs with quantum-vulnerable Ed25519`
+	return key
+}
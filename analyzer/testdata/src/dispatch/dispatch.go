@@ -0,0 +1,51 @@
+package dispatch
+
+import (
+	"crypto"
+	"crypto/ecdsa" // want `"crypto/ecdsa" uses quantum-vulnerable elliptic curve cryptography`
+	"crypto/rsa"   // want `"crypto/rsa" uses quantum-vulnerable integer factorization cryptography`
+	"crypto/x509"
+	"errors"
+)
+
+var verifiers = map[string]func(crypto.PublicKey, []byte, []byte) error{ // want `map dispatches on algorithm names "RS256", "ES256"`
+	"RS256": nil,
+	"ES256": nil,
+	"HS256": nil,
+}
+
+func keyType(name string) (string, error) {
+	switch name { // want `switch dispatches on algorithm names "rsa", "ecdsa"`
+	case "rsa":
+		return "RSA", nil
+	case "ecdsa":
+		return "EC", nil
+	}
+	return "", errors.New("unknown key type")
+}
+
+func algorithm(cert *x509.Certificate) string {
+	switch cert.PublicKeyAlgorithm { // want `switch on x509.PublicKeyAlgorithm dispatches on algorithm identifiers` `switch on x509.PublicKeyAlgorithm has no default case`
+	case x509.RSA:
+		return "rsa"
+	}
+	return ""
+}
+
+func size(pub crypto.PublicKey) int {
+	switch pub := pub.(type) { // want `type switch dispatches on key types \*rsa.PublicKey, \*ecdsa.PublicKey`
+	case *rsa.PublicKey:
+		return pub.Size()
+	case *ecdsa.PublicKey:
+		return pub.Params().BitSize
+	}
+	return 0
+}
+
+func color(name string) int {
+	switch name {
+	case "red":
+		return 1
+	}
+	return 0
+}
@@ -0,0 +1,27 @@
+package dnssec
+
+import (
+	"crypto"
+
+	"github.com/miekg/dns"
+)
+
+func zoneKey(zone string) (*dns.DNSKEY, crypto.PrivateKey, error) {
+	key := &dns.DNSKEY{
+		Hdr:       dns.RR_Header{Name: dns.Fqdn(zone)},
+		Flags:     257,
+		Protocol:  3,
+		Algorithm: dns.ECDSAP256SHA256, // want `DNSSEC algorithm "dns.ECDSAP256SHA256" signs zones with quantum-vulnerable ECDSA P-256 keys; DNSSEC has no standardized post-quantum algorithm yet`
+	}
+	priv, err := key.Generate(256) // want `method "dns.DNSKEY.Generate" signs or validates DNSSEC records with classical RSA, ECDSA or EdDSA keys`
+	return key, priv, err
+}
+
+func signZone(sig *dns.RRSIG, signer crypto.Signer, rrset []dns.RR) error {
+	sig.Algorithm = dns.RSASHA256  // want `DNSSEC algorithm "dns.RSASHA256" signs zones with quantum-vulnerable RSA keys`
+	return sig.Sign(signer, rrset) // want `method "dns.RRSIG.Sign" signs or validates DNSSEC records`
+}
+
+func validate(sig *dns.RRSIG, key *dns.DNSKEY, rrset []dns.RR) error {
+	return sig.Verify(key, rrset) // want `method "dns.RRSIG.Verify" signs or validates DNSSEC records`
+}
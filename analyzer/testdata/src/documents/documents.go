@@ -0,0 +1,32 @@
+package documents
+
+import (
+	"bytes"
+	"crypto"
+	"crypto/x509"
+
+	"github.com/digitorus/pdfsign/sign"
+	"github.com/digitorus/timestamp"
+	dsig "github.com/russellhaering/goxmldsig"
+)
+
+func signPDF(input, output string, signer crypto.Signer, cert *x509.Certificate) error {
+	return sign.SignFile(input, output, sign.SignData{ // want `function "sign.SignFile" signs, timestamps or verifies documents with RSA or ECDSA signatures that must stay verifiable for decades`
+		Signer:      signer, // want `field "sign.SignData.Signer" signs, timestamps or verifies documents`
+		Certificate: cert,
+		TSA:         sign.TSA{URL: "https://freetsa.org/tsr"}, // want `field "sign.SignData.TSA" signs, timestamps or verifies documents`
+	})
+}
+
+func stamp(digest []byte) ([]byte, error) {
+	return timestamp.CreateRequest(bytes.NewReader(digest), &timestamp.RequestOptions{Hash: crypto.SHA256, Certificates: true}) // want `function "timestamp.CreateRequest" signs, timestamps or verifies documents`
+}
+
+func signXML(ks dsig.X509KeyStore, content string) ([]byte, error) {
+	ctx := dsig.NewDefaultSigningContext(ks) // want `function "dsig.NewDefaultSigningContext" signs, timestamps or verifies documents`
+	return ctx.SignString(content)           // want `method "dsig.SigningContext.SignString" signs, timestamps or verifies documents`
+}
+
+func validateXML(store dsig.X509CertificateStore, el any) (any, error) {
+	return dsig.NewDefaultValidationContext(store).Validate(el) // want `function "dsig.NewDefaultValidationContext" signs, timestamps or verifies documents` `method "dsig.ValidationContext.Validate" signs, timestamps or verifies documents`
+}
@@ -0,0 +1,33 @@
+package e2ee
+
+import (
+	"go.mau.fi/libsignal/session"
+	"go.mau.fi/libsignal/util/keyhelper"
+	"maunium.net/go/mautrix/crypto/olm"
+)
+
+func register() (*keyhelper.IdentityKeyPair, uint32, error) {
+	identity, err := keyhelper.GenerateIdentityKeyPair() // want `function "keyhelper.GenerateIdentityKeyPair" sets up or runs a double-ratchet end-to-end encryption session keyed with X25519 and Ed25519`
+	return identity, keyhelper.GenerateRegistrationID(), err
+}
+
+func send(store, bundle any, address string, message []byte) ([]byte, error) {
+	builder := session.NewBuilderFromSignal(store, address) // want `function "session.NewBuilderFromSignal" sets up or runs a double-ratchet end-to-end encryption session`
+	if err := builder.ProcessBundle(bundle); err != nil {   // want `method "session.Builder.ProcessBundle" sets up or runs a double-ratchet end-to-end encryption session`
+		return nil, err
+	}
+	cipher := session.NewCipher(builder, address) // want `function "session.NewCipher" sets up or runs a double-ratchet end-to-end encryption session`
+	_ = cipher.SessionVersion()
+	return cipher.Encrypt(message) // want `method "session.Cipher.Encrypt" sets up or runs a double-ratchet end-to-end encryption session`
+}
+
+func room() (string, error) {
+	if _, err := olm.NewAccount(); err != nil { // want `function "olm.NewAccount" sets up or runs a double-ratchet end-to-end encryption session`
+		return "", err
+	}
+	outbound, err := olm.NewOutboundGroupSession() // want `function "olm.NewOutboundGroupSession" sets up or runs a double-ratchet end-to-end encryption session`
+	if err != nil {
+		return "", err
+	}
+	return outbound.ID(), nil
+}
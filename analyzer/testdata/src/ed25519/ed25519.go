@@ -0,0 +1,15 @@
+package ed25519
+
+import (
+	"crypto/ed25519" // want `"crypto/ed25519" uses quantum-vulnerable Ed25519 signatures`
+	"crypto/rand"
+)
+
+func sign(message []byte) ([]byte, ed25519.PublicKey) {
+	pub, priv, _ := ed25519.GenerateKey(rand.Reader) // want `function "ed25519.GenerateKey" generates keys or signs with quantum-vulnerable Ed25519`
+	return ed25519.Sign(priv, message), pub          // want `function "ed25519.Sign" generates keys or signs with quantum-vulnerable Ed25519`
+}
+
+func verify(pub ed25519.PublicKey, message, sig []byte) bool {
+	return ed25519.Verify(pub, message, sig) // want `function "ed25519.Verify" verifies quantum-vulnerable Ed25519 signatures`
+}
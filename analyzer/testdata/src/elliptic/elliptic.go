@@ -0,0 +1,23 @@
+package elliptic
+
+import (
+	"crypto/elliptic" // want `"crypto/elliptic" uses quantum-vulnerable elliptic curve cryptography`
+	"crypto/rand"
+)
+
+func sharedSecret(peer []byte) ([]byte, error) {
+	curve := elliptic.P256()
+	priv, _, _, err := elliptic.GenerateKey(curve, rand.Reader) // want `function "elliptic.GenerateKey" performs low-level quantum-vulnerable elliptic curve operations`
+	if err != nil {
+		return nil, err
+	}
+	x, y := elliptic.Unmarshal(curve, peer) // want `function "elliptic.Unmarshal" performs low-level quantum-vulnerable elliptic curve operations`
+	sx, _ := curve.ScalarMult(x, y, priv)   // want `method "elliptic.Curve.ScalarMult" performs low-level quantum-vulnerable elliptic curve operations`
+	return sx.Bytes(), nil
+}
+
+func public(priv []byte) []byte {
+	curve := elliptic.P256()
+	x, y := curve.ScalarBaseMult(priv)   // want `method "elliptic.Curve.ScalarBaseMult" performs low-level quantum-vulnerable elliptic curve operations`
+	return elliptic.Marshal(curve, x, y) // want `function "elliptic.Marshal" performs low-level quantum-vulnerable elliptic curve operations`
+}
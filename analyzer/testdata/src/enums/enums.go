@@ -0,0 +1,32 @@
+package enums
+
+import "enums/internal/signing"
+
+func signRelease(release []byte) {
+	signing.Sign(signing.Config{Algorithm: signing.AlgRSA4096, Label: "release"}, release) // want `function "signing.Sign" wraps "crypto/rsa.SignPSS"` `enum "signing.AlgRSA4096" selects classical RSA4096 for wrapper "enums/internal/signing.Sign", which wraps "crypto/rsa.SignPSS"`
+}
+
+func signDevice(token []byte) {
+	signing.SignWith(signing.AlgRSA2048, token) // want `function "signing.SignWith" wraps "crypto/rsa.SignPSS"` `enum "signing.AlgRSA2048" selects classical RSA2048`
+}
+
+func signConfigured(message []byte) {
+	var cfg signing.Config
+	cfg.KeyType = signing.KeyTypeECDSA // want `enum "signing.KeyTypeECDSA" selects classical ECDSA`
+	cfg.Algorithm = signing.AlgMLDSA65
+	signing.Sign(cfg, message) // want `function "signing.Sign" wraps "crypto/rsa.SignPSS"`
+}
+
+func signDefault(message []byte) {
+	cfg := &signing.Config{KeyType: signing.KeyTypeDefault} // want `enum "signing.KeyTypeDefault" selects classical ed25519`
+	signing.Sign(*cfg, message)                             // want `function "signing.Sign" wraps "crypto/rsa.SignPSS"`
+}
+
+func signHybrid(message []byte) {
+	signing.Sign(signing.Config{Algorithm: signing.AlgMLDSA65, KeyType: signing.KeyTypeHybrid}, message) // want `function "signing.Sign" wraps "crypto/rsa.SignPSS"`
+}
+
+func defaultAlgorithm() signing.Algorithm {
+	alg := signing.AlgRSA2048
+	return alg
+}
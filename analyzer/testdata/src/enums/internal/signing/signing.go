@@ -0,0 +1,40 @@
+package signing
+
+import (
+	"crypto"
+	"crypto/rsa" // want `"crypto/rsa" uses quantum-vulnerable integer factorization cryptography`
+	"crypto/sha256"
+)
+
+type Algorithm int
+
+const (
+	AlgRSA2048 Algorithm = iota
+	AlgRSA4096
+	AlgMLDSA65
+)
+
+type KeyType string
+
+const (
+	KeyTypeECDSA   KeyType = "EC"
+	KeyTypeDefault KeyType = "ed25519"
+	KeyTypeHybrid  KeyType = "hybrid"
+)
+
+type Config struct {
+	Algorithm Algorithm
+	KeyType   KeyType
+	Label     string
+}
+
+var key *rsa.PrivateKey
+
+func Sign(cfg Config, message []byte) ([]byte, error) {
+	digest := sha256.Sum256(message)
+	return rsa.SignPSS(nil, key, crypto.SHA256, digest[:], nil) // want `function "rsa.SignPSS" implements quantum-vulnerable cryptography`
+}
+
+func SignWith(alg Algorithm, message []byte) ([]byte, error) {
+	return Sign(Config{Algorithm: alg}, message)
+}
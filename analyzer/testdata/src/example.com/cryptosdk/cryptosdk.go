@@ -0,0 +1,7 @@
+package cryptosdk
+
+type Key struct{}
+
+func NewRSAKey(bits int) (*Key, error) { return &Key{}, nil }
+
+func (k *Key) Sign(msg []byte) ([]byte, error) { return nil, nil }
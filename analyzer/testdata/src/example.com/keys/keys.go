@@ -0,0 +1,50 @@
+package keys
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+)
+
+func NewSigner() (crypto.Signer, error) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		return nil, err
+	}
+	return key, nil
+}
+
+func Default() crypto.Signer {
+	signer, _ := NewSigner()
+	return signer
+}
+
+type Signer struct {
+	key *rsa.PrivateKey
+}
+
+func Load(der []byte) (*Signer, error) {
+	key, err := x509.ParsePKCS1PrivateKey(der)
+	if err != nil {
+		return nil, err
+	}
+	return &Signer{key: key}, nil
+}
+
+func (s *Signer) Sign(msg []byte) ([]byte, error) {
+	digest := sha256.Sum256(msg)
+	return rsa.SignPKCS1v15(rand.Reader, s.key, crypto.SHA256, digest[:])
+}
+
+func Seal(key any, msg []byte) ([]byte, error) {
+	return rsa.EncryptOAEP(sha256.New(), rand.Reader, key.(*rsa.PublicKey), msg, nil)
+}
+
+func Digest(msg []byte) []byte {
+	digest := sha256.Sum256(msg)
+	return digest[:]
+}
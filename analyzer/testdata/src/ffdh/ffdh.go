@@ -0,0 +1,25 @@
+package ffdh
+
+import (
+	"math/big"
+
+	"github.com/monnand/dhkx"
+)
+
+const modp768 = "FFFFFFFFFFFFFFFFC90FDAA22168C234C4C6628B80DC1CD129024E088A67CC74020BBEA63B139B22514A08798E3404DDEF9519B3CD3A431B302B0A6DF25F14374FE1356D6D51C245E485B576625E7EC6F44C42E9A63A3620FFFFFFFFFFFFFFFF" // want `768-bit Diffie-Hellman group prime is weak today and quantum-vulnerable`
+
+func generate() (*dhkx.DHKey, error) {
+	group, err := dhkx.GetGroup(14) // want `function "dhkx.GetGroup" implements quantum-vulnerable finite-field Diffie-Hellman`
+	if err != nil {
+		return nil, err
+	}
+	return group.GeneratePrivateKey(nil) // want `method "dhkx.DHGroup.GeneratePrivateKey" implements quantum-vulnerable finite-field Diffie-Hellman`
+}
+
+func publicValue(generator, secret, prime *big.Int) *big.Int {
+	return new(big.Int).Exp(generator, secret, prime) // want `modular exponentiation of Diffie-Hellman group parameters`
+}
+
+func power(x, y *big.Int) *big.Int {
+	return new(big.Int).Exp(x, y, nil)
+}
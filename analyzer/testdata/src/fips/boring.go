@@ -0,0 +1,3 @@
+//go:build !goexperiment.boringcrypto
+
+package fips // want `file is built conditionally on the goexperiment.boringcrypto tag`
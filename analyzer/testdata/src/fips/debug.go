@@ -0,0 +1,12 @@
+//go:debug fips140=on
+
+package fips // want `go:debug directive sets the fips140 GODEBUG setting`
+
+import "strings"
+
+func fipsOnly(godebug string) bool {
+	return strings.Contains(godebug, "fips140=only") // want `string "fips140=only" handles the fips140 GODEBUG setting`
+}
+
+// Mentions of the setting without a value are not reported.
+const setting = "fips140"
@@ -0,0 +1,14 @@
+package fips
+
+import (
+	"crypto/fips140" // want `"crypto/fips140" depends on FIPS 140 mode`
+	"os"
+)
+
+func enable() error {
+	return os.Setenv("GODEBUG", "fips140=only") // want `string "fips140=only" handles the fips140 GODEBUG setting`
+}
+
+func enabled() bool {
+	return fips140.Enabled()
+}
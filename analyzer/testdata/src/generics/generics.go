@@ -0,0 +1,27 @@
+package generics
+
+import (
+	"crypto"
+	"crypto/ecdsa" // want `"crypto/ecdsa" uses quantum-vulnerable elliptic curve cryptography`
+	"crypto/rand"
+)
+
+func sign[K crypto.Signer](key K, digest []byte) ([]byte, error) {
+	return key.Sign(rand.Reader, digest, crypto.SHA256)
+}
+
+type Keyring[K any] struct {
+	keys map[string]K
+}
+
+func use(key *ecdsa.PrivateKey, digest []byte) ([]byte, error) {
+	var ring Keyring[*ecdsa.PublicKey] // want `generic type "Keyring" is instantiated with quantum-vulnerable key type \*ecdsa.PublicKey`
+	_ = ring
+	return sign(key, digest) // want `generic function "sign" is instantiated with quantum-vulnerable key type \*ecdsa.PrivateKey`
+}
+
+type hsmSigner struct{ crypto.Signer }
+
+func useHSM(signer hsmSigner, digest []byte) ([]byte, error) {
+	return sign(signer, digest)
+}
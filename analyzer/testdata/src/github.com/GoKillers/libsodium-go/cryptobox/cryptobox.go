@@ -0,0 +1,5 @@
+package cryptobox
+
+func CryptoBoxKeyPair() ([]byte, []byte, int) { return nil, nil, 0 }
+
+func CryptoBoxSeal(m []byte, pk []byte) ([]byte, int) { return nil, 0 }
@@ -0,0 +1,3 @@
+package cryptosecretbox
+
+func CryptoSecretBoxEasy(m []byte, n []byte, k []byte) ([]byte, int) { return nil, 0 }
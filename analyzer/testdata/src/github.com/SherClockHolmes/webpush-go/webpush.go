@@ -0,0 +1,22 @@
+package webpush
+
+import "net/http"
+
+type Subscription struct {
+	Endpoint string
+}
+
+type Options struct {
+	Subscriber      string
+	TTL             int
+	VAPIDPublicKey  string
+	VAPIDPrivateKey string
+}
+
+func GenerateVAPIDKeys() (privateKey, publicKey string, err error) {
+	return "", "", nil
+}
+
+func SendNotification(message []byte, s *Subscription, options *Options) (*http.Response, error) {
+	return nil, nil
+}
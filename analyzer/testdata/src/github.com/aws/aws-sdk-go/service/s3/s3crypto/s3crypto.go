@@ -0,0 +1,14 @@
+// Package s3crypto is a minimal stub of the AWS S3 encryption client for analyzer tests.
+package s3crypto
+
+type CipherDataGenerator interface{}
+
+type EncryptionClientV2 struct{}
+
+func NewKMSContextKeyGenerator(client any, cmkID string, matdesc map[string]*string) CipherDataGenerator {
+	return nil
+}
+
+func NewEncryptionClientV2(prov any, contentCipherBuilder any) (*EncryptionClientV2, error) {
+	return nil, nil
+}
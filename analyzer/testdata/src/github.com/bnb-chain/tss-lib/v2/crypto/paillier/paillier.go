@@ -0,0 +1,22 @@
+package paillier
+
+import (
+	"context"
+	"math/big"
+)
+
+type PublicKey struct{ N *big.Int }
+
+type PrivateKey struct{ PublicKey }
+
+func GenerateKeyPair(ctx context.Context, modulusBitLen int) (*PrivateKey, *PublicKey, error) {
+	return &PrivateKey{}, &PublicKey{}, nil
+}
+
+func (pk *PublicKey) Encrypt(m *big.Int) (*big.Int, error) { return m, nil }
+
+func (pk *PublicKey) HomoAdd(c1, c2 *big.Int) (*big.Int, error) { return c1, nil }
+
+func (pk *PublicKey) Gamma() *big.Int { return pk.N }
+
+func (privateKey *PrivateKey) Decrypt(c *big.Int) (*big.Int, error) { return c, nil }
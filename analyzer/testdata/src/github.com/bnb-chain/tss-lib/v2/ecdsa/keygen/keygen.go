@@ -0,0 +1,15 @@
+package keygen
+
+import "github.com/bnb-chain/tss-lib/v2/tss"
+
+type LocalPreParams struct{}
+
+type LocalPartySaveData struct{}
+
+func GeneratePreParams(timeout int, optionalConcurrency ...int) (*LocalPreParams, error) {
+	return &LocalPreParams{}, nil
+}
+
+func NewLocalParty(params *tss.Parameters, out chan<- tss.Message, end chan<- *LocalPartySaveData, optionalPreParams ...LocalPreParams) tss.Party {
+	return nil
+}
@@ -0,0 +1,9 @@
+package tss
+
+type Party interface {
+	Start() error
+}
+
+type Parameters struct{}
+
+type Message interface{}
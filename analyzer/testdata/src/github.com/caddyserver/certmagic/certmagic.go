@@ -0,0 +1,21 @@
+package certmagic
+
+type KeyType string
+
+const (
+	ED25519 = KeyType("ed25519")
+	P256    = KeyType("p256")
+	RSA2048 = KeyType("rsa2048")
+)
+
+type StandardKeyGenerator struct {
+	KeyType KeyType
+}
+
+type Config struct {
+	KeySource *StandardKeyGenerator
+}
+
+func NewDefault() *Config { return &Config{} }
+
+func (c *Config) ManageSync(domains []string) error { return nil }
@@ -0,0 +1,24 @@
+package v1
+
+type PrivateKeyAlgorithm string
+
+const (
+	RSAKeyAlgorithm     PrivateKeyAlgorithm = "RSA"
+	ECDSAKeyAlgorithm   PrivateKeyAlgorithm = "ECDSA"
+	Ed25519KeyAlgorithm PrivateKeyAlgorithm = "Ed25519"
+)
+
+type Certificate struct {
+	Spec CertificateSpec `json:"spec"`
+}
+
+type CertificateSpec struct {
+	SecretName string                 `json:"secretName"`
+	DNSNames   []string               `json:"dnsNames,omitempty"`
+	PrivateKey *CertificatePrivateKey `json:"privateKey,omitempty"`
+}
+
+type CertificatePrivateKey struct {
+	Algorithm PrivateKeyAlgorithm `json:"algorithm,omitempty"`
+	Size      int                 `json:"size,omitempty"`
+}
@@ -0,0 +1,11 @@
+package rfc6979
+
+import (
+	"crypto/ecdsa"
+	"hash"
+	"math/big"
+)
+
+func SignECDSA(priv *ecdsa.PrivateKey, hash []byte, alg func() hash.Hash) (r, s *big.Int, err error) {
+	return nil, nil, nil
+}
@@ -0,0 +1,20 @@
+package saml
+
+import (
+	"crypto"
+	"crypto/x509"
+)
+
+type ServiceProvider struct {
+	EntityID        string
+	Key             crypto.Signer
+	Certificate     *x509.Certificate
+	SignatureMethod string
+}
+
+type IdentityProvider struct {
+	Key             crypto.PrivateKey
+	Signer          crypto.Signer
+	Certificate     *x509.Certificate
+	SignatureMethod string
+}
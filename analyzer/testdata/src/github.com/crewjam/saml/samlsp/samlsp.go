@@ -0,0 +1,27 @@
+package samlsp
+
+import (
+	"crypto/rsa"
+	"crypto/x509"
+
+	"github.com/crewjam/saml"
+)
+
+type Options struct {
+	EntityID    string
+	Key         *rsa.PrivateKey
+	Certificate *x509.Certificate
+	SignRequest bool
+}
+
+type Middleware struct {
+	ServiceProvider saml.ServiceProvider
+}
+
+func New(opts Options) (*Middleware, error) {
+	return nil, nil
+}
+
+func DefaultServiceProvider(opts Options) saml.ServiceProvider {
+	return saml.ServiceProvider{}
+}
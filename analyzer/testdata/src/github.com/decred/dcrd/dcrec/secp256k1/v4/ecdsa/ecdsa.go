@@ -0,0 +1,9 @@
+package ecdsa
+
+import "github.com/decred/dcrd/dcrec/secp256k1/v4"
+
+type Signature struct{}
+
+func Sign(key *secp256k1.PrivateKey, hash []byte) *Signature {
+	return nil
+}
@@ -0,0 +1,17 @@
+package secp256k1
+
+type PrivateKey struct{}
+
+func NonceRFC6979(privKey []byte, hash []byte, extra []byte, version []byte, extraIterations uint32) *PrivateKey {
+	return nil
+}
+
+type KoblitzCurve struct{}
+
+func S256() *KoblitzCurve {
+	return nil
+}
+
+func GeneratePrivateKey() (*PrivateKey, error) {
+	return nil, nil
+}
@@ -0,0 +1,20 @@
+package sign
+
+import (
+	"crypto"
+	"crypto/x509"
+)
+
+type TSA struct {
+	URL string
+}
+
+type SignData struct {
+	Signer      crypto.Signer
+	Certificate *x509.Certificate
+	TSA         TSA
+}
+
+func SignFile(input, output string, signData SignData) error {
+	return nil
+}
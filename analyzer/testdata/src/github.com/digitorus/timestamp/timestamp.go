@@ -0,0 +1,18 @@
+package timestamp
+
+import "crypto"
+
+type RequestOptions struct {
+	Hash         crypto.Hash
+	Certificates bool
+}
+
+type Timestamp struct{}
+
+func CreateRequest(r interface{ Read([]byte) (int, error) }, opts *RequestOptions) ([]byte, error) {
+	return nil, nil
+}
+
+func ParseResponse(bytes []byte) (*Timestamp, error) {
+	return nil, nil
+}
@@ -0,0 +1,11 @@
+package tlsv3
+
+type CommonTlsContext struct {
+	TlsCertificates []*TlsCertificate
+}
+
+type TlsCertificate struct{}
+
+type DownstreamTlsContext struct {
+	CommonTlsContext *CommonTlsContext
+}
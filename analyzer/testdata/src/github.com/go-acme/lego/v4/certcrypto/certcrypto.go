@@ -0,0 +1,14 @@
+package certcrypto
+
+import "crypto"
+
+type KeyType string
+
+const (
+	EC256   = KeyType("P256")
+	EC384   = KeyType("P384")
+	RSA2048 = KeyType("2048")
+	RSA4096 = KeyType("4096")
+)
+
+func GeneratePrivateKey(keyType KeyType) (crypto.PrivateKey, error) { return nil, nil }
@@ -0,0 +1,16 @@
+package lego
+
+import "github.com/go-acme/lego/v4/certcrypto"
+
+type CertificateConfig struct {
+	KeyType certcrypto.KeyType
+}
+
+type Config struct {
+	CADirURL    string
+	Certificate CertificateConfig
+}
+
+type User interface{}
+
+func NewConfig(user User) *Config { return &Config{} }
@@ -0,0 +1,33 @@
+package jose
+
+type JSONWebKey struct {
+	Key       interface{}
+	KeyID     string
+	Algorithm string
+	Use       string
+}
+
+type JSONWebKeySet struct {
+	Keys []JSONWebKey
+}
+
+type SignatureAlgorithm string
+
+const (
+	HS256 = SignatureAlgorithm("HS256")
+	RS256 = SignatureAlgorithm("RS256")
+	ES256 = SignatureAlgorithm("ES256")
+)
+
+type SigningKey struct {
+	Algorithm SignatureAlgorithm
+	Key       interface{}
+}
+
+type SignerOptions struct{}
+
+type Signer interface{}
+
+func NewSigner(sig SigningKey, opts *SignerOptions) (Signer, error) {
+	return nil, nil
+}
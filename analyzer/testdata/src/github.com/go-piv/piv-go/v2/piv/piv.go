@@ -0,0 +1,40 @@
+package piv
+
+import "crypto"
+
+type Algorithm int
+
+const (
+	AlgorithmEC256 Algorithm = iota + 1
+	AlgorithmEC384
+	AlgorithmRSA2048
+)
+
+type Slot struct {
+	Key    uint32
+	Object uint32
+}
+
+var SlotAuthentication = Slot{0x9a, 0x5fc105}
+
+type Key struct {
+	Algorithm Algorithm
+}
+
+type KeyAuth struct {
+	PIN string
+}
+
+type YubiKey struct{}
+
+func (yk *YubiKey) GenerateKey(key [24]byte, slot Slot, opts Key) (crypto.PublicKey, error) {
+	return nil, nil
+}
+
+func (yk *YubiKey) PrivateKey(slot Slot, public crypto.PublicKey, auth KeyAuth) (crypto.PrivateKey, error) {
+	return nil, nil
+}
+
+func (yk *YubiKey) Serial() (uint32, error) { return 0, nil }
+
+var DefaultManagementKey = [24]byte{}
@@ -0,0 +1,15 @@
+package jwt
+
+type Token struct {
+	Valid bool
+}
+
+type Keyfunc func(*Token) (any, error)
+
+type Parser struct{}
+
+func NewParser() *Parser { return &Parser{} }
+
+func (p *Parser) Parse(tokenString string, keyFunc Keyfunc) (*Token, error) { return nil, nil }
+
+func Parse(tokenString string, keyFunc Keyfunc) (*Token, error) { return nil, nil }
@@ -0,0 +1,23 @@
+package attest
+
+type Algorithm string
+
+const (
+	RSA   Algorithm = "RSA"
+	ECDSA Algorithm = "ECDSA"
+)
+
+type KeyConfig struct {
+	Algorithm Algorithm
+	Size      int
+}
+
+type AKConfig struct{}
+
+type TPM struct{}
+
+func (t *TPM) NewKey(ak *AK, opts *KeyConfig) (*Key, error) { return &Key{}, nil }
+
+type AK struct{}
+
+type Key struct{}
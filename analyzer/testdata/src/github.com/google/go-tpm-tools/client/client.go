@@ -0,0 +1,11 @@
+package client
+
+import "io"
+
+type Key struct{}
+
+func AttestationKeyRSA(rw io.ReadWriter) (*Key, error) { return &Key{}, nil }
+
+func AttestationKeyECC(rw io.ReadWriter) (*Key, error) { return &Key{}, nil }
+
+func NewCachedKey(rw io.ReadWriter) (*Key, error) { return &Key{}, nil }
@@ -0,0 +1,20 @@
+package tpm2
+
+type TPMAlgID uint16
+
+const (
+	TPMAlgRSA    TPMAlgID = 0x0001
+	TPMAlgSHA256 TPMAlgID = 0x000B
+	TPMAlgECC    TPMAlgID = 0x0023
+)
+
+type TPMECCCurve uint16
+
+const TPMECCNistP256 TPMECCCurve = 0x0003
+
+type TPMTPublic struct {
+	Type    TPMAlgID
+	NameAlg TPMAlgID
+}
+
+var RSASRKTemplate = TPMTPublic{Type: TPMAlgRSA, NameAlg: TPMAlgSHA256}
@@ -0,0 +1,25 @@
+package bls
+
+const BLS12_381 = 5
+
+type SecretKey struct{}
+
+type PublicKey struct{}
+
+type Sign struct{}
+
+func Init(curve int) error { return nil }
+
+func (sec *SecretKey) SetByCSPRNG() {}
+
+func (sec *SecretKey) GetPublicKey() *PublicKey { return &PublicKey{} }
+
+func (sec *SecretKey) SignByte(msg []byte) *Sign { return &Sign{} }
+
+func (sig *Sign) VerifyByte(pub *PublicKey, msg []byte) bool { return true }
+
+func (sig *Sign) Aggregate(sigs []Sign) {}
+
+func (sig *Sign) FastAggregateVerify(pubs []PublicKey, msg []byte) bool { return true }
+
+func (sig *Sign) Serialize() []byte { return nil }
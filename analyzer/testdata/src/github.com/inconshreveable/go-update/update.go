@@ -0,0 +1,21 @@
+// Package update is a minimal stub of github.com/inconshreveable/go-update for analyzer tests.
+package update
+
+import (
+	"crypto"
+	"io"
+)
+
+type Verifier interface{}
+
+type Options struct {
+	PublicKey crypto.PublicKey
+	Verifier  Verifier
+	Signature []byte
+}
+
+func (o *Options) SetPublicKeyPEM(pembytes []byte) error { return nil }
+
+func NewECDSAVerifier() Verifier { return nil }
+
+func Apply(update io.Reader, opts Options) error { return nil }
@@ -0,0 +1,32 @@
+package sodium
+
+type Bytes []byte
+
+type BoxPublicKey struct{ Bytes }
+type BoxSecretKey struct{ Bytes }
+type BoxKP struct {
+	PublicKey BoxPublicKey
+	SecretKey BoxSecretKey
+}
+type BoxNonce struct{ Bytes }
+
+type SignPublicKey struct{ Bytes }
+type SignSecretKey struct{ Bytes }
+type SignKP struct {
+	PublicKey SignPublicKey
+	SecretKey SignSecretKey
+}
+type Signature struct{ Bytes }
+
+type SecretBoxKey struct{ Bytes }
+type SecretBoxNonce struct{ Bytes }
+
+func MakeBoxKP() BoxKP   { return BoxKP{} }
+func MakeSignKP() SignKP { return SignKP{} }
+
+func (b Bytes) Box(n BoxNonce, pk BoxPublicKey, sk BoxSecretKey) Bytes          { return b }
+func (b Bytes) SealedBox(pk BoxPublicKey) Bytes                                 { return b }
+func (b Bytes) SignDetached(key SignSecretKey) Signature                        { return Signature{} }
+func (b Bytes) SignVerifyDetached(sig Signature, key SignPublicKey) error       { return nil }
+func (b Bytes) SecretBox(n SecretBoxNonce, key SecretBoxKey) Bytes              { return b }
+func (b Bytes) SecretBoxOpen(n SecretBoxNonce, key SecretBoxKey) (Bytes, error) { return b, nil }
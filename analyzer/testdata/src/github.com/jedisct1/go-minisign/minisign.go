@@ -0,0 +1,10 @@
+// Package minisign is a minimal stub of github.com/jedisct1/go-minisign for analyzer tests.
+package minisign
+
+type PublicKey struct{}
+
+type Signature struct{}
+
+func NewPublicKey(publicKeyStr string) (PublicKey, error) { return PublicKey{}, nil }
+
+func (publicKey *PublicKey) Verify(bin []byte, signature Signature) (bool, error) { return false, nil }
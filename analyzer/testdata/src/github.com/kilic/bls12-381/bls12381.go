@@ -0,0 +1,30 @@
+package bls12381
+
+type PointG1 [3][6]uint64
+
+type PointG2 [3][12]uint64
+
+type G1 struct{}
+
+type G2 struct{}
+
+type Engine struct {
+	G1 *G1
+	G2 *G2
+}
+
+func NewG1() *G1 { return &G1{} }
+
+func NewG2() *G2 { return &G2{} }
+
+func NewEngine() *Engine { return &Engine{G1: NewG1(), G2: NewG2()} }
+
+func (g *G1) One() *PointG1 { return &PointG1{} }
+
+func (g *G2) One() *PointG2 { return &PointG2{} }
+
+func (e *Engine) AddPair(g1 *PointG1, g2 *PointG2) *Engine { return e }
+
+func (e *Engine) AddPairInv(g1 *PointG1, g2 *PointG2) *Engine { return e }
+
+func (e *Engine) Check() bool { return true }
@@ -0,0 +1,38 @@
+package dns
+
+import "crypto"
+
+const (
+	RSASHA256       uint8 = 8
+	ECDSAP256SHA256 uint8 = 13
+	ED25519         uint8 = 15
+)
+
+type RR interface{}
+
+type RR_Header struct {
+	Name   string
+	Rrtype uint16
+}
+
+type DNSKEY struct {
+	Hdr       RR_Header
+	Flags     uint16
+	Protocol  uint8
+	Algorithm uint8
+	PublicKey string
+}
+
+func (k *DNSKEY) Generate(bits int) (crypto.PrivateKey, error) { return nil, nil }
+
+type RRSIG struct {
+	Hdr        RR_Header
+	Algorithm  uint8
+	SignerName string
+}
+
+func (rr *RRSIG) Sign(k crypto.Signer, rrset []RR) error { return nil }
+
+func (rr *RRSIG) Verify(k *DNSKEY, rrset []RR) error { return nil }
+
+func Fqdn(s string) string { return s }
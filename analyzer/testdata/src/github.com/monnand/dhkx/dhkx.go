@@ -0,0 +1,10 @@
+// Package dhkx is a minimal stub of github.com/monnand/dhkx for analyzer tests.
+package dhkx
+
+type DHGroup struct{}
+
+type DHKey struct{}
+
+func GetGroup(groupID int) (*DHGroup, error) { return nil, nil }
+
+func (g *DHGroup) GeneratePrivateKey(randReader any) (*DHKey, error) { return nil, nil }
@@ -0,0 +1,7 @@
+package fosite
+
+type Config struct {
+	AccessTokenLifespan int
+}
+
+type OAuth2Provider interface{}
@@ -0,0 +1,7 @@
+package jwt
+
+import "context"
+
+type DefaultSigner struct {
+	GetPrivateKey func(ctx context.Context) (interface{}, error)
+}
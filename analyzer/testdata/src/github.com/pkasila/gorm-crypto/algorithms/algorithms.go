@@ -0,0 +1,12 @@
+package algorithms
+
+import "crypto/rsa"
+
+type Algorithm interface {
+	Encrypt(data []byte) ([]byte, error)
+	Decrypt(data []byte) ([]byte, error)
+}
+
+func NewRSA(privateKey *rsa.PrivateKey, publicKey *rsa.PublicKey) Algorithm { return nil }
+
+func NewAES256GCM(key []byte) (Algorithm, error) { return nil, nil }
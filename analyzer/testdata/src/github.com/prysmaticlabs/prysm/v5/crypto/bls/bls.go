@@ -0,0 +1,17 @@
+package bls
+
+import "github.com/prysmaticlabs/prysm/v5/crypto/bls/common"
+
+type SecretKey = common.SecretKey
+
+type PublicKey = common.PublicKey
+
+type Signature = common.Signature
+
+func RandKey() (SecretKey, error) { return nil, nil }
+
+func SecretKeyFromBytes(privKey []byte) (SecretKey, error) { return nil, nil }
+
+func AggregateSignatures(sigs []Signature) Signature { return nil }
+
+func VerifySignature(sig []byte, msg [32]byte, pubKey PublicKey) (bool, error) { return true, nil }
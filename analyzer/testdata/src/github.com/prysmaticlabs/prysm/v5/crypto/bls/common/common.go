@@ -0,0 +1,17 @@
+package common
+
+type SecretKey interface {
+	PublicKey() PublicKey
+	Sign(msg []byte) Signature
+	Marshal() []byte
+}
+
+type PublicKey interface {
+	Marshal() []byte
+}
+
+type Signature interface {
+	Verify(pubKey PublicKey, msg []byte) bool
+	FastAggregateVerify(pubKeys []PublicKey, msg [32]byte) bool
+	Marshal() []byte
+}
@@ -0,0 +1,15 @@
+package paillier
+
+import "io"
+
+type PublicKey struct{}
+
+type PrivateKey struct{ PublicKey }
+
+func GenerateKey(random io.Reader, bits int) (*PrivateKey, error) { return &PrivateKey{}, nil }
+
+func Encrypt(pubKey *PublicKey, plainText []byte) ([]byte, error) { return plainText, nil }
+
+func Decrypt(privKey *PrivateKey, cipherText []byte) ([]byte, error) { return cipherText, nil }
+
+func AddCipher(pubKey *PublicKey, cipher1, cipher2 []byte) []byte { return cipher1 }
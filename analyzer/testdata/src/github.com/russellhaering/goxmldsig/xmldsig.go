@@ -0,0 +1,34 @@
+package dsig
+
+type X509KeyStore interface{}
+
+type SigningContext struct{}
+
+func NewDefaultSigningContext(ks X509KeyStore) *SigningContext {
+	return nil
+}
+
+func (ctx *SigningContext) SignString(content string) ([]byte, error) {
+	return nil, nil
+}
+
+type X509CertificateStore interface{}
+
+type ValidationContext struct{}
+
+func NewDefaultValidationContext(certificateStore X509CertificateStore) *ValidationContext {
+	return nil
+}
+
+func (ctx *ValidationContext) Validate(el any) (any, error) {
+	return nil, nil
+}
+
+const (
+	RSASHA256SignatureMethod   = "http://www.w3.org/2001/04/xmldsig-more#rsa-sha256"
+	ECDSASHA256SignatureMethod = "http://www.w3.org/2001/04/xmldsig-more#ecdsa-sha256"
+)
+
+func (ctx *SigningContext) SetSignatureMethod(algorithmID string) error {
+	return nil
+}
@@ -0,0 +1,13 @@
+package token
+
+import "crypto/ecdsa"
+
+type Token struct {
+	AuthKey *ecdsa.PrivateKey
+	KeyID   string
+	TeamID  string
+}
+
+func AuthKeyFromFile(filename string) (*ecdsa.PrivateKey, error) {
+	return nil, nil
+}
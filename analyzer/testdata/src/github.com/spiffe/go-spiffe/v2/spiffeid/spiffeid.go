@@ -0,0 +1,11 @@
+package spiffeid
+
+type ID struct{}
+
+func FromString(id string) (ID, error) {
+	return ID{}, nil
+}
+
+func (id ID) String() string {
+	return ""
+}
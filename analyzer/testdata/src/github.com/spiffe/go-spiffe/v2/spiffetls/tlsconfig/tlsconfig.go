@@ -0,0 +1,17 @@
+package tlsconfig
+
+import "crypto/tls"
+
+type Authorizer func() error
+
+func AuthorizeAny() Authorizer {
+	return nil
+}
+
+type SVIDSource interface{}
+
+type BundleSource interface{}
+
+func MTLSServerConfig(svid SVIDSource, bundle BundleSource, authorizer Authorizer) *tls.Config {
+	return nil
+}
@@ -0,0 +1,29 @@
+package workloadapi
+
+import (
+	"context"
+
+	"github.com/spiffe/go-spiffe/v2/svid/x509svid"
+)
+
+type X509Source struct{}
+
+func (s *X509Source) GetX509SVID() (*x509svid.SVID, error) {
+	return nil, nil
+}
+
+func (s *X509Source) Close() error {
+	return nil
+}
+
+type X509SourceOption interface{}
+
+func NewX509Source(ctx context.Context, options ...X509SourceOption) (*X509Source, error) {
+	return nil, nil
+}
+
+type ClientOption interface{}
+
+func FetchX509SVID(ctx context.Context, options ...ClientOption) (*x509svid.SVID, error) {
+	return nil, nil
+}
@@ -0,0 +1,3 @@
+package protocol
+
+type StartFunc func(sessionID []byte) (interface{}, error)
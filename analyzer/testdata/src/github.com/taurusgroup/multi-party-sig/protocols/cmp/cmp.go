@@ -0,0 +1,13 @@
+package cmp
+
+import "github.com/taurusgroup/multi-party-sig/pkg/protocol"
+
+type Config struct{}
+
+func Keygen(group interface{}, selfID string, participants []string, threshold int, pl interface{}) protocol.StartFunc {
+	return nil
+}
+
+func Sign(config *Config, signers []string, message []byte, pl interface{}) protocol.StartFunc {
+	return nil
+}
@@ -0,0 +1,15 @@
+package client
+
+type LocalStore interface{}
+
+type RemoteStore interface{}
+
+type Client struct{}
+
+func NewClient(local LocalStore, remote RemoteStore) *Client {
+	return nil
+}
+
+func (c *Client) Init(rootJSON []byte) error {
+	return nil
+}
@@ -0,0 +1,9 @@
+package keys
+
+type Signer interface {
+	Public() string
+}
+
+func GenerateEd25519Key() (Signer, error) {
+	return nil, nil
+}
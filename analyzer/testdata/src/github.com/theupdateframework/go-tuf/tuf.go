@@ -0,0 +1,17 @@
+package tuf
+
+type LocalStore interface{}
+
+type Repo struct{}
+
+func NewRepo(local LocalStore, hashAlgorithms ...string) (*Repo, error) {
+	return nil, nil
+}
+
+func (r *Repo) GenKey(role string) ([]string, error) {
+	return nil, nil
+}
+
+func (r *Repo) Commit() error {
+	return nil
+}
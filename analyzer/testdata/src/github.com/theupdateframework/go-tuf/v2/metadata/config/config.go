@@ -0,0 +1,9 @@
+package config
+
+type UpdaterConfig struct {
+	RemoteMetadataURL string
+}
+
+func New(remoteURL string, rootBytes []byte) (*UpdaterConfig, error) {
+	return nil, nil
+}
@@ -0,0 +1,6 @@
+package client
+
+type Repository interface {
+	Initialize(rootKeyIDs []string, serverManagedRoles ...string) error
+	Publish() error
+}
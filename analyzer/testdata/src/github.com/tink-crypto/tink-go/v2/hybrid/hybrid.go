@@ -0,0 +1,11 @@
+package hybrid
+
+import "github.com/tink-crypto/tink-go/v2/keyset"
+
+type Encrypt interface {
+	Encrypt(plaintext, contextInfo []byte) ([]byte, error)
+}
+
+func ECIESHKDFAES128GCMKeyTemplate() *keyset.KeyTemplate { return nil }
+
+func NewHybridEncrypt(handle *keyset.Handle) (Encrypt, error) { return nil, nil }
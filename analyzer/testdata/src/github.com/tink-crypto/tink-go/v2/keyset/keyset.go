@@ -0,0 +1,9 @@
+package keyset
+
+type Handle struct{}
+
+type KeyTemplate struct {
+	TypeUrl string
+}
+
+func NewHandle(template *KeyTemplate) (*Handle, error) { return &Handle{}, nil }
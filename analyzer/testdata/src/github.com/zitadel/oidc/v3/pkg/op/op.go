@@ -0,0 +1,13 @@
+package op
+
+type Config struct {
+	CryptoKey [32]byte
+}
+
+type Storage interface{}
+
+type Provider struct{}
+
+func NewOpenIDProvider(issuer string, config *Config, storage Storage) (*Provider, error) {
+	return nil, nil
+}
@@ -0,0 +1,15 @@
+package session
+
+type Builder struct{}
+
+func NewBuilderFromSignal(store any, remoteAddress string) *Builder { return &Builder{} }
+
+func (b *Builder) ProcessBundle(bundle any) error { return nil }
+
+type Cipher struct{}
+
+func NewCipher(builder *Builder, remoteAddress string) *Cipher { return &Cipher{} }
+
+func (c *Cipher) Encrypt(plaintext []byte) ([]byte, error) { return nil, nil }
+
+func (c *Cipher) SessionVersion() uint32 { return 3 }
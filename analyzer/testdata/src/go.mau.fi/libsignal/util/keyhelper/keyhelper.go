@@ -0,0 +1,7 @@
+package keyhelper
+
+type IdentityKeyPair struct{}
+
+func GenerateIdentityKeyPair() (*IdentityKeyPair, error) { return &IdentityKeyPair{}, nil }
+
+func GenerateRegistrationID() uint32 { return 1 }
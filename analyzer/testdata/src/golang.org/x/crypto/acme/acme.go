@@ -0,0 +1,8 @@
+package acme
+
+import "crypto"
+
+type Client struct {
+	Key          crypto.Signer
+	DirectoryURL string
+}
@@ -0,0 +1,3 @@
+package curve25519
+
+func X25519(scalar, point []byte) ([]byte, error) { return nil, nil }
@@ -0,0 +1,41 @@
+package ocsp
+
+import (
+	"crypto"
+	"crypto/x509"
+	"math/big"
+	"time"
+)
+
+const (
+	Good = iota
+	Revoked
+	Unknown
+)
+
+type RequestOptions struct {
+	Hash crypto.Hash
+}
+
+type Response struct {
+	Status       int
+	SerialNumber *big.Int
+	ThisUpdate   time.Time
+	NextUpdate   time.Time
+}
+
+func CreateRequest(cert, issuer *x509.Certificate, opts *RequestOptions) ([]byte, error) {
+	return nil, nil
+}
+
+func CreateResponse(issuer, responderCert *x509.Certificate, template Response, priv crypto.Signer) ([]byte, error) {
+	return nil, nil
+}
+
+func ParseResponse(bytes []byte, issuer *x509.Certificate) (*Response, error) {
+	return nil, nil
+}
+
+func ParseResponseForCert(bytes []byte, cert, issuer *x509.Certificate) (*Response, error) {
+	return nil, nil
+}
@@ -0,0 +1,7 @@
+package pkcs12
+
+import "encoding/pem"
+
+func ToPEM(pfxData []byte, password string) ([]*pem.Block, error) {
+	return nil, nil
+}
@@ -0,0 +1,7 @@
+// Package compute is a minimal stub of the Compute Engine API for analyzer tests.
+package compute
+
+type CustomerEncryptionKey struct {
+	RawKey          string
+	RsaEncryptedKey string
+}
@@ -0,0 +1,84 @@
+package highvolume
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa" // want `"crypto/ecdsa" uses quantum-vulnerable elliptic curve cryptography`
+	"crypto/rand"
+	"crypto/rsa" // want `"crypto/rsa" uses quantum-vulnerable integer factorization cryptography`
+	"sync"
+
+	"golang.org/x/crypto/ssh"
+	"golang.org/x/sync/errgroup"
+)
+
+func signBatch(key *rsa.PrivateKey, digests [][]byte) ([][]byte, error) {
+	var signatures [][]byte
+	for _, digest := range digests {
+		signature, err := rsa.SignPKCS1v15(rand.Reader, key, crypto.SHA256, digest) // want `function "rsa.SignPKCS1v15" implements quantum-vulnerable cryptography`
+		if err != nil {
+			return nil, err
+		}
+		signatures = append(signatures, signature)
+	}
+	return signatures, nil
+}
+
+func signWorkers(key *ecdsa.PrivateKey, jobs <-chan []byte, results chan<- []byte) {
+	var wg sync.WaitGroup
+	for range 4 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for digest := range jobs {
+				signature, _ := ecdsa.SignASN1(rand.Reader, key, digest) // want `function "ecdsa.SignASN1" implements quantum-vulnerable cryptography`
+				results <- signature
+			}
+		}()
+	}
+	wg.Wait()
+}
+
+func decryptAll(ctx context.Context, key *rsa.PrivateKey, ciphertexts [][]byte) error {
+	g, ctx := errgroup.WithContext(ctx)
+	for _, ciphertext := range ciphertexts {
+		g.Go(func() error {
+			_, err := rsa.DecryptPKCS1v15(rand.Reader, key, ciphertext) // want `function "rsa.DecryptPKCS1v15" implements quantum-vulnerable cryptography`
+			return err
+		})
+	}
+	return g.Wait()
+}
+
+func signOnce(key *rsa.PrivateKey, digest []byte) ([]byte, error) {
+	return rsa.SignPKCS1v15(rand.Reader, key, crypto.SHA256, digest) // want `function "rsa.SignPKCS1v15" implements quantum-vulnerable cryptography`
+}
+
+func verifyBatch(pub *rsa.PublicKey, digests, signatures [][]byte) error {
+	for i := range digests {
+		if err := rsa.VerifyPKCS1v15(pub, crypto.SHA256, digests[i], signatures[i]); err != nil { // want `function "rsa.VerifyPKCS1v15" implements quantum-vulnerable cryptography`
+			return err
+		}
+	}
+	return nil
+}
+
+func signLoopHeader(key *rsa.PrivateKey, digest []byte) {
+	for _, b := range must(rsa.SignPKCS1v15(rand.Reader, key, crypto.SHA256, digest)) { // want `function "rsa.SignPKCS1v15" implements quantum-vulnerable cryptography`
+		_ = b
+	}
+}
+
+func must(b []byte, err error) []byte {
+	return b
+}
+
+// Creating signers is not signing.
+func certSigners(certs []*ssh.Certificate, ca ssh.Signer) []ssh.Signer {
+	var signers []ssh.Signer
+	for _, cert := range certs {
+		signer, _ := ssh.NewCertSigner(cert, ca) // want `function "ssh.NewCertSigner" is part of quantum-vulnerable SSH certificate authority infrastructure`
+		signers = append(signers, signer)
+	}
+	return signers
+}
@@ -0,0 +1,12 @@
+package client
+
+import (
+	"crypto/ecdsa" // want `"crypto/ecdsa" uses quantum-vulnerable elliptic curve cryptography`
+
+	"github.com/go-jose/go-jose/v4"
+)
+
+// Client assertions are signed by relying parties, not identity providers.
+func newAssertionSigner(key *ecdsa.PrivateKey) (jose.Signer, error) {
+	return jose.NewSigner(jose.SigningKey{Algorithm: jose.ES256, Key: key}, nil)
+}
@@ -0,0 +1,40 @@
+package idp
+
+import (
+	"context"
+	"crypto/rsa" // want `"crypto/rsa" uses quantum-vulnerable integer factorization cryptography`
+
+	"github.com/go-jose/go-jose/v4"
+	"github.com/ory/fosite"
+	"github.com/ory/fosite/compose"
+	"github.com/ory/fosite/token/jwt"
+	"github.com/zitadel/oidc/v3/pkg/op"
+)
+
+func newFositeProvider(key *rsa.PrivateKey, storage interface{}) fosite.OAuth2Provider {
+	return compose.ComposeAllEnabled(&fosite.Config{}, storage, key) // want `"compose.ComposeAllEnabled" configures an OAuth2 or OpenID Connect provider signing tokens with classical keys`
+}
+
+func newSigner(key *rsa.PrivateKey) *jwt.DefaultSigner {
+	return &jwt.DefaultSigner{
+		GetPrivateKey: func(ctx context.Context) (interface{}, error) { // want `field "jwt.DefaultSigner.GetPrivateKey" sets the key an OAuth2 or OpenID Connect provider signs tokens with`
+			return key, nil
+		},
+	}
+}
+
+func newZitadelProvider(storage op.Storage) (*op.Provider, error) {
+	return op.NewOpenIDProvider("https://id.example.com", &op.Config{}, storage) // want `"op.NewOpenIDProvider" configures an OAuth2 or OpenID Connect provider`
+}
+
+func newIDTokenSigner(key *rsa.PrivateKey) (jose.Signer, error) {
+	return jose.NewSigner(jose.SigningKey{Algorithm: jose.RS256, Key: key}, nil) // want `"jose.NewSigner" builds a RS256 token signer in an identity provider`
+}
+
+func newKeySigner(signingKey jose.SigningKey) (jose.Signer, error) {
+	return jose.NewSigner(signingKey, nil) // want `"jose.NewSigner" builds a classical token signer in an identity provider`
+}
+
+func newSessionSigner(secret []byte) (jose.Signer, error) {
+	return jose.NewSigner(jose.SigningKey{Algorithm: jose.HS256, Key: secret}, nil)
+}
@@ -0,0 +1,19 @@
+package ignoreuntil
+
+import (
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa" // want `"crypto/rsa" uses quantum-vulnerable integer factorization cryptography`
+)
+
+// vendorSign signs with the key of a vendor SDK being upgraded.
+//
+//pqc:ignore-until=2026-06-30 reason=vendor SDK upgrade in Q2
+func vendorSign(key *rsa.PrivateKey, digest []byte) ([]byte, error) {
+	return rsa.SignPSS(rand.Reader, key, crypto.SHA256, digest, nil) // want `function "rsa.SignPSS" implements quantum-vulnerable cryptography$`
+}
+
+//pqc:ignore-until=end-of-quarter reason=malformed dates are not exceptions
+func malformedSign(key *rsa.PrivateKey, digest []byte) ([]byte, error) {
+	return rsa.SignPSS(rand.Reader, key, crypto.SHA256, digest, nil) // want `function "rsa.SignPSS" implements quantum-vulnerable cryptography$`
+}
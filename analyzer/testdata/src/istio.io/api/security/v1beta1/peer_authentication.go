@@ -0,0 +1,13 @@
+package v1beta1
+
+type PeerAuthentication_MutualTLS_Mode int32
+
+const PeerAuthentication_MutualTLS_STRICT PeerAuthentication_MutualTLS_Mode = 2
+
+type PeerAuthentication_MutualTLS struct {
+	Mode PeerAuthentication_MutualTLS_Mode
+}
+
+type PeerAuthentication struct {
+	Mtls *PeerAuthentication_MutualTLS
+}
@@ -0,0 +1,35 @@
+package jwk
+
+import (
+	"crypto"
+	"crypto/ecdsa" // want `"crypto/ecdsa" uses quantum-vulnerable elliptic curve cryptography`
+	"crypto/rsa"   // want `"crypto/rsa" uses quantum-vulnerable integer factorization cryptography`
+	"encoding/json"
+	"net/http"
+
+	"github.com/go-jose/go-jose/v4"
+	"github.com/lestrrat-go/jwx/v2/jwk"
+)
+
+func fromRSA(key *rsa.PrivateKey) (jwk.Key, error) {
+	return jwk.FromRaw(key) // want `function "jwk.FromRaw" builds a JWK from quantum-vulnerable key type \*rsa.PrivateKey`
+}
+
+func fromSigner(key crypto.Signer) (jwk.Key, error) {
+	return jwk.FromRaw(key)
+}
+
+func keySet(pub *ecdsa.PublicKey) jose.JSONWebKeySet {
+	return jose.JSONWebKeySet{Keys: []jose.JSONWebKey{{
+		Key:       pub, // want `field "jose.JSONWebKey.Key" builds a JWK from quantum-vulnerable key type \*ecdsa.PublicKey`
+		KeyID:     "1",
+		Algorithm: "ES256",
+	}}}
+}
+
+func serve(mux *http.ServeMux, set jose.JSONWebKeySet) {
+	mux.HandleFunc("/.well-known/jwks.json", func(w http.ResponseWriter, r *http.Request) { // want `method "http.ServeMux.HandleFunc" serves the JWKS endpoint "/.well-known/jwks.json"`
+		json.NewEncoder(w).Encode(set)
+	})
+	http.HandleFunc("/healthz", func(w http.ResponseWriter, r *http.Request) {})
+}
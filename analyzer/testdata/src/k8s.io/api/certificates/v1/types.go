@@ -0,0 +1,10 @@
+package v1
+
+type CertificateSigningRequest struct {
+	Spec CertificateSigningRequestSpec
+}
+
+type CertificateSigningRequestSpec struct {
+	Request    []byte
+	SignerName string
+}
@@ -0,0 +1,7 @@
+package cert
+
+import "crypto/x509/pkix"
+
+func MakeCSR(privateKey any, subject *pkix.Name, dnsSANs []string, ipSANs []any) ([]byte, error) {
+	return nil, nil
+}
@@ -0,0 +1,15 @@
+package certificate
+
+import "crypto/tls"
+
+type Manager interface {
+	Start()
+	Stop()
+	Current() *tls.Certificate
+}
+
+type Config struct {
+	SignerName string
+}
+
+func NewManager(config *Config) (Manager, error) { return nil, nil }
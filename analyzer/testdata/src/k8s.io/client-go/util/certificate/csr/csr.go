@@ -0,0 +1,7 @@
+package csr
+
+import "time"
+
+func RequestCertificate(client any, csrData []byte, name, signerName string, expirationDuration *time.Duration, usages []string, privateKey any) (string, string, error) {
+	return "", "", nil
+}
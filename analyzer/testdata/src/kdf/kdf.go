@@ -0,0 +1,49 @@
+package kdf
+
+import (
+	"crypto/ecdh" // want `"crypto/ecdh" uses quantum-vulnerable elliptic curve cryptography`
+	"crypto/hkdf"
+	"crypto/mlkem"
+	"crypto/sha256"
+	"io"
+
+	"golang.org/x/crypto/curve25519"
+	xhkdf "golang.org/x/crypto/hkdf"
+)
+
+func sessionKey(priv *ecdh.PrivateKey, peer *ecdh.PublicKey) ([]byte, error) {
+	shared, err := priv.ECDH(peer)
+	if err != nil {
+		return nil, err
+	}
+	return hkdf.Key(sha256.New, shared, nil, "session", 32) // want `function "hkdf.Key" derives keys from the classical shared secret shared; insert an ML-KEM encapsulation here`
+}
+
+func sharedSecret(scalar, point []byte) ([]byte, error) {
+	return curve25519.X25519(scalar, point)
+}
+
+func streamKey(scalar, point []byte) ([]byte, error) {
+	secret, err := sharedSecret(scalar, point)
+	if err != nil {
+		return nil, err
+	}
+	ikm := append([]byte("v1"), secret...)
+	key := make([]byte, 32)
+	_, err = io.ReadFull(xhkdf.New(sha256.New, ikm, nil, nil), key) // want `function "xhkdf.New" derives keys from the classical shared secret ikm`
+	return key, err
+}
+
+func hybridKey(priv *ecdh.PrivateKey, peer *ecdh.PublicKey, ek *mlkem.EncapsulationKey768) ([]byte, []byte, error) {
+	classical, err := priv.ECDH(peer)
+	if err != nil {
+		return nil, nil, err
+	}
+	pq, ciphertext := ek.Encapsulate()
+	key, err := hkdf.Key(sha256.New, append(pq, classical...), nil, "hybrid", 32)
+	return key, ciphertext, err
+}
+
+func passwordKey(password, salt []byte) []byte {
+	return xhkdf.Extract(sha256.New, password, salt)
+}
@@ -0,0 +1,26 @@
+package keylogging
+
+import (
+	"crypto/ecdsa" // want `"crypto/ecdsa" uses quantum-vulnerable elliptic curve cryptography`
+	"crypto/x509"
+	"fmt"
+	"log"
+	"log/slog"
+)
+
+func debug(key *ecdsa.PrivateKey) {
+	log.Printf("loaded key %v", key) // want `private key material key flows into logging sink "log.Printf"`
+
+	der, _ := x509.MarshalECPrivateKey(key) // want `function "x509.MarshalECPrivateKey" implements quantum-vulnerable cryptography`
+	encoded := fmt.Sprintf("%x", der)
+	slog.Info("key", "der", encoded) // want `private key material encoded flows into logging sink "slog.Info"`
+
+	fmt.Println(key.D.String()) // want `private key material key flows into logging sink "fmt.Println"`
+
+	// The public half of the key is not secret.
+	fmt.Println(key.PublicKey.X, key.Curve.Params().Name, key.X, key.Public())
+}
+
+func fingerprint(pub *ecdsa.PublicKey, logger *log.Logger) {
+	logger.Println("public key", pub.X)
+}
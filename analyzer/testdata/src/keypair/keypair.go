@@ -0,0 +1,26 @@
+package keypair
+
+import (
+	"crypto/tls"
+	"os"
+)
+
+func serverCertificate() (tls.Certificate, error) {
+	return tls.LoadX509KeyPair("certs/server.crt", "certs/server.key") // want `function "tls.LoadX509KeyPair" loads a TLS certificate and private key pair` `certificate file "certs/server.crt" holds a quantum-vulnerable RSA-2048 key` `private key file "certs/server.key" holds a quantum-vulnerable RSA-2048 key`
+}
+
+func systemCertificate() (tls.Certificate, error) {
+	return tls.LoadX509KeyPair("/etc/ssl/certs/server.crt", "/etc/ssl/private/server.key") // want `function "tls.LoadX509KeyPair" loads a TLS certificate and private key pair`
+}
+
+func embeddedCertificate(certFile, keyFile string) (tls.Certificate, error) {
+	certPEM, err := os.ReadFile(certFile)
+	if err != nil {
+		return tls.Certificate{}, err
+	}
+	keyPEM, err := os.ReadFile(keyFile)
+	if err != nil {
+		return tls.Certificate{}, err
+	}
+	return tls.X509KeyPair(certPEM, keyPEM) // want `function "tls.X509KeyPair" loads a TLS certificate and private key pair`
+}
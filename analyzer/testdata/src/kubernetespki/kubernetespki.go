@@ -0,0 +1,28 @@
+package kubernetespki
+
+import (
+	"crypto/x509/pkix"
+
+	certificatesv1 "k8s.io/api/certificates/v1"
+	"k8s.io/client-go/util/cert"
+	"k8s.io/client-go/util/certificate"
+	"k8s.io/client-go/util/keyutil"
+)
+
+func rotate() (certificate.Manager, error) {
+	return certificate.NewManager(&certificate.Config{SignerName: "kubernetes.io/kube-apiserver-client"}) // want `function "certificate.NewManager" creates or rotates Kubernetes certificates` `type "certificate.Config" creates or rotates Kubernetes certificates`
+}
+
+func request() (*certificatesv1.CertificateSigningRequest, error) {
+	keyPEM, err := keyutil.MakeEllipticPrivateKeyPEM() // want `function "keyutil.MakeEllipticPrivateKeyPEM" creates or rotates Kubernetes certificates`
+	if err != nil {
+		return nil, err
+	}
+	csrPEM, err := cert.MakeCSR(keyPEM, &pkix.Name{CommonName: "operator"}, nil, nil) // want `function "cert.MakeCSR" creates or rotates Kubernetes certificates`
+	if err != nil {
+		return nil, err
+	}
+	return &certificatesv1.CertificateSigningRequest{ // want `type "certificatesv1.CertificateSigningRequest" creates or rotates Kubernetes certificates`
+		Spec: certificatesv1.CertificateSigningRequestSpec{Request: csrPEM},
+	}, nil
+}
@@ -0,0 +1,30 @@
+package libsodium
+
+import (
+	"github.com/GoKillers/libsodium-go/cryptobox"
+	"github.com/GoKillers/libsodium-go/cryptosecretbox"
+	"github.com/jamesruan/sodium"
+)
+
+func seal(message []byte) ([]byte, error) {
+	pk, _, _ := cryptobox.CryptoBoxKeyPair()          // want `function "cryptobox.CryptoBoxKeyPair" performs quantum-vulnerable X25519 key exchange through libsodium`
+	sealed, _ := cryptobox.CryptoBoxSeal(message, pk) // want `function "cryptobox.CryptoBoxSeal" performs quantum-vulnerable X25519 key exchange through libsodium`
+	return sealed, nil
+}
+
+func encrypt(message, nonce, key []byte) []byte {
+	ciphertext, _ := cryptosecretbox.CryptoSecretBoxEasy(message, nonce, key)
+	return ciphertext
+}
+
+func sign(message sodium.Bytes) error {
+	kp := sodium.MakeSignKP()                            // want `function "sodium.MakeSignKP" generates keys or signs with quantum-vulnerable Ed25519 through libsodium`
+	sig := message.SignDetached(kp.SecretKey)            // want `method "sodium.Bytes.SignDetached" generates keys or signs with quantum-vulnerable Ed25519 through libsodium`
+	return message.SignVerifyDetached(sig, kp.PublicKey) // want `method "sodium.Bytes.SignVerifyDetached" verifies quantum-vulnerable Ed25519 signatures through libsodium`
+}
+
+func box(message sodium.Bytes, nonce sodium.BoxNonce, key sodium.SecretBoxKey, secretNonce sodium.SecretBoxNonce) sodium.Bytes {
+	kp := sodium.MakeBoxKP()                                // want `function "sodium.MakeBoxKP" performs quantum-vulnerable X25519 key exchange`
+	boxed := message.Box(nonce, kp.PublicKey, kp.SecretKey) // want `method "sodium.Bytes.Box" performs quantum-vulnerable X25519 key exchange`
+	return boxed.SecretBox(secretNonce, key)
+}
@@ -0,0 +1,59 @@
+package mesh
+
+import (
+	"context"
+	"crypto/ecdsa"    // want `"crypto/ecdsa" uses quantum-vulnerable elliptic curve cryptography`
+	"crypto/elliptic" // want `"crypto/elliptic" uses quantum-vulnerable elliptic curve cryptography`
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"net/url"
+
+	tlsv3 "github.com/envoyproxy/go-control-plane/envoy/extensions/transport_sockets/tls/v3"
+	"github.com/spiffe/go-spiffe/v2/spiffeid"
+	"github.com/spiffe/go-spiffe/v2/spiffetls/tlsconfig"
+	"github.com/spiffe/go-spiffe/v2/workloadapi"
+	securityv1beta1 "istio.io/api/security/v1beta1"
+)
+
+func serverConfig(ctx context.Context) (*tls.Config, error) {
+	source, err := workloadapi.NewX509Source(ctx) // want `function "workloadapi.NewX509Source" fetches or configures service mesh mTLS identity with classical X.509 SVIDs`
+	if err != nil {
+		return nil, err
+	}
+	return tlsconfig.MTLSServerConfig(source, source, tlsconfig.AuthorizeAny()), nil // want `function "tlsconfig.MTLSServerConfig" fetches or configures service mesh mTLS identity`
+}
+
+func listener() *tlsv3.DownstreamTlsContext {
+	return &tlsv3.DownstreamTlsContext{ // want `type "tlsv3.DownstreamTlsContext" fetches or configures service mesh mTLS identity`
+		CommonTlsContext: &tlsv3.CommonTlsContext{}, // want `type "tlsv3.CommonTlsContext" fetches or configures service mesh mTLS identity`
+	}
+}
+
+func strictMTLS() *securityv1beta1.PeerAuthentication {
+	return &securityv1beta1.PeerAuthentication{ // want `type "securityv1beta1.PeerAuthentication" fetches or configures service mesh mTLS identity`
+		Mtls: &securityv1beta1.PeerAuthentication_MutualTLS{Mode: securityv1beta1.PeerAuthentication_MutualTLS_STRICT}, // want `type "securityv1beta1.PeerAuthentication_MutualTLS" fetches or configures service mesh mTLS identity`
+	}
+}
+
+func mintSVID(ca *x509.Certificate, caKey any) ([]byte, error) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		return nil, err
+	}
+	id, err := url.Parse("spiffe://example.org/workload")
+	if err != nil {
+		return nil, err
+	}
+	template := &x509.Certificate{URIs: []*url.URL{id}}
+	return x509.CreateCertificate(rand.Reader, template, ca, &key.PublicKey, caKey) // want `function mintSVID mints X.509 SVIDs for SPIFFE IDs with ECDSA keys; mesh identities are issued and rotated centrally`
+}
+
+func mintForID(id spiffeid.ID, template, ca *x509.Certificate, pub, caKey any) ([]byte, error) {
+	template.Subject.CommonName = id.String()
+	return x509.CreateCertificate(rand.Reader, template, ca, pub, caKey) // want `function mintForID mints X.509 SVIDs for SPIFFE IDs with classical keys`
+}
+
+func selfSigned(template *x509.Certificate, pub, key any) ([]byte, error) {
+	return x509.CreateCertificate(rand.Reader, template, template, pub, key)
+}
@@ -0,0 +1,22 @@
+package messages
+
+import (
+	"crypto"
+	"crypto/ecdsa" // want `Der Import crypto/ecdsa \(PQC001\) muss bis 2030-12-31 durch ML-KEM \(crypto/mlkem\) for key exchange and ML-DSA for signatures ersetzt werden`
+	"crypto/rand"
+	"crypto/rsa" // want `Der Import crypto/rsa \(PQC002\) muss bis 2030-12-31 durch ML-KEM ersetzt werden`
+)
+
+//pqc:ignore-until=2026-06-30 reason=vendor SDK upgrade in Q2
+func vendorSign(key *rsa.PrivateKey, digest []byte) ([]byte, error) {
+	return rsa.SignPSS(rand.Reader, key, crypto.SHA256, digest, nil) // want `^rsa.SignPSS: replace with ML-DSA by 2026-06-30$`
+}
+
+func verify(key *ecdsa.PublicKey, digest, sig []byte) bool {
+	return ecdsa.VerifyASN1(key, digest, sig) // want `^ecdsa.VerifyASN1: replace with ML-DSA by 2030-12-31$`
+}
+
+//pqc:compat partner gateway cannot verify ML-DSA yet
+func partnerVerify(key *ecdsa.PublicKey, digest, sig []byte) bool {
+	return ecdsa.VerifyASN1(key, digest, sig) // want `^ecdsa.VerifyASN1: replace with ML-DSA by 2030-12-31 \(akzeptierte Interop-Schuld\)$`
+}
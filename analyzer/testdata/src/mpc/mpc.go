@@ -0,0 +1,56 @@
+package mpc
+
+import (
+	"context"
+	"crypto/rand"
+	"math/big"
+
+	tsspaillier "github.com/bnb-chain/tss-lib/v2/crypto/paillier"
+	"github.com/bnb-chain/tss-lib/v2/ecdsa/keygen"
+	"github.com/bnb-chain/tss-lib/v2/ecdsa/signing"
+	"github.com/bnb-chain/tss-lib/v2/tss"
+	paillier "github.com/roasbeef/go-go-gadget-paillier"
+	"github.com/taurusgroup/multi-party-sig/pkg/protocol"
+	"github.com/taurusgroup/multi-party-sig/protocols/cmp"
+)
+
+func startKeygen(params *tss.Parameters, out chan<- tss.Message, end chan<- *keygen.LocalPartySaveData) (tss.Party, error) {
+	preParams, err := keygen.GeneratePreParams(60) // want `function "keygen.GeneratePreParams" runs a threshold signing or other multi-party computation protocol`
+	if err != nil {
+		return nil, err
+	}
+	party := keygen.NewLocalParty(params, out, end, *preParams) // want `function "keygen.NewLocalParty" runs a threshold signing or other multi-party computation protocol`
+	return party, party.Start()
+}
+
+func startSigning(digest *big.Int, params *tss.Parameters, key keygen.LocalPartySaveData, out chan<- tss.Message, end chan<- *signing.SignatureData) tss.Party {
+	return signing.NewLocalParty(digest, params, key, out, end) // want `function "signing.NewLocalParty" runs a threshold signing or other multi-party computation protocol built on elliptic curve discrete logarithms and Paillier proofs; there is no drop-in post-quantum replacement, so the protocol needs specialist cryptographic review`
+}
+
+func sumCiphertexts(ctx context.Context, a, b *big.Int) (*big.Int, error) {
+	priv, pub, err := tsspaillier.GenerateKeyPair(ctx, 2048) // want `function "tsspaillier.GenerateKeyPair" uses Paillier homomorphic encryption`
+	if err != nil {
+		return nil, err
+	}
+	ca, _ := pub.Encrypt(a)       // want `method "paillier.PublicKey.Encrypt" uses Paillier homomorphic encryption`
+	cb, _ := pub.Encrypt(b)       // want `method "paillier.PublicKey.Encrypt" uses Paillier homomorphic encryption`
+	sum, _ := pub.HomoAdd(ca, cb) // want `method "paillier.PublicKey.HomoAdd" uses Paillier homomorphic encryption`
+	_ = pub.Gamma()
+	return priv.Decrypt(sum) // want `method "paillier.PrivateKey.Decrypt" uses Paillier homomorphic encryption`
+}
+
+func tally(votes [][]byte) ([]byte, error) {
+	key, err := paillier.GenerateKey(rand.Reader, 2048) // want `function "paillier.GenerateKey" uses Paillier homomorphic encryption`
+	if err != nil {
+		return nil, err
+	}
+	total, _ := paillier.Encrypt(&key.PublicKey, []byte{0}) // want `function "paillier.Encrypt" uses Paillier homomorphic encryption`
+	for _, vote := range votes {
+		total = paillier.AddCipher(&key.PublicKey, total, vote) // want `function "paillier.AddCipher" uses Paillier homomorphic encryption`
+	}
+	return paillier.Decrypt(key, total) // want `function "paillier.Decrypt" uses Paillier homomorphic encryption, built on integer factorization; there is no drop-in post-quantum additively homomorphic scheme, so its use needs specialist cryptographic review`
+}
+
+func cmpSign(config *cmp.Config, signers []string, msg []byte) protocol.StartFunc {
+	return cmp.Sign(config, signers, msg, nil) // want `function "cmp.Sign" runs a threshold signing or other multi-party computation protocol`
+}
@@ -0,0 +1,59 @@
+package nonce
+
+import (
+	"bytes"
+	"crypto/ecdsa"    // want `"crypto/ecdsa" uses quantum-vulnerable elliptic curve cryptography`
+	"crypto/elliptic" // want `"crypto/elliptic" uses quantum-vulnerable elliptic curve cryptography`
+	"crypto/rand"
+	"crypto/sha256"
+	"io"
+	"math/big"
+
+	"github.com/codahale/rfc6979"
+	"github.com/decred/dcrd/dcrec/secp256k1/v4"
+	secpecdsa "github.com/decred/dcrd/dcrec/secp256k1/v4/ecdsa"
+)
+
+type drbg struct {
+	seed []byte
+}
+
+func (d *drbg) Read(p []byte) (int, error) {
+	return copy(p, d.seed), nil
+}
+
+func signRFC6979(priv *ecdsa.PrivateKey, digest []byte) (*big.Int, *big.Int, error) {
+	return rfc6979.SignECDSA(priv, digest, sha256.New) // want `"rfc6979.SignECDSA" is a third-party deterministic ECDSA \(RFC 6979\) implementation`
+}
+
+func signSecp256k1(key *secp256k1.PrivateKey, digest []byte) *secpecdsa.Signature {
+	return secpecdsa.Sign(key, digest) // want `"secpecdsa.Sign" is a third-party deterministic ECDSA \(RFC 6979\) implementation`
+}
+
+func signSeeded(priv *ecdsa.PrivateKey, digest []byte) ([]byte, error) {
+	return ecdsa.SignASN1(bytes.NewReader(digest), priv, digest) // want `function "ecdsa.SignASN1" implements` `"ecdsa.SignASN1" draws its nonce from bytes.NewReader\(digest\) instead of crypto/rand.Reader`
+}
+
+func signDRBG(priv *ecdsa.PrivateKey, digest []byte) (*big.Int, *big.Int, error) {
+	reader := &drbg{seed: digest}
+	return ecdsa.Sign(reader, priv, digest) // want `"ecdsa.Sign" draws its nonce from reader instead of crypto/rand.Reader`
+}
+
+func signRandom(priv *ecdsa.PrivateKey, digest []byte) ([]byte, error) {
+	return ecdsa.SignASN1(rand.Reader, priv, digest) // want `function "ecdsa.SignASN1" implements`
+}
+
+func signWith(random io.Reader, priv *ecdsa.PrivateKey, digest []byte) ([]byte, error) {
+	return priv.Sign(random, digest, nil)
+}
+
+func signManually(priv *ecdsa.PrivateKey, k *big.Int, digest []byte) (*big.Int, *big.Int) { // want `function signManually computes elliptic curve points` `function signManually computes ECDSA signatures from its own nonces`
+	curve := elliptic.P256()
+	n := curve.Params().N
+	x, _ := curve.ScalarBaseMult(k.Bytes()) // want `method "elliptic.Curve.ScalarBaseMult" performs low-level`
+	r := new(big.Int).Mod(x, n)
+	s := new(big.Int).Mul(r, priv.D)
+	s.Add(s, new(big.Int).SetBytes(digest))
+	s.Mul(s, new(big.Int).ModInverse(k, n))
+	return r, s.Mod(s, n)
+}
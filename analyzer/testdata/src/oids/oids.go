@@ -0,0 +1,16 @@
+package oids
+
+import "encoding/asn1"
+
+const arcRSA = 113549
+
+var (
+	oidPublicKeyRSA     = asn1.ObjectIdentifier{1, 2, 840, arcRSA, 1, 1, 1} // want `ASN.1 object identifier 1.2.840.113549.1.1.1 identifies quantum-vulnerable RSA \(PKCS #1\)`
+	oidPublicKeyECDSA   = asn1.ObjectIdentifier{1, 2, 840, 10045, 2, 1}     // want `ASN.1 object identifier 1.2.840.10045.2.1 identifies quantum-vulnerable elliptic curve \(ANSI X9.62\)`
+	oidPublicKeyEd25519 = asn1.ObjectIdentifier{1, 3, 101, 112}             // want `ASN.1 object identifier 1.3.101.112 identifies quantum-vulnerable Ed25519`
+	oidMLDSA65          = asn1.ObjectIdentifier{2, 16, 840, 1, 101, 3, 4, 3, 18}
+)
+
+const oidSHA256WithRSA = "1.2.840.113549.1.1.11" // want `ASN.1 OID string 1.2.840.113549.1.1.11 identifies quantum-vulnerable RSA \(PKCS #1\)`
+
+const version = "1.2.840"
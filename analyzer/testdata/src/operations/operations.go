@@ -0,0 +1,16 @@
+package operations
+
+import (
+	"crypto"
+	"crypto/rsa" // want `"crypto/rsa" uses quantum-vulnerable integer factorization cryptography`
+	"crypto/x509"
+)
+
+func roundTrip(priv *rsa.PrivateKey, digest []byte) error {
+	sig, err := rsa.SignPSS(nil, priv, crypto.SHA256, digest, nil) // want `function "rsa.SignPSS" implements quantum-vulnerable cryptography`
+	if err != nil {
+		return err
+	}
+	_ = x509.MarshalPKCS1PrivateKey(priv)                                  // want `function "x509.MarshalPKCS1PrivateKey" implements quantum-vulnerable cryptography`
+	return rsa.VerifyPSS(&priv.PublicKey, crypto.SHA256, digest, sig, nil) // want `function "rsa.VerifyPSS" implements quantum-vulnerable cryptography`
+}
@@ -0,0 +1,39 @@
+package pairing
+
+import (
+	herumi "github.com/herumi/bls-eth-go-binary/bls"
+	bls12381 "github.com/kilic/bls12-381"
+	"github.com/prysmaticlabs/prysm/v5/crypto/bls"
+)
+
+func validatorKey() (*herumi.SecretKey, error) {
+	// Setting up the library computes nothing yet.
+	if err := herumi.Init(herumi.BLS12_381); err != nil {
+		return nil, err
+	}
+	var sec herumi.SecretKey
+	sec.SetByCSPRNG() // want `method "bls.SecretKey.SetByCSPRNG" signs, verifies or aggregates BLS signatures`
+	return &sec, nil
+}
+
+func attest(sec *herumi.SecretKey, msg []byte) []byte {
+	sig := sec.SignByte(msg) // want `method "bls.SecretKey.SignByte" signs, verifies or aggregates BLS signatures`
+	return sig.Serialize()
+}
+
+func aggregate(sigs []bls.Signature, pub bls.PublicKey, msg [32]byte) bool {
+	agg := bls.AggregateSignatures(sigs) // want `function "bls.AggregateSignatures" signs, verifies or aggregates BLS signatures`
+	_ = agg.Marshal()
+	return agg.Verify(pub, msg[:]) // want `method "common.Signature.Verify" signs, verifies or aggregates BLS signatures or computes elliptic curve pairings, which are quantum-vulnerable like every discrete-logarithm scheme; there is no drop-in post-quantum replacement with aggregation`
+}
+
+func propose(key bls.SecretKey, root []byte) bls.Signature {
+	return key.Sign(root) // want `method "common.SecretKey.Sign" signs, verifies or aggregates BLS signatures`
+}
+
+func pairingCheck(a *bls12381.PointG1, b *bls12381.PointG2) bool {
+	engine := bls12381.NewEngine()                      // want `function "bls12381.NewEngine" signs, verifies or aggregates BLS signatures or computes elliptic curve pairings`
+	engine.AddPair(a, b)                                // want `method "bls12381.Engine.AddPair" signs, verifies or aggregates BLS signatures or computes elliptic curve pairings`
+	engine.AddPairInv(engine.G1.One(), engine.G2.One()) // want `method "bls12381.Engine.AddPairInv" signs`
+	return engine.Check()                               // want `method "bls12381.Engine.Check" signs`
+}
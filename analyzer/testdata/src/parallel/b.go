@@ -0,0 +1,7 @@
+package parallel
+
+import "crypto/rsa" // want `"crypto/rsa" uses quantum-vulnerable integer factorization cryptography`
+
+func signb(key *rsa.PrivateKey, digest []byte) ([]byte, error) {
+	return rsa.SignPSS(nil, key, 0, digest, nil) // want `function "rsa.SignPSS" implements quantum-vulnerable cryptography`
+}
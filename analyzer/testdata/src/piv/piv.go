@@ -0,0 +1,31 @@
+package piv
+
+import (
+	"crypto"
+	"crypto/rand"
+
+	"github.com/go-piv/piv-go/v2/piv"
+	"github.com/paultag/go-ykpiv"
+)
+
+func enroll(yk *piv.YubiKey) (crypto.PublicKey, error) {
+	return yk.GenerateKey(piv.DefaultManagementKey, piv.SlotAuthentication, piv.Key{ // want `method "piv.YubiKey.GenerateKey" generates or uses a classical RSA or ECDSA key in a PIV smart card slot`
+		Algorithm: piv.AlgorithmEC256, // want `algorithm "piv.AlgorithmEC256" puts a quantum-vulnerable ECDSA P-256 key on a PIV smart card`
+	})
+}
+
+func signer(yk *piv.YubiKey, public crypto.PublicKey) (crypto.PrivateKey, error) {
+	return yk.PrivateKey(piv.SlotAuthentication, public, piv.KeyAuth{PIN: "123456"}) // want `method "piv.YubiKey.PrivateKey" generates or uses a classical RSA or ECDSA key`
+}
+
+func serial(yk *piv.YubiKey) (uint32, error) {
+	return yk.Serial()
+}
+
+func generate(yk ykpiv.Yubikey) (crypto.PublicKey, error) {
+	return yk.GenerateEC(ykpiv.Authentication, 256) // want `method "ykpiv.Yubikey.GenerateEC" generates or uses a classical RSA or ECDSA key`
+}
+
+func sign(slot ykpiv.Slot, digest []byte) ([]byte, error) {
+	return slot.Sign(rand.Reader, digest, crypto.SHA256) // want `method "ykpiv.Slot.Sign" generates or uses a classical RSA or ECDSA key`
+}
@@ -0,0 +1,39 @@
+package pkcs12
+
+import (
+	"crypto/rand"
+	"crypto/rsa" // want `"crypto/rsa" uses quantum-vulnerable integer factorization cryptography`
+	"crypto/x509"
+
+	xpkcs12 "golang.org/x/crypto/pkcs12"
+	"software.sslmate.com/src/go-pkcs12"
+)
+
+func legacyBundle(key *rsa.PrivateKey, cert *x509.Certificate) ([]byte, error) {
+	return pkcs12.Encode(rand.Reader, key, cert, nil, "changeit") // want `"pkcs12.Encode" bundles a \*rsa.PrivateKey in a PKCS#12 file, encrypted with legacy RC2 and 3DES`
+}
+
+func modernBundle(key any, cert *x509.Certificate) ([]byte, error) {
+	return pkcs12.Modern.Encode(key, cert, nil, "changeit") // want `"pkcs12.Encoder.Encode" bundles a classical private key in a PKCS#12 file, encrypted with AES-256`
+}
+
+func desBundle(key *rsa.PrivateKey, cert *x509.Certificate) ([]byte, error) {
+	return pkcs12.LegacyDES.WithRand(rand.Reader).Encode(key, cert, nil, "changeit") // want `"pkcs12.Encoder.Encode" bundles a \*rsa.PrivateKey in a PKCS#12 file, encrypted with legacy 3DES`
+}
+
+func configuredBundle(encoder *pkcs12.Encoder, key any, cert *x509.Certificate) ([]byte, error) {
+	return encoder.Encode(key, cert, nil, "changeit") // want `"pkcs12.Encoder.Encode" bundles a classical private key in a PKCS#12 file; PKCS#12 files distribute`
+}
+
+func trustStore(certs []*x509.Certificate) ([]byte, error) {
+	return pkcs12.Modern.EncodeTrustStore(certs, "changeit") // want `"pkcs12.Encoder.EncodeTrustStore" bundles classical certificates in a PKCS#12 trust store`
+}
+
+func load(pfx []byte) (any, *x509.Certificate, error) {
+	return pkcs12.Decode(pfx, "changeit") // want `"pkcs12.Decode" reads classical keys or certificates from a PKCS#12 file; PKCS#12 files`
+}
+
+func loadPEM(pfx []byte) (int, error) {
+	blocks, err := xpkcs12.ToPEM(pfx, "changeit") // want `"xpkcs12.ToPEM" reads classical keys or certificates from a PKCS#12 file, which only reads files encrypted with legacy RC2 and 3DES`
+	return len(blocks), err
+}
@@ -0,0 +1,33 @@
+package provenance
+
+import (
+	"crypto"
+	"crypto/rand"
+
+	"example.com/keys"
+)
+
+func sign(digest []byte) ([]byte, error) {
+	signer, err := keys.NewSigner()
+	if err != nil {
+		return nil, err
+	}
+	_ = signer.Public()
+	return signer.Sign(rand.Reader, digest, crypto.SHA256) // want `method "crypto.Signer.Sign" uses ECDSA key material returned by "keys.NewSigner", from ecdsa.GenerateKey; the classical algorithm is chosen in another package`
+}
+
+func signDefault(digest []byte) ([]byte, error) {
+	return keys.Default().Sign(rand.Reader, digest, crypto.SHA256) // want `method "crypto.Signer.Sign" uses ECDSA key material returned by "keys.Default", from ecdsa.GenerateKey`
+}
+
+func signLoaded(der, msg []byte) ([]byte, error) {
+	signer, err := keys.Load(der)
+	if err != nil {
+		return nil, err
+	}
+	return signer.Sign(msg) // want `method "keys.Signer.Sign" uses the key material of its receiver or arguments with RSA in rsa.SignPKCS1v15`
+}
+
+func seal(key any, msg []byte) ([]byte, error) {
+	return keys.Seal(key, keys.Digest(msg)) // want `function "keys.Seal" uses the key material of its arguments with RSA in rsa.EncryptOAEP`
+}
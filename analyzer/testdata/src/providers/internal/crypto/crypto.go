@@ -0,0 +1,8 @@
+// Package crypto is the approved internal crypto wrapper of the test.
+package crypto
+
+import "crypto/sha256"
+
+func Hash(data []byte) [32]byte {
+	return sha256.Sum256(data)
+}
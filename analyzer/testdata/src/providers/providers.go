@@ -0,0 +1,18 @@
+package providers
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+
+	"aidanwoods.dev/go-paseto" // want `"aidanwoods.dev/go-paseto" is a crypto implementation outside the approved providers`
+	"golang.org/x/crypto/ssh"  // want `"golang.org/x/crypto/ssh" is a crypto implementation outside the approved providers`
+	"providers/internal/crypto"
+)
+
+var (
+	_ = sha256.Sum256
+	_ = hex.EncodeToString
+	_ paseto.Token
+	_ ssh.Signer
+	_ = crypto.Hash
+)
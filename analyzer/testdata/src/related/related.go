@@ -0,0 +1,19 @@
+package related
+
+import (
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa" // want `"crypto/rsa" uses quantum-vulnerable integer factorization cryptography`
+)
+
+func sign(digest []byte) ([]byte, error) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		return nil, err
+	}
+	return rsa.SignPSS(rand.Reader, key, crypto.SHA256, digest, nil) // want `function "rsa.SignPSS" implements quantum-vulnerable cryptography`
+}
+
+func verify(pub *rsa.PublicKey, digest, sig []byte) error {
+	return rsa.VerifyPSS(pub, crypto.SHA256, digest, sig, nil) // want `function "rsa.VerifyPSS" implements quantum-vulnerable cryptography`
+}
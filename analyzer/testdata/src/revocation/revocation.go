@@ -0,0 +1,33 @@
+package revocation
+
+import (
+	"crypto"
+	"crypto/ecdsa" // want `"crypto/ecdsa" uses quantum-vulnerable elliptic curve cryptography`
+	"crypto/rand"
+	"crypto/x509"
+	"math/big"
+	"time"
+
+	"golang.org/x/crypto/ocsp"
+)
+
+func respond(issuer, responder *x509.Certificate, key *ecdsa.PrivateKey, serial *big.Int) ([]byte, error) {
+	template := ocsp.Response{Status: ocsp.Good, SerialNumber: serial, ThisUpdate: time.Now()}
+	return ocsp.CreateResponse(issuer, responder, template, key) // want `"ocsp.CreateResponse" signs an OCSP response with a \*ecdsa.PrivateKey; revocation infrastructure has to migrate in lockstep with certificate issuance`
+}
+
+func revoke(issuer *x509.Certificate, signer crypto.Signer, revoked []x509.RevocationListEntry) ([]byte, error) {
+	template := &x509.RevocationList{Number: big.NewInt(1), RevokedCertificateEntries: revoked}
+	return x509.CreateRevocationList(rand.Reader, template, issuer, signer) // want `"x509.CreateRevocationList" signs a certificate revocation list with a classical private key`
+}
+
+func check(cert, issuer *x509.Certificate, der []byte) (*ocsp.Response, error) {
+	if _, err := ocsp.CreateRequest(cert, issuer, &ocsp.RequestOptions{Hash: crypto.SHA256}); err != nil { // want `"ocsp.CreateRequest" creates an OCSP request identifying a certificate by its classical issuer key`
+		return nil, err
+	}
+	return ocsp.ParseResponseForCert(der, cert, issuer) // want `"ocsp.ParseResponseForCert" parses an OCSP response and verifies its classical signature`
+}
+
+func crl(der []byte) (*x509.RevocationList, error) {
+	return x509.ParseRevocationList(der) // want `"x509.ParseRevocationList" parses a certificate revocation list signed with a classical key`
+}
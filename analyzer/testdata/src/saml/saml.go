@@ -0,0 +1,38 @@
+package saml
+
+import (
+	"crypto/rsa" // want `"crypto/rsa" uses quantum-vulnerable integer factorization cryptography`
+	"crypto/x509"
+
+	"github.com/crewjam/saml"
+	"github.com/crewjam/saml/samlsp"
+	dsig "github.com/russellhaering/goxmldsig"
+)
+
+func middleware(key *rsa.PrivateKey, cert *x509.Certificate) (*samlsp.Middleware, error) {
+	return samlsp.New(samlsp.Options{ // want `function "samlsp.New" federates identities over SAML` `type "samlsp.Options" federates identities over SAML`
+		EntityID:    "https://sp.example.com/saml/metadata",
+		Key:         key,
+		Certificate: cert,
+		SignRequest: true,
+	})
+}
+
+func serviceProvider(key *rsa.PrivateKey) *saml.ServiceProvider {
+	sp := &saml.ServiceProvider{Key: key}              // want `type "saml.ServiceProvider" federates identities over SAML`
+	sp.SignatureMethod = dsig.RSASHA256SignatureMethod // want `field "saml.ServiceProvider.SignatureMethod" configures a classical XML signature algorithm, such as RSA-SHA256, for SAML messages`
+	return sp
+}
+
+func identityProvider(key *rsa.PrivateKey, cert *x509.Certificate) *saml.IdentityProvider {
+	return &saml.IdentityProvider{ // want `type "saml.IdentityProvider" federates identities over SAML`
+		Key:             key,
+		Certificate:     cert,
+		SignatureMethod: dsig.ECDSASHA256SignatureMethod, // want `field "saml.IdentityProvider.SignatureMethod" configures a classical XML signature algorithm`
+	}
+}
+
+func signingContext(ks dsig.X509KeyStore) (*dsig.SigningContext, error) {
+	ctx := dsig.NewDefaultSigningContext(ks)                          // want `function "dsig.NewDefaultSigningContext" signs, timestamps or verifies documents`
+	return ctx, ctx.SetSignatureMethod(dsig.RSASHA256SignatureMethod) // want `method "dsig.SigningContext.SetSignatureMethod" configures a classical XML signature algorithm`
+}
@@ -0,0 +1,27 @@
+package selfupdate
+
+import (
+	"io"
+
+	"github.com/inconshreveable/go-update"
+	"github.com/jedisct1/go-minisign"
+)
+
+func apply(binary io.Reader, signature, pemKey []byte) error {
+	opts := update.Options{
+		Signature: signature,
+		Verifier:  update.NewECDSAVerifier(), // want `field "update.Options.Verifier" signs or verifies software updates` `function "update.NewECDSAVerifier" signs or verifies software updates`
+	}
+	if err := opts.SetPublicKeyPEM(pemKey); err != nil { // want `method "update.Options.SetPublicKeyPEM" signs or verifies software updates`
+		return err
+	}
+	return update.Apply(binary, opts)
+}
+
+func verify(release []byte, signature minisign.Signature) (bool, error) {
+	key, err := minisign.NewPublicKey("RWQf6LRCGA9i53mlYecO4IzT51TGPpvWucNSCh1CBM0QTaLn73Y7GFO3") // want `function "minisign.NewPublicKey" signs or verifies software updates`
+	if err != nil {
+		return false, err
+	}
+	return key.Verify(release, signature) // want `method "minisign.PublicKey.Verify" signs or verifies software updates`
+}
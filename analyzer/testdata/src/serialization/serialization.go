@@ -0,0 +1,55 @@
+package serialization
+
+import (
+	"bytes"
+	"crypto/ecdh"  // want `"crypto/ecdh" uses quantum-vulnerable elliptic curve cryptography`
+	"crypto/ecdsa" // want `"crypto/ecdsa" uses quantum-vulnerable elliptic curve cryptography`
+	"crypto/rsa"   // want `"crypto/rsa" uses quantum-vulnerable integer factorization cryptography`
+	"encoding/gob"
+	"encoding/json"
+)
+
+type account struct {
+	Name     string
+	Sessions map[string]*session
+}
+
+type session struct {
+	ID     string
+	Signer *ecdsa.PrivateKey
+	Parent *session
+}
+
+type publicOnly struct {
+	Key     *rsa.PublicKey
+	private *rsa.PrivateKey
+}
+
+// The signer is left out of JSON, but not out of gob.
+type cached struct {
+	ID     string
+	Signer *ecdsa.PrivateKey `json:"-"`
+}
+
+func store(key *rsa.PrivateKey, account account) ([]byte, error) {
+	if _, err := json.Marshal(key); err != nil { // want `"json.Marshal" serializes key, of quantum-vulnerable private key type \*rsa.PrivateKey`
+		return nil, err
+	}
+	var buf bytes.Buffer
+	if err := gob.NewEncoder(&buf).Encode(&account); err != nil { // want `"gob.Encoder.Encode" serializes &account field Sessions.Signer, of quantum-vulnerable private key type \*ecdsa.PrivateKey`
+		return nil, err
+	}
+	return json.MarshalIndent(publicOnly{}, "", "  ")
+}
+
+func cache(entry cached) ([]byte, error) {
+	var buf bytes.Buffer
+	if err := gob.NewEncoder(&buf).Encode(entry); err != nil { // want `"gob.Encoder.Encode" serializes entry field Signer, of quantum-vulnerable private key type \*ecdsa.PrivateKey`
+		return nil, err
+	}
+	return json.Marshal(entry)
+}
+
+func exchangeKey(key *ecdh.PrivateKey) ([]byte, error) {
+	return json.Marshal(key)
+}
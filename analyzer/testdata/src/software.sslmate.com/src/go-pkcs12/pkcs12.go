@@ -0,0 +1,41 @@
+package pkcs12
+
+import (
+	"crypto/x509"
+	"io"
+)
+
+type Encoder struct{}
+
+var (
+	LegacyRC2    = &Encoder{}
+	LegacyDES    = &Encoder{}
+	Legacy       = LegacyRC2
+	Modern       = &Encoder{}
+	Modern2023   = &Encoder{}
+	Passwordless = &Encoder{}
+)
+
+func (enc Encoder) WithRand(rand io.Reader) *Encoder {
+	return &enc
+}
+
+func (enc *Encoder) Encode(privateKey interface{}, certificate *x509.Certificate, caCerts []*x509.Certificate, password string) ([]byte, error) {
+	return nil, nil
+}
+
+func (enc *Encoder) EncodeTrustStore(certs []*x509.Certificate, password string) ([]byte, error) {
+	return nil, nil
+}
+
+func Encode(rand io.Reader, privateKey interface{}, certificate *x509.Certificate, caCerts []*x509.Certificate, password string) ([]byte, error) {
+	return nil, nil
+}
+
+func Decode(pfxData []byte, password string) (privateKey interface{}, certificate *x509.Certificate, err error) {
+	return nil, nil, nil
+}
+
+func DecodeTrustStore(pfxData []byte, password string) ([]*x509.Certificate, error) {
+	return nil, nil
+}
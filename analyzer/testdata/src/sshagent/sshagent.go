@@ -0,0 +1,25 @@
+package sshagent
+
+import (
+	"net"
+
+	"golang.org/x/crypto/ssh"
+	"golang.org/x/crypto/ssh/agent"
+	"golang.org/x/crypto/ssh/knownhosts"
+)
+
+func hostKeys() (ssh.HostKeyCallback, error) {
+	return knownhosts.New("/etc/ssh/ssh_known_hosts") // want `function "knownhosts.New" accepts quantum-vulnerable RSA, ECDSA and Ed25519 SSH keys`
+}
+
+func keys(conn net.Conn) ([]*agent.Key, error) {
+	client := agent.NewClient(conn) // want `function "agent.NewClient" accepts quantum-vulnerable`
+	return client.List()            // want `method "agent.ExtendedAgent.List" accepts quantum-vulnerable`
+}
+
+func forward(client *ssh.Client, session *ssh.Session, socket string) error {
+	if err := agent.ForwardToRemote(client, socket); err != nil { // want `function "agent.ForwardToRemote" accepts quantum-vulnerable`
+		return err
+	}
+	return agent.RequestAgentForwarding(session) // want `function "agent.RequestAgentForwarding" accepts quantum-vulnerable`
+}
@@ -0,0 +1,33 @@
+package sshca
+
+import (
+	"crypto/rand"
+
+	"golang.org/x/crypto/ssh"
+)
+
+func issue(cert *ssh.Certificate, pemBytes []byte) error {
+	ca, err := ssh.ParsePrivateKey(pemBytes) // want `function "ssh.ParsePrivateKey" loads the key of a quantum-vulnerable SSH certificate authority`
+	if err != nil {
+		return err
+	}
+	if err := cert.SignCert(rand.Reader, ca); err != nil { // want `method "ssh.Certificate.SignCert" is part of quantum-vulnerable SSH certificate authority infrastructure`
+		return err
+	}
+	_, err = ssh.NewCertSigner(cert, ca) // want `function "ssh.NewCertSigner" is part of quantum-vulnerable SSH certificate authority infrastructure`
+	return err
+}
+
+func checker() *ssh.CertChecker {
+	return &ssh.CertChecker{ // want `type "ssh.CertChecker" is part of quantum-vulnerable SSH certificate authority infrastructure`
+		IsUserAuthority: func(auth ssh.PublicKey) bool { return false },
+	}
+}
+
+func clientAuth(pemBytes []byte) (ssh.AuthMethod, error) {
+	signer, err := ssh.ParsePrivateKey(pemBytes)
+	if err != nil {
+		return nil, err
+	}
+	return ssh.PublicKeys(signer), nil
+}
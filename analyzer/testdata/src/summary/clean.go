@@ -0,0 +1,8 @@
+package summary
+
+import "crypto/sha256"
+
+func digest(data []byte) []byte {
+	sum := sha256.Sum256(data)
+	return sum[:]
+}
@@ -0,0 +1,11 @@
+package summary // want `1 high, 1 medium PQC findings in this file`
+
+import (
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa" // want `"crypto/rsa" uses quantum-vulnerable integer factorization cryptography`
+)
+
+func sign(key *rsa.PrivateKey, digest []byte) ([]byte, error) {
+	return rsa.SignPKCS1v15(rand.Reader, key, crypto.SHA256, digest) // want `function "rsa.SignPKCS1v15" implements quantum-vulnerable cryptography`
+}
@@ -0,0 +1,32 @@
+package tink
+
+import (
+	"github.com/tink-crypto/tink-go/v2/hybrid"
+	"github.com/tink-crypto/tink-go/v2/keyset"
+	"github.com/tink-crypto/tink-go/v2/signature"
+)
+
+func encrypter() (hybrid.Encrypt, error) {
+	handle, err := keyset.NewHandle(hybrid.ECIESHKDFAES128GCMKeyTemplate()) // want `function "hybrid.ECIESHKDFAES128GCMKeyTemplate" uses a Tink keyset of quantum-vulnerable hybrid encryption or signatures`
+	if err != nil {
+		return nil, err
+	}
+	return hybrid.NewHybridEncrypt(handle) // want `function "hybrid.NewHybridEncrypt" uses a Tink keyset`
+}
+
+func signingKeys() (*keyset.Handle, *keyset.Handle, error) {
+	classical, err := keyset.NewHandle(signature.ECDSAP256KeyTemplate()) // want `function "signature.ECDSAP256KeyTemplate" uses a Tink keyset`
+	if err != nil {
+		return nil, nil, err
+	}
+	postQuantum, err := keyset.NewHandle(signature.MLDSA65KeyTemplate())
+	return classical, postQuantum, err
+}
+
+var customTemplate = &keyset.KeyTemplate{
+	TypeUrl: "type.googleapis.com/google.crypto.tink.RsaSsaPssPrivateKey", // want `Tink key type RsaSsaPssPrivateKey is quantum-vulnerable RSA`
+}
+
+var aeadTemplate = &keyset.KeyTemplate{
+	TypeUrl: "type.googleapis.com/google.crypto.tink.AesGcmKey",
+}
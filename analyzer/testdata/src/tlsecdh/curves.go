@@ -0,0 +1,13 @@
+package tlsecdh
+
+import (
+	"crypto/ecdh" // want `"crypto/ecdh" is only used to configure crypto/tls key exchange, which negotiates hybrid post-quantum X25519MLKEM768 key exchange by default since go1.24`
+	"crypto/tls"
+)
+
+func preferredCurve(config *tls.Config) ecdh.Curve {
+	if len(config.CurvePreferences) > 0 && config.CurvePreferences[0] == tls.CurveP256 {
+		return ecdh.P256()
+	}
+	return ecdh.X25519()
+}
@@ -0,0 +1,21 @@
+package tlsecdh
+
+import (
+	"crypto/ecdh" // want `"crypto/ecdh" uses quantum-vulnerable elliptic curve cryptography`
+	"crypto/rand"
+	"crypto/tls"
+)
+
+// ECH keys are HPKE DHKEM(X25519) keys, outside the hybrid handshake.
+func serverConfig(echConfig []byte) (*tls.Config, error) {
+	key, err := ecdh.X25519().GenerateKey(rand.Reader)
+	if err != nil {
+		return nil, err
+	}
+	return &tls.Config{
+		EncryptedClientHelloKeys: []tls.EncryptedClientHelloKey{{
+			Config:     echConfig,
+			PrivateKey: key.Bytes(),
+		}},
+	}, nil
+}
@@ -0,0 +1,18 @@
+package tlsecdh
+
+import (
+	"crypto/ecdh" // want `"crypto/ecdh" uses quantum-vulnerable elliptic curve cryptography`
+	"crypto/rand"
+	"crypto/tls"
+)
+
+func exchange(conn *tls.Conn, peer *ecdh.PublicKey) ([]byte, error) {
+	key, err := ecdh.X25519().GenerateKey(rand.Reader)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := conn.Write(key.PublicKey().Bytes()); err != nil {
+		return nil, err
+	}
+	return key.ECDH(peer)
+}
@@ -0,0 +1,16 @@
+package tlsecdh
+
+import (
+	"crypto/ecdh" // want `"crypto/ecdh" uses quantum-vulnerable elliptic curve cryptography`
+	"crypto/tls"
+)
+
+// The shared secret is computed outside the handshake.
+func channelBinding(config *tls.Config, key *ecdh.PrivateKey, peer *ecdh.PublicKey) (*tls.Config, []byte, error) {
+	secret, err := key.ECDH(peer)
+	if err != nil {
+		return nil, nil, err
+	}
+	config.CurvePreferences = []tls.CurveID{tls.X25519MLKEM768}
+	return config, secret, nil
+}
@@ -0,0 +1,13 @@
+package tlssession
+
+import "crypto/tls"
+
+func server(keys [][32]byte) *tls.Config {
+	cfg := &tls.Config{
+		ClientSessionCache: tls.NewLRUClientSessionCache(64), // want `field "tls.Config.ClientSessionCache" handles TLS session tickets` `function "tls.NewLRUClientSessionCache" handles TLS session tickets`
+	}
+	cfg.SessionTicketKey = keys[0] // want `field "tls.Config.SessionTicketKey" handles TLS session tickets`
+	cfg.SetSessionTicketKeys(keys) // want `method "tls.Config.SetSessionTicketKeys" handles TLS session tickets`
+	cfg.MinVersion = tls.VersionTLS13
+	return cfg
+}
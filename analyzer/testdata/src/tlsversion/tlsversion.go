@@ -0,0 +1,20 @@
+package tlsversion
+
+import "crypto/tls"
+
+const legacyMinimum = tls.VersionTLS11
+
+var legacy = &tls.Config{
+	MinVersion: tls.VersionTLS10, // want `tls.Config.MinVersion allows deprecated TLS 1.0, which cannot negotiate hybrid post-quantum key exchange`
+	MaxVersion: tls.VersionTLS12, // want `tls.Config.MaxVersion caps connections at TLS 1.2, which rules out hybrid post-quantum key exchange`
+}
+
+var modern = &tls.Config{
+	MinVersion: tls.VersionTLS12,
+	MaxVersion: tls.VersionTLS13,
+}
+
+func configure(config *tls.Config) {
+	config.MinVersion = legacyMinimum // want `tls.Config.MinVersion allows deprecated TLS 1.1`
+	config.MaxVersion = tls.VersionTLS13
+}
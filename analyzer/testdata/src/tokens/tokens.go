@@ -0,0 +1,17 @@
+package tokens
+
+import "aidanwoods.dev/go-paseto"
+
+func public() (*paseto.Token, error) {
+	key := paseto.NewV4AsymmetricSecretKey() // want `function "paseto.NewV4AsymmetricSecretKey" signs or verifies tokens with quantum-vulnerable`
+	token := paseto.NewToken()
+	signed := token.V4Sign(key, nil)                                   // want `method "paseto.Token.V4Sign" signs or verifies tokens with quantum-vulnerable`
+	return paseto.NewParser().ParseV4Public(key.Public(), signed, nil) // want `method "paseto.Parser.ParseV4Public" signs or verifies tokens with quantum-vulnerable`
+}
+
+func local() (*paseto.Token, error) {
+	key := paseto.NewV4SymmetricKey()
+	token := paseto.NewToken()
+	encrypted := token.V4Encrypt(key, nil)
+	return paseto.NewParser().ParseV4Local(key, encrypted, nil)
+}
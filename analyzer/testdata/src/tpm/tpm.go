@@ -0,0 +1,37 @@
+package tpm
+
+import (
+	"io"
+
+	"github.com/google/go-attestation/attest"
+	"github.com/google/go-tpm-tools/client"
+	"github.com/google/go-tpm/tpm2"
+)
+
+func attestationKey(rw io.ReadWriter) (*client.Key, error) {
+	return client.AttestationKeyECC(rw) // want `key template "client.AttestationKeyECC" selects a quantum-vulnerable ECC P-256 hardware-bound key`
+}
+
+func cachedKey(rw io.ReadWriter) (*client.Key, error) {
+	return client.NewCachedKey(rw)
+}
+
+var endorsementKey = client.AttestationKeyRSA // want `key template "client.AttestationKeyRSA" selects a quantum-vulnerable RSA-2048 hardware-bound key`
+
+func storageRootKey() tpm2.TPMTPublic {
+	return tpm2.RSASRKTemplate // want `key template "tpm2.RSASRKTemplate" selects a quantum-vulnerable RSA-2048 hardware-bound key`
+}
+
+func signingKey() tpm2.TPMTPublic {
+	return tpm2.TPMTPublic{
+		Type:    tpm2.TPMAlgECC, // want `algorithm "tpm2.TPMAlgECC" selects a quantum-vulnerable ECC hardware-bound key`
+		NameAlg: tpm2.TPMAlgSHA256,
+	}
+}
+
+func appKey(tpm *attest.TPM, ak *attest.AK) (*attest.Key, error) {
+	return tpm.NewKey(ak, &attest.KeyConfig{
+		Algorithm: attest.RSA, // want `algorithm "attest.RSA" selects a quantum-vulnerable RSA hardware-bound key`
+		Size:      2048,
+	})
+}
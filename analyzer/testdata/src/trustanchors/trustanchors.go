@@ -0,0 +1,20 @@
+package trustanchors
+
+import (
+	"crypto/ed25519" // want `"crypto/ed25519" uses quantum-vulnerable Ed25519 signatures`
+	"net/http"
+
+	"github.com/bwmarrin/discordgo"
+	"github.com/golang-jwt/jwt/v5"
+)
+
+func interaction(r *http.Request, key ed25519.PublicKey) bool {
+	return discordgo.VerifyInteraction(r, key) // want `function "discordgo.VerifyInteraction" verifies artifacts signed by another organization`
+}
+
+func token(raw string, keyFunc jwt.Keyfunc) (*jwt.Token, error) {
+	if _, err := jwt.NewParser().Parse(raw, keyFunc); err != nil { // want `method "jwt.Parser.Parse" verifies artifacts signed by another organization`
+		return nil, err
+	}
+	return jwt.Parse(raw, keyFunc) // want `function "jwt.Parse" verifies artifacts signed by another organization`
+}
@@ -0,0 +1,45 @@
+package tuf
+
+import (
+	_ "embed"
+
+	"github.com/theupdateframework/go-tuf"
+	"github.com/theupdateframework/go-tuf/client"
+	"github.com/theupdateframework/go-tuf/pkg/keys"
+	"github.com/theupdateframework/go-tuf/v2/metadata/config"
+	notary "github.com/theupdateframework/notary/client"
+)
+
+//go:embed root.json
+var rootJSON []byte
+
+func bootstrap(store tuf.LocalStore) error {
+	repo, err := tuf.NewRepo(store) // want `function "tuf.NewRepo" handles TUF or Notary root-of-trust keys, Ed25519, ECDSA or RSA`
+	if err != nil {
+		return err
+	}
+	if _, err := repo.GenKey("root"); err != nil { // want `method "tuf.Repo.GenKey" handles TUF or Notary root-of-trust keys`
+		return err
+	}
+	return repo.Commit()
+}
+
+func signer() (keys.Signer, error) {
+	return keys.GenerateEd25519Key() // want `function "keys.GenerateEd25519Key" handles TUF or Notary root-of-trust keys`
+}
+
+func trust(local client.LocalStore, remote client.RemoteStore) error {
+	c := client.NewClient(local, remote) // want `function "client.NewClient" handles TUF or Notary root-of-trust keys`
+	return c.Init(rootJSON)              // want `method "client.Client.Init" handles TUF or Notary root-of-trust keys`
+}
+
+func updater() (*config.UpdaterConfig, error) {
+	return config.New("https://tuf.example.com/metadata", rootJSON) // want `function "config.New" handles TUF or Notary root-of-trust keys`
+}
+
+func initialize(repo notary.Repository, rootKeyID string) error {
+	if err := repo.Initialize([]string{rootKeyID}); err != nil { // want `method "client.Repository.Initialize" handles TUF or Notary root-of-trust keys`
+		return err
+	}
+	return repo.Publish()
+}
@@ -0,0 +1,45 @@
+package verification
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+)
+
+func pinnedConfig(roots *x509.CertPool) *tls.Config {
+	return &tls.Config{
+		RootCAs: roots, // want `field "tls.Config.RootCAs" pins certificate verification to a custom root pool`
+		VerifyPeerCertificate: func(rawCerts [][]byte, chains [][]*x509.Certificate) error { // want `field "tls.Config.VerifyPeerCertificate" customizes certificate chain verification`
+			leaf := chains[0][0]
+			if leaf.SignatureAlgorithm != x509.SHA256WithRSA { // want `comparison of field "x509.Certificate.SignatureAlgorithm" with x509.SHA256WithRSA restricts accepted certificates to a fixed algorithm`
+				return errors.New("unexpected signature algorithm")
+			}
+			return nil
+		},
+	}
+}
+
+func requireClientCerts(config *tls.Config, pool *x509.CertPool) {
+	config.ClientCAs = pool                                           // want `field "tls.Config.ClientCAs" pins certificate verification to a custom root pool`
+	config.VerifyConnection = func(state tls.ConnectionState) error { // want `field "tls.Config.VerifyConnection" customizes certificate chain verification`
+		return nil
+	}
+}
+
+func verifyChain(leaf, issuer *x509.Certificate) error {
+	if x509.ECDSA == issuer.PublicKeyAlgorithm { // want `comparison of field "x509.Certificate.PublicKeyAlgorithm" with x509.ECDSA`
+		return errors.New("ECDSA issuers are not accepted")
+	}
+	return leaf.CheckSignatureFrom(issuer) // want `method "x509.Certificate.CheckSignatureFrom" customizes certificate chain verification`
+}
+
+func verify(leaf *x509.Certificate, roots *x509.CertPool) error {
+	_, err := leaf.Verify(x509.VerifyOptions{
+		Roots: roots, // want `field "x509.VerifyOptions.Roots" pins certificate verification to a custom root pool`
+	})
+	return err
+}
+
+func sameAlgorithm(a, b *x509.Certificate) bool {
+	return a.SignatureAlgorithm == b.SignatureAlgorithm
+}
@@ -0,0 +1,33 @@
+package webpush
+
+import (
+	"os"
+
+	"github.com/SherClockHolmes/webpush-go"
+	"github.com/sideshow/apns2/token"
+)
+
+func keys() (string, string, error) {
+	return webpush.GenerateVAPIDKeys() // want `function "webpush.GenerateVAPIDKeys" generates or uses the ECDSA P-256 keys push notifications are sent with`
+}
+
+func notify(subscription *webpush.Subscription, message []byte) error {
+	options := &webpush.Options{
+		Subscriber:      "ops@example.com",
+		VAPIDPublicKey:  os.Getenv("VAPID_PUBLIC_KEY"),  // want `field "webpush.Options.VAPIDPublicKey" generates or uses the ECDSA P-256 keys`
+		VAPIDPrivateKey: os.Getenv("VAPID_PRIVATE_KEY"), // want `field "webpush.Options.VAPIDPrivateKey" generates or uses the ECDSA P-256 keys`
+	}
+	resp, err := webpush.SendNotification(message, subscription, options) // want `function "webpush.SendNotification" generates or uses the ECDSA P-256 keys push notifications are sent with, or encrypts their payloads with ECDH P-256`
+	if err != nil {
+		return err
+	}
+	return resp.Body.Close()
+}
+
+func apnsToken() (*token.Token, error) {
+	key, err := token.AuthKeyFromFile("AuthKey.p8") // want `function "token.AuthKeyFromFile" generates or uses the ECDSA P-256 keys`
+	if err != nil {
+		return nil, err
+	}
+	return &token.Token{AuthKey: key, KeyID: "ABC123DEFG", TeamID: "DEF123GHIJ"}, nil // want `field "token.Token.AuthKey" generates or uses the ECDSA P-256 keys`
+}
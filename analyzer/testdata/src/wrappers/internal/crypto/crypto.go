@@ -0,0 +1,19 @@
+package crypto
+
+import (
+	"crypto"
+	"crypto/rsa" // want `"crypto/rsa" uses quantum-vulnerable integer factorization cryptography`
+	"crypto/sha256"
+)
+
+var key *rsa.PrivateKey
+
+func Sign(message []byte) ([]byte, error) {
+	digest := sha256.Sum256(message)
+	return rsa.SignPSS(nil, key, crypto.SHA256, digest[:], nil) // want `function "rsa.SignPSS" implements quantum-vulnerable cryptography`
+}
+
+func Hash(message []byte) []byte {
+	digest := sha256.Sum256(message)
+	return digest[:]
+}
@@ -0,0 +1,11 @@
+package wrappers
+
+import "wrappers/internal/crypto"
+
+func signRelease(release []byte) ([]byte, error) {
+	return crypto.Sign(release) // want `function "crypto.Sign" wraps "crypto/rsa.SignPSS", which implements quantum-vulnerable cryptography`
+}
+
+func checksum(release []byte) []byte {
+	return crypto.Hash(release)
+}
@@ -0,0 +1,3 @@
+module tlsdefaults
+
+go 1.23
@@ -0,0 +1,7 @@
+package plain
+
+import "strings"
+
+func upper(s string) string {
+	return strings.ToUpper(s)
+}
@@ -0,0 +1,7 @@
+package server // want `go1.23: crypto/tls negotiates the pre-standard X25519Kyber768Draft00 hybrid key exchange by default, which go1.24 replaces with X25519MLKEM768, and verifies classical RSA, ECDSA and Ed25519 certificates, accepting SHA-1 signatures in TLS 1.2 handshakes and RSA keys under 1024 bits; raise the go directive to go1.24 or later for hybrid X25519MLKEM768 key exchange by default`
+
+import "net/http"
+
+func serve(handler http.Handler) error {
+	return http.ListenAndServeTLS(":443", "cert.pem", "key.pem", handler)
+}
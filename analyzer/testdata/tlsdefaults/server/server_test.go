@@ -0,0 +1,10 @@
+package server_test
+
+import (
+	"net/http"
+	"testing"
+)
+
+func TestServe(t *testing.T) {
+	_ = http.DefaultClient
+}
@@ -0,0 +1,3 @@
+module tlshybrid
+
+go 1.24
@@ -0,0 +1,7 @@
+package tlshybrid
+
+import "net/http"
+
+func serve(handler http.Handler) error {
+	return http.ListenAndServeTLS(":443", "cert.pem", "key.pem", handler)
+}
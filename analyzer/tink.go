@@ -0,0 +1,111 @@
+package analyzer
+
+import (
+	"go/ast"
+	"go/token"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// Import paths of Tink, before and after its move to the tink-crypto
+// organization.
+var tinkModules = []string{
+	"github.com/tink-crypto/tink-go/v2",
+	"github.com/google/tink/go",
+}
+
+// Tink key templates and primitives of classical hybrid encryption (ECIES
+// and HPKE over X25519 or NIST curves) and signatures (ECDSA, Ed25519 and
+// RSA). Keysets are created from templates, so the algorithm is only visible
+// in the name of the template function.
+var tinkIdentifiers = slices.Concat(
+	tinkFunctions("hybrid",
+		"NewHybridEncrypt",
+		"NewHybridDecrypt",
+		"ECIESHKDFAES128GCMKeyTemplate",
+		"ECIESHKDFAES128CTRHMACSHA256KeyTemplate",
+		"DHKEM_X25519_HKDF_SHA256_HKDF_SHA256_AES_128_GCM_Key_Template",
+		"DHKEM_X25519_HKDF_SHA256_HKDF_SHA256_AES_128_GCM_Raw_Key_Template",
+		"DHKEM_X25519_HKDF_SHA256_HKDF_SHA256_AES_256_GCM_Key_Template",
+		"DHKEM_X25519_HKDF_SHA256_HKDF_SHA256_AES_256_GCM_Raw_Key_Template",
+		"DHKEM_X25519_HKDF_SHA256_HKDF_SHA256_CHACHA20_POLY1305_Key_Template",
+		"DHKEM_X25519_HKDF_SHA256_HKDF_SHA256_CHACHA20_POLY1305_Raw_Key_Template",
+	),
+	tinkFunctions("signature",
+		"NewSigner",
+		"NewVerifier",
+		"ECDSAP256KeyTemplate",
+		"ECDSAP256KeyWithoutPrefixTemplate",
+		"ECDSAP256RawKeyTemplate",
+		"ECDSAP384KeyTemplate",
+		"ECDSAP384SHA384KeyTemplate",
+		"ECDSAP384SHA512KeyTemplate",
+		"ECDSAP521KeyTemplate",
+		"ED25519KeyTemplate",
+		"ED25519KeyWithoutPrefixTemplate",
+		"RSA_SSA_PKCS1_3072_SHA256_F4_Key_Template",
+		"RSA_SSA_PKCS1_3072_SHA256_F4_RAW_Key_Template",
+		"RSA_SSA_PKCS1_4096_SHA512_F4_Key_Template",
+		"RSA_SSA_PKCS1_4096_SHA512_F4_RAW_Key_Template",
+		"RSA_SSA_PSS_3072_SHA256_32_F4_Key_Template",
+		"RSA_SSA_PSS_3072_SHA256_32_F4_Raw_Key_Template",
+		"RSA_SSA_PSS_4096_SHA512_64_F4_Key_Template",
+		"RSA_SSA_PSS_4096_SHA512_64_F4_Raw_Key_Template",
+	),
+)
+
+func tinkFunctions(pkg string, names ...string) []QvFunction {
+	var functions []QvFunction
+	for _, module := range tinkModules {
+		functions = append(functions, functionsOf(module+"/"+pkg, names...)...)
+	}
+	return functions
+}
+
+// Prefix of the type URLs of Tink keys, which identify the key type in
+// serialized keysets and key templates built by hand.
+const tinkTypeURLPrefix = "type.googleapis.com/google.crypto.tink."
+
+// Tink key types of classical algorithms, by type URL prefix after
+// tinkTypeURLPrefix.
+var tinkClassicalKeyTypes = []struct {
+	keyType   string
+	algorithm string
+}{
+	{"EciesAeadHkdf", "ECIES"},
+	{"Hpke", "HPKE with a classical KEM"},
+	{"JwtEcdsa", "ECDSA"},
+	{"JwtRsaSsaPkcs1", "RSA"},
+	{"JwtRsaSsaPss", "RSA"},
+	{"Ecdsa", "ECDSA"},
+	{"Ed25519", "Ed25519"},
+	{"RsaSsaPkcs1", "RSA"},
+	{"RsaSsaPss", "RSA"},
+}
+
+// Reports Tink type URLs of classical key types in string literals, as used
+// in hand-built key templates and keyset handling.
+func reportTinkTypeURLs(r *reporter, file *ast.File) {
+	ast.Inspect(file, func(node ast.Node) bool {
+		lit, ok := node.(*ast.BasicLit)
+		if !ok || lit.Kind != token.STRING {
+			return true
+		}
+		value, err := strconv.Unquote(lit.Value)
+		if err != nil {
+			return true
+		}
+		keyType, ok := strings.CutPrefix(value, tinkTypeURLPrefix)
+		if !ok {
+			return true
+		}
+		for _, classical := range tinkClassicalKeyTypes {
+			if strings.HasPrefix(keyType, classical.keyType) {
+				r.report(lit.Pos(), ruleTink, "Tink key type %s is quantum-vulnerable %s", keyType, classical.algorithm)
+				break
+			}
+		}
+		return true
+	})
+}
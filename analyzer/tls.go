@@ -0,0 +1,93 @@
+package analyzer
+
+import (
+	"crypto/tls"
+	"go/ast"
+	"go/constant"
+	"strings"
+)
+
+// Identifiers of TLS session ticket and resumption state handling. Resumed
+// sessions skip the key exchange, so their confidentiality rests on the
+// ticket keys and on the key exchange of the original handshake, which a
+// migration to hybrid post-quantum key exchange does not retroactively fix.
+var tlsSessionIdentifiers = []QvFunction{
+	{"SetSessionTicketKeys", "crypto/tls"},
+	{"EncryptTicket", "crypto/tls"},
+	{"DecryptTicket", "crypto/tls"},
+	{"NewLRUClientSessionCache", "crypto/tls"},
+	{"NewResumptionState", "crypto/tls"},
+	{"ParseSessionState", "crypto/tls"},
+}
+
+// Fields of tls.Config configuring session tickets and resumption.
+var tlsSessionFields = []QvField{
+	{"SessionTicketKey", "Config", "crypto/tls"},
+	{"ClientSessionCache", "Config", "crypto/tls"},
+	{"WrapSession", "Config", "crypto/tls"},
+	{"UnwrapSession", "Config", "crypto/tls"},
+}
+
+// Fields of tls.Config bounding the negotiated protocol version.
+var tlsVersionFields = []QvField{
+	{"MinVersion", "Config", "crypto/tls"},
+	{"MaxVersion", "Config", "crypto/tls"},
+}
+
+// Protocol versions of crypto/tls.
+const (
+	tlsVersion12 = 0x0303
+	tlsVersion13 = 0x0304
+)
+
+// Reports tls.Config versions allowing deprecated TLS 1.0 and 1.1, and
+// maximum versions below TLS 1.3, which rule out hybrid post-quantum key
+// exchange: it is only negotiated in TLS 1.3.
+func reportTLSVersions(r *reporter, file *ast.File) {
+	info := r.pass.TypesInfo
+	ast.Inspect(file, func(node ast.Node) bool {
+		switch node := node.(type) {
+		case *ast.CompositeLit:
+			for _, elt := range node.Elts {
+				keyValue, ok := elt.(*ast.KeyValueExpr)
+				if !ok {
+					continue
+				}
+				if fieldName, ok := vulnerableField(info, node, keyValue.Key, tlsVersionFields); ok {
+					reportTLSVersion(r, fieldName, keyValue.Value)
+				}
+			}
+		case *ast.AssignStmt:
+			if len(node.Lhs) != len(node.Rhs) {
+				return true
+			}
+			for i, lhs := range node.Lhs {
+				selector, ok := lhs.(*ast.SelectorExpr)
+				if !ok {
+					continue
+				}
+				if fieldName, ok := vulnerableFieldSelection(info, selector, tlsVersionFields); ok {
+					reportTLSVersion(r, fieldName, node.Rhs[i])
+				}
+			}
+		}
+		return true
+	})
+}
+
+func reportTLSVersion(r *reporter, fieldName string, value ast.Expr) {
+	tv := r.pass.TypesInfo.Types[value]
+	if tv.Value == nil {
+		return
+	}
+	version, ok := constant.Uint64Val(constant.ToInt(tv.Value))
+	if !ok || version == 0 {
+		return
+	}
+	switch {
+	case version < tlsVersion12:
+		r.report(value.Pos(), ruleTLSVersion, "%s allows deprecated %s, which cannot negotiate hybrid post-quantum key exchange; require TLS 1.2 or later, and allow TLS 1.3", fieldName, tls.VersionName(uint16(version)))
+	case version < tlsVersion13 && strings.HasSuffix(fieldName, ".MaxVersion"):
+		r.report(value.Pos(), ruleTLSVersion, "%s caps connections at %s, which rules out hybrid post-quantum key exchange, only available in TLS 1.3", fieldName, tls.VersionName(uint16(version)))
+	}
+}
@@ -0,0 +1,77 @@
+package analyzer
+
+import (
+	"go/ast"
+	"go/types"
+	"go/version"
+	"slices"
+
+	"golang.org/x/tools/go/analysis"
+)
+
+// Names of the crypto/tls types and fields configuring key exchange.
+var tlsConfigurationNames = []string{
+	"Config",
+	"CurveID",
+	"CurvePreferences",
+}
+
+// Names of the crypto/tls types and fields configuring Encrypted Client Hello.
+// Its HPKE DHKEM(X25519) keys are not protected by the hybrid key exchange of
+// the handshake.
+var tlsECHNames = []string{
+	"EncryptedClientHelloKey",
+	"EncryptedClientHelloKeys",
+	"EncryptedClientHelloConfigList",
+}
+
+// Reports whether the file uses crypto/ecdh only to configure the key exchange
+// of crypto/tls: every function using the package also configures TLS key
+// exchange, without configuring Encrypted Client Hello or computing a shared
+// secret with ecdh.PrivateKey.ECDH, and no package-level declaration uses it.
+// TLS negotiates hybrid post-quantum key exchange by default since
+// goVersionPQ, so such uses, like mapping curve preferences to crypto/ecdh
+// curves, are not a harvest-now-decrypt-later risk of their own.
+func tlsOnlyECDH(info *types.Info, file *ast.File) bool {
+	found := false
+	for _, decl := range file.Decls {
+		if !usesPackage(info, decl, "crypto/ecdh", nil) {
+			continue
+		}
+		funcDecl, ok := decl.(*ast.FuncDecl)
+		if !ok || !usesPackage(info, funcDecl, "crypto/tls", tlsConfigurationNames) ||
+			usesPackage(info, funcDecl, "crypto/tls", tlsECHNames) ||
+			usesPackage(info, funcDecl, "crypto/ecdh", []string{"ECDH"}) {
+			return false
+		}
+		found = true
+	}
+	return found
+}
+
+// Reports whether node references an object of the package, restricted to the
+// given names if any.
+func usesPackage(info *types.Info, node ast.Node, path string, names []string) bool {
+	found := false
+	ast.Inspect(node, func(node ast.Node) bool {
+		ident, ok := node.(*ast.Ident)
+		if !ok || found {
+			return !found
+		}
+		obj := info.Uses[ident]
+		if obj == nil || obj.Pkg() == nil || obj.Pkg().Path() != path {
+			return true
+		}
+		found = names == nil || slices.Contains(names, obj.Name())
+		return !found
+	})
+	return found
+}
+
+// Reports whether the file negotiates hybrid post-quantum TLS key exchange by
+// default, that is whether it targets goVersionPQ or later, or the current
+// toolchain.
+func tlsHybridByDefault(pass *analysis.Pass, file *ast.File) bool {
+	goVersion := fileGoVersion(pass, file)
+	return goVersion == "" || version.Compare(goVersion, goVersionPQ) >= 0
+}
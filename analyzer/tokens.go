@@ -0,0 +1,26 @@
+package analyzer
+
+// Identifiers of token libraries signing with classical asymmetric keys:
+// PASETO public mode (v2 and v4 use Ed25519, v3 ECDSA P-384) and the NaCl box
+// keys macaroon-bakery encrypts third-party caveats with. The symmetric modes
+// of the same libraries, PASETO local tokens, branca and plain macaroons, are
+// not quantum-vulnerable and are never reported.
+var tokenSigningIdentifiers = []QvFunction{
+	{"NewV4AsymmetricSecretKey", "aidanwoods.dev/go-paseto"},
+	{"NewV4AsymmetricSecretKeyFromHex", "aidanwoods.dev/go-paseto"},
+	{"NewV4AsymmetricSecretKeyFromSeed", "aidanwoods.dev/go-paseto"},
+	{"NewV4AsymmetricPublicKeyFromHex", "aidanwoods.dev/go-paseto"},
+	{"NewV4AsymmetricPublicKeyFromBytes", "aidanwoods.dev/go-paseto"},
+	{"NewV3AsymmetricSecretKey", "aidanwoods.dev/go-paseto"},
+	{"NewV3AsymmetricSecretKeyFromHex", "aidanwoods.dev/go-paseto"},
+	{"NewV3AsymmetricPublicKeyFromHex", "aidanwoods.dev/go-paseto"},
+	{"NewV3AsymmetricPublicKeyFromBytes", "aidanwoods.dev/go-paseto"},
+	{"V4Sign", "aidanwoods.dev/go-paseto"},
+	{"V3Sign", "aidanwoods.dev/go-paseto"},
+	{"ParseV4Public", "aidanwoods.dev/go-paseto"},
+	{"ParseV3Public", "aidanwoods.dev/go-paseto"},
+	{"Sign", "github.com/o1egl/paseto"},
+	{"Verify", "github.com/o1egl/paseto"},
+	{"GenerateKey", "gopkg.in/macaroon-bakery.v2/bakery"},
+	{"GenerateKey", "github.com/go-macaroon-bakery/macaroon-bakery/v3/bakery"},
+}
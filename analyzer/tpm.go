@@ -0,0 +1,59 @@
+package analyzer
+
+import "go/ast"
+
+// Algorithm constants of TPM 2.0 and attestation libraries, with the
+// classical algorithm they select: go-tpm, in its current and legacy APIs, and
+// go-attestation.
+var tpmKeyAlgorithms = map[QvFunction]string{
+	{"TPMAlgRSA", "github.com/google/go-tpm/tpm2"}:            "RSA",
+	{"TPMAlgRSASSA", "github.com/google/go-tpm/tpm2"}:         "RSA",
+	{"TPMAlgRSAPSS", "github.com/google/go-tpm/tpm2"}:         "RSA",
+	{"TPMAlgECC", "github.com/google/go-tpm/tpm2"}:            "ECC",
+	{"TPMAlgECDSA", "github.com/google/go-tpm/tpm2"}:          "ECDSA",
+	{"TPMAlgECDH", "github.com/google/go-tpm/tpm2"}:           "ECDH",
+	{"TPMECCNistP256", "github.com/google/go-tpm/tpm2"}:       "ECC P-256",
+	{"TPMECCNistP384", "github.com/google/go-tpm/tpm2"}:       "ECC P-384",
+	{"AlgRSA", "github.com/google/go-tpm/tpm2"}:               "RSA",
+	{"AlgECC", "github.com/google/go-tpm/tpm2"}:               "ECC",
+	{"CurveNISTP256", "github.com/google/go-tpm/tpm2"}:        "ECC P-256",
+	{"AlgRSA", "github.com/google/go-tpm/legacy/tpm2"}:        "RSA",
+	{"AlgRSASSA", "github.com/google/go-tpm/legacy/tpm2"}:     "RSA",
+	{"AlgECC", "github.com/google/go-tpm/legacy/tpm2"}:        "ECC",
+	{"AlgECDSA", "github.com/google/go-tpm/legacy/tpm2"}:      "ECDSA",
+	{"CurveNISTP256", "github.com/google/go-tpm/legacy/tpm2"}: "ECC P-256",
+	{"CurveNISTP384", "github.com/google/go-tpm/legacy/tpm2"}: "ECC P-384",
+	{"RSA", "github.com/google/go-attestation/attest"}:        "RSA",
+	{"ECDSA", "github.com/google/go-attestation/attest"}:      "ECDSA",
+}
+
+// Key templates of go-tpm and the key helpers of go-tpm-tools, with the
+// classical algorithm of the keys they create.
+var tpmKeyTemplates = map[QvFunction]string{
+	{"RSASRKTemplate", "github.com/google/go-tpm/tpm2"}:               "RSA-2048",
+	{"ECCSRKTemplate", "github.com/google/go-tpm/tpm2"}:               "ECC P-256",
+	{"RSAEKTemplate", "github.com/google/go-tpm/tpm2"}:                "RSA-2048",
+	{"ECCEKTemplate", "github.com/google/go-tpm/tpm2"}:                "ECC P-256",
+	{"AKTemplateRSA", "github.com/google/go-tpm-tools/client"}:        "RSA-2048",
+	{"AKTemplateECC", "github.com/google/go-tpm-tools/client"}:        "ECC P-256",
+	{"SRKTemplateRSA", "github.com/google/go-tpm-tools/client"}:       "RSA-2048",
+	{"SRKTemplateECC", "github.com/google/go-tpm-tools/client"}:       "ECC P-256",
+	{"DefaultEKTemplateRSA", "github.com/google/go-tpm-tools/client"}: "RSA-2048",
+	{"DefaultEKTemplateECC", "github.com/google/go-tpm-tools/client"}: "ECC P-256",
+	{"AttestationKeyRSA", "github.com/google/go-tpm-tools/client"}:    "RSA-2048",
+	{"AttestationKeyECC", "github.com/google/go-tpm-tools/client"}:    "ECC P-256",
+	{"EndorsementKeyRSA", "github.com/google/go-tpm-tools/client"}:    "RSA-2048",
+	{"EndorsementKeyECC", "github.com/google/go-tpm-tools/client"}:    "ECC P-256",
+	{"GceAttestationKeyRSA", "github.com/google/go-tpm-tools/client"}: "RSA-2048",
+	{"GceAttestationKeyECC", "github.com/google/go-tpm-tools/client"}: "ECC P-256",
+}
+
+// Reports the algorithm constants and key templates of TPM libraries
+// selecting classical keys. Keys rooted in hardware, such as attestation,
+// endorsement and storage root keys, have the longest replacement lead
+// times: replacing them needs TPMs supporting post-quantum algorithms, and
+// often new devices.
+func reportTPMKeyTemplates(r *reporter, file *ast.File) {
+	reportConstants(r, file, tpmKeyAlgorithms, ruleTPMKeyTemplate, `algorithm "%s.%s" selects a quantum-vulnerable %s hardware-bound key, the slowest kind of key to replace`)
+	reportConstants(r, file, tpmKeyTemplates, ruleTPMKeyTemplate, `key template "%s.%s" selects a quantum-vulnerable %s hardware-bound key, the slowest kind of key to replace`)
+}
@@ -0,0 +1,24 @@
+package analyzer
+
+// Identifiers of entry points verifying artifacts signed by other
+// organizations: webhook payloads, license keys and JWTs. Their signing keys
+// belong to the counterparty, so these cannot migrate without it. Webhooks
+// authenticated with HMAC, like those of GitHub and Stripe, are symmetric and
+// not listed.
+var externalTrustIdentifiers = []QvFunction{
+	{"VerifyInteraction", "github.com/bwmarrin/discordgo"},
+	{"ConvertPublicKeyBase64ToECDSA", "github.com/sendgrid/sendgrid-go/helpers/eventwebhook"},
+	{"VerifySignature", "github.com/sendgrid/sendgrid-go/helpers/eventwebhook"},
+	{"PublicKeyFromB64String", "github.com/hyperboloide/lk"},
+	{"PublicKeyFromBytes", "github.com/hyperboloide/lk"},
+	{"Verify", "github.com/hyperboloide/lk"},
+	{"Verify", "github.com/keygen-sh/keygen-go/v3"},
+	{"Parse", "github.com/golang-jwt/jwt/v5"},
+	{"ParseWithClaims", "github.com/golang-jwt/jwt/v5"},
+	{"Parse", "github.com/golang-jwt/jwt/v4"},
+	{"ParseWithClaims", "github.com/golang-jwt/jwt/v4"},
+	{"Parse", "github.com/lestrrat-go/jwx/v2/jwt"},
+	{"Verify", "github.com/lestrrat-go/jwx/v2/jws"},
+	{"Verify", "github.com/go-jose/go-jose/v4"},
+	{"Verify", "github.com/coreos/go-oidc/v3/oidc"},
+}
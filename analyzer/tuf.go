@@ -0,0 +1,32 @@
+package analyzer
+
+// Identifiers of The Update Framework (TUF) and Notary root-of-trust key
+// handling: repositories generating and adding the Ed25519, ECDSA or RSA keys
+// of the root and top-level roles, and clients initialized with a trusted
+// root. Clients pin the root keys until a root rotation signed by the
+// current root keys, often held offline by several people, reaches them.
+var tufIdentifiers = []QvFunction{
+	{"NewRepo", "github.com/theupdateframework/go-tuf"},
+	{"NewRepoIndent", "github.com/theupdateframework/go-tuf"},
+	{"GenKey", "github.com/theupdateframework/go-tuf"},
+	{"GenKeyWithExpires", "github.com/theupdateframework/go-tuf"},
+	{"AddPrivateKey", "github.com/theupdateframework/go-tuf"},
+	{"AddVerificationKey", "github.com/theupdateframework/go-tuf"},
+	{"RevokeKey", "github.com/theupdateframework/go-tuf"},
+	{"NewClient", "github.com/theupdateframework/go-tuf/client"},
+	{"Init", "github.com/theupdateframework/go-tuf/client"},
+	{"GenerateEd25519Key", "github.com/theupdateframework/go-tuf/pkg/keys"},
+	{"GenerateEcdsaKey", "github.com/theupdateframework/go-tuf/pkg/keys"},
+	{"GenerateRsaKey", "github.com/theupdateframework/go-tuf/pkg/keys"},
+	{"Root", "github.com/theupdateframework/go-tuf/v2/metadata"},
+	{"KeyFromPublicKey", "github.com/theupdateframework/go-tuf/v2/metadata"},
+	{"AddKey", "github.com/theupdateframework/go-tuf/v2/metadata"},
+	{"New", "github.com/theupdateframework/go-tuf/v2/metadata/config"},
+	{"New", "github.com/theupdateframework/go-tuf/v2/metadata/trustedmetadata"},
+	{"NewFileCachedRepository", "github.com/theupdateframework/notary/client"},
+	{"NewRepository", "github.com/theupdateframework/notary/client"},
+	{"Initialize", "github.com/theupdateframework/notary/client"},
+	{"RotateKey", "github.com/theupdateframework/notary/client"},
+	{"NewCryptoService", "github.com/theupdateframework/notary/cryptoservice"},
+	{"Create", "github.com/theupdateframework/notary/cryptoservice"},
+}
@@ -0,0 +1,61 @@
+package analyzer
+
+import (
+	"go/ast"
+	"go/token"
+	"go/types"
+)
+
+// Fields of tls.Config replacing or extending certificate chain verification
+// with callbacks.
+var verificationCallbackFields = []QvField{
+	{"VerifyPeerCertificate", "Config", "crypto/tls"},
+	{"VerifyConnection", "Config", "crypto/tls"},
+}
+
+// Methods of crypto/x509 checking certificate signatures one link at a time,
+// as custom chain verification does.
+var signatureCheckIdentifiers = functionsOf("crypto/x509", "CheckSignatureFrom", "CheckSignature")
+
+// Fields pinning certificate verification to a custom pool of roots.
+var rootPoolFields = []QvField{
+	{"RootCAs", "Config", "crypto/tls"},
+	{"ClientCAs", "Config", "crypto/tls"},
+	{"Roots", "VerifyOptions", "crypto/x509"},
+}
+
+// Fields of x509.Certificate naming the algorithms of its signature and key.
+var certificateAlgorithmFields = []QvField{
+	{"SignatureAlgorithm", "Certificate", "crypto/x509"},
+	{"PublicKeyAlgorithm", "Certificate", "crypto/x509"},
+}
+
+// Reports comparisons of the algorithms of certificates with fixed algorithms,
+// as custom verification does to restrict the chains it accepts. Chains with
+// ML-DSA or composite signatures fail those checks even where the standard
+// verification would accept them.
+func reportCertificateAlgorithmChecks(r *reporter, file *ast.File) {
+	info := r.pass.TypesInfo
+	ast.Inspect(file, func(node ast.Node) bool {
+		binary, ok := node.(*ast.BinaryExpr)
+		if !ok || (binary.Op != token.EQL && binary.Op != token.NEQ) {
+			return true
+		}
+		for _, operands := range [][2]ast.Expr{{binary.X, binary.Y}, {binary.Y, binary.X}} {
+			selector, ok := ast.Unparen(operands[0]).(*ast.SelectorExpr)
+			if !ok {
+				continue
+			}
+			fieldName, ok := vulnerableFieldSelection(info, selector, certificateAlgorithmFields)
+			if !ok {
+				continue
+			}
+			if tv := info.Types[operands[1]]; tv.Value == nil {
+				continue
+			}
+			r.report(binary.Pos(), ruleCustomCertificateVerification, `comparison of field "%s" with %s restricts accepted certificates to a fixed algorithm; post-quantum and hybrid chains will fail it`, fieldName, types.ExprString(operands[1]))
+			return true
+		}
+		return true
+	})
+}
@@ -0,0 +1,19 @@
+package analyzer
+
+import "slices"
+
+// Functions of push notification libraries generating or signing with the
+// ECDSA P-256 keys push services authenticate senders with, and encrypting
+// payloads with ECDH P-256: webpush-go's VAPID keys and RFC 8291 payload
+// encryption, and the APNs authentication keys of apns2.
+var webPushIdentifiers = slices.Concat(
+	functionsOf("github.com/SherClockHolmes/webpush-go", "GenerateVAPIDKeys", "SendNotification", "SendNotificationWithContext"),
+	functionsOf("github.com/sideshow/apns2/token", "AuthKeyFromFile", "AuthKeyFromBytes"),
+)
+
+// Struct fields setting the keys push notifications are sent with.
+var webPushFields = []QvField{
+	{"VAPIDPrivateKey", "Options", "github.com/SherClockHolmes/webpush-go"},
+	{"VAPIDPublicKey", "Options", "github.com/SherClockHolmes/webpush-go"},
+	{"AuthKey", "Token", "github.com/sideshow/apns2/token"},
+}
@@ -0,0 +1,75 @@
+package analyzer
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// Wrapper declares a function of an internal crypto wrapper package as
+// performing the same operation as a quantum-vulnerable function it wraps, so
+// calls to it are reported like calls to the wrapped function without
+// whole-program analysis.
+type Wrapper struct {
+	Function QvFunction
+	Wraps    QvFunction
+}
+
+// ParseWrapper returns the Wrapper declaring that function wraps wraps. Both
+// are qualified by import path, such as "example.com/internal/crypto.Sign"
+// and "crypto/rsa.SignPSS", and wraps has to be a function the analyzer
+// reports.
+func ParseWrapper(function, wraps string) (Wrapper, error) {
+	fn, err := parseQualifiedFunction(function)
+	if err != nil {
+		return Wrapper{}, err
+	}
+	wrapped, err := parseQualifiedFunction(wraps)
+	if err != nil {
+		return Wrapper{}, err
+	}
+	if _, ok := wrappedSymbolRule(wrapped); !ok {
+		return Wrapper{}, fmt.Errorf("%q is not a function reported by the analyzer", wraps)
+	}
+	return Wrapper{Function: fn, Wraps: wrapped}, nil
+}
+
+// Splits an import path qualified function name, such as "crypto/rsa.SignPSS".
+func parseQualifiedFunction(name string) (QvFunction, error) {
+	dot := strings.LastIndex(name, ".")
+	if dot <= strings.LastIndex(name, "/") || dot == len(name)-1 {
+		return QvFunction{}, fmt.Errorf("invalid function %q, want an import path qualified name such as \"crypto/rsa.SignPSS\"", name)
+	}
+	return QvFunction{FnName: name[dot+1:], Package: name[:dot]}, nil
+}
+
+// Returns the symbol rule reporting the function.
+func wrappedSymbolRule(fn QvFunction) (symbolRule, bool) {
+	idx := slices.IndexFunc(symbolRules, func(symbolRule symbolRule) bool {
+		return slices.Contains(symbolRule.symbols, fn)
+	})
+	if idx == -1 {
+		return symbolRule{}, false
+	}
+	return symbolRules[idx], true
+}
+
+// Returns the symbol rules reporting calls to the wrappers under the rules of
+// the functions they wrap. The operation of a wrapper is that of the function
+// it wraps, whatever its own name.
+func wrapperSymbolRules(wrappers []Wrapper) []symbolRule {
+	var wrapperRules []symbolRule
+	for _, wrapper := range wrappers {
+		wrapped, ok := wrappedSymbolRule(wrapper.Wraps)
+		if !ok {
+			continue
+		}
+		wrapperRules = append(wrapperRules, symbolRule{
+			rule:      wrapped.rule,
+			symbols:   []QvFunction{wrapper.Function},
+			message:   fmt.Sprintf(`wraps "%s.%s", which %s`, wrapper.Wraps.Package, wrapper.Wraps.FnName, wrapped.message),
+			operation: classifyOperation(wrapper.Wraps.FnName),
+		})
+	}
+	return wrapperRules
+}
@@ -0,0 +1,38 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"runtime/debug"
+
+	"github.com/ahan-adelaide/pqc-analyzer/docsite"
+)
+
+func runDocs(args []string) int {
+	flags := flag.NewFlagSet("docs", flag.ContinueOnError)
+	format := flags.String("format", docsite.FormatHTML, "output format: html or markdown")
+	output := flags.String("o", "pqc-analyzer-docs", "directory to write the documentation to")
+	flags.Usage = func() {
+		fmt.Fprintln(flags.Output(), "usage: pqc-analyzer docs [flags]")
+		flags.PrintDefaults()
+	}
+	if err := flags.Parse(args); err != nil {
+		return exitError
+	}
+	if flags.NArg() != 0 {
+		flags.Usage()
+		return exitError
+	}
+
+	var version string
+	if info, ok := debug.ReadBuildInfo(); ok && info.Main.Version != "(devel)" {
+		version = info.Main.Version
+	}
+	if err := docsite.Write(*output, *format, version); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return exitError
+	}
+	fmt.Printf("wrote the documentation of the rules to %s\n", *output)
+	return exitOK
+}
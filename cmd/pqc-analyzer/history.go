@@ -0,0 +1,101 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/ahan-adelaide/pqc-analyzer/history"
+	"github.com/ahan-adelaide/pqc-analyzer/report"
+)
+
+func runRecord(args []string) int {
+	flags := flag.NewFlagSet("record", flag.ContinueOnError)
+	historyPath := flags.String("history", history.DefaultPath, "path to the history file")
+	label := flags.String("label", "", "label of the scanned revision, such as a commit or tag")
+	flags.Usage = func() {
+		fmt.Fprintln(flags.Output(), "usage: pqc-analyzer record [flags] report.json")
+		flags.PrintDefaults()
+	}
+	if err := flags.Parse(args); err != nil {
+		return exitError
+	}
+	if flags.NArg() != 1 {
+		flags.Usage()
+		return exitError
+	}
+
+	rep, err := report.Load(flags.Arg(0))
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return exitError
+	}
+	if err := history.Append(*historyPath, history.Summarize(rep, time.Now(), *label)); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return exitError
+	}
+	return exitOK
+}
+
+func runTrend(args []string) int {
+	flags := flag.NewFlagSet("trend", flag.ContinueOnError)
+	historyPath := flags.String("history", history.DefaultPath, "path to the history file")
+	flags.Usage = func() {
+		fmt.Fprintln(flags.Output(), "usage: pqc-analyzer trend [flags]")
+		flags.PrintDefaults()
+	}
+	if err := flags.Parse(args); err != nil {
+		return exitError
+	}
+
+	entries, err := history.Load(*historyPath)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return exitError
+	}
+	if err := history.WriteTrend(os.Stdout, entries); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return exitError
+	}
+	return exitOK
+}
+
+func runBadge(args []string) int {
+	flags := flag.NewFlagSet("badge", flag.ContinueOnError)
+	out := flags.String("o", "", "write the badge to this file instead of the standard output")
+	label := flags.String("label", "quantum readiness", "label of the badge")
+	flags.Usage = func() {
+		fmt.Fprintln(flags.Output(), "usage: pqc-analyzer badge [flags] report.json")
+		flags.PrintDefaults()
+	}
+	if err := flags.Parse(args); err != nil {
+		return exitError
+	}
+	if flags.NArg() != 1 {
+		flags.Usage()
+		return exitError
+	}
+
+	rep, err := report.Load(flags.Arg(0))
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return exitError
+	}
+	badge := history.NewBadge(history.Summarize(rep, time.Now(), ""), *label)
+
+	if *out == "" {
+		err = history.WriteBadge(os.Stdout, badge)
+	} else {
+		var file *os.File
+		if file, err = os.Create(*out); err == nil {
+			err = errors.Join(history.WriteBadge(file, badge), file.Close())
+		}
+	}
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return exitError
+	}
+	return exitOK
+}
@@ -2,13 +2,59 @@
 //
 // It finds where quantum-vulnerable libraries and functions are used in code,
 // and warns of them, potentially proposing alternatives.
+//
+// Run without a subcommand it behaves like any other analysis driver. The
+// subcommands are:
+//
+//	scan	analyze packages, a source archive or a file from standard input, optionally under a matrix of build configurations
+//	discover	analyze every Go module under a directory into a single report
+//	report	work with report files written by scan -format=json
+//	record	append the summary of a report file to the history
+//	trend	chart the finding counts of the history over time
+//	badge	write a shields.io badge summarizing a report file
+//	plan	turn a report file into a phased migration plan
+//	suppress	accept the findings of a rule under a path in the configuration file
+//	rules	update the rules database from the URL in the configuration file
+//	selftest	check that every rule fires on an embedded known-vulnerable corpus
+//	docs	write the documentation of the rules as a static HTML or Markdown site
+//	scaffold	generate a crypto agility layer with ML-KEM and classical implementations behind build tags
 package main
 
 import (
+	"os"
+
 	"github.com/ahan-adelaide/pqc-analyzer/analyzer"
 	"golang.org/x/tools/go/analysis/singlechecker"
 )
 
 func main() {
+	if len(os.Args) > 1 {
+		switch os.Args[1] {
+		case "scan":
+			os.Exit(runScan(os.Args[2:]))
+		case "discover":
+			os.Exit(runScanCommand("discover", os.Args[2:]))
+		case "report":
+			os.Exit(runReport(os.Args[2:]))
+		case "record":
+			os.Exit(runRecord(os.Args[2:]))
+		case "trend":
+			os.Exit(runTrend(os.Args[2:]))
+		case "badge":
+			os.Exit(runBadge(os.Args[2:]))
+		case "plan":
+			os.Exit(runPlan(os.Args[2:]))
+		case "suppress":
+			os.Exit(runSuppress(os.Args[2:]))
+		case "rules":
+			os.Exit(runRules(os.Args[2:]))
+		case "selftest":
+			os.Exit(runSelftest(os.Args[2:]))
+		case "docs":
+			os.Exit(runDocs(os.Args[2:]))
+		case "scaffold":
+			os.Exit(runScaffold(os.Args[2:]))
+		}
+	}
 	singlechecker.Main(&analyzer.PqcAnalyzer)
 }
@@ -0,0 +1,88 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/ahan-adelaide/pqc-analyzer/analyzer"
+	"github.com/ahan-adelaide/pqc-analyzer/config"
+	"github.com/ahan-adelaide/pqc-analyzer/plan"
+	"github.com/ahan-adelaide/pqc-analyzer/report"
+)
+
+func runPlan(args []string) int {
+	flags := flag.NewFlagSet("plan", flag.ContinueOnError)
+	configPath := flags.String("config", "", "path to the configuration file with the effort weights (default "+config.DefaultPath+")")
+	format := flags.String("format", "markdown", "output format: markdown or json")
+	flags.Usage = func() {
+		fmt.Fprintln(flags.Output(), "usage: pqc-analyzer plan [flags] report.json")
+		flags.PrintDefaults()
+	}
+	if err := flags.Parse(args); err != nil {
+		return exitError
+	}
+	if flags.NArg() != 1 {
+		flags.Usage()
+		return exitError
+	}
+
+	cfg, err := config.Load(*configPath)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return exitError
+	}
+	rep, err := report.Load(flags.Arg(0))
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return exitError
+	}
+
+	weights, err := planWeights(cfg)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return exitError
+	}
+	p := plan.New(rep, weights)
+	switch *format {
+	case "markdown":
+		err = plan.WriteMarkdown(os.Stdout, p)
+	case "json":
+		encoder := json.NewEncoder(os.Stdout)
+		encoder.SetIndent("", "  ")
+		err = encoder.Encode(p)
+	default:
+		err = fmt.Errorf("unknown output format %q", *format)
+	}
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return exitError
+	}
+	return exitOK
+}
+
+// Returns the default effort weights, overridden by those of the
+// configuration.
+func planWeights(cfg *config.Config) (plan.Weights, error) {
+	weights := plan.DefaultWeights()
+	if cfg.Plan == nil {
+		return weights, nil
+	}
+	for severity, days := range cfg.Plan.Severities {
+		if _, err := analyzer.ParseSeverity(severity); err != nil {
+			return plan.Weights{}, fmt.Errorf("invalid config plan: %s", err.Error())
+		}
+		if days < 0 {
+			return plan.Weights{}, fmt.Errorf("invalid config plan: negative effort %v for severity %q", days, severity)
+		}
+		weights.Severities[severity] = days
+	}
+	for category, multiplier := range cfg.Plan.Categories {
+		if multiplier < 0 {
+			return plan.Weights{}, fmt.Errorf("invalid config plan: negative multiplier %v for category %q", multiplier, category)
+		}
+		weights.Categories[category] = multiplier
+	}
+	return weights, nil
+}
@@ -0,0 +1,67 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/ahan-adelaide/pqc-analyzer/report"
+)
+
+func runReport(args []string) int {
+	if len(args) == 0 {
+		fmt.Fprintln(os.Stderr, "usage: pqc-analyzer report diff [flags] old.json new.json")
+		return exitError
+	}
+	switch args[0] {
+	case "diff":
+		return runReportDiff(args[1:])
+	}
+	fmt.Fprintf(os.Stderr, "unknown report command %q\n", args[0])
+	return exitError
+}
+
+func runReportDiff(args []string) int {
+	flags := flag.NewFlagSet("report diff", flag.ContinueOnError)
+	format := flags.String("format", "text", "output format: text or json")
+	flags.Usage = func() {
+		fmt.Fprintln(flags.Output(), "usage: pqc-analyzer report diff [flags] old.json new.json")
+		flags.PrintDefaults()
+	}
+	if err := flags.Parse(args); err != nil {
+		return exitError
+	}
+	if flags.NArg() != 2 {
+		flags.Usage()
+		return exitError
+	}
+
+	oldReport, err := report.Load(flags.Arg(0))
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return exitError
+	}
+	newReport, err := report.Load(flags.Arg(1))
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return exitError
+	}
+
+	diff := report.ComputeDiff(oldReport, newReport)
+	switch *format {
+	case "text":
+		err = report.WriteDiffText(os.Stdout, diff)
+	case "json":
+		encoder := json.NewEncoder(os.Stdout)
+		encoder.SetIndent("", "  ")
+		err = encoder.Encode(diff)
+	default:
+		err = fmt.Errorf("unknown output format %q", *format)
+	}
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return exitError
+	}
+	return exitOK
+}
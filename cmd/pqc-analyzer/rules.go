@@ -0,0 +1,116 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io/fs"
+	"os"
+	"time"
+
+	"github.com/ahan-adelaide/pqc-analyzer/analyzer"
+	"github.com/ahan-adelaide/pqc-analyzer/config"
+	"github.com/ahan-adelaide/pqc-analyzer/rulesdb"
+)
+
+func runRules(args []string) int {
+	if len(args) == 0 {
+		fmt.Fprintln(os.Stderr, "usage: pqc-analyzer rules update [flags]")
+		return exitError
+	}
+	switch args[0] {
+	case "update":
+		return runRulesUpdate(args[1:])
+	}
+	fmt.Fprintf(os.Stderr, "unknown rules command %q\n", args[0])
+	return exitError
+}
+
+func runRulesUpdate(args []string) int {
+	flags := flag.NewFlagSet("rules update", flag.ContinueOnError)
+	configPath := flags.String("config", "", "path to the configuration file (default "+config.DefaultPath+")")
+	retries := flags.Int("retries", 3, "number of retries of failed requests")
+	timeout := flags.Duration("timeout", time.Minute, "give up updating after this long")
+	flags.Usage = func() {
+		fmt.Fprintln(flags.Output(), "usage: pqc-analyzer rules update [flags]")
+		flags.PrintDefaults()
+	}
+	if err := flags.Parse(args); err != nil {
+		return exitError
+	}
+
+	cfg, err := config.Load(*configPath)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return exitError
+	}
+	if cfg.Rules == nil || cfg.Rules.URL == "" {
+		fmt.Fprintln(os.Stderr, "rules update requires a rules URL in the configuration file")
+		return exitError
+	}
+	publicKey, err := rulesdb.ParsePublicKey(cfg.Rules.PublicKey)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return exitError
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), *timeout)
+	defer cancel()
+	source := rulesdb.Source{
+		URL:       cfg.Rules.URL,
+		PublicKey: publicKey,
+		Retries:   *retries,
+		Backoff:   time.Second,
+	}
+	path := rulesPath(cfg.Rules)
+	db, err := source.Update(ctx, path)
+	var fetchErr *rulesdb.FetchError
+	if errors.As(err, &fetchErr) {
+		// Offline, fall back to the local copy of a previous update.
+		current, loadErr := rulesdb.Load(path, publicKey)
+		if loadErr != nil {
+			fmt.Fprintln(os.Stderr, err)
+			return exitError
+		}
+		fmt.Fprintf(os.Stderr, "%s; keeping rules database %s\n", err.Error(), current)
+		return exitOK
+	}
+	if err != nil {
+		// A database failing verification or older than the local copy
+		// may have been tampered with or rolled back.
+		fmt.Fprintln(os.Stderr, err)
+		return exitError
+	}
+	fmt.Printf("updated rules database to %s\n", db)
+	return exitOK
+}
+
+// Returns the path of the local copy of the rules database.
+func rulesPath(rules *config.Rules) string {
+	if rules.Path != "" {
+		return rules.Path
+	}
+	return rulesdb.DefaultPath
+}
+
+// Returns the wrappers of the local copy of the rules database of the
+// configuration. Until the first update, there are none.
+func rulesWrappers(cfg *config.Config) ([]analyzer.Wrapper, error) {
+	if cfg.Rules == nil {
+		return nil, nil
+	}
+	publicKey, err := rulesdb.ParsePublicKey(cfg.Rules.PublicKey)
+	if err != nil {
+		return nil, err
+	}
+	path := rulesPath(cfg.Rules)
+	if _, err := os.Stat(path); errors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	}
+	db, err := rulesdb.Load(path, publicKey)
+	if err != nil {
+		return nil, err
+	}
+	return db.Wrappers(), nil
+}
@@ -0,0 +1,45 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/ahan-adelaide/pqc-analyzer/scaffold"
+)
+
+func runScaffold(args []string) int {
+	usage := "usage: pqc-analyzer scaffold " + strings.Join(scaffold.Kinds(), "|") + " [flags]"
+	if len(args) == 0 {
+		fmt.Fprintln(os.Stderr, usage)
+		return exitError
+	}
+	kind := args[0]
+	flags := flag.NewFlagSet("scaffold "+kind, flag.ContinueOnError)
+	dir := flags.String("dir", "internal/pqcrypto", "directory to write the package to")
+	pkg := flags.String("package", "", "name of the package (default the base name of -dir)")
+	force := flags.Bool("force", false, "overwrite existing files")
+	flags.Usage = func() {
+		fmt.Fprintln(flags.Output(), usage)
+		flags.PrintDefaults()
+	}
+	if err := flags.Parse(args[1:]); err != nil {
+		return exitError
+	}
+	if flags.NArg() != 0 {
+		flags.Usage()
+		return exitError
+	}
+
+	paths, err := scaffold.Write(kind, scaffold.Options{Dir: *dir, Package: *pkg, Force: *force})
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return exitError
+	}
+	for _, path := range paths {
+		fmt.Printf("wrote %s\n", path)
+	}
+	fmt.Printf("\nCall NewKEM, GenerateSigner and NewVerifier of %s instead of crypto/ecdh, crypto/ecdsa, crypto/ed25519 and crypto/rsa, then build with -tags=%s where peers still need X25519.\n", *dir, scaffold.ClassicalTag)
+	return exitOK
+}
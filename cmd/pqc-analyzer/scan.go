@@ -0,0 +1,343 @@
+package main
+
+import (
+	"cmp"
+	"flag"
+	"fmt"
+	"io"
+	"maps"
+	"os"
+	"path"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/ahan-adelaide/pqc-analyzer/analyzer"
+	"github.com/ahan-adelaide/pqc-analyzer/config"
+	"github.com/ahan-adelaide/pqc-analyzer/report"
+	"github.com/ahan-adelaide/pqc-analyzer/scan"
+)
+
+// Exit codes, matching those of the analysis drivers.
+const (
+	exitOK       = 0
+	exitError    = 1
+	exitFindings = 3
+)
+
+func runScan(args []string) int {
+	return runScanCommand("scan", args)
+}
+
+// Runs the scan command, or the discover command scanning every module under
+// a directory, which share their flags.
+func runScanCommand(name string, args []string) int {
+	discover := name == "discover"
+	flags := flag.NewFlagSet(name, flag.ContinueOnError)
+	configPath := flags.String("config", "", "path to the configuration file (default "+config.DefaultPath+")")
+	matrix := flags.Bool("matrix", false, "analyze under every build configuration in the config matrix and merge the findings")
+	format := flags.String("format", "text", "output format: "+strings.Join(report.Formats(), ", "))
+	deep := flags.Bool("deep", false, "enable whole-program analysis (slower)")
+	reachableFrom := flags.String("reachable-from", "", "in deep mode, demote findings unreachable from these entrypoints: main or exported")
+	schemas := flags.Bool("schemas", false, "also scan .proto files and OpenAPI documents for classical key, signature and certificate fields")
+	keyFiles := flags.Bool("keyfiles", false, "also scan YAML, JSON, .env and Terraform files for committed classical private keys and JSON Web Keys")
+	top := flags.Int("top", 0, "only report the findings in the N files with the most findings of each category")
+	maxPerRule := flags.Int("max-per-rule", 0, "only report the first N findings of each rule")
+	cacheDir := flags.String("cache-dir", "", "cache the findings of packages in this directory, to skip unchanged packages in later scans")
+	metricsOut := flags.String("metrics-out", "", "write run metrics as JSON to this local file")
+	tests := flags.Bool("test", true, "indicates whether test files should be analyzed, too")
+	includeDeps := flags.Bool("include-deps", false, "also analyze the dependencies of the packages outside the standard library, tagging their findings with their module")
+	failOn := flags.String("fail-on", "info", "minimum severity of first-party findings failing the scan, or none")
+	failOnDeps := flags.String("fail-on-deps", "none", "minimum severity of dependency findings failing the scan, or none")
+	stdinFilename := flags.String("stdin-filename", "", "analyze a single file read from standard input, reported under this name, with JSON output unless -format is set")
+	flags.Usage = func() {
+		if discover {
+			fmt.Fprintln(flags.Output(), "usage: pqc-analyzer discover [flags] [dir]")
+		} else {
+			fmt.Fprintln(flags.Output(), "usage: pqc-analyzer scan [flags] [packages | archive | -]")
+		}
+		flags.PrintDefaults()
+	}
+	if err := flags.Parse(args); err != nil {
+		return exitError
+	}
+	if discover && flags.NArg() > 1 {
+		flags.Usage()
+		return exitError
+	}
+	// A single file read from standard input, named by -stdin-filename or
+	// the "-" pattern.
+	stdin := *stdinFilename != "" || slices.Contains(flags.Args(), "-")
+	if stdin {
+		if discover || flags.NArg() > 1 || flags.NArg() == 1 && flags.Arg(0) != "-" {
+			flags.Usage()
+			return exitError
+		}
+		explicit := false
+		flags.Visit(func(f *flag.Flag) {
+			explicit = explicit || f.Name == "format"
+		})
+		if !explicit {
+			*format = "json"
+		}
+	}
+
+	cfg, err := config.Load(*configPath)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return exitError
+	}
+	policies, err := policies(cfg)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return exitError
+	}
+	var thresholds report.Thresholds
+	if thresholds.FirstParty, err = threshold(*failOn); err != nil {
+		fmt.Fprintf(os.Stderr, "invalid -fail-on: %s\n", err.Error())
+		return exitError
+	}
+	if thresholds.Dependencies, err = threshold(*failOnDeps); err != nil {
+		fmt.Fprintf(os.Stderr, "invalid -fail-on-deps: %s\n", err.Error())
+		return exitError
+	}
+
+	opts := scan.Options{
+		Patterns:      flags.Args(),
+		Tests:         *tests,
+		IncludeDeps:   *includeDeps,
+		Deep:          *deep,
+		ReachableFrom: *reachableFrom,
+		Allow:         cfg.Allow,
+		Providers:     cfg.Providers,
+		Schemas:       *schemas,
+		KeyFiles:      *keyFiles,
+		CacheDir:      *cacheDir,
+	}
+	if len(opts.Patterns) == 0 {
+		opts.Patterns = []string{"."}
+	}
+	if opts.OperationSeverities, err = operationSeverities(cfg); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return exitError
+	}
+	if opts.SeverityOverrides, err = severityOverrides(cfg); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return exitError
+	}
+	if opts.Suppressions, err = suppressions(cfg); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return exitError
+	}
+	if opts.Wrappers, err = wrappers(cfg); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return exitError
+	}
+	rulesWrappers, err := rulesWrappers(cfg)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return exitError
+	}
+	opts.Wrappers = append(rulesWrappers, opts.Wrappers...)
+	if opts.Messages, err = messages(cfg); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return exitError
+	}
+	if *matrix {
+		if len(cfg.Matrix) == 0 {
+			fmt.Fprintln(os.Stderr, "-matrix requires a build matrix in the configuration file")
+			return exitError
+		}
+		opts.Builds = cfg.Matrix
+	}
+
+	if *metricsOut != "" {
+		opts.Metrics = &scan.Metrics{}
+	}
+
+	var rep *report.Report
+	if discover {
+		root := "."
+		if flags.NArg() == 1 {
+			root = flags.Arg(0)
+		}
+		rep, err = scan.RunModules(root, opts)
+	} else if stdin {
+		src, readErr := io.ReadAll(os.Stdin)
+		if readErr != nil {
+			fmt.Fprintf(os.Stderr, "failed to read standard input: %s\n", readErr.Error())
+			return exitError
+		}
+		rep, err = scan.RunSource(cmp.Or(*stdinFilename, "stdin.go"), src, opts)
+	} else if len(opts.Patterns) == 1 && scan.IsArchive(opts.Patterns[0]) {
+		rep, err = scan.RunArchive(opts.Patterns[0], opts)
+	} else {
+		rep, err = scan.Run(opts)
+	}
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return exitError
+	}
+	if opts.Metrics != nil {
+		if err := scan.WriteMetrics(*metricsOut, opts.Metrics); err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			return exitError
+		}
+	}
+	if len(policies) > 0 {
+		wd, _ := os.Getwd()
+		rep.PolicyViolations = rep.Violations(policies, wd)
+		// Policies replace the default thresholds, not explicit ones.
+		explicit := false
+		flags.Visit(func(f *flag.Flag) {
+			explicit = explicit || f.Name == "fail-on" || f.Name == "fail-on-deps"
+		})
+		if !explicit {
+			thresholds = report.Thresholds{}
+		}
+	}
+	// Capping only limits what is written: the scan fails on every finding.
+	failing := rep.Failing(thresholds)
+	rep.Cap(*top, *maxPerRule)
+	if err := report.WriteFormat(os.Stdout, *format, rep); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return exitError
+	}
+	if len(failing) > 0 || len(rep.PolicyViolations) > 0 {
+		return exitFindings
+	}
+	return exitOK
+}
+
+// Returns the severity threshold with the given name, or nil for "none".
+func threshold(name string) (*analyzer.Severity, error) {
+	if name == "none" {
+		return nil, nil
+	}
+	severity, err := analyzer.ParseSeverity(name)
+	if err != nil {
+		return nil, err
+	}
+	return &severity, nil
+}
+
+// Returns the severity overrides by operation of the configuration.
+func operationSeverities(cfg *config.Config) (map[analyzer.Operation]analyzer.Severity, error) {
+	severities := make(map[analyzer.Operation]analyzer.Severity)
+	for name, severityName := range cfg.Operations {
+		operation, err := analyzer.ParseOperation(name)
+		if err != nil {
+			return nil, fmt.Errorf("invalid config operations: %s", err.Error())
+		}
+		severity, err := analyzer.ParseSeverity(severityName)
+		if err != nil {
+			return nil, fmt.Errorf("invalid config operations: %s", err.Error())
+		}
+		severities[operation] = severity
+	}
+	return severities, nil
+}
+
+// Returns the severity overrides by rule and path of the configuration.
+func severityOverrides(cfg *config.Config) ([]scan.SeverityOverride, error) {
+	var overrides []scan.SeverityOverride
+	for _, override := range cfg.Overrides {
+		if override.Rule != "" && !slices.ContainsFunc(analyzer.Rules(), func(rule analyzer.Rule) bool {
+			return rule.ID == override.Rule || rule.Category == override.Rule
+		}) {
+			return nil, fmt.Errorf("invalid config overrides: unknown rule or category %q", override.Rule)
+		}
+		if _, err := path.Match(override.Path, ""); err != nil {
+			return nil, fmt.Errorf("invalid config overrides: invalid path %q: %s", override.Path, err.Error())
+		}
+		severity, err := analyzer.ParseSeverity(override.Severity)
+		if err != nil {
+			return nil, fmt.Errorf("invalid config overrides: %s", err.Error())
+		}
+		overrides = append(overrides, scan.SeverityOverride{Rule: override.Rule, Path: override.Path, Severity: severity})
+	}
+	return overrides, nil
+}
+
+// Returns the suppressions of the configuration.
+func suppressions(cfg *config.Config) ([]scan.Suppression, error) {
+	var suppressions []scan.Suppression
+	for _, suppression := range cfg.Suppressions {
+		if suppression.Rule != "" && !slices.ContainsFunc(analyzer.Rules(), func(rule analyzer.Rule) bool {
+			return rule.ID == suppression.Rule || rule.Category == suppression.Rule
+		}) {
+			return nil, fmt.Errorf("invalid config suppressions: unknown rule or category %q", suppression.Rule)
+		}
+		if _, err := path.Match(suppression.Path, ""); err != nil {
+			return nil, fmt.Errorf("invalid config suppressions: invalid path %q: %s", suppression.Path, err.Error())
+		}
+		if suppression.Reason == "" {
+			return nil, fmt.Errorf("invalid config suppressions: suppression of %q under %q has no reason", suppression.Rule, suppression.Path)
+		}
+		if suppression.Until != "" {
+			if _, err := time.Parse(time.DateOnly, suppression.Until); err != nil {
+				return nil, fmt.Errorf("invalid config suppressions: invalid date %q, want YYYY-MM-DD", suppression.Until)
+			}
+		}
+		suppressions = append(suppressions, scan.Suppression(suppression))
+	}
+	return suppressions, nil
+}
+
+// Returns the policies of the configuration.
+func policies(cfg *config.Config) ([]*report.Policy, error) {
+	var policies []*report.Policy
+	for _, policy := range cfg.Policies {
+		parsed, err := report.ParsePolicy(policy.Name, policy.Deny)
+		if err != nil {
+			return nil, fmt.Errorf("invalid config policies: %s", err.Error())
+		}
+		policies = append(policies, parsed)
+	}
+	return policies, nil
+}
+
+// Returns the message catalog of the configuration in its locale, or nil if
+// it does not override messages.
+func messages(cfg *config.Config) (*analyzer.Catalog, error) {
+	if cfg.Messages == nil {
+		return nil, nil
+	}
+	templates, replacements := make(map[string]string), make(map[string]string)
+	maps.Copy(templates, cfg.Messages.Templates)
+	maps.Copy(replacements, cfg.Messages.Replacements)
+	if locale := cfg.Messages.Locale; locale != "" {
+		overrides, ok := cfg.Messages.Locales[locale]
+		if !ok {
+			language, _, _ := strings.Cut(strings.ReplaceAll(locale, "_", "-"), "-")
+			if overrides, ok = cfg.Messages.Locales[language]; !ok {
+				return nil, fmt.Errorf("invalid config messages: no templates for locale %q", locale)
+			}
+		}
+		maps.Copy(templates, overrides.Templates)
+		maps.Copy(replacements, overrides.Replacements)
+	}
+	if deadline := cfg.Messages.Deadline; deadline != "" {
+		if _, err := time.Parse(time.DateOnly, deadline); err != nil {
+			return nil, fmt.Errorf("invalid config messages: invalid deadline %q", deadline)
+		}
+	}
+	catalog, err := analyzer.NewCatalog(templates, replacements, cfg.Messages.Deadline)
+	if err != nil {
+		return nil, fmt.Errorf("invalid config messages: %s", err.Error())
+	}
+	return catalog, nil
+}
+
+// Returns the crypto wrappers of the configuration, ordered by function.
+func wrappers(cfg *config.Config) ([]analyzer.Wrapper, error) {
+	var wrappers []analyzer.Wrapper
+	for _, function := range slices.Sorted(maps.Keys(cfg.Wrappers)) {
+		wrapper, err := analyzer.ParseWrapper(function, cfg.Wrappers[function])
+		if err != nil {
+			return nil, fmt.Errorf("invalid config wrappers: %s", err.Error())
+		}
+		wrappers = append(wrappers, wrapper)
+	}
+	return wrappers, nil
+}
@@ -0,0 +1,61 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/ahan-adelaide/pqc-analyzer/config"
+	"github.com/ahan-adelaide/pqc-analyzer/scan"
+	"github.com/ahan-adelaide/pqc-analyzer/selftest"
+)
+
+func runSelftest(args []string) int {
+	flags := flag.NewFlagSet("selftest", flag.ContinueOnError)
+	configPath := flags.String("config", "", "path to the configuration file (default "+config.DefaultPath+")")
+	flags.Usage = func() {
+		fmt.Fprintln(flags.Output(), "usage: pqc-analyzer selftest [flags]")
+		flags.PrintDefaults()
+	}
+	if err := flags.Parse(args); err != nil {
+		return exitError
+	}
+
+	cfg, err := config.Load(*configPath)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return exitError
+	}
+	var opts scan.Options
+	if opts.Wrappers, err = wrappers(cfg); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return exitError
+	}
+	rulesWrappers, err := rulesWrappers(cfg)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return exitError
+	}
+	opts.Wrappers = append(rulesWrappers, opts.Wrappers...)
+
+	results, err := selftest.Run(opts)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return exitError
+	}
+	failed := 0
+	for _, result := range results {
+		status := "ok"
+		if !result.Passed() {
+			status = "FAIL"
+			failed++
+		}
+		fmt.Printf("%-4s %s %s (%d)\n", status, result.Rule.ID, result.Rule.Name, result.Findings)
+	}
+	if failed > 0 {
+		fmt.Printf("%d of %d rules did not fire on the self-test corpus\n", failed, len(results))
+		return exitError
+	}
+	fmt.Printf("all %d rules fired on the self-test corpus\n", len(results))
+	return exitOK
+}
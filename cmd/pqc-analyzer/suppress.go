@@ -0,0 +1,47 @@
+package main
+
+import (
+	"cmp"
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/ahan-adelaide/pqc-analyzer/config"
+)
+
+func runSuppress(args []string) int {
+	flags := flag.NewFlagSet("suppress", flag.ContinueOnError)
+	configPath := flags.String("config", "", "path to the configuration file to update (default "+config.DefaultPath+")")
+	rule := flags.String("rule", "", "rule ID or category of the findings to suppress")
+	pathGlob := flags.String("path", "", "glob of the files to suppress findings in, relative to the scanned directory, such as legacy/**")
+	reason := flags.String("reason", "", "why the findings are accepted")
+	until := flags.String("until", "", "date the suppression expires after, as YYYY-MM-DD")
+	flags.Usage = func() {
+		fmt.Fprintln(flags.Output(), "usage: pqc-analyzer suppress -rule=ID -path=GLOB -reason=TEXT [-until=YYYY-MM-DD] [flags]")
+		flags.PrintDefaults()
+	}
+	if err := flags.Parse(args); err != nil {
+		return exitError
+	}
+	if flags.NArg() > 0 || *rule == "" && *pathGlob == "" {
+		flags.Usage()
+		return exitError
+	}
+
+	suppression := config.Suppression{Rule: *rule, Path: *pathGlob, Reason: *reason, Until: *until}
+	if _, err := suppressions(&config.Config{Suppressions: []config.Suppression{suppression}}); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return exitError
+	}
+	replaced, err := config.AddSuppression(*configPath, suppression)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return exitError
+	}
+	verb := "added"
+	if replaced {
+		verb = "updated"
+	}
+	fmt.Printf("%s suppression of %s under %s in %s\n", verb, cmp.Or(*rule, "every rule"), cmp.Or(*pathGlob, "every file"), cmp.Or(*configPath, config.DefaultPath))
+	return exitOK
+}
@@ -0,0 +1,191 @@
+// Package config loads the pqc-analyzer configuration file.
+package config
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"strings"
+)
+
+// DefaultPath is the configuration file used when no explicit path is given.
+// A missing default configuration file is not an error.
+const DefaultPath = ".pqc-analyzer.json"
+
+// Config is the on-disk configuration of pqc-analyzer.
+type Config struct {
+	// Build configurations analyzed in matrix mode. Crypto selection often
+	// hides behind build tags or platform-specific files that the default
+	// build configuration never loads.
+	Matrix []BuildConfig `json:"matrix,omitempty"`
+
+	// Severities of findings by the side of the algorithm the call is on,
+	// "public" or "private", overriding the severity of their rule. Verifying
+	// existing classical signatures often has to continue long after new
+	// signing stops, so it can be weighted lower.
+	Operations map[string]string `json:"operations,omitempty"`
+
+	// Import path patterns of packages that are never reported, extending
+	// the built-in allowlist of symmetric and hash-based crypto packages,
+	// such as internal wrappers around them. Patterns ending in "/..."
+	// match every package below the prefix.
+	Allow []string `json:"allow,omitempty"`
+
+	// Import path patterns of the approved crypto providers. If set, imports
+	// of crypto implementations outside them are reported.
+	Providers []string `json:"providers,omitempty"`
+
+	// Functions of internal crypto wrapper packages, mapped to the
+	// quantum-vulnerable functions they wrap, both qualified by import path,
+	// such as "example.com/internal/crypto.Sign": "crypto/rsa.SignPSS".
+	// Calls to the wrappers are reported like calls to the wrapped functions.
+	Wrappers map[string]string `json:"wrappers,omitempty"`
+
+	// Severities of findings by rule and path, overriding the severity of
+	// their rule and the operations setting. The last matching override
+	// applies.
+	Overrides []Override `json:"overrides,omitempty"`
+
+	// Findings accepted by rule and path, like //pqc:ignore-until
+	// annotations without editing the code, as written by
+	// "pqc-analyzer suppress".
+	Suppressions []Suppression `json:"suppressions,omitempty"`
+
+	// Policies over the findings of scans. Findings violating a policy fail
+	// the scan; when policies are set, the severity thresholds only apply
+	// if given explicitly.
+	Policies []Policy `json:"policies,omitempty"`
+
+	// Wording of the messages of findings, so they can be localized or
+	// legally vetted.
+	Messages *Messages `json:"messages,omitempty"`
+
+	// Remote rules database extending the built-in rules, fetched by
+	// "pqc-analyzer rules update".
+	Rules *Rules `json:"rules,omitempty"`
+
+	// Weights of the effort estimates of "pqc-analyzer plan".
+	Plan *Plan `json:"plan,omitempty"`
+}
+
+// Plan weights the effort estimates of migration plans, in days per finding.
+type Plan struct {
+	// Days per finding of each severity, such as "high": 2, replacing the
+	// defaults of the severities given.
+	Severities map[string]float64 `json:"severities,omitempty"`
+	// Multipliers of the effort of the findings of each category, such as
+	// "hardware-bound-key": 4 for keys that need new devices.
+	Categories map[string]float64 `json:"categories,omitempty"`
+}
+
+// Rules locates a signed rules database and its local copy.
+type Rules struct {
+	URL string `json:"url"`
+	// Base64 encoded Ed25519 public key verifying the database.
+	PublicKey string `json:"publicKey"`
+	// Path of the local copy. Empty means the default path.
+	Path string `json:"path,omitempty"`
+}
+
+// Override sets the severity of the findings of a rule under some paths.
+type Override struct {
+	// Rule ID, such as "PQC001", or category, such as "elliptic-curve", of
+	// the findings. Empty matches every rule.
+	Rule string `json:"rule,omitempty"`
+	// Glob of the file paths relative to the scanned directory, such as
+	// "examples/**". Empty matches every file.
+	Path     string `json:"path,omitempty"`
+	Severity string `json:"severity"`
+}
+
+// Suppression accepts the findings of a rule under some paths, with a
+// reason, until a date.
+type Suppression struct {
+	// Rule ID or category of the findings. Empty matches every rule.
+	Rule string `json:"rule,omitempty"`
+	// Glob of the file paths relative to the scanned directory. Empty
+	// matches every file.
+	Path   string `json:"path,omitempty"`
+	Reason string `json:"reason"`
+	// Date the suppression expires after, as YYYY-MM-DD. Empty never
+	// expires.
+	Until string `json:"until,omitempty"`
+}
+
+// Policy denies the findings satisfying a condition, such as
+// `severity >= "critical" && path matches "services/payments/**" && !accepted`.
+type Policy struct {
+	Name string `json:"name"`
+	Deny string `json:"deny"`
+}
+
+// Messages overrides the messages of findings with text/template templates
+// over their symbol, replacement and deadline, such as
+// "{{.Symbol}} must be replaced by {{.Replacement}} before {{.Deadline}}".
+type Messages struct {
+	// Templates by rule ID, category, or "*" for every rule, and by status
+	// of accepted findings, such as "accepted-until".
+	Templates map[string]string `json:"templates,omitempty"`
+	// Replacements by rule ID or category, overriding the built-in ones.
+	Replacements map[string]string `json:"replacements,omitempty"`
+	// Migration deadline of findings, as YYYY-MM-DD.
+	Deadline string `json:"deadline,omitempty"`
+	// Locale whose templates and replacements apply, such as "de-CH". A
+	// locale missing from Locales falls back to its language, such as "de".
+	Locale string `json:"locale,omitempty"`
+	// Templates and replacements by locale, overriding the ones above.
+	Locales map[string]Locale `json:"locales,omitempty"`
+}
+
+// Locale is the wording of the messages of findings in one locale.
+type Locale struct {
+	Templates    map[string]string `json:"templates,omitempty"`
+	Replacements map[string]string `json:"replacements,omitempty"`
+}
+
+// BuildConfig is one build configuration packages can be loaded under.
+// Empty fields inherit the values of the current environment.
+type BuildConfig struct {
+	GOOS   string   `json:"goos,omitempty"`
+	GOARCH string   `json:"goarch,omitempty"`
+	Tags   []string `json:"tags,omitempty"`
+}
+
+func (b BuildConfig) String() string {
+	var parts []string
+	if b.GOOS != "" || b.GOARCH != "" {
+		parts = append(parts, b.GOOS+"/"+b.GOARCH)
+	}
+	if len(b.Tags) > 0 {
+		parts = append(parts, "tags="+strings.Join(b.Tags, ","))
+	}
+	if len(parts) == 0 {
+		return "default"
+	}
+	return strings.Join(parts, " ")
+}
+
+// Load reads the configuration file at path. If path is empty, DefaultPath is
+// read instead, and an empty configuration is returned if it does not exist.
+func Load(path string) (*Config, error) {
+	explicit := path != ""
+	if !explicit {
+		path = DefaultPath
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if !explicit && errors.Is(err, fs.ErrNotExist) {
+			return &Config{}, nil
+		}
+		return nil, fmt.Errorf("failed to read config %s: %s", path, err.Error())
+	}
+
+	var cfg Config
+	if err := json.Unmarshal(data, &cfg); err != nil {
+		return nil, fmt.Errorf("failed to parse config %s: %s", path, err.Error())
+	}
+	return &cfg, nil
+}
@@ -0,0 +1,109 @@
+package config_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/ahan-adelaide/pqc-analyzer/config"
+)
+
+func TestLoadMissingDefault(t *testing.T) {
+	t.Chdir(t.TempDir())
+
+	cfg, err := config.Load("")
+	if err != nil {
+		t.Fatalf("missing default config should not fail: %s", err.Error())
+	}
+	if len(cfg.Matrix) != 0 {
+		t.Errorf("expected empty config, got %+v", cfg)
+	}
+}
+
+func TestLoadMissingExplicit(t *testing.T) {
+	if _, err := config.Load(filepath.Join(t.TempDir(), "missing.json")); err == nil {
+		t.Error("missing explicit config should fail")
+	}
+}
+
+func TestLoadMatrix(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.json")
+	data := `{"matrix": [{}, {"goos": "windows", "goarch": "amd64", "tags": ["fips", "legacy"]}]}`
+	if err := os.WriteFile(path, []byte(data), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	cfg, err := config.Load(path)
+	if err != nil {
+		t.Fatalf("failed to load config: %s", err.Error())
+	}
+	if len(cfg.Matrix) != 2 {
+		t.Fatalf("expected 2 build configurations, got %d", len(cfg.Matrix))
+	}
+	if got := cfg.Matrix[0].String(); got != "default" {
+		t.Errorf("expected default build, got %q", got)
+	}
+	if got := cfg.Matrix[1].String(); got != "windows/amd64 tags=fips,legacy" {
+		t.Errorf("unexpected build string %q", got)
+	}
+}
+
+func TestAddSuppression(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.json")
+	data := "{\n\t\"operations\": {\"public\": \"low\"},\n\t\"allow\": [\"example.com/internal/hash\"]\n}\n"
+	if err := os.WriteFile(path, []byte(data), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, suppression := range []config.Suppression{
+		{Rule: "PQC003", Path: "legacy/**", Reason: "retired in Q3", Until: "2026-09-30"},
+		{Rule: "PQC001", Reason: "interop"},
+		{Rule: "PQC003", Path: "legacy/**", Reason: "retired in Q4", Until: "2026-12-31"},
+	} {
+		if _, err := config.AddSuppression(path, suppression); err != nil {
+			t.Fatalf("failed to add suppression: %s", err.Error())
+		}
+	}
+
+	got, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := `{
+	"operations": {"public": "low"},
+	"allow": ["example.com/internal/hash"],
+	"suppressions": [
+		{"rule":"PQC003","path":"legacy/**","reason":"retired in Q4","until":"2026-12-31"},
+		{"rule":"PQC001","reason":"interop"}
+	]
+}
+`
+	if string(got) != want {
+		t.Errorf("got config\n%s\nwant\n%s", got, want)
+	}
+	cfg, err := config.Load(path)
+	if err != nil {
+		t.Fatalf("failed to load updated config: %s", err.Error())
+	}
+	if len(cfg.Suppressions) != 2 || cfg.Operations["public"] != "low" {
+		t.Errorf("unexpected config %+v", cfg)
+	}
+}
+
+func TestAddSuppressionMissing(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.json")
+	replaced, err := config.AddSuppression(path, config.Suppression{Path: "examples/**", Reason: "demo code"})
+	if err != nil {
+		t.Fatalf("failed to create config: %s", err.Error())
+	}
+	if replaced {
+		t.Error("suppression of a new config reported as replaced")
+	}
+	cfg, err := config.Load(path)
+	if err != nil {
+		t.Fatalf("failed to load created config: %s", err.Error())
+	}
+	if len(cfg.Suppressions) != 1 || cfg.Suppressions[0].Path != "examples/**" {
+		t.Errorf("unexpected suppressions %+v", cfg.Suppressions)
+	}
+}
@@ -0,0 +1,112 @@
+package config
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"slices"
+)
+
+// AddSuppression adds a suppression to the configuration file at path, or
+// replaces the suppression of the same rule and path, and reports whether
+// one was replaced. If path is empty, DefaultPath is used. A missing file is
+// created. Only the suppressions are rewritten: the other settings of the
+// file are kept as written, in order.
+func AddSuppression(path string, suppression Suppression) (bool, error) {
+	if path == "" {
+		path = DefaultPath
+	}
+	data, err := os.ReadFile(path)
+	if err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return false, fmt.Errorf("failed to read config %s: %s", path, err.Error())
+	}
+	var cfg Config
+	var keys []string
+	var values []json.RawMessage
+	if len(bytes.TrimSpace(data)) > 0 {
+		if err := json.Unmarshal(data, &cfg); err != nil {
+			return false, fmt.Errorf("failed to parse config %s: %s", path, err.Error())
+		}
+		if keys, values, err = objectFields(data); err != nil {
+			return false, fmt.Errorf("failed to parse config %s: %s", path, err.Error())
+		}
+	}
+
+	replaced := false
+	if i := slices.IndexFunc(cfg.Suppressions, func(s Suppression) bool {
+		return s.Rule == suppression.Rule && s.Path == suppression.Path
+	}); i >= 0 {
+		cfg.Suppressions[i] = suppression
+		replaced = true
+	} else {
+		cfg.Suppressions = append(cfg.Suppressions, suppression)
+	}
+	// One suppression per line, like the other lists of the file.
+	var list bytes.Buffer
+	list.WriteString("[")
+	for i, s := range cfg.Suppressions {
+		entry, err := json.Marshal(s)
+		if err != nil {
+			return false, fmt.Errorf("failed to encode suppression: %s", err.Error())
+		}
+		if i > 0 {
+			list.WriteString(",")
+		}
+		list.WriteString("\n\t\t")
+		list.Write(entry)
+	}
+	list.WriteString("\n\t]")
+	if i := slices.Index(keys, "suppressions"); i >= 0 {
+		values[i] = list.Bytes()
+	} else {
+		keys = append(keys, "suppressions")
+		values = append(values, list.Bytes())
+	}
+
+	var out bytes.Buffer
+	out.WriteString("{\n")
+	for i, key := range keys {
+		name, _ := json.Marshal(key)
+		out.WriteString("\t")
+		out.Write(name)
+		out.WriteString(": ")
+		out.Write(values[i])
+		if i < len(keys)-1 {
+			out.WriteString(",")
+		}
+		out.WriteString("\n")
+	}
+	out.WriteString("}\n")
+	if err := os.WriteFile(path, out.Bytes(), 0o644); err != nil {
+		return false, fmt.Errorf("failed to write config %s: %s", path, err.Error())
+	}
+	return replaced, nil
+}
+
+// Returns the keys of a JSON object in order, and their values as written.
+func objectFields(data []byte) ([]string, []json.RawMessage, error) {
+	dec := json.NewDecoder(bytes.NewReader(data))
+	if token, err := dec.Token(); err != nil {
+		return nil, nil, err
+	} else if token != json.Delim('{') {
+		return nil, nil, fmt.Errorf("not a JSON object")
+	}
+	var keys []string
+	var values []json.RawMessage
+	for dec.More() {
+		token, err := dec.Token()
+		if err != nil {
+			return nil, nil, err
+		}
+		var value json.RawMessage
+		if err := dec.Decode(&value); err != nil {
+			return nil, nil, err
+		}
+		keys = append(keys, token.(string))
+		values = append(values, value)
+	}
+	return keys, values, nil
+}
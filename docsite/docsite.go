@@ -0,0 +1,237 @@
+// Package docsite renders the embedded rule documentation into a static site,
+// so environments without network access have the documentation of the rules
+// of their binary.
+package docsite
+
+import (
+	"fmt"
+	"html"
+	"html/template"
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/ahan-adelaide/pqc-analyzer/analyzer"
+)
+
+// Formats of the site.
+const (
+	FormatHTML     = "html"
+	FormatMarkdown = "markdown"
+)
+
+// Write writes the documentation of every rule to dir, one page per rule
+// named after its ID and an index listing the rules, in the given format.
+// The version of the binary, if known, is shown on the index.
+func Write(dir, format, version string) error {
+	var ext string
+	switch format {
+	case FormatHTML:
+		ext = ".html"
+	case FormatMarkdown:
+		ext = ".md"
+	default:
+		return fmt.Errorf("unknown documentation format %q", format)
+	}
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return fmt.Errorf("failed to create documentation directory: %s", err.Error())
+	}
+
+	// Rules of detectors are documented by their own help pages.
+	rules := slices.DeleteFunc(analyzer.Rules(), func(rule analyzer.Rule) bool {
+		return rule.Detector != ""
+	})
+	for _, rule := range rules {
+		doc, err := analyzer.RuleDoc(rule.ID)
+		if err != nil {
+			return err
+		}
+		page := doc
+		if format == FormatHTML {
+			page, err = htmlPage(rule.ID+": "+rule.Name, ruleHTML(rule, doc))
+			if err != nil {
+				return err
+			}
+		}
+		if err := writePage(filepath.Join(dir, rule.ID+ext), page); err != nil {
+			return err
+		}
+	}
+
+	index := indexMarkdown(rules, version)
+	if format == FormatHTML {
+		var err error
+		if index, err = htmlPage("pqc-analyzer rules", markdownHTML(index, ".html")); err != nil {
+			return err
+		}
+	}
+	return writePage(filepath.Join(dir, "index"+ext), index)
+}
+
+func writePage(path, page string) error {
+	if err := os.WriteFile(path, []byte(page), 0o644); err != nil {
+		return fmt.Errorf("failed to write documentation: %s", err.Error())
+	}
+	return nil
+}
+
+// Returns the index of the rules, as a Markdown table linking their pages.
+func indexMarkdown(rules []analyzer.Rule, version string) string {
+	var b strings.Builder
+	b.WriteString("# pqc-analyzer rules\n\n")
+	if version != "" {
+		fmt.Fprintf(&b, "Documentation of the rules of pqc-analyzer %s.\n\n", version)
+	}
+	b.WriteString("| Rule | Name | Category | Severity | Summary |\n")
+	b.WriteString("|---|---|---|---|---|\n")
+	for _, rule := range rules {
+		fmt.Fprintf(&b, "| [%s](%s.md) | %s | %s | %s | %s |\n", rule.ID, rule.ID, rule.Name, rule.Category, rule.Severity, strings.ReplaceAll(rule.Summary, "|", `\|`))
+	}
+	return b.String()
+}
+
+// Returns the HTML of the page of a rule: its documentation, with the
+// metadata of the rule after the title.
+func ruleHTML(rule analyzer.Rule, doc string) template.HTML {
+	title, body, _ := strings.Cut(doc, "\n")
+	meta := fmt.Sprintf("| Category | Severity | Summary |\n|---|---|---|\n| %s | %s | %s |\n", rule.Category, rule.Severity, strings.ReplaceAll(rule.Summary, "|", `\|`))
+	return markdownHTML(title+"\n\n"+meta+"\n"+body+"\n[All rules](index.md)\n", ".html")
+}
+
+var pageTemplate = template.Must(template.New("page").Parse(`<!DOCTYPE html>
+<html lang="en">
+<head>
+<meta charset="utf-8">
+<title>{{.Title}}</title>
+<style>
+body { font-family: sans-serif; max-width: 60em; margin: 2em auto; padding: 0 1em; line-height: 1.5; }
+code { background: #f3f3f3; padding: 0 0.2em; }
+pre { background: #f3f3f3; padding: 0.5em; overflow-x: auto; }
+table { border-collapse: collapse; }
+th, td { border: 1px solid #ccc; padding: 0.2em 0.5em; text-align: left; vertical-align: top; }
+</style>
+</head>
+<body>
+{{.Body}}</body>
+</html>
+`))
+
+func htmlPage(title string, body template.HTML) (string, error) {
+	var b strings.Builder
+	if err := pageTemplate.Execute(&b, struct {
+		Title string
+		Body  template.HTML
+	}{title, body}); err != nil {
+		return "", fmt.Errorf("failed to render documentation: %s", err.Error())
+	}
+	return b.String(), nil
+}
+
+// Renders the Markdown of the documentation pages to HTML: headings,
+// paragraphs, lists, tables, fenced code blocks, inline code and links. Links
+// to other pages, ending in .md, are rewritten to end in ext.
+func markdownHTML(markdown, ext string) template.HTML {
+	var b strings.Builder
+	lines := strings.Split(strings.TrimRight(markdown, "\n"), "\n")
+	for i := 0; i < len(lines); {
+		line := lines[i]
+		switch {
+		case strings.TrimSpace(line) == "":
+			i++
+		case strings.HasPrefix(line, "```"):
+			i++
+			b.WriteString("<pre><code>")
+			for ; i < len(lines) && !strings.HasPrefix(lines[i], "```"); i++ {
+				b.WriteString(html.EscapeString(lines[i]) + "\n")
+			}
+			b.WriteString("</code></pre>\n")
+			i++
+		case strings.HasPrefix(line, "#"):
+			level := len(line) - len(strings.TrimLeft(line, "#"))
+			fmt.Fprintf(&b, "<h%d>%s</h%d>\n", level, inlineHTML(strings.TrimSpace(line[level:]), ext), level)
+			i++
+		case strings.HasPrefix(line, "|"):
+			var rows [][]string
+			for ; i < len(lines) && strings.HasPrefix(lines[i], "|"); i++ {
+				rows = append(rows, tableCells(lines[i]))
+			}
+			b.WriteString("<table>\n")
+			for j, row := range rows {
+				// The second row separates the header from the body.
+				if j == 1 {
+					continue
+				}
+				cell := "td"
+				if j == 0 {
+					cell = "th"
+				}
+				b.WriteString("<tr>")
+				for _, text := range row {
+					fmt.Fprintf(&b, "<%s>%s</%s>", cell, inlineHTML(text, ext), cell)
+				}
+				b.WriteString("</tr>\n")
+			}
+			b.WriteString("</table>\n")
+		case strings.HasPrefix(line, "- "):
+			b.WriteString("<ul>\n")
+			for i < len(lines) && strings.HasPrefix(lines[i], "- ") {
+				item := []string{strings.TrimPrefix(lines[i], "- ")}
+				// Continuation lines of an item are indented.
+				for i++; i < len(lines) && strings.HasPrefix(lines[i], " ") && strings.TrimSpace(lines[i]) != ""; i++ {
+					item = append(item, strings.TrimSpace(lines[i]))
+				}
+				fmt.Fprintf(&b, "<li>%s</li>\n", inlineHTML(strings.Join(item, " "), ext))
+			}
+			b.WriteString("</ul>\n")
+		default:
+			var paragraph []string
+			for ; i < len(lines) && !blockStart(lines[i]); i++ {
+				paragraph = append(paragraph, lines[i])
+			}
+			fmt.Fprintf(&b, "<p>%s</p>\n", inlineHTML(strings.Join(paragraph, "\n"), ext))
+		}
+	}
+	return template.HTML(b.String())
+}
+
+// Reports whether a line ends a paragraph, by being blank or starting
+// another block.
+func blockStart(line string) bool {
+	return strings.TrimSpace(line) == "" || strings.HasPrefix(line, "#") || strings.HasPrefix(line, "|") ||
+		strings.HasPrefix(line, "- ") || strings.HasPrefix(line, "```")
+}
+
+// Splits a table row into its cells, keeping escaped pipes in them.
+func tableCells(row string) []string {
+	row = strings.TrimSpace(row)
+	row = strings.TrimSuffix(strings.TrimPrefix(row, "|"), "|")
+	var cells []string
+	for _, cell := range strings.Split(strings.ReplaceAll(row, `\|`, "\x00"), "|") {
+		cells = append(cells, strings.ReplaceAll(strings.TrimSpace(cell), "\x00", "|"))
+	}
+	return cells
+}
+
+var linkPattern = regexp.MustCompile(`\[([^\]]+)\]\(([^)\s]+)\)`)
+
+// Renders the inline code and links of text to HTML, escaping the rest.
+func inlineHTML(text, ext string) string {
+	var b strings.Builder
+	for i, part := range strings.Split(text, "`") {
+		if i%2 == 1 {
+			b.WriteString("<code>" + html.EscapeString(part) + "</code>")
+			continue
+		}
+		b.WriteString(linkPattern.ReplaceAllStringFunc(html.EscapeString(part), func(link string) string {
+			match := linkPattern.FindStringSubmatch(link)
+			target := match[2]
+			if !strings.Contains(target, "://") && strings.HasSuffix(target, ".md") {
+				target = strings.TrimSuffix(target, ".md") + ext
+			}
+			return fmt.Sprintf(`<a href="%s">%s</a>`, target, match[1])
+		}))
+	}
+	return b.String()
+}
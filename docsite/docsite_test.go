@@ -0,0 +1,85 @@
+package docsite_test
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/ahan-adelaide/pqc-analyzer/analyzer"
+	"github.com/ahan-adelaide/pqc-analyzer/docsite"
+)
+
+func TestWriteHTML(t *testing.T) {
+	dir := t.TempDir()
+	if err := docsite.Write(dir, docsite.FormatHTML, "v1.2.3"); err != nil {
+		t.Fatal(err)
+	}
+
+	index, err := os.ReadFile(filepath.Join(dir, "index.html"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, want := range []string{"pqc-analyzer v1.2.3", `<a href="PQC001.html">PQC001</a>`, "<td>elliptic-curve-import</td>"} {
+		if !strings.Contains(string(index), want) {
+			t.Errorf("index does not contain %q", want)
+		}
+	}
+	for _, rule := range analyzer.Rules() {
+		if _, err := os.Stat(filepath.Join(dir, rule.ID+".html")); err != nil {
+			t.Errorf("no page for rule %s: %s", rule.ID, err.Error())
+		}
+	}
+
+	page, err := os.ReadFile(filepath.Join(dir, "PQC001.html"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, want := range []string{
+		"<title>PQC001: elliptic-curve-import</title>",
+		"<h2>Migration</h2>",
+		"<li>",
+		"<code>crypto/ecdsa</code>",
+		`<a href="PQC012.html">PQC012</a>`,
+		`<a href="index.html">All rules</a>`,
+	} {
+		if !strings.Contains(string(page), want) {
+			t.Errorf("PQC001 page does not contain %q", want)
+		}
+	}
+	if strings.Contains(string(page), ".md") {
+		t.Error("PQC001 page links to Markdown pages")
+	}
+}
+
+func TestWriteMarkdown(t *testing.T) {
+	dir := t.TempDir()
+	if err := docsite.Write(dir, docsite.FormatMarkdown, ""); err != nil {
+		t.Fatal(err)
+	}
+
+	index, err := os.ReadFile(filepath.Join(dir, "index.md"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(index), "| [PQC001](PQC001.md) | elliptic-curve-import |") {
+		t.Errorf("index does not list PQC001:\n%s", index)
+	}
+	if strings.Contains(string(index), "Documentation of the rules of") {
+		t.Error("index shows a version without one")
+	}
+	page, err := os.ReadFile(filepath.Join(dir, "PQC001.md"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	doc, _ := analyzer.RuleDoc("PQC001")
+	if string(page) != doc {
+		t.Error("PQC001 page differs from the embedded documentation")
+	}
+}
+
+func TestWriteUnknownFormat(t *testing.T) {
+	if err := docsite.Write(t.TempDir(), "pdf", ""); err == nil {
+		t.Error("unknown format was accepted")
+	}
+}
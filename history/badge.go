@@ -0,0 +1,71 @@
+package history
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+)
+
+// Score deductions per finding of each severity. A repository without
+// findings scores 100.
+var severityPenalties = map[string]int{
+	"critical": 20,
+	"high":     10,
+	"medium":   5,
+	"low":      2,
+}
+
+// Score returns the quantum-readiness score of the entry, from 0 to 100.
+// Accepted interop debt and info findings do not lower the score.
+func Score(entry Entry) int {
+	score := 100
+	for severity, count := range entry.Severities {
+		score -= severityPenalties[severity] * count
+	}
+	return max(score, 0)
+}
+
+// Badge is a shields.io endpoint badge, rendered by
+// https://img.shields.io/endpoint?url=<location of the badge file>.
+type Badge struct {
+	SchemaVersion int    `json:"schemaVersion"`
+	Label         string `json:"label"`
+	Message       string `json:"message"`
+	Color         string `json:"color"`
+}
+
+// NewBadge returns the badge of the entry, with its score, finding count and
+// scan date.
+func NewBadge(entry Entry, label string) Badge {
+	score := Score(entry)
+	var color string
+	switch {
+	case score == 100:
+		color = "brightgreen"
+	case score >= 80:
+		color = "green"
+	case score >= 60:
+		color = "yellow"
+	case score >= 40:
+		color = "orange"
+	default:
+		color = "red"
+	}
+	findings := "findings"
+	if entry.Findings == 1 {
+		findings = "finding"
+	}
+	return Badge{
+		SchemaVersion: 1,
+		Label:         label,
+		Message:       fmt.Sprintf("%d/100, %d %s (%s)", score, entry.Findings, findings, entry.Time.Format("2006-01-02")),
+		Color:         color,
+	}
+}
+
+// WriteBadge writes the badge as JSON.
+func WriteBadge(w io.Writer, badge Badge) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(badge)
+}
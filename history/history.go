@@ -0,0 +1,93 @@
+// Package history keeps a local history of scan summaries, to track the
+// progress of a migration over time without an external dashboard.
+package history
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"time"
+
+	"github.com/ahan-adelaide/pqc-analyzer/report"
+)
+
+// DefaultPath is the history file used when no explicit path is given.
+const DefaultPath = ".pqc-history.jsonl"
+
+// Entry summarizes a single scan.
+type Entry struct {
+	Time time.Time `json:"time"`
+	// Label of the scanned revision, such as a commit or release tag.
+	Label    string `json:"label,omitempty"`
+	Findings int    `json:"findings"`
+	// Number of findings per category and per severity.
+	Categories  map[string]int `json:"categories"`
+	Severities  map[string]int `json:"severities"`
+	InteropDebt int            `json:"interopDebt"`
+}
+
+// Summarize returns the history entry of the report.
+func Summarize(r *report.Report, at time.Time, label string) Entry {
+	entry := Entry{
+		Time:        at,
+		Label:       label,
+		Findings:    len(r.Findings),
+		Categories:  make(map[string]int),
+		Severities:  make(map[string]int),
+		InteropDebt: len(r.InteropDebt),
+	}
+	for _, finding := range r.Findings {
+		entry.Categories[finding.Category]++
+		entry.Severities[finding.Severity]++
+	}
+	return entry
+}
+
+// Append appends the entry to the history file at path, creating it if
+// needed. The file holds one JSON entry per line.
+func Append(path string, entry Entry) error {
+	data, err := json.Marshal(entry)
+	if err != nil {
+		return fmt.Errorf("failed to encode history entry: %s", err.Error())
+	}
+	file, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o644)
+	if err != nil {
+		return fmt.Errorf("failed to open history %s: %s", path, err.Error())
+	}
+	if _, err := file.Write(append(data, '\n')); err != nil {
+		file.Close()
+		return fmt.Errorf("failed to write history %s: %s", path, err.Error())
+	}
+	return file.Close()
+}
+
+// Load reads the entries of the history file at path, in the order they were
+// appended. A missing history file has no entries.
+func Load(path string) ([]Entry, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to read history %s: %s", path, err.Error())
+	}
+
+	var entries []Entry
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	scanner.Buffer(nil, len(data)+1)
+	for line := 1; scanner.Scan(); line++ {
+		if len(bytes.TrimSpace(scanner.Bytes())) == 0 {
+			continue
+		}
+		var entry Entry
+		if err := json.Unmarshal(scanner.Bytes(), &entry); err != nil {
+			return nil, fmt.Errorf("failed to parse history %s:%d: %s", path, line, err.Error())
+		}
+		entries = append(entries, entry)
+	}
+	return entries, nil
+}
@@ -0,0 +1,76 @@
+package history_test
+
+import (
+	"bytes"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ahan-adelaide/pqc-analyzer/history"
+	"github.com/ahan-adelaide/pqc-analyzer/report"
+)
+
+func TestAppendAndLoad(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "history.jsonl")
+
+	entries, err := history.Load(path)
+	if err != nil || len(entries) != 0 {
+		t.Fatalf("missing history should be empty, got %v, %v", entries, err)
+	}
+
+	rep := &report.Report{
+		Findings: []report.Finding{
+			{RuleID: "PQC001", Category: "elliptic-curve", Severity: "medium"},
+			{RuleID: "PQC003", Category: "vulnerable-function", Severity: "high"},
+			{RuleID: "PQC003", Category: "vulnerable-function", Severity: "high"},
+		},
+	}
+	at := time.Date(2026, 10, 1, 12, 0, 0, 0, time.UTC)
+	for i, label := range []string{"v1.0", "v1.1"} {
+		if err := history.Append(path, history.Summarize(rep, at.AddDate(0, 0, i), label)); err != nil {
+			t.Fatal(err)
+		}
+		rep.Findings = rep.Findings[1:]
+	}
+
+	entries, err = history.Load(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) != 2 {
+		t.Fatalf("expected 2 entries, got %d", len(entries))
+	}
+	if entries[0].Label != "v1.0" || entries[0].Findings != 3 || entries[0].Categories["vulnerable-function"] != 2 {
+		t.Errorf("unexpected first entry %+v", entries[0])
+	}
+	if entries[1].Findings != 2 || entries[1].Severities["high"] != 2 {
+		t.Errorf("unexpected second entry %+v", entries[1])
+	}
+
+	var buf bytes.Buffer
+	if err := history.WriteTrend(&buf, entries); err != nil {
+		t.Fatal(err)
+	}
+	for _, want := range []string{"elliptic-curve", "2026-10-01 12:00  v1.0", "3 -> 2 findings (-1) since 2026-10-01"} {
+		if !strings.Contains(buf.String(), want) {
+			t.Errorf("trend does not contain %q:\n%s", want, buf.String())
+		}
+	}
+}
+
+func TestNewBadge(t *testing.T) {
+	entry := history.Entry{
+		Time:       time.Date(2026, 10, 1, 12, 0, 0, 0, time.UTC),
+		Findings:   3,
+		Severities: map[string]int{"medium": 1, "high": 2},
+	}
+	if score := history.Score(entry); score != 75 {
+		t.Errorf("got score %d, want 75", score)
+	}
+	badge := history.NewBadge(entry, "quantum readiness")
+	want := history.Badge{SchemaVersion: 1, Label: "quantum readiness", Message: "75/100, 3 findings (2026-10-01)", Color: "yellow"}
+	if badge != want {
+		t.Errorf("got badge %+v, want %+v", badge, want)
+	}
+}
@@ -0,0 +1,57 @@
+package history
+
+import (
+	"fmt"
+	"io"
+	"maps"
+	"slices"
+	"strings"
+	"text/tabwriter"
+)
+
+// Width of the longest bar of a trend chart.
+const barWidth = 40
+
+// WriteTrend charts the finding counts of the entries per category over time,
+// one row per entry, with a bar scaled to the largest total.
+func WriteTrend(w io.Writer, entries []Entry) error {
+	if len(entries) == 0 {
+		_, err := fmt.Fprintln(w, "no history recorded yet")
+		return err
+	}
+
+	categories := make(map[string]bool)
+	largest := 0
+	for _, entry := range entries {
+		for category := range entry.Categories {
+			categories[category] = true
+		}
+		largest = max(largest, entry.Findings)
+	}
+	columns := slices.Sorted(maps.Keys(categories))
+
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintf(tw, "DATE\tLABEL\tTOTAL\t%s\t\n", strings.Join(columns, "\t"))
+	for _, entry := range entries {
+		counts := make([]string, len(columns))
+		for i, category := range columns {
+			counts[i] = fmt.Sprint(entry.Categories[category])
+		}
+		bar := ""
+		if largest > 0 {
+			bar = strings.Repeat("#", (entry.Findings*barWidth+largest-1)/largest)
+		}
+		fmt.Fprintf(tw, "%s\t%s\t%d\t%s\t%s\n", entry.Time.Format("2006-01-02 15:04"), entry.Label, entry.Findings, strings.Join(counts, "\t"), bar)
+	}
+	if err := tw.Flush(); err != nil {
+		return err
+	}
+
+	if len(entries) > 1 {
+		first, last := entries[0], entries[len(entries)-1]
+		if _, err := fmt.Fprintf(w, "\n%d -> %d findings (%+d) since %s\n", first.Findings, last.Findings, last.Findings-first.Findings, first.Time.Format("2006-01-02")); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,381 @@
+// Package keyfiles scans configuration files, such as YAML, JSON, .env and
+// Terraform files, for the private keys and JSON Web Keys committed in them.
+// Configuration repositories often carry the actual classical keys the code
+// loads, which have to be rotated along with the code.
+package keyfiles
+
+import (
+	"bytes"
+	"crypto"
+	"crypto/ecdh"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/rsa"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/asn1"
+	"encoding/binary"
+	"encoding/pem"
+	"fmt"
+	"io/fs"
+	"math/big"
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/ahan-adelaide/pqc-analyzer/analyzer"
+	"github.com/ahan-adelaide/pqc-analyzer/report"
+)
+
+// RuleID is the ID of the analyzer rule key file findings are reported under.
+const RuleID = "PQC058"
+
+// Extensions of the configuration files scanned.
+var extensions = []string{".yaml", ".yml", ".json", ".env", ".tf", ".tfvars"}
+
+// Directories never scanned.
+var skippedDirs = []string{".git", "node_modules", "vendor", "testdata"}
+
+var (
+	pemBeginPattern = regexp.MustCompile(`-----BEGIN ([A-Z0-9 ]*PRIVATE KEY)-----`)
+	jwkTypePattern  = regexp.MustCompile(`"?kty"?\s*[:=]\s*"?(RSA|EC|OKP)\b`)
+	// Members of private JWKs: the private exponent or key, and the primes of
+	// RSA keys.
+	jwkPrivatePattern = regexp.MustCompile(`(?:^|[^\w-])"?[dpq]"?\s*[:=]`)
+)
+
+// Curves of OpenSSH ECDSA key types.
+var opensshCurves = map[string]string{
+	"ecdsa-sha2-nistp256": "P-256",
+	"ecdsa-sha2-nistp384": "P-384",
+	"ecdsa-sha2-nistp521": "P-521",
+}
+
+const message = "keys committed with configuration are the keys in use, and have to be rotated and moved to a secret store when their algorithm is replaced"
+
+// Scan walks the directory tree rooted at dir and returns the findings in its
+// configuration files: PEM private keys, written as is, in heredocs or in
+// strings with escaped newlines, and private JSON Web Keys of classical key
+// types.
+func Scan(dir string) ([]report.Finding, error) {
+	rule, ok := analyzer.LookupRule(RuleID)
+	if !ok {
+		return nil, fmt.Errorf("unknown rule %s", RuleID)
+	}
+
+	var findings []report.Finding
+	err := filepath.WalkDir(dir, func(path string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if entry.IsDir() {
+			if path != dir && slices.Contains(skippedDirs, entry.Name()) {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if !isConfigFile(entry.Name()) {
+			return nil
+		}
+		fileFindings, err := scanFile(path, rule)
+		if err != nil {
+			return err
+		}
+		findings = append(findings, fileFindings...)
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to scan key files in %s: %s", dir, err.Error())
+	}
+	return findings, nil
+}
+
+// Reports whether a file is a configuration file, by its extension, or by
+// its name for .env files such as .env.production.
+func isConfigFile(name string) bool {
+	return slices.Contains(extensions, filepath.Ext(name)) || strings.HasPrefix(name, ".env.")
+}
+
+func scanFile(path string, rule analyzer.Rule) ([]report.Finding, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %s", path, err.Error())
+	}
+	abs, err := filepath.Abs(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve %s: %s", path, err.Error())
+	}
+
+	var findings []report.Finding
+	lines := strings.Split(string(data), "\n")
+	for i := range lines {
+		column, message, ok := scanLine(lines, i)
+		if !ok {
+			continue
+		}
+		findings = append(findings, report.Finding{
+			File:     abs,
+			Line:     i + 1,
+			Column:   column,
+			RuleID:   rule.ID,
+			Category: rule.Category,
+			Severity: rule.Severity.String(),
+			Message:  message,
+			HelpURI:  rule.DocURL(),
+		})
+	}
+	return findings, nil
+}
+
+// Returns the column and message of a finding on the line at index i, the
+// start of a PEM private key or the key type of a JWK.
+func scanLine(lines []string, i int) (int, string, bool) {
+	line := lines[i]
+	if match := pemBeginPattern.FindStringSubmatchIndex(line); match != nil {
+		label := line[match[2]:match[3]]
+		algorithm, ok := describePrivateKey(pemBlock(lines, i, match[0]), label)
+		if !ok {
+			return 0, "", false
+		}
+		return match[0] + 1, fmt.Sprintf("PEM block %q holds a classical %s private key; %s", label, algorithm, message), true
+	}
+	if match := jwkTypePattern.FindStringSubmatchIndex(line); match != nil {
+		// Public JWKs, such as those of a JWKS endpoint, are not secrets.
+		if !jwkPrivatePattern.MatchString(jwkObject(lines, i, match[0])) {
+			return 0, "", false
+		}
+		kty := line[match[2]:match[3]]
+		return match[0] + 1, fmt.Sprintf("JSON Web Key of type %s holds a classical private key; %s", kty, message), true
+	}
+	return 0, "", false
+}
+
+// Returns the members of the JWK whose key type is at the given column of the
+// line at index i: the JSON or HCL object enclosing it, or its YAML mapping.
+func jwkObject(lines []string, i, column int) string {
+	text := strings.Join(lines, "\n")
+	pos := column
+	for _, line := range lines[:i] {
+		pos += len(line) + 1
+	}
+	// The innermost braces enclosing the key type, if any.
+	start, depth := -1, 0
+	for j := pos - 1; j >= 0 && start == -1; j-- {
+		switch text[j] {
+		case '}':
+			depth++
+		case '{':
+			if depth == 0 {
+				start = j
+			}
+			depth--
+		}
+	}
+	if start != -1 {
+		depth = 0
+		for j := start; j < len(text); j++ {
+			switch text[j] {
+			case '{':
+				depth++
+			case '}':
+				if depth--; depth == 0 {
+					return text[start : j+1]
+				}
+			}
+		}
+		return text[start:]
+	}
+
+	// The lines of the YAML mapping: those indented at least like the key
+	// type, from the list item it starts, if any, to a line indented less.
+	indent := column
+	members := []string{lines[i]}
+	first := strings.TrimSpace(lines[i][:column]) == "-"
+	for j := i - 1; j >= 0 && !first; j-- {
+		trimmed := strings.TrimLeft(lines[j], " ")
+		lineIndent := len(lines[j]) - len(trimmed)
+		if trimmed == "" || lineIndent < indent && !strings.HasPrefix(trimmed, "- ") {
+			break
+		}
+		members = append(members, lines[j])
+		if lineIndent < indent || strings.HasPrefix(trimmed, "- ") {
+			break
+		}
+	}
+	for _, line := range lines[i+1:] {
+		trimmed := strings.TrimLeft(line, " ")
+		if trimmed == "" || len(line)-len(trimmed) < indent {
+			break
+		}
+		members = append(members, line)
+	}
+	return strings.Join(members, "\n")
+}
+
+// Returns the PEM block starting at the given column of the line at index
+// i, up to its END line, with the quoting, indentation and escaped newlines
+// of the configuration file removed.
+func pemBlock(lines []string, i, column int) []byte {
+	var text []string
+	for j, line := range lines[i:] {
+		if j == 0 {
+			line = line[column:]
+		}
+		text = append(text, line)
+		if strings.Contains(line, "-----END ") {
+			break
+		}
+	}
+	joined := strings.NewReplacer(`\\n`, "\n", `\n`, "\n").Replace(strings.Join(text, "\n"))
+	var b strings.Builder
+	for _, line := range strings.Split(joined, "\n") {
+		line = strings.Trim(strings.TrimSpace(line), `"',\`)
+		if end := strings.Index(line, "-----END "); end != -1 {
+			if close := strings.Index(line[end+len("-----END "):], "-----"); close != -1 {
+				line = line[:end+len("-----END ")+close+len("-----")]
+			}
+		}
+		b.WriteString(line + "\n")
+	}
+	return []byte(b.String())
+}
+
+// Returns the classical algorithm of the private key of a PEM block, with its
+// size or curve when it can be parsed, or the algorithm its label names. PKCS#8
+// keys of other algorithms, such as ML-DSA, are not classical.
+func describePrivateKey(data []byte, label string) (string, bool) {
+	if block, _ := pem.Decode(data); block != nil {
+		var key any
+		var err error
+		switch block.Type {
+		case "RSA PRIVATE KEY":
+			key, err = x509.ParsePKCS1PrivateKey(block.Bytes)
+		case "EC PRIVATE KEY":
+			key, err = x509.ParseECPrivateKey(block.Bytes)
+		case "PRIVATE KEY":
+			if key, err = x509.ParsePKCS8PrivateKey(block.Bytes); err != nil {
+				return pkcs8Algorithm(block.Bytes)
+			}
+		case "OPENSSH PRIVATE KEY":
+			if algorithm, ok := opensshAlgorithm(block.Bytes); ok {
+				return algorithm, true
+			}
+		}
+		if signer, ok := key.(crypto.Signer); ok && err == nil {
+			return describePublicKey(signer.Public())
+		}
+		if _, ok := key.(*ecdh.PrivateKey); ok && err == nil {
+			return "X25519", true
+		}
+	}
+	switch label {
+	case "RSA PRIVATE KEY":
+		return "RSA", true
+	case "EC PRIVATE KEY":
+		return "ECDSA", true
+	case "DSA PRIVATE KEY":
+		return "DSA", true
+	}
+	return "RSA, ECDSA or Ed25519", true
+}
+
+// Classical algorithms of PKCS#8 private keys, by the object identifier of
+// their algorithm.
+var pkcs8Algorithms = map[string]string{
+	"1.2.840.113549.1.1.1": "RSA",
+	"1.2.840.10045.2.1":    "ECDSA",
+	"1.2.840.10040.4.1":    "DSA",
+	"1.3.101.110":          "X25519",
+	"1.3.101.111":          "X448",
+	"1.3.101.112":          "Ed25519",
+	"1.3.101.113":          "Ed448",
+}
+
+// Returns the classical algorithm of a PKCS#8 private key Go cannot parse,
+// from its algorithm identifier. Keys whose algorithm is not known to be
+// classical are not reported.
+func pkcs8Algorithm(der []byte) (string, bool) {
+	var key struct {
+		Version    int
+		Algorithm  pkix.AlgorithmIdentifier
+		PrivateKey []byte
+	}
+	if _, err := asn1.Unmarshal(der, &key); err != nil {
+		// Truncated or placeholder keys are reported all the same.
+		return "RSA, ECDSA or Ed25519", true
+	}
+	algorithm, ok := pkcs8Algorithms[key.Algorithm.Algorithm.String()]
+	return algorithm, ok
+}
+
+// Returns the classical algorithm and size or curve of a public key.
+func describePublicKey(pub any) (string, bool) {
+	switch pub := pub.(type) {
+	case *rsa.PublicKey:
+		return fmt.Sprintf("RSA-%d", pub.N.BitLen()), true
+	case *ecdsa.PublicKey:
+		return "ECDSA " + pub.Curve.Params().Name, true
+	case ed25519.PublicKey:
+		return "Ed25519", true
+	}
+	return "", false
+}
+
+// Returns the algorithm of an unencrypted or encrypted OpenSSH private key,
+// from the public key stored in its header.
+func opensshAlgorithm(data []byte) (string, bool) {
+	rest, ok := bytes.CutPrefix(data, []byte("openssh-key-v1\x00"))
+	if !ok {
+		return "", false
+	}
+	// The cipher name, KDF name and KDF options precede the number of keys
+	// and the first public key.
+	var pub []byte
+	for range 3 {
+		if _, rest, ok = sshString(rest); !ok {
+			return "", false
+		}
+	}
+	if len(rest) < 4 {
+		return "", false
+	}
+	if pub, _, ok = sshString(rest[4:]); !ok {
+		return "", false
+	}
+	keyType, pub, ok := sshString(pub)
+	if !ok {
+		return "", false
+	}
+	switch keyType := string(keyType); keyType {
+	case "ssh-rsa":
+		// The exponent precedes the modulus.
+		_, pub, _ = sshString(pub)
+		if modulus, _, ok := sshString(pub); ok {
+			return fmt.Sprintf("RSA-%d", new(big.Int).SetBytes(modulus).BitLen()), true
+		}
+		return "RSA", true
+	case "ssh-ed25519":
+		return "Ed25519", true
+	case "ssh-dss":
+		return "DSA", true
+	default:
+		if curve, ok := opensshCurves[keyType]; ok {
+			return "ECDSA " + curve, true
+		}
+	}
+	return "", false
+}
+
+// Splits a length-prefixed string of the SSH wire format off data.
+func sshString(data []byte) ([]byte, []byte, bool) {
+	if len(data) < 4 {
+		return nil, nil, false
+	}
+	n := binary.BigEndian.Uint32(data)
+	if uint64(len(data)-4) < uint64(n) {
+		return nil, nil, false
+	}
+	return data[4 : 4+n], data[4+n:], true
+}
@@ -0,0 +1,39 @@
+package keyfiles_test
+
+import (
+	"fmt"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/ahan-adelaide/pqc-analyzer/keyfiles"
+)
+
+func TestScan(t *testing.T) {
+	findings, err := keyfiles.Scan("testdata")
+	if err != nil {
+		t.Fatalf("scan failed: %s", err.Error())
+	}
+
+	var got []string
+	for _, finding := range findings {
+		if finding.RuleID != keyfiles.RuleID {
+			t.Errorf("unexpected rule %s", finding.RuleID)
+		}
+		message, _, _ := strings.Cut(finding.Message, ";")
+		got = append(got, fmt.Sprintf("%s:%d:%d: %s", filepath.Base(finding.File), finding.Line, finding.Column, message))
+	}
+	want := []string{
+		".env.production:2:13: PEM block \"OPENSSH PRIVATE KEY\" holds a classical Ed25519 private key",
+		"deploy.yaml:4:5: PEM block \"RSA PRIVATE KEY\" holds a classical RSA-1024 private key",
+		"main.tf:7:7: PEM block \"PRIVATE KEY\" holds a classical RSA, ECDSA or Ed25519 private key",
+		"realm.yml:2:5: JSON Web Key of type OKP holds a classical private key",
+		"realm.yml:10:5: JSON Web Key of type RSA holds a classical private key",
+		"secrets.json:1:56: PEM block \"PRIVATE KEY\" holds a classical ECDSA P-256 private key",
+		"signing-key.json:4:5: JSON Web Key of type EC holds a classical private key",
+	}
+	if !slices.Equal(got, want) {
+		t.Errorf("got findings\n%q\nwant\n%q", got, want)
+	}
+}
@@ -0,0 +1,239 @@
+// Package plan turns the findings of a report into a phased migration plan,
+// grouping them by category and estimating the effort of each group, so raw
+// findings become a roadmap teams can schedule.
+package plan
+
+import (
+	"cmp"
+	"fmt"
+	"io"
+	"maps"
+	"math"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/ahan-adelaide/pqc-analyzer/analyzer"
+	"github.com/ahan-adelaide/pqc-analyzer/report"
+)
+
+// Weights estimate the effort of migrating findings.
+type Weights struct {
+	// Days per finding of each severity.
+	Severities map[string]float64
+	// Multipliers of the effort of the findings of each category. Categories
+	// without one have a multiplier of 1.
+	Categories map[string]float64
+}
+
+// DefaultWeights returns the default weights: half a day for a high severity
+// finding, scaled up and down by severity, and more for the categories whose
+// keys are shared with other parties or bound to hardware.
+func DefaultWeights() Weights {
+	return Weights{
+		Severities: map[string]float64{
+			"critical": 1,
+			"high":     0.5,
+			"medium":   0.25,
+			"low":      0.1,
+			"info":     0.05,
+		},
+		Categories: map[string]float64{
+			analyzer.CategoryHardware:      4,
+			analyzer.CategoryE2EE:          3,
+			analyzer.CategoryExternalTrust: 3,
+			analyzer.CategoryMessaging:     2,
+			analyzer.CategoryPKI:           2,
+			analyzer.CategoryTokens:        2,
+			analyzer.CategoryAPI:           2,
+		},
+	}
+}
+
+// Phases of a plan, in the order they are carried out.
+var phases = []string{
+	"Critical and high severity findings",
+	"Medium and low severity findings",
+	"Algorithm agility, API surface and accepted interop debt",
+}
+
+// Plan is a phased migration plan.
+type Plan struct {
+	Phases []Phase `json:"phases"`
+	// Estimated effort of the whole plan, in days.
+	Effort float64 `json:"effortDays"`
+}
+
+// Phase is a stage of a plan, migrating groups of findings in order.
+type Phase struct {
+	Name   string  `json:"name"`
+	Groups []Group `json:"groups"`
+	// Estimated effort of the phase, in days.
+	Effort float64 `json:"effortDays"`
+}
+
+// Group is the findings of a category in a phase.
+type Group struct {
+	Category string `json:"category"`
+	// Highest severity of the findings.
+	Severity string   `json:"severity"`
+	Findings int      `json:"findings"`
+	Files    int      `json:"files"`
+	Rules    []string `json:"rules"`
+	// Number of the findings signing or decrypting in loops or worker
+	// pools, whose throughput has to be checked with the larger
+	// post-quantum signatures and ciphertexts.
+	HighVolume int `json:"highVolume,omitempty"`
+	// Number of other groups with findings in the same packages. Groups
+	// coupled with fewer others can be migrated without coordinating with
+	// other work, so they come first.
+	Coupling int `json:"coupling"`
+	// Estimated effort of the group, in days.
+	Effort float64 `json:"effortDays"`
+
+	severity analyzer.Severity
+	// Files and package directories of the findings.
+	files, dirs map[string]bool
+}
+
+// New returns the migration plan of the report. The main findings are
+// planned by severity; algorithm agility points, the API surface and accepted
+// interop debt are planned last, as they do not fail scans.
+func New(r *report.Report, weights Weights) *Plan {
+	groups := make([]map[string]*Group, len(phases))
+	for i := range groups {
+		groups[i] = make(map[string]*Group)
+	}
+	add := func(phase int, finding report.Finding) {
+		group, ok := groups[phase][finding.Category]
+		if !ok {
+			group = &Group{Category: finding.Category, files: make(map[string]bool), dirs: make(map[string]bool)}
+			groups[phase][finding.Category] = group
+		}
+		if severity, err := analyzer.ParseSeverity(finding.Severity); err == nil && severity > group.severity {
+			group.severity = severity
+		}
+		group.Findings++
+		if finding.HighVolume {
+			group.HighVolume++
+		}
+		if !slices.Contains(group.Rules, finding.RuleID) {
+			group.Rules = append(group.Rules, finding.RuleID)
+		}
+		group.files[finding.File] = true
+		group.dirs[filepath.Dir(finding.File)] = true
+		multiplier, ok := weights.Categories[finding.Category]
+		if !ok {
+			multiplier = 1
+		}
+		group.Effort += weights.Severities[finding.Severity] * multiplier
+	}
+	for _, finding := range r.Findings {
+		phase := 1
+		if severity, err := analyzer.ParseSeverity(finding.Severity); err == nil && severity >= analyzer.SeverityHigh {
+			phase = 0
+		}
+		add(phase, finding)
+	}
+	for _, finding := range slices.Concat(r.Inventory, r.APISurface, r.InteropDebt) {
+		add(len(phases)-1, finding)
+	}
+
+	var all []*Group
+	for _, phase := range groups {
+		all = slices.AppendSeq(all, maps.Values(phase))
+	}
+	for _, group := range all {
+		group.Severity = group.severity.String()
+		group.Files = len(group.files)
+		slices.Sort(group.Rules)
+		for _, other := range all {
+			if other.Category != group.Category && sharesDir(group, other) {
+				group.Coupling++
+			}
+		}
+	}
+
+	plan := &Plan{}
+	for i, name := range phases {
+		phase := Phase{Name: name}
+		for _, group := range slices.SortedFunc(maps.Values(groups[i]), compareGroups) {
+			group.Effort = round(group.Effort)
+			phase.Groups = append(phase.Groups, *group)
+			phase.Effort += group.Effort
+		}
+		if len(phase.Groups) == 0 {
+			continue
+		}
+		phase.Effort = round(phase.Effort)
+		plan.Phases = append(plan.Phases, phase)
+		plan.Effort += phase.Effort
+	}
+	plan.Effort = round(plan.Effort)
+	return plan
+}
+
+// Orders groups by descending severity, then ascending coupling, then
+// descending effort.
+func compareGroups(a, b *Group) int {
+	return cmp.Or(
+		cmp.Compare(b.severity, a.severity),
+		cmp.Compare(a.Coupling, b.Coupling),
+		cmp.Compare(b.Effort, a.Effort),
+		cmp.Compare(a.Category, b.Category),
+	)
+}
+
+// Reports whether the groups have findings in a common package directory.
+func sharesDir(a, b *Group) bool {
+	for dir := range a.dirs {
+		if b.dirs[dir] {
+			return true
+		}
+	}
+	return false
+}
+
+// Rounds days to a tenth.
+func round(days float64) float64 {
+	return math.Round(days*10) / 10
+}
+
+// WriteMarkdown writes the plan as a Markdown document, with a table of the
+// groups of each phase and the number of its high-volume findings.
+func WriteMarkdown(w io.Writer, p *Plan) error {
+	var b strings.Builder
+	b.WriteString("# Post-quantum migration plan\n\n")
+	if len(p.Phases) == 0 {
+		b.WriteString("No findings to migrate.\n")
+		_, err := io.WriteString(w, b.String())
+		return err
+	}
+	phases := "phases"
+	if len(p.Phases) == 1 {
+		phases = "phase"
+	}
+	fmt.Fprintf(&b, "Estimated effort: %s days in %d %s.\n", formatDays(p.Effort), len(p.Phases), phases)
+	for i, phase := range p.Phases {
+		fmt.Fprintf(&b, "\n## Phase %d: %s (%s days)\n\n", i+1, phase.Name, formatDays(phase.Effort))
+		b.WriteString("| Category | Severity | Findings | Files | Rules | Coupling | Effort (days) |\n")
+		b.WriteString("|---|---|---|---|---|---|---|\n")
+		for _, group := range phase.Groups {
+			fmt.Fprintf(&b, "| %s | %s | %d | %d | %s | %d | %s |\n", group.Category, group.Severity, group.Findings, group.Files, strings.Join(group.Rules, ", "), group.Coupling, formatDays(group.Effort))
+		}
+		highVolume := 0
+		for _, group := range phase.Groups {
+			highVolume += group.HighVolume
+		}
+		if highVolume > 0 {
+			fmt.Fprintf(&b, "\n%d of the findings sign or decrypt in loops or worker pools; benchmark them with post-quantum signature and ciphertext sizes.\n", highVolume)
+		}
+	}
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+func formatDays(days float64) string {
+	return strconv.FormatFloat(days, 'f', -1, 64)
+}
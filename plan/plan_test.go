@@ -0,0 +1,84 @@
+package plan_test
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/ahan-adelaide/pqc-analyzer/plan"
+	"github.com/ahan-adelaide/pqc-analyzer/report"
+)
+
+var testReport = &report.Report{
+	Findings: []report.Finding{
+		{File: "/src/keys/a.go", RuleID: "PQC002", Category: "integer-factorization", Severity: "medium"},
+		{File: "/src/keys/b.go", RuleID: "PQC004", Category: "vulnerable-function", Severity: "high"},
+		{File: "/src/keys/b.go", RuleID: "PQC004", Category: "vulnerable-function", Severity: "high", HighVolume: true},
+		{File: "/src/chat/c.go", RuleID: "PQC038", Category: "end-to-end-encryption", Severity: "critical"},
+		{File: "/src/tpm/d.go", RuleID: "PQC034", Category: "hardware-bound-key", Severity: "high"},
+	},
+	Inventory: []report.Finding{
+		{File: "/src/keys/a.go", RuleID: "PQC019", Category: "algorithm-agility", Severity: "info"},
+	},
+}
+
+func TestNew(t *testing.T) {
+	p := plan.New(testReport, plan.DefaultWeights())
+	if len(p.Phases) != 3 {
+		t.Fatalf("got %d phases, want 3", len(p.Phases))
+	}
+
+	// Critical findings come first; of the high severity groups, the one
+	// sharing no package with another group comes before the coupled one.
+	var categories []string
+	for _, group := range p.Phases[0].Groups {
+		categories = append(categories, group.Category)
+	}
+	if want := "end-to-end-encryption hardware-bound-key vulnerable-function"; strings.Join(categories, " ") != want {
+		t.Errorf("got first phase groups %v, want %s", categories, want)
+	}
+	functions := p.Phases[0].Groups[2]
+	if functions.Findings != 2 || functions.Files != 1 || functions.Coupling != 2 || functions.Effort != 1 || functions.HighVolume != 1 {
+		t.Errorf("unexpected group %+v", functions)
+	}
+	if hardware := p.Phases[0].Groups[1]; hardware.Effort != 2 {
+		t.Errorf("got hardware-bound key effort %v, want 2", hardware.Effort)
+	}
+	if p.Phases[2].Groups[0].Category != "algorithm-agility" {
+		t.Errorf("unexpected last phase %+v", p.Phases[2])
+	}
+	if p.Effort != 6.4 {
+		t.Errorf("got effort %v, want 6.4", p.Effort)
+	}
+
+	weights := plan.DefaultWeights()
+	weights.Severities["high"] = 2
+	if p := plan.New(testReport, weights); p.Phases[0].Groups[2].Effort != 4 {
+		t.Errorf("got effort %v with custom weights, want 4", p.Phases[0].Groups[2].Effort)
+	}
+}
+
+func TestWriteMarkdown(t *testing.T) {
+	var buf bytes.Buffer
+	if err := plan.WriteMarkdown(&buf, plan.New(testReport, plan.DefaultWeights())); err != nil {
+		t.Fatal(err)
+	}
+	for _, want := range []string{
+		"Estimated effort: 6.4 days in 3 phases.",
+		"## Phase 1: Critical and high severity findings (6 days)",
+		"| end-to-end-encryption | critical | 1 | 1 | PQC038 | 0 | 3 |",
+		"1 of the findings sign or decrypt in loops or worker pools",
+	} {
+		if !strings.Contains(buf.String(), want) {
+			t.Errorf("plan does not contain %q:\n%s", want, buf.String())
+		}
+	}
+
+	buf.Reset()
+	if err := plan.WriteMarkdown(&buf, plan.New(&report.Report{}, plan.DefaultWeights())); err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(buf.String(), "No findings to migrate.") {
+		t.Errorf("unexpected empty plan:\n%s", buf.String())
+	}
+}
@@ -0,0 +1,90 @@
+package report
+
+import (
+	"cmp"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+)
+
+// Caps records how the findings of a report were capped, so readers know the
+// report is not exhaustive.
+type Caps struct {
+	// Number of files with the most findings kept per category, or 0.
+	TopFiles int `json:"topFiles,omitempty"`
+	// Number of findings kept per rule, or 0.
+	PerRule int `json:"perRule,omitempty"`
+	// Number of findings omitted per rule.
+	Omitted map[string]int `json:"omitted"`
+}
+
+// OmittedFindings returns the total number of findings omitted.
+func (c *Caps) OmittedFindings() int {
+	total := 0
+	for _, omitted := range c.Omitted {
+		total += omitted
+	}
+	return total
+}
+
+// String describes the caps, such as "top 5 files per category, 100 per rule".
+func (c *Caps) String() string {
+	var parts []string
+	if c.TopFiles > 0 {
+		parts = append(parts, fmt.Sprintf("top %d files per category", c.TopFiles))
+	}
+	if c.PerRule > 0 {
+		parts = append(parts, fmt.Sprintf("%d per rule", c.PerRule))
+	}
+	return strings.Join(parts, ", ")
+}
+
+// Cap limits the findings of the main section of a sorted report, for a
+// digestible first report of an enormous codebase. If topFiles is positive,
+// only the findings in the topFiles files with the most findings of each
+// category are kept. If perRule is positive, only the first perRule findings
+// of each rule are kept. The omitted findings are counted in r.Caps.
+func (r *Report) Cap(topFiles, perRule int) {
+	if topFiles <= 0 && perRule <= 0 {
+		return
+	}
+	caps := &Caps{TopFiles: max(topFiles, 0), PerRule: max(perRule, 0), Omitted: make(map[string]int)}
+
+	var topFilesOf map[string][]string
+	if topFiles > 0 {
+		topFilesOf = topFilesPerCategory(r.Findings, topFiles)
+	}
+	kept := make(map[string]int)
+	findings := r.Findings[:0]
+	for _, finding := range r.Findings {
+		if topFiles > 0 && !slices.Contains(topFilesOf[finding.Category], finding.File) ||
+			perRule > 0 && kept[finding.RuleID] >= perRule {
+			caps.Omitted[finding.RuleID]++
+			continue
+		}
+		kept[finding.RuleID]++
+		findings = append(findings, finding)
+	}
+	r.Findings = findings
+	r.Caps = caps
+}
+
+// Returns the files with the most findings of each category, most first.
+func topFilesPerCategory(findings []Finding, n int) map[string][]string {
+	counts := make(map[string]map[string]int)
+	for _, finding := range findings {
+		if counts[finding.Category] == nil {
+			counts[finding.Category] = make(map[string]int)
+		}
+		counts[finding.Category][finding.File]++
+	}
+	top := make(map[string][]string)
+	for category, files := range counts {
+		sorted := slices.SortedFunc(maps.Keys(files), func(a, b string) int {
+			return cmp.Or(cmp.Compare(files[b], files[a]), cmp.Compare(a, b))
+		})
+		top[category] = sorted[:min(n, len(sorted))]
+	}
+	return top
+}
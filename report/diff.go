@@ -0,0 +1,130 @@
+package report
+
+import (
+	"cmp"
+	"fmt"
+	"io"
+	"maps"
+	"slices"
+
+	"github.com/ahan-adelaide/pqc-analyzer/analyzer"
+)
+
+// Diff is the difference between the findings of two reports.
+type Diff struct {
+	// Schema version of the findings, SchemaVersion.
+	SchemaVersion int       `json:"schemaVersion"`
+	Added         []Finding `json:"added"`
+	Removed       []Finding `json:"removed"`
+	Unchanged     []Finding `json:"unchanged"`
+	// Finding counts per severity, ordered from most to least severe.
+	Severities []SeverityDelta `json:"severities"`
+}
+
+// SeverityDelta is the change in the number of findings of a severity.
+type SeverityDelta struct {
+	Severity string `json:"severity"`
+	Old      int    `json:"old"`
+	New      int    `json:"new"`
+	Delta    int    `json:"delta"`
+}
+
+// A diffKey identifies a finding across reports. Line and column are left
+// out, as unrelated edits move findings around between releases.
+type diffKey struct {
+	file, ruleID, message string
+}
+
+// ComputeDiff compares the findings of the old and new reports. Findings are
+// matched by file, rule and message; when a file has several matching
+// findings, they are paired in order of position.
+func ComputeDiff(oldReport, newReport *Report) *Diff {
+	oldFindings := groupFindings(oldReport.Findings)
+	newFindings := groupFindings(newReport.Findings)
+
+	diff := &Diff{SchemaVersion: SchemaVersion}
+	for key, oldGroup := range oldFindings {
+		newGroup := newFindings[key]
+		shared := min(len(oldGroup), len(newGroup))
+		diff.Unchanged = append(diff.Unchanged, newGroup[:shared]...)
+		diff.Removed = append(diff.Removed, oldGroup[shared:]...)
+	}
+	for key, newGroup := range newFindings {
+		shared := min(len(oldFindings[key]), len(newGroup))
+		diff.Added = append(diff.Added, newGroup[shared:]...)
+	}
+	slices.SortFunc(diff.Added, compareFindings)
+	slices.SortFunc(diff.Removed, compareFindings)
+	slices.SortFunc(diff.Unchanged, compareFindings)
+
+	counts := make(map[string]*SeverityDelta)
+	count := func(finding Finding) *SeverityDelta {
+		delta, ok := counts[finding.Severity]
+		if !ok {
+			delta = &SeverityDelta{Severity: finding.Severity}
+			counts[finding.Severity] = delta
+		}
+		return delta
+	}
+	for _, finding := range oldReport.Findings {
+		count(finding).Old++
+	}
+	for _, finding := range newReport.Findings {
+		count(finding).New++
+	}
+	for _, severity := range slices.SortedFunc(maps.Keys(counts), compareSeverities) {
+		delta := counts[severity]
+		delta.Delta = delta.New - delta.Old
+		diff.Severities = append(diff.Severities, *delta)
+	}
+	return diff
+}
+
+func groupFindings(findings []Finding) map[diffKey][]Finding {
+	groups := make(map[diffKey][]Finding)
+	for _, finding := range findings {
+		key := diffKey{finding.File, finding.RuleID, finding.Message}
+		groups[key] = append(groups[key], finding)
+	}
+	for _, group := range groups {
+		slices.SortFunc(group, compareFindings)
+	}
+	return groups
+}
+
+// Orders severity names from most to least severe, unknown names last.
+func compareSeverities(a, b string) int {
+	rank := func(name string) int {
+		severity, err := analyzer.ParseSeverity(name)
+		if err != nil {
+			return -1
+		}
+		return int(severity)
+	}
+	return cmp.Or(cmp.Compare(rank(b), rank(a)), cmp.Compare(a, b))
+}
+
+// WriteDiffText writes the added and removed findings, followed by the
+// finding counts per severity.
+func WriteDiffText(w io.Writer, diff *Diff) error {
+	for _, finding := range diff.Added {
+		if _, err := fmt.Fprintf(w, "+ %s:%d:%d: %s\n", finding.File, finding.Line, finding.Column, finding.Message); err != nil {
+			return err
+		}
+	}
+	for _, finding := range diff.Removed {
+		if _, err := fmt.Fprintf(w, "- %s:%d:%d: %s\n", finding.File, finding.Line, finding.Column, finding.Message); err != nil {
+			return err
+		}
+	}
+
+	if _, err := fmt.Fprintf(w, "\n%d added, %d removed, %d unchanged\n", len(diff.Added), len(diff.Removed), len(diff.Unchanged)); err != nil {
+		return err
+	}
+	for _, delta := range diff.Severities {
+		if _, err := fmt.Fprintf(w, "%-8s %5d -> %5d (%+d)\n", delta.Severity, delta.Old, delta.New, delta.Delta); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,141 @@
+package report
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"slices"
+	"strings"
+)
+
+func init() {
+	RegisterWriter("github", bufferedWriterFactory(writeGitHubActions))
+}
+
+// Writes the workflow annotations of the report to w and, when running in a
+// GitHub Actions job, appends its summary to the job summary.
+func writeGitHubActions(w io.Writer, r *Report) error {
+	if err := WriteGitHub(w, r); err != nil {
+		return err
+	}
+	path := os.Getenv("GITHUB_STEP_SUMMARY")
+	if path == "" {
+		return nil
+	}
+	summary, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o644)
+	if err != nil {
+		return fmt.Errorf("failed to open job summary: %s", err.Error())
+	}
+	if err := WriteGitHubSummary(summary, r); err != nil {
+		summary.Close()
+		return fmt.Errorf("failed to write job summary: %s", err.Error())
+	}
+	return summary.Close()
+}
+
+// WriteGitHub writes the findings as GitHub Actions workflow commands, which
+// show up as annotations on the lines of the pull request. Findings of high
+// and critical severity are errors, the others warnings; accepted interop
+// debt, algorithm agility points and the API surface are only notices.
+func WriteGitHub(w io.Writer, r *Report) error {
+	wd, _ := os.Getwd()
+	for _, finding := range r.Findings {
+		if err := writeGitHubAnnotation(w, r, wd, githubLevel(finding.Severity), finding); err != nil {
+			return err
+		}
+	}
+	for _, finding := range r.InteropDebt {
+		if err := writeGitHubAnnotation(w, r, wd, "notice", finding); err != nil {
+			return err
+		}
+	}
+	for _, finding := range slices.Concat(r.Inventory, r.APISurface) {
+		if err := writeGitHubAnnotation(w, r, wd, "notice", finding); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func writeGitHubAnnotation(w io.Writer, r *Report, wd, level string, finding Finding) error {
+	file, _ := relativePath(wd, finding.File)
+	title := finding.RuleID
+	if rule, ok := r.Rule(finding.RuleID); ok {
+		title += " " + rule.Name
+	}
+	message := finding.Message
+	if finding.Justification != "" {
+		message += ": " + finding.Justification
+	}
+	_, err := fmt.Fprintf(w, "::%s file=%s,line=%d,col=%d,title=%s::%s\n",
+		level,
+		githubEscapeProperty(file),
+		finding.Line,
+		finding.Column,
+		githubEscapeProperty(title),
+		githubEscapeData(message),
+	)
+	return err
+}
+
+// WriteGitHubSummary writes a Markdown summary of the report, with the number
+// of findings per rule, for the job summary of a GitHub Actions run.
+func WriteGitHubSummary(w io.Writer, r *Report) error {
+	var b strings.Builder
+	b.WriteString("## pqc-analyzer\n\n")
+	if len(r.Findings) == 0 {
+		b.WriteString("No quantum-vulnerable cryptography found.\n")
+	} else {
+		counts := make(map[string]int)
+		for _, finding := range r.Findings {
+			counts[finding.RuleID]++
+		}
+		fmt.Fprintf(&b, "%d findings.\n\n", len(r.Findings))
+		b.WriteString("| Rule | Name | Severity | Findings |\n")
+		b.WriteString("| --- | --- | --- | ---: |\n")
+		for _, rule := range r.Rules {
+			if counts[rule.ID] == 0 {
+				continue
+			}
+			id := rule.ID
+			if rule.HelpURI != "" {
+				id = "[" + rule.ID + "](" + rule.HelpURI + ")"
+			}
+			fmt.Fprintf(&b, "| %s | %s | %s | %d |\n", id, rule.Name, rule.Severity, counts[rule.ID])
+		}
+	}
+	if r.Caps != nil && r.Caps.OmittedFindings() > 0 {
+		fmt.Fprintf(&b, "\n%d more findings omitted (%s).\n", r.Caps.OmittedFindings(), r.Caps)
+	}
+	if len(r.InteropDebt) > 0 {
+		fmt.Fprintf(&b, "\nAccepted interop debt: %d.\n", len(r.InteropDebt))
+	}
+	if len(r.Inventory) > 0 {
+		fmt.Fprintf(&b, "\nAlgorithm agility points: %d.\n", len(r.Inventory))
+	}
+	if len(r.APISurface) > 0 {
+		fmt.Fprintf(&b, "\nExported API exposing classical key types: %d.\n", len(r.APISurface))
+	}
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+func githubLevel(severity string) string {
+	switch severity {
+	case "critical", "high":
+		return "error"
+	case "info":
+		return "notice"
+	}
+	return "warning"
+}
+
+// Escapes the message of a workflow command.
+func githubEscapeData(s string) string {
+	return strings.NewReplacer("%", "%25", "\r", "%0D", "\n", "%0A").Replace(s)
+}
+
+// Escapes a property value of a workflow command.
+func githubEscapeProperty(s string) string {
+	return strings.NewReplacer("%", "%25", "\r", "%0D", "\n", "%0A", ":", "%3A", ",", "%2C").Replace(s)
+}
@@ -0,0 +1,77 @@
+package report
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+)
+
+// WriteJSON writes the report as JSON, the format read back by Load. File
+// paths under the working directory are written relative to it, so reports
+// from different checkouts of a repository can be compared.
+func WriteJSON(w io.Writer, r *Report) error {
+	wd, _ := os.Getwd()
+	portable := *r
+	portable.SchemaVersion = SchemaVersion
+	portable.Findings = relativeFindings(wd, r.Findings)
+	portable.InteropDebt = relativeFindings(wd, r.InteropDebt)
+	portable.Inventory = relativeFindings(wd, r.Inventory)
+	portable.APISurface = relativeFindings(wd, r.APISurface)
+	if r.PolicyViolations != nil {
+		portable.PolicyViolations = make([]Violation, len(r.PolicyViolations))
+		for i, violation := range r.PolicyViolations {
+			violation.Finding.File, _ = relativePath(wd, violation.Finding.File)
+			portable.PolicyViolations[i] = violation
+		}
+	}
+
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(portable)
+}
+
+// Load reads a report written by WriteJSON, of the current or a previous
+// schema version, migrated to SchemaVersion.
+func Load(path string) (*Report, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read report %s: %s", path, err.Error())
+	}
+	r, err := decodeReport(data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse report %s: %s", path, err.Error())
+	}
+	return r, nil
+}
+
+func relativeFindings(wd string, findings []Finding) []Finding {
+	if findings == nil {
+		return nil
+	}
+	relative := make([]Finding, len(findings))
+	for i, finding := range findings {
+		finding.File, _ = relativePath(wd, finding.File)
+		if finding.Related != nil {
+			finding.Related = slices.Clone(finding.Related)
+			for j := range finding.Related {
+				finding.Related[j].File, _ = relativePath(wd, finding.Related[j].File)
+			}
+		}
+		relative[i] = finding
+	}
+	return relative
+}
+
+// Returns the path of file relative to wd, and whether it is under wd.
+func relativePath(wd, file string) (string, bool) {
+	if wd != "" && filepath.IsAbs(file) {
+		if rel, err := filepath.Rel(wd, file); err == nil && !strings.HasPrefix(rel, "..") {
+			return filepath.ToSlash(rel), true
+		}
+	}
+	return filepath.ToSlash(file), !filepath.IsAbs(file)
+}
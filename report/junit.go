@@ -0,0 +1,158 @@
+package report
+
+import (
+	"cmp"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"slices"
+	"strings"
+)
+
+func init() {
+	RegisterWriter("junit", bufferedWriterFactory(WriteJUnit))
+}
+
+type junitTestSuites struct {
+	XMLName  xml.Name         `xml:"testsuites"`
+	Name     string           `xml:"name,attr"`
+	Tests    int              `xml:"tests,attr"`
+	Failures int              `xml:"failures,attr"`
+	Skipped  int              `xml:"skipped,attr"`
+	Suites   []junitTestSuite `xml:"testsuite"`
+}
+
+type junitTestSuite struct {
+	Name     string          `xml:"name,attr"`
+	Tests    int             `xml:"tests,attr"`
+	Failures int             `xml:"failures,attr"`
+	Skipped  int             `xml:"skipped,attr"`
+	Cases    []junitTestCase `xml:"testcase"`
+}
+
+type junitTestCase struct {
+	Name      string        `xml:"name,attr"`
+	Classname string        `xml:"classname,attr"`
+	Failure   *junitMessage `xml:"failure,omitempty"`
+	Skipped   *junitMessage `xml:"skipped,omitempty"`
+	SystemOut string        `xml:"system-out,omitempty"`
+}
+
+type junitMessage struct {
+	Message string `xml:"message,attr"`
+	Type    string `xml:"type,attr,omitempty"`
+	Text    string `xml:",chardata"`
+}
+
+// WriteJUnit writes the report as JUnit XML, for CI servers that can only
+// show test reports, such as Jenkins, GitLab and Bamboo. Every package, by
+// the directory of its files, is a test suite with one test case per rule
+// with findings in it. Test cases with main findings fail, listing them;
+// those with only accepted interop debt, algorithm agility points or API
+// surface are skipped. A report without findings has a single passing test
+// case, so the test report is not empty.
+func WriteJUnit(w io.Writer, r *Report) error {
+	wd, _ := os.Getwd()
+	type key struct{ pkg, ruleID string }
+	cases := make(map[key]*junitTestCase)
+	var keys []key
+	testCase := func(finding Finding) *junitTestCase {
+		file, _ := relativePath(wd, finding.File)
+		k := key{path.Dir(file), finding.RuleID}
+		if c, ok := cases[k]; ok {
+			return c
+		}
+		name := finding.RuleID
+		if rule, ok := r.Rule(finding.RuleID); ok {
+			name += " " + rule.Name
+		}
+		c := &junitTestCase{Name: name, Classname: k.pkg}
+		cases[k] = c
+		keys = append(keys, k)
+		return c
+	}
+	location := func(finding Finding) string {
+		file, _ := relativePath(wd, finding.File)
+		line := fmt.Sprintf("%s:%d:%d: %s", file, finding.Line, finding.Column, finding.Message)
+		if finding.Justification != "" {
+			line += ": " + finding.Justification
+		}
+		return line + "\n"
+	}
+
+	counts := make(map[*junitTestCase]int)
+	for _, finding := range r.Findings {
+		c := testCase(finding)
+		if c.Failure == nil {
+			c.Failure = &junitMessage{Type: finding.Severity}
+		}
+		c.Failure.Text += location(finding)
+		counts[c]++
+		noun := "findings"
+		if counts[c] == 1 {
+			noun = "finding"
+		}
+		c.Failure.Message = fmt.Sprintf("%d quantum-vulnerable %s", counts[c], noun)
+	}
+	sections := []struct {
+		name     string
+		findings []Finding
+	}{
+		{"accepted interop debt", r.InteropDebt},
+		{"algorithm agility point", r.Inventory},
+		{"exported API exposing a classical key type", r.APISurface},
+	}
+	for _, section := range sections {
+		for _, finding := range section.findings {
+			c := testCase(finding)
+			c.SystemOut += strings.TrimSuffix(location(finding), "\n") + " (" + section.name + ")\n"
+		}
+	}
+
+	suites := junitTestSuites{Name: "pqc-analyzer"}
+	slices.SortFunc(keys, func(a, b key) int {
+		return cmp.Or(strings.Compare(a.pkg, b.pkg), strings.Compare(a.ruleID, b.ruleID))
+	})
+	for _, k := range keys {
+		c := cases[k]
+		if c.Failure == nil {
+			c.Skipped = &junitMessage{Message: "no findings failing the scan; accepted interop debt, algorithm agility points or API surface only"}
+		}
+		if len(suites.Suites) == 0 || suites.Suites[len(suites.Suites)-1].Name != k.pkg {
+			suites.Suites = append(suites.Suites, junitTestSuite{Name: k.pkg})
+		}
+		suite := &suites.Suites[len(suites.Suites)-1]
+		suite.Cases = append(suite.Cases, *c)
+		suite.Tests++
+		if c.Failure != nil {
+			suite.Failures++
+		} else {
+			suite.Skipped++
+		}
+	}
+	if len(suites.Suites) == 0 {
+		suites.Suites = []junitTestSuite{{
+			Name:  "pqc-analyzer",
+			Tests: 1,
+			Cases: []junitTestCase{{Name: "no quantum-vulnerable cryptography", Classname: "pqc-analyzer"}},
+		}}
+	}
+	for _, suite := range suites.Suites {
+		suites.Tests += suite.Tests
+		suites.Failures += suite.Failures
+		suites.Skipped += suite.Skipped
+	}
+
+	if _, err := io.WriteString(w, xml.Header); err != nil {
+		return err
+	}
+	encoder := xml.NewEncoder(w)
+	encoder.Indent("", "  ")
+	if err := encoder.Encode(suites); err != nil {
+		return fmt.Errorf("failed to write JUnit report: %s", err.Error())
+	}
+	_, err := io.WriteString(w, "\n")
+	return err
+}
@@ -0,0 +1,298 @@
+package report
+
+import (
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/ahan-adelaide/pqc-analyzer/analyzer"
+)
+
+// Policy is a named condition over the findings of a report, such as
+//
+//	severity >= "critical" && path matches "services/payments/**" && !accepted
+//
+// Findings satisfying the condition violate the policy. Conditions compare
+// the fields of findings with string literals, with ==, != and, for
+// severities, <, <=, > and >=; match paths with glob patterns, in which "**"
+// matches any number of directories, with matches; test substrings with
+// contains; and combine them with &&, || and !. The fields are:
+//
+//	rule		rule ID, such as "PQC001"
+//	category	rule category, such as "elliptic-curve"
+//	severity	severity, compared by rank
+//	path		slash-separated file path, relative to the scanned directory
+//	message		message
+//	operation	"public", "private" or empty
+//	dependency	module path and version of dependency findings, or empty
+//	justification	why the finding was accepted, or empty
+//	section		"findings", "accepted", "inventory" or "api"
+//	accepted	whether the finding is accepted interop debt
+//	unreachable	whether the finding is unreachable from the entrypoints
+type Policy struct {
+	Name string
+	// Condition of the findings violating the policy, as written.
+	Deny string
+	expr policyExpr
+}
+
+// Violation is a finding violating a policy.
+type Violation struct {
+	Policy  string  `json:"policy"`
+	Finding Finding `json:"finding"`
+}
+
+// ParsePolicy returns the policy with the given name denying the findings
+// satisfying the condition.
+func ParsePolicy(name, deny string) (*Policy, error) {
+	tokens, err := tokenizePolicy(deny)
+	if err != nil {
+		return nil, fmt.Errorf("invalid policy %q: %s", name, err.Error())
+	}
+	p := &policyParser{tokens: tokens}
+	expr, err := p.parseOr()
+	if err == nil && p.pos < len(p.tokens) {
+		err = fmt.Errorf("unexpected %q", p.tokens[p.pos])
+	}
+	if err != nil {
+		return nil, fmt.Errorf("invalid policy %q: %s", name, err.Error())
+	}
+	return &Policy{Name: name, Deny: deny, expr: expr}, nil
+}
+
+// Violations returns the findings of every section of the report violating
+// each of the policies, in policy order. File paths are matched relative to
+// dir.
+func (r *Report) Violations(policies []*Policy, dir string) []Violation {
+	sections := []struct {
+		name     string
+		findings []Finding
+	}{
+		{"findings", r.Findings},
+		{StatusAccepted, r.InteropDebt},
+		{StatusInventory, r.Inventory},
+		{StatusAPI, r.APISurface},
+	}
+	var violations []Violation
+	for _, policy := range policies {
+		for _, section := range sections {
+			for _, finding := range section.findings {
+				file, _ := relativePath(dir, finding.File)
+				if policy.expr.eval(policyFinding{finding, file, section.name}) {
+					violations = append(violations, Violation{Policy: policy.Name, Finding: finding})
+				}
+			}
+		}
+	}
+	return violations
+}
+
+// A finding as seen by policies.
+type policyFinding struct {
+	Finding
+	path    string
+	section string
+}
+
+// Fields of findings policies can use, and their values.
+var policyFields = map[string]func(f policyFinding) string{
+	"rule":          func(f policyFinding) string { return f.RuleID },
+	"category":      func(f policyFinding) string { return f.Category },
+	"severity":      func(f policyFinding) string { return f.Severity },
+	"path":          func(f policyFinding) string { return f.path },
+	"message":       func(f policyFinding) string { return f.Message },
+	"operation":     func(f policyFinding) string { return f.Operation },
+	"dependency":    func(f policyFinding) string { return f.Dependency },
+	"justification": func(f policyFinding) string { return f.Justification },
+	"section":       func(f policyFinding) string { return f.section },
+	"accepted":      func(f policyFinding) string { return strconv.FormatBool(f.section == StatusAccepted) },
+	"unreachable":   func(f policyFinding) string { return strconv.FormatBool(f.Unreachable) },
+}
+
+// Boolean fields, which can be used as conditions on their own.
+var policyBoolFields = []string{"accepted", "unreachable"}
+
+type policyExpr interface {
+	eval(f policyFinding) bool
+}
+
+type policyAnd struct{ left, right policyExpr }
+
+func (e policyAnd) eval(f policyFinding) bool { return e.left.eval(f) && e.right.eval(f) }
+
+type policyOr struct{ left, right policyExpr }
+
+func (e policyOr) eval(f policyFinding) bool { return e.left.eval(f) || e.right.eval(f) }
+
+type policyNot struct{ expr policyExpr }
+
+func (e policyNot) eval(f policyFinding) bool { return !e.expr.eval(f) }
+
+// A comparison of a field with a literal.
+type policyComparison struct {
+	field   string
+	op      string
+	literal string
+}
+
+func (e policyComparison) eval(f policyFinding) bool {
+	value := policyFields[e.field](f)
+	switch e.op {
+	case "==":
+		return value == e.literal
+	case "!=":
+		return value != e.literal
+	case "matches":
+		return MatchGlob(e.literal, value)
+	case "contains":
+		return strings.Contains(value, e.literal)
+	}
+	// Ordering operators only apply to severities, checked when parsing.
+	severity, err := analyzer.ParseSeverity(value)
+	if err != nil {
+		return false
+	}
+	literal, _ := analyzer.ParseSeverity(e.literal)
+	switch e.op {
+	case "<":
+		return severity < literal
+	case "<=":
+		return severity <= literal
+	case ">":
+		return severity > literal
+	}
+	return severity >= literal
+}
+
+type policyParser struct {
+	tokens []string
+	pos    int
+}
+
+// Returns the next token, or "" at the end of the condition.
+func (p *policyParser) peek() string {
+	if p.pos == len(p.tokens) {
+		return ""
+	}
+	return p.tokens[p.pos]
+}
+
+func (p *policyParser) parseOr() (policyExpr, error) {
+	left, err := p.parseAnd()
+	for err == nil && p.peek() == "||" {
+		p.pos++
+		var right policyExpr
+		right, err = p.parseAnd()
+		left = policyOr{left, right}
+	}
+	return left, err
+}
+
+func (p *policyParser) parseAnd() (policyExpr, error) {
+	left, err := p.parseUnary()
+	for err == nil && p.peek() == "&&" {
+		p.pos++
+		var right policyExpr
+		right, err = p.parseUnary()
+		left = policyAnd{left, right}
+	}
+	return left, err
+}
+
+func (p *policyParser) parseUnary() (policyExpr, error) {
+	switch token := p.peek(); token {
+	case "!":
+		p.pos++
+		expr, err := p.parseUnary()
+		return policyNot{expr}, err
+	case "(":
+		p.pos++
+		expr, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+		if p.peek() != ")" {
+			return nil, fmt.Errorf("missing )")
+		}
+		p.pos++
+		return expr, nil
+	case "":
+		return nil, fmt.Errorf("unexpected end of condition")
+	}
+
+	field := p.peek()
+	if _, ok := policyFields[field]; !ok {
+		return nil, fmt.Errorf("unknown field %q", field)
+	}
+	p.pos++
+	op := p.peek()
+	if !slices.Contains([]string{"==", "!=", "<", "<=", ">", ">=", "matches", "contains"}, op) {
+		if slices.Contains(policyBoolFields, field) {
+			return policyComparison{field, "==", "true"}, nil
+		}
+		return nil, fmt.Errorf("field %q must be compared", field)
+	}
+	p.pos++
+	literal, err := strconv.Unquote(p.peek())
+	if err != nil || !strings.HasPrefix(p.peek(), `"`) {
+		return nil, fmt.Errorf("%s %s must be followed by a string", field, op)
+	}
+	p.pos++
+	if strings.HasPrefix(op, "<") || strings.HasPrefix(op, ">") {
+		if field != "severity" {
+			return nil, fmt.Errorf("%s only applies to severity", op)
+		}
+		if _, err := analyzer.ParseSeverity(literal); err != nil {
+			return nil, err
+		}
+	}
+	return policyComparison{field, op, literal}, nil
+}
+
+// Splits a condition into identifiers, string literals and operators.
+func tokenizePolicy(s string) ([]string, error) {
+	var tokens []string
+	for i := 0; i < len(s); {
+		c := rune(s[i])
+		switch {
+		case unicode.IsSpace(c):
+			i++
+		case c == '"':
+			end := i + 1
+			for end < len(s) && s[end] != '"' {
+				if s[end] == '\\' {
+					end++
+				}
+				end++
+			}
+			if end >= len(s) {
+				return nil, fmt.Errorf("unterminated string")
+			}
+			tokens = append(tokens, s[i:end+1])
+			i = end + 1
+		case unicode.IsLetter(c):
+			end := i
+			for end < len(s) && unicode.IsLetter(rune(s[end])) {
+				end++
+			}
+			tokens = append(tokens, s[i:end])
+			i = end
+		default:
+			op := ""
+			for _, candidate := range []string{"&&", "||", "==", "!=", "<=", ">=", "<", ">", "!", "(", ")"} {
+				if strings.HasPrefix(s[i:], candidate) {
+					op = candidate
+					break
+				}
+			}
+			if op == "" {
+				return nil, fmt.Errorf("unexpected %q", s[i:i+1])
+			}
+			tokens = append(tokens, op)
+			i += len(op)
+		}
+	}
+	return tokens, nil
+}
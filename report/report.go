@@ -0,0 +1,198 @@
+// Package report defines the findings produced by a pqc-analyzer scan and the
+// formats they can be written in.
+package report
+
+import (
+	"cmp"
+	"slices"
+
+	"github.com/ahan-adelaide/pqc-analyzer/analyzer"
+)
+
+// Finding is a single quantum-vulnerable usage found by a scan.
+type Finding struct {
+	File     string `json:"file"`
+	Line     int    `json:"line"`
+	Column   int    `json:"column"`
+	RuleID   string `json:"ruleId"`
+	Category string `json:"category,omitempty"`
+	Severity string `json:"severity"`
+	Message  string `json:"message"`
+	// Documentation page explaining the finding and how to migrate.
+	HelpURI string `json:"helpUri,omitempty"`
+	// Side of the algorithm the call is on, "public" (verify, encrypt) or
+	// "private" (sign, decrypt, generate), when it could be determined.
+	Operation string `json:"operation,omitempty"`
+	// Whether the call signs or decrypts in a loop or a worker started per
+	// item, where larger post-quantum signatures and ciphertexts weigh most
+	// on throughput.
+	HighVolume bool `json:"highVolume,omitempty"`
+	// Places leading up to the finding, in the order of the flow to it: the
+	// import of the package of the reported call and the generation of the
+	// keys it uses, when they are known.
+	Related []Location `json:"related,omitempty"`
+
+	// Build configurations the finding was seen under, when the scan
+	// analyzed more than one.
+	Builds []string `json:"builds,omitempty"`
+
+	// Whether the finding is unreachable from the scan's entrypoints, such
+	// as crypto only kept for fuzzers or examples.
+	Unreachable bool `json:"unreachable,omitempty"`
+
+	// Module path and version of the dependency the finding is in, such as
+	// "golang.org/x/crypto@v0.31.0", or empty for first-party findings.
+	Dependency string `json:"dependency,omitempty"`
+
+	// Why the finding was accepted, for accepted findings.
+	Justification string `json:"justification,omitempty"`
+
+	// Section of the report the finding is in, StatusAccepted,
+	// StatusInventory or StatusAPI, or empty for the main findings. It is only set for
+	// Writers and streamed findings; reports keep the sections apart.
+	Status string `json:"-"`
+}
+
+// Location is a place in the source related to a finding.
+type Location struct {
+	File    string `json:"file"`
+	Line    int    `json:"line"`
+	Column  int    `json:"column"`
+	Message string `json:"message"`
+}
+
+// Thresholds are the minimum severities of the findings failing a scan, set
+// separately for first-party and dependency findings, so CI can fail on a
+// team's own code while only inventorying its dependencies. A nil threshold
+// fails on no findings.
+type Thresholds struct {
+	FirstParty   *analyzer.Severity
+	Dependencies *analyzer.Severity
+}
+
+// Failing returns the main findings of the report at or above the threshold
+// for their origin.
+func (r *Report) Failing(t Thresholds) []Finding {
+	var failing []Finding
+	for _, finding := range r.Findings {
+		threshold := t.FirstParty
+		if finding.Dependency != "" {
+			threshold = t.Dependencies
+		}
+		if threshold == nil {
+			continue
+		}
+		if severity, err := analyzer.ParseSeverity(finding.Severity); err != nil || severity >= *threshold {
+			failing = append(failing, finding)
+		}
+	}
+	return failing
+}
+
+// Rule describes a rule that produced findings in a report.
+type Rule struct {
+	ID       string `json:"id"`
+	Name     string `json:"name"`
+	Category string `json:"category"`
+	Severity string `json:"severity"`
+	Summary  string `json:"summary"`
+	HelpURI  string `json:"helpUri,omitempty"`
+}
+
+// Report is the result of a scan.
+type Report struct {
+	// Schema version of the report, SchemaVersion when written by WriteJSON.
+	SchemaVersion int `json:"schemaVersion"`
+	// Build configurations the scan analyzed.
+	Builds []string `json:"builds,omitempty"`
+	// Number of findings seen under each build configuration, when the scan
+	// analyzed more than one, telling which builds ship classical crypto.
+	BuildFindings map[string]int `json:"buildFindings,omitempty"`
+	// Rules with findings in the report, ordered by ID.
+	Rules    []Rule    `json:"rules,omitempty"`
+	Findings []Finding `json:"findings"`
+	// Findings inside deliberate classical-compat shims (//pqc:compat), or
+	// time-boxed exceptions (//pqc:ignore-until) before their date. They are
+	// accepted interop debt, not failures.
+	InteropDebt []Finding `json:"interopDebt,omitempty"`
+	// Algorithm agility choke points, such as switches dispatching on
+	// algorithm names, which have to be extended for post-quantum
+	// algorithms. They are an inventory, not failures.
+	Inventory []Finding `json:"inventory,omitempty"`
+	// Exported functions, types and fields exposing classical key types,
+	// which cannot change without breaking importers. They are migration
+	// points needing semver planning, not failures.
+	APISurface []Finding `json:"apiSurface,omitempty"`
+	// How the findings were capped, if they were.
+	Caps *Caps `json:"caps,omitempty"`
+
+	// Go modules the scan analyzed, relative to the directory they were
+	// discovered under, for scans of several modules.
+	Modules []string `json:"modules,omitempty"`
+	// Number of findings in each module, for scans of several modules,
+	// telling which modules ship classical crypto.
+	ModuleFindings map[string]int `json:"moduleFindings,omitempty"`
+	// Findings violating the configured policies, which fail the scan.
+	PolicyViolations []Violation `json:"policyViolations,omitempty"`
+}
+
+// Merge adds the builds, rules and findings of other to the report. The
+// reports are expected to cover different packages, so findings are not
+// deduplicated.
+func (r *Report) Merge(other *Report) {
+	for _, build := range other.Builds {
+		if !slices.Contains(r.Builds, build) {
+			r.Builds = append(r.Builds, build)
+		}
+	}
+	if len(other.BuildFindings) > 0 {
+		if r.BuildFindings == nil {
+			r.BuildFindings = make(map[string]int)
+		}
+		for build, n := range other.BuildFindings {
+			r.BuildFindings[build] += n
+		}
+	}
+	for _, rule := range other.Rules {
+		if _, ok := r.Rule(rule.ID); !ok {
+			r.Rules = append(r.Rules, rule)
+		}
+	}
+	r.Findings = append(r.Findings, other.Findings...)
+	r.InteropDebt = append(r.InteropDebt, other.InteropDebt...)
+	r.Inventory = append(r.Inventory, other.Inventory...)
+	r.APISurface = append(r.APISurface, other.APISurface...)
+	r.Sort()
+}
+
+// Rule returns the rule with the given ID.
+func (r *Report) Rule(id string) (Rule, bool) {
+	idx := slices.IndexFunc(r.Rules, func(rule Rule) bool {
+		return rule.ID == id
+	})
+	if idx == -1 {
+		return Rule{}, false
+	}
+	return r.Rules[idx], true
+}
+
+// Sort orders the rules by ID and the findings of each section by position,
+// then message.
+func (r *Report) Sort() {
+	slices.SortFunc(r.Rules, func(a, b Rule) int {
+		return cmp.Compare(a.ID, b.ID)
+	})
+	slices.SortFunc(r.Findings, compareFindings)
+	slices.SortFunc(r.InteropDebt, compareFindings)
+	slices.SortFunc(r.Inventory, compareFindings)
+	slices.SortFunc(r.APISurface, compareFindings)
+}
+
+func compareFindings(a, b Finding) int {
+	return cmp.Or(
+		cmp.Compare(a.File, b.File),
+		cmp.Compare(a.Line, b.Line),
+		cmp.Compare(a.Column, b.Column),
+		cmp.Compare(a.Message, b.Message),
+	)
+}
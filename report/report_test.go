@@ -0,0 +1,465 @@
+package report_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/ahan-adelaide/pqc-analyzer/analyzer"
+	"github.com/ahan-adelaide/pqc-analyzer/report"
+)
+
+var testReport = &report.Report{
+	Rules: []report.Rule{{
+		ID:       "PQC002",
+		Name:     "integer-factorization-import",
+		Category: "integer-factorization",
+		Severity: "medium",
+		Summary:  "Import of a package implementing integer factorization cryptography",
+		HelpURI:  "https://example.com/PQC002.md",
+	}},
+	Findings: []report.Finding{{
+		File:     "/src/a.go",
+		Line:     3,
+		Column:   8,
+		RuleID:   "PQC002",
+		Category: "integer-factorization",
+		Severity: "medium",
+		Message:  `"crypto/rsa" uses quantum-vulnerable integer factorization cryptography`,
+		HelpURI:  "https://example.com/PQC002.md",
+	}},
+}
+
+func TestWriteText(t *testing.T) {
+	var buf bytes.Buffer
+	if err := report.WriteText(&buf, testReport); err != nil {
+		t.Fatal(err)
+	}
+	want := "/src/a.go:3:8: \"crypto/rsa\" uses quantum-vulnerable integer factorization cryptography\n"
+	if buf.String() != want {
+		t.Errorf("got %q, want %q", buf.String(), want)
+	}
+}
+
+func TestWriteSARIF(t *testing.T) {
+	var buf bytes.Buffer
+	if err := report.WriteSARIF(&buf, testReport); err != nil {
+		t.Fatal(err)
+	}
+
+	var log struct {
+		Version string
+		Runs    []struct {
+			Tool struct {
+				Driver struct {
+					Rules []struct {
+						ID      string
+						HelpURI string
+					}
+				}
+			}
+			Results []struct {
+				RuleID string
+				Level  string
+			}
+			Properties struct {
+				SchemaVersion int
+			}
+		}
+	}
+	if err := json.Unmarshal(buf.Bytes(), &log); err != nil {
+		t.Fatalf("invalid SARIF: %s", err.Error())
+	}
+	if log.Version != "2.1.0" || len(log.Runs) != 1 {
+		t.Fatalf("unexpected SARIF log: %s", buf.String())
+	}
+	run := log.Runs[0]
+	if len(run.Tool.Driver.Rules) != 1 || run.Tool.Driver.Rules[0].HelpURI != "https://example.com/PQC002.md" {
+		t.Errorf("rule helpUri missing: %+v", run.Tool.Driver.Rules)
+	}
+	if len(run.Results) != 1 || run.Results[0].RuleID != "PQC002" || run.Results[0].Level != "warning" {
+		t.Errorf("unexpected results: %+v", run.Results)
+	}
+	if run.Properties.SchemaVersion != report.SchemaVersion {
+		t.Errorf("got schema version %d, want %d", run.Properties.SchemaVersion, report.SchemaVersion)
+	}
+}
+
+func TestWriteSARIFCodeFlows(t *testing.T) {
+	finding := report.Finding{
+		File:     "/src/a.go",
+		Line:     12,
+		Column:   9,
+		RuleID:   "PQC003",
+		Severity: "high",
+		Message:  `function "rsa.SignPKCS1v15" implements quantum-vulnerable cryptography`,
+		Related: []report.Location{
+			{File: "/src/a.go", Line: 4, Column: 2, Message: `"crypto/rsa" imported here`},
+			{File: "/src/a.go", Line: 10, Column: 14, Message: "key generated here by rsa.GenerateKey"},
+		},
+	}
+	var buf bytes.Buffer
+	if err := report.WriteSARIF(&buf, &report.Report{Findings: []report.Finding{finding}}); err != nil {
+		t.Fatal(err)
+	}
+
+	type location struct {
+		ID               int
+		PhysicalLocation struct {
+			Region struct {
+				StartLine int
+			}
+		}
+		Message struct {
+			Text string
+		}
+	}
+	var log struct {
+		Runs []struct {
+			Results []struct {
+				RelatedLocations []location
+				CodeFlows        []struct {
+					ThreadFlows []struct {
+						Locations []struct {
+							Location location
+						}
+					}
+				}
+			}
+		}
+	}
+	if err := json.Unmarshal(buf.Bytes(), &log); err != nil {
+		t.Fatalf("invalid SARIF: %s", err.Error())
+	}
+	result := log.Runs[0].Results[0]
+	if len(result.RelatedLocations) != 2 || result.RelatedLocations[0].ID != 1 || result.RelatedLocations[1].PhysicalLocation.Region.StartLine != 10 {
+		t.Errorf("unexpected related locations: %+v", result.RelatedLocations)
+	}
+	if len(result.CodeFlows) != 1 || len(result.CodeFlows[0].ThreadFlows) != 1 {
+		t.Fatalf("unexpected code flows: %s", buf.String())
+	}
+	var lines []int
+	for _, step := range result.CodeFlows[0].ThreadFlows[0].Locations {
+		lines = append(lines, step.Location.PhysicalLocation.Region.StartLine)
+	}
+	if want := []int{4, 10, 12}; !slices.Equal(lines, want) {
+		t.Errorf("got code flow through lines %v, want %v", lines, want)
+	}
+}
+
+func TestLoad(t *testing.T) {
+	dir := t.TempDir()
+	current := filepath.Join(dir, "current.json")
+	var buf bytes.Buffer
+	if err := report.WriteJSON(&buf, testReport); err != nil {
+		t.Fatal(err)
+	}
+	// Reports written before schema versions lack the category and
+	// documentation of findings.
+	unversioned := filepath.Join(dir, "unversioned.json")
+	newer := filepath.Join(dir, "newer.json")
+	err := errors.Join(
+		os.WriteFile(current, buf.Bytes(), 0o644),
+		os.WriteFile(unversioned, []byte(`{"findings": [{"file": "a.go", "line": 3, "column": 8, "ruleId": "PQC002", "severity": "medium", "message": "m"}]}`), 0o644),
+		os.WriteFile(newer, []byte(fmt.Sprintf(`{"schemaVersion": %d, "findings": []}`, report.SchemaVersion+1)), 0o644),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	rep, err := report.Load(current)
+	if err != nil {
+		t.Fatalf("failed to load current report: %s", err.Error())
+	}
+	if rep.SchemaVersion != report.SchemaVersion || len(rep.Findings) != 1 || rep.Findings[0].HelpURI != "https://example.com/PQC002.md" {
+		t.Errorf("unexpected current report %+v", rep)
+	}
+
+	rep, err = report.Load(unversioned)
+	if err != nil {
+		t.Fatalf("failed to load unversioned report: %s", err.Error())
+	}
+	if rep.SchemaVersion != report.SchemaVersion || len(rep.Findings) != 1 {
+		t.Fatalf("unexpected migrated report %+v", rep)
+	}
+	if finding := rep.Findings[0]; finding.Category != analyzer.CategoryIntegerFactorization || !strings.HasSuffix(finding.HelpURI, "/PQC002.md") {
+		t.Errorf("migration did not fill in the rule of %+v", finding)
+	}
+
+	if _, err := report.Load(newer); err == nil || !strings.Contains(err.Error(), "newer") {
+		t.Errorf("loading a newer report did not fail: %v", err)
+	}
+}
+
+func TestComputeDiff(t *testing.T) {
+	finding := func(file string, line int, ruleID, severity, message string) report.Finding {
+		return report.Finding{File: file, Line: line, RuleID: ruleID, Severity: severity, Message: message}
+	}
+	oldReport := &report.Report{Findings: []report.Finding{
+		finding("a.go", 3, "PQC002", "medium", "rsa import"),
+		finding("a.go", 10, "PQC003", "high", "rsa.SignPSS"),
+		finding("a.go", 20, "PQC003", "high", "rsa.SignPSS"),
+	}}
+	newReport := &report.Report{Findings: []report.Finding{
+		// Moved by an unrelated edit.
+		finding("a.go", 5, "PQC002", "medium", "rsa import"),
+		finding("a.go", 12, "PQC003", "high", "rsa.SignPSS"),
+		finding("b.go", 7, "PQC005", "info", "fips"),
+	}}
+
+	diff := report.ComputeDiff(oldReport, newReport)
+	if len(diff.Unchanged) != 2 || len(diff.Removed) != 1 || len(diff.Added) != 1 {
+		t.Fatalf("unexpected diff: %+v", diff)
+	}
+	if diff.Added[0].File != "b.go" || diff.Removed[0].Line != 20 {
+		t.Errorf("unexpected added/removed findings: %+v / %+v", diff.Added, diff.Removed)
+	}
+
+	want := []report.SeverityDelta{
+		{Severity: "high", Old: 2, New: 1, Delta: -1},
+		{Severity: "medium", Old: 1, New: 1, Delta: 0},
+		{Severity: "info", Old: 0, New: 1, Delta: 1},
+	}
+	if !slices.Equal(diff.Severities, want) {
+		t.Errorf("got severities %+v, want %+v", diff.Severities, want)
+	}
+}
+
+// Counts the findings of each section, as a custom output format.
+type countingWriter struct {
+	w      io.Writer
+	counts map[string]int
+}
+
+func (c *countingWriter) Begin(r *report.Report) error {
+	c.counts = make(map[string]int)
+	return nil
+}
+
+func (c *countingWriter) Write(finding report.Finding) error {
+	c.counts[finding.Status]++
+	return nil
+}
+
+func (c *countingWriter) End() error {
+	_, err := fmt.Fprintf(c.w, "%d open, %d accepted, %d inventory", c.counts[""], c.counts[report.StatusAccepted], c.counts[report.StatusInventory])
+	return err
+}
+
+func TestRegisterWriter(t *testing.T) {
+	report.RegisterWriter("counts", func(w io.Writer) report.Writer {
+		return &countingWriter{w: w}
+	})
+	if !slices.Contains(report.Formats(), "counts") || !slices.Contains(report.Formats(), "sarif") {
+		t.Errorf("unexpected formats %v", report.Formats())
+	}
+
+	rep := *testReport
+	rep.InteropDebt = rep.Findings
+	var buf bytes.Buffer
+	if err := report.WriteFormat(&buf, "counts", &rep); err != nil {
+		t.Fatal(err)
+	}
+	if want := "1 open, 1 accepted, 0 inventory"; buf.String() != want {
+		t.Errorf("got %q, want %q", buf.String(), want)
+	}
+
+	// Built-in formats go through the same interface.
+	buf.Reset()
+	if err := report.WriteFormat(&buf, "text", &rep); err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(buf.String(), "Accepted interop debt (1):") {
+		t.Errorf("text output lost the interop debt section:\n%s", buf.String())
+	}
+
+	if err := report.WriteFormat(&buf, "unknown", &rep); err == nil {
+		t.Error("expected unknown format to fail")
+	}
+}
+
+func TestWriteGitHub(t *testing.T) {
+	var buf bytes.Buffer
+	if err := report.WriteGitHub(&buf, testReport); err != nil {
+		t.Fatal(err)
+	}
+	want := "::warning file=/src/a.go,line=3,col=8,title=PQC002 integer-factorization-import::\"crypto/rsa\" uses quantum-vulnerable integer factorization cryptography\n"
+	if buf.String() != want {
+		t.Errorf("got %q, want %q", buf.String(), want)
+	}
+
+	buf.Reset()
+	if err := report.WriteGitHubSummary(&buf, testReport); err != nil {
+		t.Fatal(err)
+	}
+	if row := "| [PQC002](https://example.com/PQC002.md) | integer-factorization-import | medium | 1 |"; !strings.Contains(buf.String(), row) {
+		t.Errorf("summary is missing %q:\n%s", row, buf.String())
+	}
+}
+
+func TestWriteJUnit(t *testing.T) {
+	rep := *testReport
+	rep.Inventory = []report.Finding{{File: "/src/b/b.go", Line: 7, Column: 2, RuleID: "PQC016", Severity: "info", Message: "switch dispatches on algorithm names"}}
+	var buf bytes.Buffer
+	if err := report.WriteFormat(&buf, "junit", &rep); err != nil {
+		t.Fatal(err)
+	}
+	var suites struct {
+		Tests    int `xml:"tests,attr"`
+		Failures int `xml:"failures,attr"`
+		Suites   []struct {
+			Name  string `xml:"name,attr"`
+			Cases []struct {
+				Name    string  `xml:"name,attr"`
+				Failure *string `xml:"failure"`
+				Skipped *string `xml:"skipped"`
+			} `xml:"testcase"`
+		} `xml:"testsuite"`
+	}
+	if err := xml.Unmarshal(buf.Bytes(), &suites); err != nil {
+		t.Fatal(err)
+	}
+	if suites.Tests != 2 || suites.Failures != 1 || len(suites.Suites) != 2 {
+		t.Fatalf("got %d tests, %d failures in %d suites:\n%s", suites.Tests, suites.Failures, len(suites.Suites), buf.String())
+	}
+	failed := suites.Suites[0]
+	if failed.Name != "/src" || failed.Cases[0].Name != "PQC002 integer-factorization-import" || failed.Cases[0].Failure == nil {
+		t.Errorf("unexpected failing suite:\n%s", buf.String())
+	}
+	if !strings.Contains(*failed.Cases[0].Failure, "/src/a.go:3:8: ") {
+		t.Errorf("failure does not list the finding:\n%s", buf.String())
+	}
+	if skipped := suites.Suites[1]; skipped.Name != "/src/b" || skipped.Cases[0].Skipped == nil {
+		t.Errorf("inventory is not skipped:\n%s", buf.String())
+	}
+
+	buf.Reset()
+	if err := report.WriteJUnit(&buf, &report.Report{}); err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(buf.String(), `tests="1" failures="0"`) {
+		t.Errorf("empty report has no passing test case:\n%s", buf.String())
+	}
+}
+
+func TestCap(t *testing.T) {
+	finding := func(file, ruleID, category string, line int) report.Finding {
+		return report.Finding{File: file, Line: line, RuleID: ruleID, Category: category}
+	}
+	r := &report.Report{Findings: []report.Finding{
+		finding("a.go", "PQC001", "elliptic-curve", 1),
+		finding("a.go", "PQC003", "vulnerable-function", 2),
+		finding("a.go", "PQC003", "vulnerable-function", 3),
+		finding("b.go", "PQC003", "vulnerable-function", 1),
+		finding("b.go", "PQC003", "vulnerable-function", 2),
+		finding("b.go", "PQC003", "vulnerable-function", 3),
+		finding("c.go", "PQC001", "elliptic-curve", 1),
+	}}
+	r.Sort()
+	r.Cap(1, 2)
+
+	var kept []string
+	for _, finding := range r.Findings {
+		kept = append(kept, fmt.Sprintf("%s:%d", finding.File, finding.Line))
+	}
+	if want := []string{"a.go:1", "b.go:1", "b.go:2"}; !slices.Equal(kept, want) {
+		t.Errorf("kept %v, want %v", kept, want)
+	}
+	if r.Caps.OmittedFindings() != 4 || r.Caps.Omitted["PQC001"] != 1 || r.Caps.Omitted["PQC003"] != 3 {
+		t.Errorf("unexpected omitted findings %v", r.Caps.Omitted)
+	}
+
+	var buf bytes.Buffer
+	if err := report.WriteText(&buf, r); err != nil {
+		t.Fatal(err)
+	}
+	if note := "4 findings omitted (top 1 files per category, 2 per rule):"; !strings.Contains(buf.String(), note) {
+		t.Errorf("text report is missing %q:\n%s", note, buf.String())
+	}
+}
+
+func TestFailing(t *testing.T) {
+	rep := &report.Report{Findings: []report.Finding{
+		{File: "/src/a.go", RuleID: "PQC002", Severity: "medium"},
+		{File: "/src/b.go", RuleID: "PQC004", Severity: "high"},
+		{File: "/mod/c.go", RuleID: "PQC004", Severity: "high", Dependency: "golang.org/x/crypto@v0.31.0"},
+	}}
+	high, info := analyzer.SeverityHigh, analyzer.SeverityInfo
+	for _, tt := range []struct {
+		thresholds report.Thresholds
+		want       []string
+	}{
+		{report.Thresholds{FirstParty: &info}, []string{"/src/a.go", "/src/b.go"}},
+		{report.Thresholds{FirstParty: &high}, []string{"/src/b.go"}},
+		{report.Thresholds{FirstParty: &high, Dependencies: &info}, []string{"/src/b.go", "/mod/c.go"}},
+		{report.Thresholds{Dependencies: &high}, []string{"/mod/c.go"}},
+		{report.Thresholds{}, nil},
+	} {
+		var got []string
+		for _, finding := range rep.Failing(tt.thresholds) {
+			got = append(got, finding.File)
+		}
+		if !slices.Equal(got, tt.want) {
+			t.Errorf("got failing findings %v, want %v", got, tt.want)
+		}
+	}
+}
+
+func TestPolicy(t *testing.T) {
+	rep := &report.Report{
+		Findings: []report.Finding{
+			{File: "/src/services/payments/charge.go", RuleID: "PQC002", Category: "integer-factorization", Severity: "critical"},
+			{File: "/src/services/payments/refund.go", RuleID: "PQC001", Category: "elliptic-curve", Severity: "medium"},
+			{File: "/src/services/search/index.go", RuleID: "PQC002", Category: "integer-factorization", Severity: "critical"},
+		},
+		InteropDebt: []report.Finding{
+			{File: "/src/services/payments/legacy.go", RuleID: "PQC002", Category: "integer-factorization", Severity: "critical", Justification: "bank API"},
+		},
+	}
+	for _, tt := range []struct {
+		deny string
+		want []string
+	}{
+		{`severity >= "critical" && path matches "services/payments/**" && !accepted`, []string{"/src/services/payments/charge.go"}},
+		{`severity >= "critical" && path matches "services/payments/**"`, []string{"/src/services/payments/charge.go", "/src/services/payments/legacy.go"}},
+		{`severity < "high" || justification contains "bank"`, []string{"/src/services/payments/refund.go", "/src/services/payments/legacy.go"}},
+		{`!(rule == "PQC002") && section == "findings"`, []string{"/src/services/payments/refund.go"}},
+		{`accepted`, []string{"/src/services/payments/legacy.go"}},
+	} {
+		policy, err := report.ParsePolicy("test", tt.deny)
+		if err != nil {
+			t.Fatalf("ParsePolicy(%q) failed: %s", tt.deny, err.Error())
+		}
+		var got []string
+		for _, violation := range rep.Violations([]*report.Policy{policy}, "/src") {
+			got = append(got, violation.Finding.File)
+		}
+		if !slices.Equal(got, tt.want) {
+			t.Errorf("%s: got violations %v, want %v", tt.deny, got, tt.want)
+		}
+	}
+
+	for _, deny := range []string{
+		``,
+		`owner == "payments"`,
+		`severity`,
+		`severity >= "urgent"`,
+		`path > "a"`,
+		`rule == PQC002`,
+		`(accepted`,
+		`accepted unreachable`,
+		`rule == "PQC002`,
+	} {
+		if _, err := report.ParsePolicy("test", deny); err == nil {
+			t.Errorf("ParsePolicy(%q) succeeded, want error", deny)
+		}
+	}
+}
@@ -0,0 +1,218 @@
+package report
+
+import (
+	"encoding/json"
+	"io"
+	"os"
+	"slices"
+)
+
+// The subset of the SARIF 2.1.0 format written by WriteSARIF.
+type sarifLog struct {
+	Schema  string     `json:"$schema"`
+	Version string     `json:"version"`
+	Runs    []sarifRun `json:"runs"`
+}
+
+type sarifRun struct {
+	Tool       sarifTool      `json:"tool"`
+	Results    []sarifResult  `json:"results"`
+	Properties map[string]any `json:"properties,omitempty"`
+}
+
+type sarifTool struct {
+	Driver sarifDriver `json:"driver"`
+}
+
+type sarifDriver struct {
+	Name           string      `json:"name"`
+	InformationURI string      `json:"informationUri"`
+	Rules          []sarifRule `json:"rules"`
+}
+
+type sarifRule struct {
+	ID                   string             `json:"id"`
+	Name                 string             `json:"name"`
+	ShortDescription     sarifMessage       `json:"shortDescription"`
+	HelpURI              string             `json:"helpUri,omitempty"`
+	DefaultConfiguration sarifConfiguration `json:"defaultConfiguration"`
+	Properties           map[string]any     `json:"properties,omitempty"`
+}
+
+type sarifConfiguration struct {
+	Level string `json:"level"`
+}
+
+type sarifMessage struct {
+	Text string `json:"text"`
+}
+
+type sarifResult struct {
+	RuleID    string          `json:"ruleId"`
+	Level     string          `json:"level"`
+	Message   sarifMessage    `json:"message"`
+	Locations []sarifLocation `json:"locations"`
+	// The related locations of the finding, and a code flow through them to
+	// the finding, so code scanning services show how it is reached.
+	RelatedLocations []sarifLocation    `json:"relatedLocations,omitempty"`
+	CodeFlows        []sarifCodeFlow    `json:"codeFlows,omitempty"`
+	Suppressions     []sarifSuppression `json:"suppressions,omitempty"`
+	Properties       map[string]any     `json:"properties,omitempty"`
+}
+
+type sarifSuppression struct {
+	Kind          string `json:"kind"`
+	Justification string `json:"justification,omitempty"`
+}
+
+type sarifLocation struct {
+	// Identifier of a related location, from 1.
+	ID               int                   `json:"id,omitempty"`
+	PhysicalLocation sarifPhysicalLocation `json:"physicalLocation"`
+	Message          *sarifMessage         `json:"message,omitempty"`
+}
+
+type sarifCodeFlow struct {
+	ThreadFlows []sarifThreadFlow `json:"threadFlows"`
+}
+
+type sarifThreadFlow struct {
+	Locations []sarifThreadFlowLocation `json:"locations"`
+}
+
+type sarifThreadFlowLocation struct {
+	Location sarifLocation `json:"location"`
+}
+
+type sarifPhysicalLocation struct {
+	ArtifactLocation sarifArtifactLocation `json:"artifactLocation"`
+	Region           sarifRegion           `json:"region"`
+}
+
+type sarifArtifactLocation struct {
+	URI       string `json:"uri"`
+	URIBaseID string `json:"uriBaseId,omitempty"`
+}
+
+type sarifRegion struct {
+	StartLine   int `json:"startLine"`
+	StartColumn int `json:"startColumn"`
+}
+
+// WriteSARIF writes the report in the SARIF 2.1.0 format, as consumed by
+// code scanning services, with the SchemaVersion of its findings in the
+// properties of the run. File paths under the working directory are written
+// relative to it.
+func WriteSARIF(w io.Writer, r *Report) error {
+	driver := sarifDriver{
+		Name:           "pqc-analyzer",
+		InformationURI: "https://github.com/ahan-adelaide/pqc-analyzer",
+		Rules:          []sarifRule{},
+	}
+	for _, rule := range r.Rules {
+		driver.Rules = append(driver.Rules, sarifRule{
+			ID:                   rule.ID,
+			Name:                 rule.Name,
+			ShortDescription:     sarifMessage{rule.Summary},
+			HelpURI:              rule.HelpURI,
+			DefaultConfiguration: sarifConfiguration{sarifLevel(rule.Severity)},
+			Properties:           map[string]any{"category": rule.Category, "severity": rule.Severity},
+		})
+	}
+
+	wd, _ := os.Getwd()
+	results := []sarifResult{}
+	for _, finding := range r.Findings {
+		results = append(results, newSARIFResult(wd, finding))
+	}
+	// Accepted interop debt is suppressed in source, so code scanning
+	// services list it without raising alerts.
+	for _, finding := range r.InteropDebt {
+		result := newSARIFResult(wd, finding)
+		result.Suppressions = []sarifSuppression{{Kind: "inSource", Justification: finding.Justification}}
+		results = append(results, result)
+	}
+	// Algorithm agility points and the API surface have info severity, so
+	// they are only notes.
+	for _, finding := range slices.Concat(r.Inventory, r.APISurface) {
+		results = append(results, newSARIFResult(wd, finding))
+	}
+
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(sarifLog{
+		Schema:  "https://json.schemastore.org/sarif-2.1.0.json",
+		Version: "2.1.0",
+		Runs: []sarifRun{{
+			Tool:       sarifTool{driver},
+			Results:    results,
+			Properties: map[string]any{"schemaVersion": SchemaVersion},
+		}},
+	})
+}
+
+func newSARIFResult(wd string, finding Finding) sarifResult {
+	var properties map[string]any
+	if finding.Dependency != "" {
+		properties = map[string]any{"dependency": finding.Dependency}
+	}
+	if finding.HighVolume {
+		if properties == nil {
+			properties = make(map[string]any)
+		}
+		properties["highVolume"] = true
+	}
+	location := sarifLocation{
+		PhysicalLocation: sarifPhysicalLocation{
+			ArtifactLocation: sarifArtifact(wd, finding.File),
+			Region:           sarifRegion{finding.Line, finding.Column},
+		},
+	}
+	result := sarifResult{
+		RuleID:     finding.RuleID,
+		Level:      sarifLevel(finding.Severity),
+		Message:    sarifMessage{finding.Message},
+		Locations:  []sarifLocation{location},
+		Properties: properties,
+	}
+	if len(finding.Related) == 0 {
+		return result
+	}
+
+	// The flow leads from the import and the key generation to the finding.
+	var flow sarifThreadFlow
+	for i, related := range finding.Related {
+		relatedLocation := sarifLocation{
+			PhysicalLocation: sarifPhysicalLocation{
+				ArtifactLocation: sarifArtifact(wd, related.File),
+				Region:           sarifRegion{related.Line, related.Column},
+			},
+			Message: &sarifMessage{related.Message},
+		}
+		flow.Locations = append(flow.Locations, sarifThreadFlowLocation{relatedLocation})
+		relatedLocation.ID = i + 1
+		result.RelatedLocations = append(result.RelatedLocations, relatedLocation)
+	}
+	location.Message = &sarifMessage{finding.Message}
+	flow.Locations = append(flow.Locations, sarifThreadFlowLocation{location})
+	result.CodeFlows = []sarifCodeFlow{{ThreadFlows: []sarifThreadFlow{flow}}}
+	return result
+}
+
+func sarifArtifact(wd, file string) sarifArtifactLocation {
+	path, relative := relativePath(wd, file)
+	if relative {
+		return sarifArtifactLocation{URI: path, URIBaseID: "%SRCROOT%"}
+	}
+	return sarifArtifactLocation{URI: "file://" + path}
+}
+
+func sarifLevel(severity string) string {
+	switch severity {
+	case "critical", "high":
+		return "error"
+	case "info":
+		return "note"
+	}
+	return "warning"
+}
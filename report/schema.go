@@ -0,0 +1,72 @@
+package report
+
+import (
+	"cmp"
+	"encoding/json"
+	"fmt"
+
+	"github.com/ahan-adelaide/pqc-analyzer/analyzer"
+)
+
+// SchemaVersion is the version of the finding schema of JSON reports and
+// diffs, in their schemaVersion field, and of SARIF logs, in the properties
+// of their run.
+//
+// Within a schema version, fields are only added, so consumers must ignore
+// fields they do not know. Removing or renaming a field, or changing its
+// type or meaning, increments the version and adds a migration from the
+// previous one. Load reads reports of the current and at least the previous
+// version, so baselines written before an upgrade keep working with report
+// diff; a migration is only dropped once its version is two behind.
+const SchemaVersion = 1
+
+// Migrations of decoded reports to the next schema version, by the version
+// they migrate from.
+var migrations = map[int]func(r *Report){
+	0: migrateUnversioned,
+}
+
+// Returns the report decoded from JSON data of any supported schema version,
+// migrated to the current one.
+func decodeReport(data []byte) (*Report, error) {
+	var r Report
+	if err := json.Unmarshal(data, &r); err != nil {
+		return nil, err
+	}
+	if r.SchemaVersion > SchemaVersion {
+		return nil, fmt.Errorf("schema version %d is newer than version %d supported by this pqc-analyzer", r.SchemaVersion, SchemaVersion)
+	}
+	for r.SchemaVersion < SchemaVersion {
+		migrate, ok := migrations[r.SchemaVersion]
+		if !ok {
+			return nil, fmt.Errorf("schema version %d is no longer supported; the oldest supported version is %d", r.SchemaVersion, oldestSchemaVersion())
+		}
+		migrate(&r)
+		r.SchemaVersion++
+	}
+	return &r, nil
+}
+
+// Returns the oldest schema version decodeReport can migrate.
+func oldestSchemaVersion() int {
+	oldest := SchemaVersion
+	for version := range migrations {
+		oldest = min(oldest, version)
+	}
+	return oldest
+}
+
+// Migrates reports written before schema versions, whose findings may lack
+// the category and documentation of their rule, from the built-in rules.
+func migrateUnversioned(r *Report) {
+	for _, findings := range [][]Finding{r.Findings, r.InteropDebt, r.Inventory, r.APISurface} {
+		for i := range findings {
+			rule, ok := analyzer.LookupRule(findings[i].RuleID)
+			if !ok {
+				continue
+			}
+			findings[i].Category = cmp.Or(findings[i].Category, rule.Category)
+			findings[i].HelpURI = cmp.Or(findings[i].HelpURI, rule.DocURL())
+		}
+	}
+}
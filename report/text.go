@@ -0,0 +1,110 @@
+package report
+
+import (
+	"fmt"
+	"io"
+	"maps"
+	"slices"
+	"strings"
+)
+
+// WriteText writes the findings in the same "file:line:col: message" form the
+// analysis drivers use. Findings that were only seen under some of the
+// analyzed build configurations are annotated with those configurations.
+// Accepted interop debt, algorithm agility points and the exported API
+// exposing classical key types follow the findings in their own sections,
+// then the findings violating policies.
+// Reports of several build configurations or modules count the findings of
+// each, and a note of the omitted findings closes capped reports.
+func WriteText(w io.Writer, r *Report) error {
+	if err := writeTextFindings(w, r, r.Findings); err != nil {
+		return err
+	}
+	if len(r.InteropDebt) > 0 {
+		if _, err := fmt.Fprintf(w, "\nAccepted interop debt (%d):\n", len(r.InteropDebt)); err != nil {
+			return err
+		}
+		if err := writeTextFindings(w, r, r.InteropDebt); err != nil {
+			return err
+		}
+	}
+	if len(r.Inventory) > 0 {
+		if _, err := fmt.Fprintf(w, "\nAlgorithm agility points (%d):\n", len(r.Inventory)); err != nil {
+			return err
+		}
+		if err := writeTextFindings(w, r, r.Inventory); err != nil {
+			return err
+		}
+	}
+	if len(r.APISurface) > 0 {
+		if _, err := fmt.Fprintf(w, "\nExported API exposing classical key types (%d):\n", len(r.APISurface)); err != nil {
+			return err
+		}
+		if err := writeTextFindings(w, r, r.APISurface); err != nil {
+			return err
+		}
+	}
+	if len(r.PolicyViolations) > 0 {
+		if _, err := fmt.Fprintf(w, "\nPolicy violations (%d):\n", len(r.PolicyViolations)); err != nil {
+			return err
+		}
+		for _, violation := range r.PolicyViolations {
+			if _, err := fmt.Fprintf(w, "%s: %s:%d:%d: %s\n", violation.Policy, violation.Finding.File, violation.Finding.Line, violation.Finding.Column, violation.Finding.Message); err != nil {
+				return err
+			}
+		}
+	}
+	if len(r.BuildFindings) > 0 {
+		if _, err := fmt.Fprintln(w, "\nFindings per build configuration:"); err != nil {
+			return err
+		}
+		for _, build := range r.Builds {
+			if _, err := fmt.Fprintf(w, "%s: %d\n", build, r.BuildFindings[build]); err != nil {
+				return err
+			}
+		}
+	}
+	if len(r.ModuleFindings) > 0 {
+		if _, err := fmt.Fprintln(w, "\nFindings per module:"); err != nil {
+			return err
+		}
+		for _, module := range r.Modules {
+			if _, err := fmt.Fprintf(w, "%s: %d\n", module, r.ModuleFindings[module]); err != nil {
+				return err
+			}
+		}
+	}
+	if r.Caps != nil && r.Caps.OmittedFindings() > 0 {
+		if _, err := fmt.Fprintf(w, "\n%d findings omitted (%s):\n", r.Caps.OmittedFindings(), r.Caps); err != nil {
+			return err
+		}
+		for _, rule := range slices.Sorted(maps.Keys(r.Caps.Omitted)) {
+			if _, err := fmt.Fprintf(w, "%s: %d\n", rule, r.Caps.Omitted[rule]); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func writeTextFindings(w io.Writer, r *Report, findings []Finding) error {
+	for _, finding := range findings {
+		line := fmt.Sprintf("%s:%d:%d: %s", finding.File, finding.Line, finding.Column, finding.Message)
+		if len(finding.Builds) > 0 && len(finding.Builds) < len(r.Builds) {
+			line += " [" + strings.Join(finding.Builds, "; ") + "]"
+		}
+		if finding.Dependency != "" {
+			line += " [dependency " + finding.Dependency + "]"
+		}
+		if finding.Unreachable {
+			line += " (unreachable)"
+		}
+		if finding.Justification != "" {
+			line += ": " + finding.Justification
+		}
+		if _, err := fmt.Fprintln(w, line); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,151 @@
+package report
+
+import (
+	"fmt"
+	"io"
+	"slices"
+	"sync"
+)
+
+// Statuses of findings outside the main findings section of a report.
+const (
+	// The finding is accepted interop debt.
+	StatusAccepted = "accepted"
+	// The finding is an algorithm agility point in the inventory.
+	StatusInventory = "inventory"
+	// The finding is an exported API exposing a classical key type.
+	StatusAPI = "api"
+)
+
+// Writer writes a report in an output format, one finding at a time.
+// Downstream users can implement it for custom formats, such as internal
+// ticketing systems, and register it with RegisterWriter.
+type Writer interface {
+	// Begin starts writing the report r. Its findings are passed to Write
+	// afterwards; Begin should only use its metadata, such as its builds and
+	// rules.
+	Begin(r *Report) error
+	// Write writes a finding. Findings of the main section come first, then
+	// accepted interop debt, inventory and API surface, told apart by
+	// Finding.Status.
+	Write(finding Finding) error
+	// End finishes writing the report.
+	End() error
+}
+
+// A WriterFactory returns a Writer writing to w.
+type WriterFactory func(w io.Writer) Writer
+
+var (
+	writersMu sync.RWMutex
+	writers   = make(map[string]WriterFactory)
+)
+
+func init() {
+	RegisterWriter("text", bufferedWriterFactory(WriteText))
+	RegisterWriter("json", bufferedWriterFactory(WriteJSON))
+	RegisterWriter("sarif", bufferedWriterFactory(WriteSARIF))
+}
+
+// RegisterWriter makes an output format available under the given name. It
+// panics if the name is already registered.
+func RegisterWriter(format string, factory WriterFactory) {
+	writersMu.Lock()
+	defer writersMu.Unlock()
+	if _, ok := writers[format]; ok {
+		panic("report: output format " + format + " registered twice")
+	}
+	writers[format] = factory
+}
+
+// Formats returns the names of the registered output formats, sorted.
+func Formats() []string {
+	writersMu.RLock()
+	defer writersMu.RUnlock()
+	formats := make([]string, 0, len(writers))
+	for format := range writers {
+		formats = append(formats, format)
+	}
+	slices.Sort(formats)
+	return formats
+}
+
+// NewWriter returns a Writer of the registered output format writing to w.
+func NewWriter(format string, w io.Writer) (Writer, error) {
+	writersMu.RLock()
+	factory, ok := writers[format]
+	writersMu.RUnlock()
+	if !ok {
+		return nil, fmt.Errorf("unknown output format %q", format)
+	}
+	return factory(w), nil
+}
+
+// WriteFormat writes the report to w in the registered output format.
+func WriteFormat(w io.Writer, format string, r *Report) error {
+	writer, err := NewWriter(format, w)
+	if err != nil {
+		return err
+	}
+	if err := writer.Begin(r); err != nil {
+		return err
+	}
+	sections := []struct {
+		status   string
+		findings []Finding
+	}{
+		{"", r.Findings},
+		{StatusAccepted, r.InteropDebt},
+		{StatusInventory, r.Inventory},
+		{StatusAPI, r.APISurface},
+	}
+	for _, section := range sections {
+		for _, finding := range section.findings {
+			finding.Status = section.status
+			if err := writer.Write(finding); err != nil {
+				return err
+			}
+		}
+	}
+	return writer.End()
+}
+
+// A bufferedWriter collects the findings and writes the whole report at the
+// end, for formats that are not written incrementally.
+type bufferedWriter struct {
+	w      io.Writer
+	write  func(io.Writer, *Report) error
+	report Report
+}
+
+func bufferedWriterFactory(write func(io.Writer, *Report) error) WriterFactory {
+	return func(w io.Writer) Writer {
+		return &bufferedWriter{w: w, write: write}
+	}
+}
+
+func (b *bufferedWriter) Begin(r *Report) error {
+	b.report = *r
+	b.report.Findings, b.report.InteropDebt, b.report.Inventory, b.report.APISurface = []Finding{}, nil, nil, nil
+	return nil
+}
+
+func (b *bufferedWriter) Write(finding Finding) error {
+	status := finding.Status
+	finding.Status = ""
+	switch status {
+	case StatusAccepted:
+		b.report.InteropDebt = append(b.report.InteropDebt, finding)
+	case StatusInventory:
+		b.report.Inventory = append(b.report.Inventory, finding)
+	case StatusAPI:
+		b.report.APISurface = append(b.report.APISurface, finding)
+	default:
+		b.report.Findings = append(b.report.Findings, finding)
+	}
+	return nil
+}
+
+func (b *bufferedWriter) End() error {
+	return b.write(b.w, &b.report)
+}
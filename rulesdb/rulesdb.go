@@ -0,0 +1,243 @@
+// Package rulesdb keeps a local copy of a signed rules database, which
+// extends the built-in rules with the APIs of third-party crypto libraries,
+// so deployments keep up with new libraries without upgrading the binary.
+package rulesdb
+
+import (
+	"bytes"
+	"context"
+	"crypto/ed25519"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"maps"
+	"net/http"
+	"os"
+	"path/filepath"
+	"slices"
+	"time"
+
+	"github.com/ahan-adelaide/pqc-analyzer/analyzer"
+)
+
+// DefaultPath is the local copy of the rules database used when no explicit
+// path is given. Its signature is kept next to it, with a ".sig" suffix.
+const DefaultPath = ".pqc-rules.json"
+
+// Suffix of the signature of a rules database, both remotely and locally.
+const signatureSuffix = ".sig"
+
+// Database is a rules database.
+type Database struct {
+	// Version of the database, increasing with every release. Updates never
+	// replace a database with an older one.
+	Version int `json:"version"`
+	// Functions of third-party crypto libraries, mapped to the functions
+	// reported by the analyzer that perform the same operation, both
+	// qualified by import path, such as
+	// "example.com/ecc.Sign": "crypto/ecdsa.SignASN1".
+	Functions map[string]string `json:"functions"`
+}
+
+// String describes the database, such as "version 3, 12 functions".
+func (db *Database) String() string {
+	return fmt.Sprintf("version %d, %d functions", db.Version, len(db.Functions))
+}
+
+// Wrappers returns the functions of the database as analyzer wrappers,
+// ordered by function. Functions mapped to functions this version of the
+// analyzer does not know, added for newer versions, are skipped.
+func (db *Database) Wrappers() []analyzer.Wrapper {
+	var wrappers []analyzer.Wrapper
+	for _, function := range slices.Sorted(maps.Keys(db.Functions)) {
+		wrapper, err := analyzer.ParseWrapper(function, db.Functions[function])
+		if err != nil {
+			continue
+		}
+		wrappers = append(wrappers, wrapper)
+	}
+	return wrappers
+}
+
+// ParsePublicKey parses the base64 encoded Ed25519 public key verifying the
+// signatures of a rules database.
+func ParsePublicKey(s string) (ed25519.PublicKey, error) {
+	key, err := base64.StdEncoding.DecodeString(s)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode rules public key: %s", err.Error())
+	}
+	if len(key) != ed25519.PublicKeySize {
+		return nil, fmt.Errorf("invalid rules public key: got %d bytes, want %d", len(key), ed25519.PublicKeySize)
+	}
+	return ed25519.PublicKey(key), nil
+}
+
+// Load reads the local copy of the rules database at path and verifies its
+// signature, so a tampered copy is never used.
+func Load(path string, publicKey ed25519.PublicKey) (*Database, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read rules database %s: %s", path, err.Error())
+	}
+	signature, err := os.ReadFile(path + signatureSuffix)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read rules database signature: %s", err.Error())
+	}
+	db, err := parse(data, signature, publicKey)
+	if err != nil {
+		return nil, fmt.Errorf("invalid rules database %s: %s", path, err.Error())
+	}
+	return db, nil
+}
+
+// Verifies the signature of the database and parses it.
+//
+//pqc:compat the standard library has no post-quantum signature scheme yet
+func parse(data, signature []byte, publicKey ed25519.PublicKey) (*Database, error) {
+	sig, err := base64.StdEncoding.DecodeString(string(bytes.TrimSpace(signature)))
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode signature: %s", err.Error())
+	}
+	if !ed25519.Verify(publicKey, data, sig) {
+		return nil, errors.New("signature verification failed")
+	}
+	var db Database
+	if err := json.Unmarshal(data, &db); err != nil {
+		return nil, fmt.Errorf("failed to parse: %s", err.Error())
+	}
+	return &db, nil
+}
+
+// Source is the remote location of a rules database.
+type Source struct {
+	// URL of the database. Its signature is fetched from the same URL with a
+	// ".sig" suffix.
+	URL       string
+	PublicKey ed25519.PublicKey
+	// Client fetching the database, http.DefaultClient if nil.
+	Client *http.Client
+	// Number of retries of failed requests, and the delay before the first
+	// retry, doubling with every retry.
+	Retries int
+	Backoff time.Duration
+}
+
+// FetchError is the error of an update that could not fetch the database or
+// its signature, such as when offline, as opposed to one rejecting what it
+// fetched.
+type FetchError struct {
+	URL string
+	Err error
+}
+
+func (e *FetchError) Error() string {
+	return fmt.Sprintf("failed to fetch %s: %s", e.URL, e.Err.Error())
+}
+
+func (e *FetchError) Unwrap() error {
+	return e.Err
+}
+
+// Update fetches the rules database, verifies its signature and replaces the
+// local copy at path with it. The local copy is left untouched if fetching
+// or verifying fails, or if it is newer than the fetched database. Callers
+// can fall back to it when fetching fails, with a *FetchError, but a
+// database failing verification or older than the local copy must fail
+// the update, as the source may have been tampered with or rolled back.
+func (s *Source) Update(ctx context.Context, path string) (*Database, error) {
+	data, err := s.fetch(ctx, s.URL)
+	if err != nil {
+		return nil, err
+	}
+	signature, err := s.fetch(ctx, s.URL+signatureSuffix)
+	if err != nil {
+		return nil, err
+	}
+	db, err := parse(data, signature, s.PublicKey)
+	if err != nil {
+		return nil, fmt.Errorf("invalid rules database %s: %s", s.URL, err.Error())
+	}
+	if current, err := Load(path, s.PublicKey); err == nil && current.Version > db.Version {
+		return nil, fmt.Errorf("refusing to replace rules database version %d with older version %d", current.Version, db.Version)
+	}
+
+	// The signature is written first: until the database is replaced as well,
+	// the local copy fails verification rather than passing with a stale
+	// signature.
+	if err := writeFile(path+signatureSuffix, signature); err != nil {
+		return nil, err
+	}
+	if err := writeFile(path, data); err != nil {
+		return nil, err
+	}
+	return db, nil
+}
+
+// Fetches url, retrying network errors, rate limiting and server errors.
+func (s *Source) fetch(ctx context.Context, url string) ([]byte, error) {
+	client := s.Client
+	if client == nil {
+		client = http.DefaultClient
+	}
+	backoff := s.Backoff
+	for attempt := 0; ; attempt++ {
+		data, retry, err := get(ctx, client, url)
+		if err == nil {
+			return data, nil
+		}
+		if !retry || attempt >= s.Retries {
+			return nil, &FetchError{URL: url, Err: err}
+		}
+		select {
+		case <-ctx.Done():
+			return nil, &FetchError{URL: url, Err: ctx.Err()}
+		case <-time.After(backoff):
+		}
+		backoff *= 2
+	}
+}
+
+// Gets url, and reports whether a failed request is worth retrying.
+func get(ctx context.Context, client *http.Client, url string) ([]byte, bool, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, false, err
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, ctx.Err() == nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		retry := resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode >= 500
+		return nil, retry, fmt.Errorf("unexpected status %s", resp.Status)
+	}
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, ctx.Err() == nil, err
+	}
+	return data, false, nil
+}
+
+// Replaces the file at path atomically, so an interrupted update never leaves
+// a truncated file behind.
+func writeFile(path string, data []byte) error {
+	tmp, err := os.CreateTemp(filepath.Dir(path), "."+filepath.Base(path)+"-*")
+	if err != nil {
+		return fmt.Errorf("failed to write %s: %s", path, err.Error())
+	}
+	defer os.Remove(tmp.Name())
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return fmt.Errorf("failed to write %s: %s", path, err.Error())
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("failed to write %s: %s", path, err.Error())
+	}
+	if err := os.Rename(tmp.Name(), path); err != nil {
+		return fmt.Errorf("failed to write %s: %s", path, err.Error())
+	}
+	return nil
+}
@@ -0,0 +1,114 @@
+package rulesdb_test
+
+import (
+	"context"
+	"crypto/ed25519"
+	"encoding/base64"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/ahan-adelaide/pqc-analyzer/analyzer"
+	"github.com/ahan-adelaide/pqc-analyzer/rulesdb"
+)
+
+// Serves a database signed by key, failing the first failures requests.
+func serve(t *testing.T, key ed25519.PrivateKey, database string, failures int32) *httptest.Server {
+	t.Helper()
+	signature := base64.StdEncoding.EncodeToString(ed25519.Sign(key, []byte(database)))
+	var requests atomic.Int32
+	mux := http.NewServeMux()
+	mux.HandleFunc("/rules.json", func(w http.ResponseWriter, r *http.Request) {
+		if requests.Add(1) <= failures {
+			http.Error(w, "unavailable", http.StatusServiceUnavailable)
+			return
+		}
+		w.Write([]byte(database))
+	})
+	mux.HandleFunc("/rules.json.sig", func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(signature + "\n"))
+	})
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+	return server
+}
+
+func TestUpdate(t *testing.T) {
+	publicKey, key, err := ed25519.GenerateKey(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	path := filepath.Join(t.TempDir(), "rules.json")
+	database := `{"version": 2, "functions": {"example.com/ecc.Sign": "crypto/ecdsa.SignASN1", "example.com/pq.Sign": "example.com/future.Sign"}}`
+	server := serve(t, key, database, 2)
+
+	source := rulesdb.Source{URL: server.URL + "/rules.json", PublicKey: publicKey, Retries: 2, Backoff: time.Millisecond}
+	db, err := source.Update(context.Background(), path)
+	if err != nil {
+		t.Fatalf("update failed: %s", err.Error())
+	}
+	if db.Version != 2 {
+		t.Errorf("got version %d, want 2", db.Version)
+	}
+
+	// The local copy is verified, and functions unknown to the analyzer are
+	// skipped.
+	loaded, err := rulesdb.Load(path, publicKey)
+	if err != nil {
+		t.Fatalf("load failed: %s", err.Error())
+	}
+	wrappers := loaded.Wrappers()
+	want := analyzer.Wrapper{
+		Function: analyzer.QvFunction{Package: "example.com/ecc", FnName: "Sign"},
+		Wraps:    analyzer.QvFunction{Package: "crypto/ecdsa", FnName: "SignASN1"},
+	}
+	if len(wrappers) != 1 || wrappers[0] != want {
+		t.Errorf("got wrappers %v, want %v", wrappers, want)
+	}
+
+	// Older databases never replace newer ones.
+	older := serve(t, key, `{"version": 1, "functions": {}}`, 0)
+	source.URL = older.URL + "/rules.json"
+	if _, err := source.Update(context.Background(), path); err == nil {
+		t.Error("update to an older version succeeded")
+	}
+	if loaded, err := rulesdb.Load(path, publicKey); err != nil || loaded.Version != 2 {
+		t.Errorf("got local copy %v, %v after refused update, want version 2", loaded, err)
+	}
+}
+
+func TestUpdateFailures(t *testing.T) {
+	publicKey, key, err := ed25519.GenerateKey(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, otherKey, err := ed25519.GenerateKey(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	path := filepath.Join(t.TempDir(), "rules.json")
+	database := `{"version": 1, "functions": {}}`
+
+	// Retries run out.
+	unavailable := serve(t, key, database, 3)
+	source := rulesdb.Source{URL: unavailable.URL + "/rules.json", PublicKey: publicKey, Retries: 2, Backoff: time.Millisecond}
+	var fetchErr *rulesdb.FetchError
+	if _, err := source.Update(context.Background(), path); !errors.As(err, &fetchErr) {
+		t.Errorf("update after the retries ran out failed with %v, want a fetch error", err)
+	}
+
+	// Signed by another key.
+	forged := serve(t, otherKey, database, 0)
+	source.URL = forged.URL + "/rules.json"
+	if _, err := source.Update(context.Background(), path); err == nil || errors.As(err, &fetchErr) {
+		t.Errorf("update with a forged signature failed with %v, want a verification error", err)
+	}
+
+	if _, err := rulesdb.Load(path, publicKey); err == nil {
+		t.Error("failed updates left a local copy behind")
+	}
+}
@@ -0,0 +1,110 @@
+// Package scaffold generates starting points for acting on scan findings,
+// such as a crypto agility layer for code to call instead of classical
+// algorithms directly.
+package scaffold
+
+import (
+	"bytes"
+	"embed"
+	"fmt"
+	"go/format"
+	"go/token"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+	"text/template"
+)
+
+// Kinds of scaffolds.
+const (
+	KindCryptoWrapper = "crypto-wrapper"
+)
+
+// ClassicalTag is the build tag selecting the classical implementations of
+// generated crypto wrappers.
+const ClassicalTag = "pqc_classical"
+
+//go:embed templates
+var templates embed.FS
+
+// Kinds returns the kinds of scaffolds, sorted.
+func Kinds() []string {
+	return []string{KindCryptoWrapper}
+}
+
+// Options configures a scaffold.
+type Options struct {
+	// Directory to write the scaffold to.
+	Dir string
+	// Name of the generated package. If empty, it is the base name of Dir.
+	Package string
+	// Whether existing files are overwritten.
+	Force bool
+}
+
+// Write writes the scaffold of the given kind and returns the paths of the
+// written files. It writes nothing if any of the files exists, unless
+// opts.Force is set.
+func Write(kind string, opts Options) ([]string, error) {
+	var dir string
+	switch kind {
+	case KindCryptoWrapper:
+		dir = "templates/cryptowrapper"
+	default:
+		return nil, fmt.Errorf("unknown scaffold %q", kind)
+	}
+	pkg := opts.Package
+	if pkg == "" {
+		abs, err := filepath.Abs(opts.Dir)
+		if err != nil {
+			return nil, fmt.Errorf("failed to resolve %s: %s", opts.Dir, err.Error())
+		}
+		pkg = strings.ToLower(strings.NewReplacer("-", "", ".", "", "_", "").Replace(filepath.Base(abs)))
+	}
+	if !token.IsIdentifier(pkg) || token.IsKeyword(pkg) {
+		return nil, fmt.Errorf("invalid package name %q", pkg)
+	}
+
+	entries, err := fs.ReadDir(templates, dir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read templates: %s", err.Error())
+	}
+	data := struct {
+		Package      string
+		ClassicalTag string
+	}{pkg, ClassicalTag}
+	files := make(map[string][]byte)
+	var paths []string
+	for _, entry := range entries {
+		tmpl, err := template.ParseFS(templates, path.Join(dir, entry.Name()))
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse template %s: %s", entry.Name(), err.Error())
+		}
+		var b bytes.Buffer
+		if err := tmpl.Execute(&b, data); err != nil {
+			return nil, fmt.Errorf("failed to render %s: %s", entry.Name(), err.Error())
+		}
+		src, err := format.Source(b.Bytes())
+		if err != nil {
+			return nil, fmt.Errorf("failed to format %s: %s", entry.Name(), err.Error())
+		}
+		name := filepath.Join(opts.Dir, strings.TrimSuffix(entry.Name(), ".tmpl"))
+		if _, err := os.Stat(name); err == nil && !opts.Force {
+			return nil, fmt.Errorf("%s already exists; use -force to overwrite it", name)
+		}
+		files[name] = src
+		paths = append(paths, name)
+	}
+
+	if err := os.MkdirAll(opts.Dir, 0o755); err != nil {
+		return nil, fmt.Errorf("failed to create %s: %s", opts.Dir, err.Error())
+	}
+	for _, name := range paths {
+		if err := os.WriteFile(name, files[name], 0o644); err != nil {
+			return nil, fmt.Errorf("failed to write %s: %s", name, err.Error())
+		}
+	}
+	return paths, nil
+}
@@ -0,0 +1,105 @@
+package scaffold_test
+
+import (
+	"go/ast"
+	"go/build/constraint"
+	"go/importer"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/ahan-adelaide/pqc-analyzer/scaffold"
+)
+
+func TestWriteCryptoWrapper(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "pqc-crypto")
+	paths, err := scaffold.Write(scaffold.KindCryptoWrapper, scaffold.Options{Dir: dir})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(paths) != 5 {
+		t.Errorf("wrote %v", paths)
+	}
+
+	// Both builds type-check, with the KEM of their build.
+	for _, tags := range [][]string{nil, {scaffold.ClassicalTag}} {
+		fset := token.NewFileSet()
+		var files []*ast.File
+		for _, path := range paths {
+			file, err := parser.ParseFile(fset, path, nil, parser.ParseComments)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if file.Name.Name != "pqccrypto" {
+				t.Errorf("%s is in package %s", path, file.Name.Name)
+			}
+			if !strings.HasSuffix(path, "_test.go") && built(file, tags) {
+				files = append(files, file)
+			}
+		}
+		conf := types.Config{Importer: importer.ForCompiler(fset, "source", nil)}
+		pkg, err := conf.Check("pqccrypto", fset, files, nil)
+		if err != nil {
+			t.Fatalf("build with tags %v: %s", tags, err.Error())
+		}
+		for _, name := range []string{"NewKEM", "GenerateSigner", "NewVerifier", "ErrVerification"} {
+			if pkg.Scope().Lookup(name) == nil {
+				t.Errorf("build with tags %v lacks %s", tags, name)
+			}
+		}
+	}
+
+	// The examples run in both builds, in a module of their own.
+	if _, err := exec.LookPath("go"); err != nil {
+		t.Log("go command not found, not running the examples")
+	} else {
+		if err := os.WriteFile(filepath.Join(dir, "go.mod"), []byte("module example.com/pqccrypto\n\ngo 1.24\n"), 0o644); err != nil {
+			t.Fatal(err)
+		}
+		for _, tags := range []string{"", scaffold.ClassicalTag} {
+			cmd := exec.Command("go", "test", "-tags="+tags, "-run=^Example", "-v", ".")
+			cmd.Dir = dir
+			output, err := cmd.CombinedOutput()
+			if err != nil {
+				t.Errorf("examples with tags %q failed: %s\n%s", tags, err.Error(), output)
+			} else if !strings.Contains(string(output), "--- PASS: ExampleKEM") {
+				t.Errorf("examples with tags %q did not run:\n%s", tags, output)
+			}
+		}
+	}
+
+	if _, err := scaffold.Write(scaffold.KindCryptoWrapper, scaffold.Options{Dir: dir}); err == nil {
+		t.Error("existing files were overwritten")
+	}
+	if _, err := scaffold.Write(scaffold.KindCryptoWrapper, scaffold.Options{Dir: dir, Package: "agility", Force: true}); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestWriteInvalid(t *testing.T) {
+	if _, err := scaffold.Write("service", scaffold.Options{Dir: t.TempDir()}); err == nil {
+		t.Error("unknown scaffold was accepted")
+	}
+	if _, err := scaffold.Write(scaffold.KindCryptoWrapper, scaffold.Options{Dir: t.TempDir(), Package: "func"}); err == nil {
+		t.Error("invalid package name was accepted")
+	}
+}
+
+// Reports whether the file is built with the given tags.
+func built(file *ast.File, tags []string) bool {
+	for _, group := range file.Comments {
+		for _, comment := range group.List {
+			if expr, err := constraint.Parse(comment.Text); err == nil {
+				return expr.Eval(func(tag string) bool {
+					return tag == "go1.24" || len(tags) > 0 && tag == tags[0]
+				})
+			}
+		}
+	}
+	return true
+}
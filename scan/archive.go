@@ -0,0 +1,400 @@
+package scan
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"bytes"
+	"compress/gzip"
+	"fmt"
+	"go/ast"
+	"go/build"
+	"go/importer"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"io"
+	"maps"
+	"os"
+	"path"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/ahan-adelaide/pqc-analyzer/config"
+	"github.com/ahan-adelaide/pqc-analyzer/report"
+	"golang.org/x/mod/modfile"
+	"golang.org/x/tools/go/packages"
+)
+
+// Suffixes of the source archives LoadArchive reads.
+var archiveSuffixes = []string{".zip", ".tar", ".tar.gz", ".tgz"}
+
+// Limits of the archives read, so a crafted archive cannot exhaust memory:
+// the largest file read, the limit of go.mod files in module zips, the total
+// size of the files read, the limit of module zips, and the number of
+// entries.
+var (
+	maxArchiveFileSize int64 = 16 << 20
+	maxArchiveSize     int64 = 500 << 20
+	maxArchiveEntries        = 100_000
+)
+
+// IsArchive reports whether path names a source archive LoadArchive reads,
+// by its suffix.
+func IsArchive(path string) bool {
+	return slices.ContainsFunc(archiveSuffixes, func(suffix string) bool {
+		return strings.HasSuffix(strings.ToLower(path), suffix)
+	})
+}
+
+// RunArchive analyzes the packages of a source archive and merges the
+// findings of every build configuration into a single report, like Run. See
+// LoadArchive.
+func RunArchive(path string, opts Options) (*report.Report, error) {
+	pkgs, err := LoadArchive(path, opts)
+	if err != nil {
+		return nil, err
+	}
+	return pkgs.Run(opts)
+}
+
+// LoadArchive loads the packages of the Go modules in a source archive, such
+// as a module zip served by proxy.golang.org or a tarball of a source tree,
+// without extracting it or downloading dependencies, so third-party module
+// versions can be assessed without checking them out. Packages are
+// type-checked against the standard library and the other packages of their
+// module in the archive; imports of other modules are left unresolved, so
+// findings needing their types are missed. File paths are those inside the
+// archive. Only the Tests and Builds options are used, and deep analysis is
+// not supported.
+func LoadArchive(path string, opts Options) (*Packages, error) {
+	if opts.Deep {
+		return nil, fmt.Errorf("deep analysis is not supported for archives")
+	}
+	files, err := readArchive(path)
+	if err != nil {
+		return nil, err
+	}
+	return loadBuilds(opts, func(buildConfig config.BuildConfig) (loadedBuild, error) {
+		pkgs, err := loadArchiveBuild(files, opts, buildConfig)
+		return loadedBuild{build: buildConfig, pkgs: pkgs}, err
+	})
+}
+
+// Returns the Go source files and go.mod files of an archive, by slash-separated
+// path inside it. Archives with entries outside their root, or exceeding the
+// limits of their size or number of entries, are rejected.
+func readArchive(name string) (map[string][]byte, error) {
+	files := make(map[string][]byte)
+	var entries int
+	var total int64
+	// Counts an entry of the archive, of any type.
+	count := func() error {
+		if entries++; entries > maxArchiveEntries {
+			return fmt.Errorf("failed to read archive %s: more than %d entries", name, maxArchiveEntries)
+		}
+		return nil
+	}
+	add := func(entry string, size int64, open func() (io.ReadCloser, error)) error {
+		entry = path.Clean(strings.TrimPrefix(entry, "./"))
+		if entry == ".." || strings.HasPrefix(entry, "../") || path.IsAbs(entry) {
+			return fmt.Errorf("failed to read archive %s: %s is outside the archive", name, entry)
+		}
+		if !strings.HasSuffix(entry, ".go") && path.Base(entry) != "go.mod" {
+			return nil
+		}
+		if size > maxArchiveFileSize {
+			return fmt.Errorf("failed to read archive %s: %s is larger than %d bytes", name, entry, maxArchiveFileSize)
+		}
+		r, err := open()
+		if err != nil {
+			return fmt.Errorf("failed to read archive %s: %s", name, err.Error())
+		}
+		defer r.Close()
+		data, err := io.ReadAll(io.LimitReader(r, maxArchiveFileSize+1))
+		if err != nil {
+			return fmt.Errorf("failed to read archive %s: %s", name, err.Error())
+		}
+		if int64(len(data)) > maxArchiveFileSize {
+			return fmt.Errorf("failed to read archive %s: %s is larger than %d bytes", name, entry, maxArchiveFileSize)
+		}
+		if total += int64(len(data)); total > maxArchiveSize {
+			return fmt.Errorf("failed to read archive %s: files are larger than %d bytes in total", name, maxArchiveSize)
+		}
+		files[entry] = data
+		return nil
+	}
+
+	if strings.HasSuffix(strings.ToLower(name), ".zip") {
+		r, err := zip.OpenReader(name)
+		if err != nil {
+			return nil, fmt.Errorf("failed to open archive %s: %s", name, err.Error())
+		}
+		defer r.Close()
+		for _, f := range r.File {
+			if err := count(); err != nil {
+				return nil, err
+			}
+			if !f.Mode().IsRegular() {
+				continue
+			}
+			if err := add(f.Name, int64(f.UncompressedSize64), f.Open); err != nil {
+				return nil, err
+			}
+		}
+		return files, nil
+	}
+
+	f, err := os.Open(name)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open archive %s: %s", name, err.Error())
+	}
+	defer f.Close()
+	var r io.Reader = f
+	if lower := strings.ToLower(name); strings.HasSuffix(lower, ".gz") || strings.HasSuffix(lower, ".tgz") {
+		gz, err := gzip.NewReader(f)
+		if err != nil {
+			return nil, fmt.Errorf("failed to open archive %s: %s", name, err.Error())
+		}
+		defer gz.Close()
+		r = gz
+	}
+	tr := tar.NewReader(r)
+	for {
+		header, err := tr.Next()
+		if err == io.EOF {
+			return files, nil
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to read archive %s: %s", name, err.Error())
+		}
+		if err := count(); err != nil {
+			return nil, err
+		}
+		if header.Typeflag != tar.TypeReg {
+			continue
+		}
+		if err := add(header.Name, header.Size, func() (io.ReadCloser, error) {
+			return io.NopCloser(tr), nil
+		}); err != nil {
+			return nil, err
+		}
+	}
+}
+
+// A package of an archive, before type-checking.
+type archivePackage struct {
+	path   string
+	name   string
+	files  []*ast.File
+	module *packages.Module
+	types  *types.Package
+	info   *types.Info
+	// Whether the package is being type-checked, to break import cycles.
+	checking bool
+}
+
+var (
+	// Version suffix of the top directory of a module zip.
+	moduleVersion = regexp.MustCompile(`@(v[0-9][^/]*)$`)
+	// Major version element or suffix of an import path.
+	majorVersionElem   = regexp.MustCompile(`^v[0-9]+$`)
+	majorVersionSuffix = regexp.MustCompile(`\.v[0-9]+$`)
+)
+
+// Parses and type-checks the packages of the files of an archive under the
+// build configuration.
+func loadArchiveBuild(files map[string][]byte, opts Options, buildConfig config.BuildConfig) ([]*packages.Package, error) {
+	ctxt := archiveContext(files, buildConfig)
+
+	fset := token.NewFileSet()
+	pkgs := make(map[string]*archivePackage)
+	modules := make(map[string]*packages.Module)
+	for _, name := range slices.Sorted(maps.Keys(files)) {
+		dir, base := path.Split(name)
+		dir = path.Clean(dir)
+		if !strings.HasSuffix(base, ".go") || ignoredArchiveDir(dir) {
+			continue
+		}
+		if strings.HasSuffix(base, "_test.go") && !opts.Tests {
+			continue
+		}
+		if match, err := ctxt.MatchFile(dir, base); err != nil || !match {
+			continue
+		}
+		file, err := parser.ParseFile(fset, name, files[name], parser.ParseComments|parser.SkipObjectResolution)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse %s: %s", name, err.Error())
+		}
+		// External test packages are never analyzed.
+		if strings.HasSuffix(file.Name.Name, "_test") {
+			continue
+		}
+
+		module := archiveModule(files, modules, dir)
+		pkgPath := dir
+		if module != nil {
+			modDir := strings.TrimSuffix(module.Dir, "/")
+			pkgPath = module.Path + strings.TrimPrefix(dir, modDir)
+			if modDir == "." {
+				pkgPath = path.Join(module.Path, dir)
+			}
+		}
+		pkg, ok := pkgs[pkgPath]
+		if !ok {
+			pkg = &archivePackage{path: pkgPath, name: file.Name.Name, module: module}
+			pkgs[pkgPath] = pkg
+		}
+		// Files of other packages in the directory would not type-check.
+		if file.Name.Name == pkg.name {
+			pkg.files = append(pkg.files, file)
+		}
+	}
+
+	imp := &archiveImporter{std: importer.Default(), pkgs: pkgs, fset: fset, sizes: types.SizesFor("gc", ctxt.GOARCH)}
+	var loaded []*packages.Package
+	for _, pkgPath := range slices.Sorted(maps.Keys(pkgs)) {
+		pkg := pkgs[pkgPath]
+		imp.check(pkg)
+		loaded = append(loaded, &packages.Package{
+			ID:         pkg.path,
+			Name:       pkg.name,
+			PkgPath:    pkg.path,
+			Fset:       fset,
+			Syntax:     pkg.files,
+			Types:      pkg.types,
+			TypesInfo:  pkg.info,
+			TypesSizes: imp.sizes,
+			Module:     pkg.module,
+		})
+	}
+	return loaded, nil
+}
+
+// Reports whether the go command ignores the packages of the directory.
+func ignoredArchiveDir(dir string) bool {
+	return slices.ContainsFunc(strings.Split(dir, "/"), func(elem string) bool {
+		return elem == "testdata" || elem == "vendor" || strings.HasPrefix(elem, ".") && elem != "." || strings.HasPrefix(elem, "_")
+	})
+}
+
+// Returns the module of the nearest go.mod file at or above dir in the
+// archive, if any, with the version of the module zip it is in.
+func archiveModule(files map[string][]byte, modules map[string]*packages.Module, dir string) *packages.Module {
+	for d := dir; ; d = path.Dir(d) {
+		if module, ok := modules[d]; ok {
+			return module
+		}
+		if data, ok := files[path.Join(d, "go.mod")]; ok {
+			var module *packages.Module
+			if f, err := modfile.ParseLax(path.Join(d, "go.mod"), data, nil); err == nil && f.Module != nil {
+				module = &packages.Module{Path: f.Module.Mod.Path, Dir: d, Main: true}
+				if f.Go != nil {
+					module.GoVersion = f.Go.Version
+				}
+				if match := moduleVersion.FindStringSubmatch(path.Base(d)); match != nil {
+					module.Version = match[1]
+				}
+			}
+			modules[d] = module
+			return module
+		}
+		if d == "." || d == "/" {
+			return nil
+		}
+	}
+}
+
+// An importer resolving the standard library with the default importer, the
+// packages of the archive by type-checking them, and every other package to
+// an empty package.
+type archiveImporter struct {
+	std   types.Importer
+	pkgs  map[string]*archivePackage
+	fset  *token.FileSet
+	sizes types.Sizes
+}
+
+func (imp *archiveImporter) Import(importPath string) (*types.Package, error) {
+	if pkg, ok := imp.pkgs[importPath]; ok && !pkg.checking {
+		imp.check(pkg)
+		return pkg.types, nil
+	}
+	if first, _, _ := strings.Cut(importPath, "/"); !strings.Contains(first, ".") {
+		if pkg, err := imp.std.Import(importPath); err == nil {
+			return pkg, nil
+		}
+	}
+	pkg := types.NewPackage(importPath, unresolvedPackageName(importPath))
+	pkg.MarkComplete()
+	return pkg, nil
+}
+
+// Type-checks the package, once. Type errors are ignored, as references to
+// unresolved imports do not type-check.
+func (imp *archiveImporter) check(pkg *archivePackage) {
+	if pkg.types != nil {
+		return
+	}
+	pkg.checking = true
+	defer func() { pkg.checking = false }()
+	pkg.info = &types.Info{
+		Types:        make(map[ast.Expr]types.TypeAndValue),
+		Instances:    make(map[*ast.Ident]types.Instance),
+		Defs:         make(map[*ast.Ident]types.Object),
+		Uses:         make(map[*ast.Ident]types.Object),
+		Implicits:    make(map[ast.Node]types.Object),
+		Selections:   make(map[*ast.SelectorExpr]*types.Selection),
+		Scopes:       make(map[ast.Node]*types.Scope),
+		FileVersions: make(map[*ast.File]string),
+	}
+	cfg := &types.Config{
+		Importer: imp,
+		Sizes:    imp.sizes,
+		Error:    func(error) {},
+	}
+	if pkg.module != nil && pkg.module.GoVersion != "" {
+		cfg.GoVersion = "go" + pkg.module.GoVersion
+	}
+	pkg.types, _ = cfg.Check(pkg.path, imp.fset, pkg.files, pkg.info)
+}
+
+// Returns the likely name of a package that cannot be resolved from its
+// import path, such as "jose" for "gopkg.in/square/go-jose.v2".
+func unresolvedPackageName(importPath string) string {
+	elems := strings.Split(importPath, "/")
+	name := elems[len(elems)-1]
+	if len(elems) > 1 && majorVersionElem.MatchString(name) {
+		name = elems[len(elems)-2]
+	}
+	name = majorVersionSuffix.ReplaceAllString(name, "")
+	name = strings.TrimSuffix(strings.TrimPrefix(name, "go-"), "-go")
+	return strings.Map(func(r rune) rune {
+		if r == '-' || r == '.' {
+			return '_'
+		}
+		return r
+	}, name)
+}
+
+// Returns the build context of the build configuration, reading files from
+// the archive.
+func archiveContext(files map[string][]byte, buildConfig config.BuildConfig) build.Context {
+	ctxt := build.Default
+	if buildConfig.GOOS != "" {
+		ctxt.GOOS = buildConfig.GOOS
+	}
+	if buildConfig.GOARCH != "" {
+		ctxt.GOARCH = buildConfig.GOARCH
+	}
+	ctxt.BuildTags = buildConfig.Tags
+	ctxt.JoinPath = path.Join
+	ctxt.OpenFile = func(name string) (io.ReadCloser, error) {
+		data, ok := files[name]
+		if !ok {
+			return nil, os.ErrNotExist
+		}
+		return io.NopCloser(bytes.NewReader(data)), nil
+	}
+	return ctxt
+}
@@ -0,0 +1,326 @@
+package scan
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"maps"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/ahan-adelaide/pqc-analyzer/analyzer"
+	"github.com/ahan-adelaide/pqc-analyzer/config"
+	"github.com/ahan-adelaide/pqc-analyzer/report"
+	"golang.org/x/tools/go/packages"
+)
+
+// Version of the layout of cache entries, changed whenever it changes.
+const cacheVersion = "3"
+
+// A cache of the findings of packages in a directory, which survives across
+// runs, such as a CI cache artifact. Entries are keyed by a hash of the files
+// of the package, the keys of its dependencies, the analyzer options, the
+// build configuration and the analyzer binary itself, so changing any of them
+// misses the cache rather than reusing stale findings. Packages whose findings
+// depend on other files, such as the key files of certificate paths, are not
+// cached.
+type cache struct {
+	dir string
+	// Hash of the analyzer binary and options, common to every key.
+	fingerprint  string
+	hits, misses int
+}
+
+// A finding of the analyzer in a package, before the severity options of a
+// scan apply to it.
+type packageFinding struct {
+	RuleID string `json:"rule"`
+	// File of the finding. In cache entries, it is relative to the directory
+	// of the scan, unless it is outside of it.
+	File          string             `json:"file"`
+	Line          int                `json:"line"`
+	Column        int                `json:"column"`
+	Message       string             `json:"message"`
+	HelpURI       string             `json:"helpUri,omitempty"`
+	Operation     analyzer.Operation `json:"operation,omitempty"`
+	Compat        bool               `json:"compat,omitempty"`
+	Justification string             `json:"justification,omitempty"`
+	HighVolume    bool               `json:"highVolume,omitempty"`
+	// Date of the time-boxed exception the finding is inside, if any. Whether
+	// it expired is decided by each scan, so cache entries stay valid.
+	AcceptedUntil string `json:"acceptedUntil,omitempty"`
+	// Module path and version of the dependency the finding is in, if any.
+	Dependency string `json:"dependency,omitempty"`
+	// Places leading up to the finding, with files relative like File in
+	// cache entries.
+	Related []report.Location `json:"related,omitempty"`
+}
+
+// The findings of a package found in the cache.
+type cachedPackage struct {
+	id, pkgPath, module string
+	findings            []packageFinding
+}
+
+// Returns the cache in dir for scans with the options.
+func newCache(dir string, opts Options) (*cache, error) {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, fmt.Errorf("failed to create cache directory: %s", err.Error())
+	}
+	h := sha256.New()
+	fmt.Fprintf(h, "version %s\n", cacheVersion)
+	// The analyzer binary stands for the rules and their implementation.
+	if executable, err := os.Executable(); err == nil {
+		if f, err := os.Open(executable); err == nil {
+			_, err = io.Copy(h, f)
+			f.Close()
+			if err != nil {
+				return nil, fmt.Errorf("failed to hash analyzer binary: %s", err.Error())
+			}
+		}
+	}
+	fmt.Fprintf(h, "tests %t\n", opts.Tests)
+	for _, pattern := range opts.Allow {
+		fmt.Fprintf(h, "allow %s\n", pattern)
+	}
+	for _, pattern := range opts.Providers {
+		fmt.Fprintf(h, "provider %s\n", pattern)
+	}
+	for _, wrapper := range opts.Wrappers {
+		fmt.Fprintf(h, "wrapper %s.%s %s.%s\n", wrapper.Function.Package, wrapper.Function.FnName, wrapper.Wraps.Package, wrapper.Wraps.FnName)
+	}
+	// Messages are cached as rendered by the catalog.
+	if opts.Messages != nil {
+		for _, key := range slices.Sorted(maps.Keys(opts.Messages.Templates)) {
+			fmt.Fprintf(h, "template %s %q\n", key, opts.Messages.Templates[key])
+		}
+		for _, key := range slices.Sorted(maps.Keys(opts.Messages.Replacements)) {
+			fmt.Fprintf(h, "replacement %s %q\n", key, opts.Messages.Replacements[key])
+		}
+		fmt.Fprintf(h, "deadline %s\n", opts.Messages.Deadline)
+	}
+	return &cache{dir: dir, fingerprint: hex.EncodeToString(h.Sum(nil))}, nil
+}
+
+// Returns the path of the entry with the key.
+func (c *cache) path(key string) string {
+	return filepath.Join(c.dir, key[:2], key+".json")
+}
+
+// Returns the findings cached under the key, if any.
+func (c *cache) lookup(key string) ([]packageFinding, bool) {
+	data, err := os.ReadFile(c.path(key))
+	if err != nil {
+		c.misses++
+		return nil, false
+	}
+	var findings []packageFinding
+	if err := json.Unmarshal(data, &findings); err != nil {
+		c.misses++
+		return nil, false
+	}
+	c.hits++
+	return findings, true
+}
+
+// Stores the findings under the key. A failure to store them only costs a
+// later cache miss, so it is not an error.
+func (c *cache) store(key string, findings []packageFinding) {
+	if findings == nil {
+		findings = []packageFinding{}
+	}
+	data, err := json.Marshal(findings)
+	if err != nil {
+		return
+	}
+	path := c.path(key)
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return
+	}
+	// Entries are replaced atomically, so concurrent scans sharing the cache
+	// never read partial entries.
+	tmp, err := os.CreateTemp(filepath.Dir(path), key+"-*")
+	if err != nil {
+		return
+	}
+	_, err = tmp.Write(data)
+	if closeErr := tmp.Close(); err == nil {
+		err = closeErr
+	}
+	if err == nil {
+		err = os.Rename(tmp.Name(), path)
+	}
+	if err != nil {
+		os.Remove(tmp.Name())
+	}
+}
+
+// Returns the cache metrics of the lookups so far.
+func (c *cache) metrics() *CacheMetrics {
+	metrics := &CacheMetrics{Hits: c.hits, Misses: c.misses}
+	if lookups := c.hits + c.misses; lookups > 0 {
+		metrics.HitRate = float64(c.hits) / float64(lookups)
+	}
+	return metrics
+}
+
+// Loads the packages of a build configuration, taking the findings of the
+// packages whose key is in the cache from there. Only the packages missing
+// from the cache are type-checked, which is what makes repeated scans fast.
+func loadCached(ctx context.Context, opts Options, build config.BuildConfig, c *cache) (loadedBuild, error) {
+	loaded := loadedBuild{build: build, cache: c, keys: make(map[string]string)}
+	// Listing the packages and their files is cheap next to type-checking
+	// them from source.
+	listMode := packages.NeedName | packages.NeedFiles | packages.NeedCompiledGoFiles | packages.NeedImports | packages.NeedDeps | packages.NeedModule
+	listed, err := load(ctx, opts, build, listMode)
+	if err != nil {
+		return loadedBuild{}, err
+	}
+
+	keys := make(map[*packages.Package]string)
+	var missed []string
+	for _, pkg := range listed {
+		key, err := c.packageKey(pkg, build, keys)
+		if err != nil {
+			return loadedBuild{}, err
+		}
+		if findings, ok := c.lookup(key); ok {
+			loaded.cached = append(loaded.cached, cachedPackage{pkg.ID, pkg.PkgPath, modulePath(pkg), findings})
+			continue
+		}
+		loaded.keys[pkg.ID] = key
+		if path := importPath(pkg); !slices.Contains(missed, path) {
+			missed = append(missed, path)
+		}
+	}
+	if len(missed) == 0 {
+		return loaded, nil
+	}
+
+	// Loading the import paths of the missed packages loads their test
+	// variants too, even those that hit the cache; they are only analyzed
+	// once.
+	missOpts := opts
+	missOpts.Patterns = missed
+	pkgs, err := load(ctx, missOpts, build, packages.LoadAllSyntax)
+	if err != nil {
+		return loadedBuild{}, err
+	}
+	for _, pkg := range pkgs {
+		if _, ok := loaded.keys[pkg.ID]; ok {
+			loaded.pkgs = append(loaded.pkgs, pkg)
+		}
+	}
+	return loaded, nil
+}
+
+// Returns the cache key of the package, memoizing the keys of the package and
+// its dependencies in keys.
+func (c *cache) packageKey(pkg *packages.Package, build config.BuildConfig, keys map[*packages.Package]string) (string, error) {
+	if key, ok := keys[pkg]; ok {
+		return key, nil
+	}
+	h := sha256.New()
+	fmt.Fprintf(h, "%s\n%s\n%s\n", c.fingerprint, build, pkg.ID)
+	switch module := pkg.Module; {
+	case module != nil && !module.Main && module.Replace == nil && module.Version != "":
+		// Modules in the module cache never change.
+		fmt.Fprintf(h, "module %s@%s\n", module.Path, module.Version)
+	default:
+		if module != nil {
+			fmt.Fprintf(h, "go %s\n", module.GoVersion)
+		}
+		files := slices.Concat(pkg.CompiledGoFiles, pkg.IgnoredFiles)
+		slices.Sort(files)
+		for _, file := range slices.Compact(files) {
+			if err := hashFile(h, file); err != nil {
+				return "", err
+			}
+		}
+	}
+	for _, path := range slices.Sorted(maps.Keys(pkg.Imports)) {
+		key, err := c.packageKey(pkg.Imports[path], build, keys)
+		if err != nil {
+			return "", err
+		}
+		fmt.Fprintf(h, "import %s %s\n", path, key)
+	}
+	key := hex.EncodeToString(h.Sum(nil))
+	keys[pkg] = key
+	return key, nil
+}
+
+// Returns the import path loading the package, which is that of the package
+// under test for test variants, external test packages and test mains.
+func importPath(pkg *packages.Package) string {
+	if i := strings.Index(pkg.ID, " ["); i >= 0 {
+		return strings.TrimSuffix(strings.TrimSuffix(pkg.ID[i+len(" ["):], "]"), ".test")
+	}
+	if pkg.Name == "main" && strings.HasSuffix(pkg.ID, ".test") {
+		return strings.TrimSuffix(pkg.ID, ".test")
+	}
+	return pkg.PkgPath
+}
+
+// Writes the name and content hash of the file to w.
+func hashFile(w io.Writer, path string) error {
+	f, err := os.Open(path)
+	if err != nil {
+		return fmt.Errorf("failed to hash %s: %s", path, err.Error())
+	}
+	defer f.Close()
+	h := sha256.New()
+	if _, err := io.Copy(h, f); err != nil {
+		return fmt.Errorf("failed to hash %s: %s", path, err.Error())
+	}
+	fmt.Fprintf(w, "file %s %x\n", filepath.Base(path), h.Sum(nil))
+	return nil
+}
+
+// Returns the findings with their files relative to dir, for a cache entry
+// that stays valid wherever the repository is checked out.
+func relativeFindings(dir string, findings []packageFinding) []packageFinding {
+	root, err := filepath.Abs(dir)
+	if err != nil {
+		return findings
+	}
+	relative := slices.Clone(findings)
+	for i, finding := range relative {
+		if rel, err := filepath.Rel(root, finding.File); err == nil && !strings.HasPrefix(rel, "..") {
+			relative[i].File = rel
+		}
+		relative[i].Related = slices.Clone(finding.Related)
+		for j, location := range relative[i].Related {
+			if rel, err := filepath.Rel(root, location.File); err == nil && !strings.HasPrefix(rel, "..") {
+				relative[i].Related[j].File = rel
+			}
+		}
+	}
+	return relative
+}
+
+// Returns the findings of a cache entry with their files resolved in dir.
+func absoluteFindings(dir string, findings []packageFinding) []packageFinding {
+	root, err := filepath.Abs(dir)
+	if err != nil {
+		return findings
+	}
+	absolute := slices.Clone(findings)
+	for i, finding := range absolute {
+		if !filepath.IsAbs(finding.File) {
+			absolute[i].File = filepath.Join(root, finding.File)
+		}
+		absolute[i].Related = slices.Clone(finding.Related)
+		for j, location := range absolute[i].Related {
+			if !filepath.IsAbs(location.File) {
+				absolute[i].Related[j].File = filepath.Join(root, location.File)
+			}
+		}
+	}
+	return absolute
+}
@@ -0,0 +1,83 @@
+package scan
+
+import (
+	"fmt"
+	"io/fs"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/ahan-adelaide/pqc-analyzer/report"
+)
+
+// Discover returns the directories of the Go modules under root, the
+// directories containing a go.mod file, in lexical order. Like the go
+// command, it skips testdata directories and directories whose names start
+// with "." or "_", and it skips vendor directories too.
+func Discover(root string) ([]string, error) {
+	var dirs []string
+	err := filepath.WalkDir(root, func(path string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if !entry.IsDir() {
+			if entry.Name() == "go.mod" {
+				dirs = append(dirs, filepath.Dir(path))
+			}
+			return nil
+		}
+		name := entry.Name()
+		if path != root && (name == "testdata" || name == "vendor" || strings.HasPrefix(name, ".") || strings.HasPrefix(name, "_")) {
+			return filepath.SkipDir
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to discover modules under %s: %s", root, err.Error())
+	}
+	slices.Sort(dirs)
+	return dirs, nil
+}
+
+// RunModules scans every package of each Go module under root, as found by
+// Discover, and merges the reports into one, listing the modules with their
+// numbers of findings. The Dir and Patterns options are ignored, the paths of
+// severity overrides are relative to each module, and metrics are summed over
+// the modules.
+func RunModules(root string, opts Options) (*report.Report, error) {
+	dirs, err := Discover(root)
+	if err != nil {
+		return nil, err
+	}
+	if len(dirs) == 0 {
+		return nil, fmt.Errorf("no Go modules found under %s", root)
+	}
+
+	merged := &report.Report{ModuleFindings: make(map[string]int)}
+	metrics := opts.Metrics
+	for _, dir := range dirs {
+		module, err := filepath.Rel(root, dir)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan module %s: %s", dir, err.Error())
+		}
+		module = filepath.ToSlash(module)
+
+		moduleOpts := opts
+		moduleOpts.Dir = dir
+		moduleOpts.Patterns = []string{"./..."}
+		if metrics != nil {
+			moduleOpts.Metrics = &Metrics{}
+		}
+		rep, err := Run(moduleOpts)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan module %s: %s", module, err.Error())
+		}
+		if metrics != nil {
+			metrics.add(moduleOpts.Metrics, len(merged.Modules) == 0)
+		}
+		merged.Modules = append(merged.Modules, module)
+		merged.ModuleFindings[module] = len(rep.Findings)
+		merged.Merge(rep)
+	}
+	return merged, nil
+}
@@ -0,0 +1,13 @@
+package scan
+
+import "testing"
+
+// SetArchiveLimits lowers the limits of the archives read until the end of
+// the test.
+func SetArchiveLimits(t testing.TB, fileSize, totalSize int64, entries int) {
+	prevFileSize, prevSize, prevEntries := maxArchiveFileSize, maxArchiveSize, maxArchiveEntries
+	maxArchiveFileSize, maxArchiveSize, maxArchiveEntries = fileSize, totalSize, entries
+	t.Cleanup(func() {
+		maxArchiveFileSize, maxArchiveSize, maxArchiveEntries = prevFileSize, prevSize, prevEntries
+	})
+}
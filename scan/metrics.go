@@ -0,0 +1,72 @@
+package scan
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"time"
+)
+
+// Metrics describe the health of a scan run. They are only ever written to a
+// local file, so platform teams can aggregate them across CI runs.
+type Metrics struct {
+	StartTime time.Time `json:"startTime"`
+	// Wall-clock duration of the scan, in seconds, including loading the
+	// packages.
+	Duration float64 `json:"durationSeconds"`
+	// Number of build configurations analyzed.
+	Builds int `json:"builds"`
+	// Number of distinct packages analyzed across the builds.
+	Packages int `json:"packages"`
+	// Number of findings per rule ID, including accepted interop debt.
+	FindingsPerRule map[string]int `json:"findingsPerRule"`
+	// Number of findings that are accepted interop debt.
+	InteropDebt int `json:"interopDebt"`
+	// Cache statistics, if the scan used a cache.
+	Cache *CacheMetrics `json:"cache,omitempty"`
+}
+
+// CacheMetrics describe how effective a scan's cache was.
+type CacheMetrics struct {
+	Hits   int `json:"hits"`
+	Misses int `json:"misses"`
+	// Fraction of lookups that hit the cache.
+	HitRate float64 `json:"hitRate"`
+}
+
+// Adds the metrics of the scan of another module to m, starting from them if
+// first.
+func (m *Metrics) add(other *Metrics, first bool) {
+	if first {
+		*m = Metrics{StartTime: other.StartTime, FindingsPerRule: make(map[string]int)}
+	}
+	m.Duration += other.Duration
+	m.Builds = max(m.Builds, other.Builds)
+	m.Packages += other.Packages
+	for rule, n := range other.FindingsPerRule {
+		m.FindingsPerRule[rule] += n
+	}
+	m.InteropDebt += other.InteropDebt
+	if other.Cache != nil {
+		if m.Cache == nil {
+			m.Cache = &CacheMetrics{}
+		}
+		m.Cache.Hits += other.Cache.Hits
+		m.Cache.Misses += other.Cache.Misses
+		if lookups := m.Cache.Hits + m.Cache.Misses; lookups > 0 {
+			m.Cache.HitRate = float64(m.Cache.Hits) / float64(lookups)
+		}
+	}
+}
+
+// WriteMetrics writes the metrics as JSON to the file at path.
+func WriteMetrics(path string, metrics *Metrics) error {
+	data, err := json.MarshalIndent(metrics, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode metrics: %s", err.Error())
+	}
+	if err := os.WriteFile(path, append(data, '\n'), 0o644); err != nil {
+		return fmt.Errorf("failed to write metrics %s: %s", path, err.Error())
+	}
+	return nil
+}
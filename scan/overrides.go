@@ -0,0 +1,122 @@
+package scan
+
+import (
+	"path/filepath"
+	"time"
+
+	"github.com/ahan-adelaide/pqc-analyzer/analyzer"
+	"github.com/ahan-adelaide/pqc-analyzer/report"
+)
+
+// SeverityOverride sets the severity of the findings of a rule under the
+// files matching a path glob, such as demoting elliptic curve findings under
+// examples/** to info, or promoting every finding under payments/** to
+// critical.
+type SeverityOverride struct {
+	// Rule ID or category of the findings, or empty for every finding.
+	Rule string
+	// Slash-separated glob of the files, relative to the scanned directory.
+	// "**" matches any number of directories; other elements are matched
+	// with path.Match.
+	Path     string
+	Severity analyzer.Severity
+}
+
+// Returns whether the override applies to the finding, whose file is given
+// relative to the scanned directory.
+func (o SeverityOverride) matches(finding report.Finding, file string) bool {
+	if o.Rule != "" && o.Rule != finding.RuleID && o.Rule != finding.Category {
+		return false
+	}
+	return o.Path == "" || report.MatchGlob(o.Path, file)
+}
+
+// Applies the last matching override to each finding. Paths of findings are
+// matched relative to dir.
+func applySeverityOverrides(overrides []SeverityOverride, dir string, findings []*collected) {
+	if len(overrides) == 0 {
+		return
+	}
+	root := scanRoot(dir)
+	for _, c := range findings {
+		file := relativeFile(root, c.finding.File)
+		for _, override := range overrides {
+			if override.matches(c.finding, file) {
+				c.finding.Severity = override.Severity.String()
+			}
+		}
+	}
+}
+
+// Suppression accepts the findings of a rule under the files matching a path
+// glob with a reason, like a //pqc:ignore-until annotation covering them,
+// such as every RSA finding under legacy/** while it is being retired.
+type Suppression struct {
+	// Rule ID or category of the findings, or empty for every finding.
+	Rule string
+	// Slash-separated glob of the files, relative to the scanned directory,
+	// as in SeverityOverride.
+	Path   string
+	Reason string
+	// Date the suppression lasts until, as YYYY-MM-DD, or empty for a
+	// suppression that does not expire.
+	Until string
+}
+
+// Applies the last matching suppression to each finding not accepted
+// already, accepting it until the suppression expires, with its status worded
+// by the message catalog. Paths of findings are matched relative to dir.
+func applySuppressions(suppressions []Suppression, messages *analyzer.Catalog, dir string, now time.Time, findings []*collected) {
+	if len(suppressions) == 0 {
+		return
+	}
+	root := scanRoot(dir)
+	for _, c := range findings {
+		if c.accepted {
+			continue
+		}
+		file := relativeFile(root, c.finding.File)
+		var match *Suppression
+		for i, suppression := range suppressions {
+			override := SeverityOverride{Rule: suppression.Rule, Path: suppression.Path}
+			if override.matches(c.finding, file) {
+				match = &suppressions[i]
+			}
+		}
+		switch {
+		case match == nil:
+			continue
+		case match.Until == "":
+			c.finding.Message = messages.Status(analyzer.StatusSuppressed, c.finding.Message, "")
+			c.accepted = true
+		case acceptedOn(match.Until, now):
+			c.finding.Message = messages.Status(analyzer.StatusAcceptedUntil, c.finding.Message, match.Until)
+			c.accepted = true
+		default:
+			c.finding.Message = messages.Status(analyzer.StatusExceptionExpired, c.finding.Message, match.Until)
+		}
+		c.finding.Justification = match.Reason
+	}
+}
+
+// Returns the absolute path of the scanned directory, if it can be
+// resolved.
+func scanRoot(dir string) string {
+	if dir == "" {
+		dir = "."
+	}
+	root, err := filepath.Abs(dir)
+	if err != nil {
+		return dir
+	}
+	return root
+}
+
+// Returns the slash-separated path of a file relative to the scanned
+// directory.
+func relativeFile(root, file string) string {
+	if rel, err := filepath.Rel(root, file); err == nil && filepath.IsAbs(file) {
+		file = rel
+	}
+	return filepath.ToSlash(file)
+}
@@ -0,0 +1,130 @@
+package scan
+
+import (
+	"fmt"
+	"go/token"
+	"go/types"
+	"strings"
+
+	"golang.org/x/tools/go/callgraph/rta"
+	"golang.org/x/tools/go/packages"
+	"golang.org/x/tools/go/ssa"
+	"golang.org/x/tools/go/ssa/ssautil"
+)
+
+// Entrypoints findings can be required to be reachable from.
+const (
+	// The main and init functions of main packages.
+	ReachableFromMain = "main"
+	// Additionally the exported functions and methods of library packages.
+	ReachableFromExported = "exported"
+)
+
+// reachability records which functions of a program are reachable from its
+// entrypoints, so findings in dead code (kept for fuzzers, examples or
+// tests) can be told apart from findings in code that actually runs.
+type reachability struct {
+	// Every function with syntax, to find the function enclosing a position.
+	funcs     []*ssa.Function
+	reachable map[*ssa.Function]struct{ AddrTaken bool }
+}
+
+func newReachability(pkgs []*packages.Package, from string) (*reachability, error) {
+	if from != ReachableFromMain && from != ReachableFromExported {
+		return nil, fmt.Errorf("unknown reachability entrypoints %q", from)
+	}
+
+	// Only the analyzed packages are built; calls into dependencies are
+	// leaves. Callbacks from dependencies are still found, as the exported
+	// methods of types converted to interfaces are reachable.
+	prog, ssaPkgs := ssautil.Packages(pkgs, ssa.InstantiateGenerics)
+	prog.Build()
+
+	var roots []*ssa.Function
+	for i, ssaPkg := range ssaPkgs {
+		// Test binaries reach the tests, fuzzers and examples, which is
+		// exactly the code this filter is meant to exclude.
+		if ssaPkg == nil || strings.HasSuffix(pkgs[i].PkgPath, ".test") {
+			continue
+		}
+		if ssaPkg.Pkg.Name() == "main" {
+			roots = append(roots, ssaPkg.Func("init"))
+			if main := ssaPkg.Func("main"); main != nil {
+				roots = append(roots, main)
+			}
+			continue
+		}
+		if from == ReachableFromExported {
+			roots = append(roots, ssaPkg.Func("init"))
+			roots = append(roots, exportedFunctions(prog, ssaPkg)...)
+		}
+	}
+
+	r := &reachability{reachable: rta.Analyze(roots, false).Reachable}
+	for fn := range ssautil.AllFunctions(prog) {
+		if fn.Syntax() != nil {
+			r.funcs = append(r.funcs, fn)
+		}
+	}
+	return r, nil
+}
+
+// Returns the exported functions and methods of exported types of the package.
+func exportedFunctions(prog *ssa.Program, pkg *ssa.Package) []*ssa.Function {
+	var funcs []*ssa.Function
+	for _, member := range pkg.Members {
+		if !token.IsExported(member.Name()) {
+			continue
+		}
+		switch member := member.(type) {
+		case *ssa.Function:
+			funcs = append(funcs, member)
+		case *ssa.Type:
+			// Methods of generic types are only built for instantiations.
+			if named, ok := member.Type().(*types.Named); ok && named.TypeParams().Len() > 0 {
+				continue
+			}
+			for _, recv := range []types.Type{member.Type(), types.NewPointer(member.Type())} {
+				methods := prog.MethodSets.MethodSet(recv)
+				for i := range methods.Len() {
+					if fn := prog.MethodValue(methods.At(i)); fn != nil && token.IsExported(fn.Name()) {
+						funcs = append(funcs, fn)
+					}
+				}
+			}
+		}
+	}
+	return funcs
+}
+
+// Reports whether the innermost function enclosing pos is reachable. Positions
+// outside of any function, such as imports, are always reachable.
+func (r *reachability) isReachable(pos token.Pos) bool {
+	var enclosing *ssa.Function
+	for _, fn := range r.funcs {
+		syntax := fn.Syntax()
+		if syntax.Pos() <= pos && pos < syntax.End() {
+			if enclosing == nil || syntax.Pos() > enclosing.Syntax().Pos() {
+				enclosing = fn
+			}
+		}
+	}
+	if enclosing == nil {
+		return true
+	}
+	if enclosing.Origin() != nil {
+		enclosing = enclosing.Origin()
+	}
+
+	if _, ok := r.reachable[enclosing]; ok {
+		return true
+	}
+	// Instantiations of generic functions are reachable instead of their
+	// origin, which shares its syntax.
+	for fn := range r.reachable {
+		if fn.Origin() == enclosing {
+			return true
+		}
+	}
+	return false
+}
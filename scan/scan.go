@@ -0,0 +1,662 @@
+// Package scan runs the pqc-analyzer over packages loaded with go/packages and
+// collects the diagnostics into a report.
+package scan
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"os"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/ahan-adelaide/pqc-analyzer/analyzer"
+	"github.com/ahan-adelaide/pqc-analyzer/config"
+	"github.com/ahan-adelaide/pqc-analyzer/keyfiles"
+	"github.com/ahan-adelaide/pqc-analyzer/report"
+	"github.com/ahan-adelaide/pqc-analyzer/schema"
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/analysis/checker"
+	"golang.org/x/tools/go/packages"
+)
+
+// Options controls a scan.
+type Options struct {
+	// Directory the patterns are resolved in. Defaults to the working directory.
+	Dir string
+	// Package patterns to analyze, as accepted by go/packages.
+	Patterns []string
+	// Whether to analyze test packages too.
+	Tests bool
+	// Whether to also analyze the dependencies of the packages outside the
+	// standard library. Findings in other modules are tagged with the module
+	// they are in.
+	IncludeDeps bool
+	// Build configurations to load the packages under. Findings from every
+	// configuration are merged. If empty, the default configuration is used.
+	Builds []config.BuildConfig
+
+	// Deep enables whole-program analysis over an SSA representation of the
+	// loaded packages. It is considerably slower than the default analysis.
+	Deep bool
+	// Entrypoints findings must be reachable from, ReachableFromMain or
+	// ReachableFromExported. Unreachable findings are demoted to info
+	// severity and marked as such. Requires Deep.
+	ReachableFrom string
+
+	// Severities of findings on each side of the algorithm, overriding the
+	// severity of their rule.
+	OperationSeverities map[analyzer.Operation]analyzer.Severity
+	// Severities of findings by rule and path, overriding both the severity
+	// of their rule and OperationSeverities. The last matching override
+	// applies.
+	SeverityOverrides []SeverityOverride
+	// Findings accepted by rule and path, with a reason and an optional
+	// expiry checked against Now. The last matching suppression applies.
+	Suppressions []Suppression
+
+	// Package patterns extending the analyzer's allowlist.
+	Allow []string
+	// Package patterns of the approved crypto providers.
+	Providers []string
+	// Functions of internal crypto wrappers reported like the functions they
+	// wrap.
+	Wrappers []analyzer.Wrapper
+	// Wording of the messages of findings. If nil, findings keep the
+	// messages of their rules.
+	Messages *analyzer.Catalog
+
+	// Directory of a cache of the findings of packages, keyed by hashes of
+	// their content, which can be kept across runs, such as a CI cache
+	// artifact. Only packages missing from it are type-checked and analyzed.
+	// It is not used by deep analysis, which analyzes the whole program, nor
+	// with IncludeDeps.
+	CacheDir string
+
+	// Time time-boxed exceptions (//pqc:ignore-until) and suppressions are
+	// checked against. Defaults to the current time.
+	Now time.Time
+
+	// Whether to also scan the .proto files and OpenAPI documents under Dir
+	// for fields carrying classical keys, signatures and certificates.
+	Schemas bool
+
+	// Whether to also scan the YAML, JSON, .env and Terraform files under Dir
+	// for committed classical private keys and JSON Web Keys.
+	KeyFiles bool
+
+	// If non-nil, Run records the metrics of the scan into it.
+	Metrics *Metrics
+}
+
+// Packages are the packages of a scan, loaded and type-checked once per build
+// configuration. They can be analyzed several times, such as in several modes
+// of a single invocation, without loading and type-checking them again.
+type Packages struct {
+	dir    string
+	builds []loadedBuild
+	// Time spent loading the packages.
+	loadTime time.Duration
+	// Cache the findings of packages were taken from, if any.
+	cache *cache
+}
+
+// The packages loaded under a build configuration.
+type loadedBuild struct {
+	build config.BuildConfig
+	pkgs  []*packages.Package
+	// With a cache, the findings of the packages found in it, which are not
+	// in pkgs, and the keys to store the findings of those in pkgs under, by
+	// package ID.
+	cache  *cache
+	cached []cachedPackage
+	keys   map[string]string
+}
+
+// Load loads the packages matching the patterns of opts once per build
+// configuration. Only the Dir, Patterns, Tests and Builds options are used.
+func Load(opts Options) (*Packages, error) {
+	return loadBuilds(opts, func(build config.BuildConfig) (loadedBuild, error) {
+		pkgs, err := load(context.Background(), opts, build, packages.LoadAllSyntax)
+		return loadedBuild{build: build, pkgs: pkgs}, err
+	})
+}
+
+// Loads the packages of every build configuration of opts with loadBuild.
+func loadBuilds(opts Options, loadBuild func(config.BuildConfig) (loadedBuild, error)) (*Packages, error) {
+	start := time.Now()
+	builds := opts.Builds
+	if len(builds) == 0 {
+		builds = []config.BuildConfig{{}}
+	}
+
+	loaded := &Packages{dir: opts.Dir}
+	for _, build := range builds {
+		pkgs, err := loadBuild(build)
+		if err != nil {
+			return nil, err
+		}
+		loaded.builds = append(loaded.builds, pkgs)
+	}
+	loaded.loadTime = time.Since(start)
+	return loaded, nil
+}
+
+// Run loads and analyzes the packages once per build configuration and merges
+// the findings into a single report.
+func Run(opts Options) (*report.Report, error) {
+	if opts.ReachableFrom != "" && !opts.Deep {
+		return nil, fmt.Errorf("reachability filtering requires deep analysis")
+	}
+	if opts.CacheDir == "" || opts.Deep || opts.IncludeDeps {
+		pkgs, err := Load(opts)
+		if err != nil {
+			return nil, err
+		}
+		return pkgs.Run(opts)
+	}
+
+	c, err := newCache(opts.CacheDir, opts)
+	if err != nil {
+		return nil, err
+	}
+	pkgs, err := loadBuilds(opts, func(build config.BuildConfig) (loadedBuild, error) {
+		return loadCached(context.Background(), opts, build, c)
+	})
+	if err != nil {
+		return nil, err
+	}
+	pkgs.cache = c
+	return pkgs.Run(opts)
+}
+
+// Run analyzes the loaded packages under every build configuration they were
+// loaded under, and merges the findings into a single report. The loading
+// options of opts are ignored.
+func (p *Packages) Run(opts Options) (*report.Report, error) {
+	start := time.Now()
+	if opts.ReachableFrom != "" && !opts.Deep {
+		return nil, fmt.Errorf("reachability filtering requires deep analysis")
+	}
+
+	rep := &report.Report{}
+	var findings []*collected
+	seen := make(map[string]*collected)
+	analyzed := make(map[string]bool)
+	for _, loaded := range p.builds {
+		build := loaded.build.String()
+		rep.Builds = append(rep.Builds, build)
+		err := analyzeBuild(context.Background(), loaded, opts, analyzed, func(rule analyzer.Rule, f *collected) error {
+			key := fmt.Sprintf("%s:%d:%d: %s", f.finding.File, f.finding.Line, f.finding.Column, f.finding.Message)
+			c, ok := seen[key]
+			if !ok {
+				if _, ok := rep.Rule(rule.ID); !ok {
+					rep.Rules = append(rep.Rules, reportRule(rule))
+				}
+				c = f
+				seen[key] = c
+				findings = append(findings, c)
+			}
+			if !slices.Contains(c.finding.Builds, build) {
+				c.finding.Builds = append(c.finding.Builds, build)
+			}
+			c.reachable = c.reachable || f.reachable
+			return nil
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	fileFindings, err := scanFiles(p.dir, opts)
+	if err != nil {
+		return nil, err
+	}
+	for _, finding := range fileFindings {
+		if _, ok := rep.Rule(finding.RuleID); !ok {
+			rule, _ := analyzer.LookupRule(finding.RuleID)
+			rep.Rules = append(rep.Rules, reportRule(rule))
+		}
+		findings = append(findings, &collected{finding: finding, reachable: true})
+	}
+
+	applySuppressions(opts.Suppressions, opts.Messages, p.dir, opts.now(), findings)
+	applySeverityOverrides(opts.SeverityOverrides, p.dir, findings)
+	for _, c := range findings {
+		c.demoteUnreachable()
+		switch c.status() {
+		case report.StatusAccepted:
+			rep.InteropDebt = append(rep.InteropDebt, c.finding)
+		case report.StatusInventory:
+			rep.Inventory = append(rep.Inventory, c.finding)
+		case report.StatusAPI:
+			rep.APISurface = append(rep.APISurface, c.finding)
+		default:
+			rep.Findings = append(rep.Findings, c.finding)
+		}
+	}
+	rep.Sort()
+	if len(p.builds) > 1 {
+		rep.BuildFindings = make(map[string]int)
+		for _, build := range rep.Builds {
+			rep.BuildFindings[build] = 0
+		}
+		for _, finding := range rep.Findings {
+			for _, build := range finding.Builds {
+				rep.BuildFindings[build]++
+			}
+		}
+	}
+
+	if opts.Metrics != nil {
+		*opts.Metrics = Metrics{
+			StartTime:       start.Add(-p.loadTime),
+			Duration:        (p.loadTime + time.Since(start)).Seconds(),
+			Builds:          len(p.builds),
+			Packages:        len(analyzed),
+			FindingsPerRule: make(map[string]int),
+			InteropDebt:     len(rep.InteropDebt),
+		}
+		for _, c := range findings {
+			opts.Metrics.FindingsPerRule[c.finding.RuleID]++
+		}
+		if p.cache != nil {
+			opts.Metrics.Cache = p.cache.metrics()
+		}
+	}
+	return rep, nil
+}
+
+// Analyze loads and analyzes the packages matching the patterns of opts, and
+// passes each finding to fn instead of collecting a report. Build
+// configurations are loaded and analyzed one at a time, so only the packages
+// of a single configuration are held in memory: the findings of a
+// configuration are passed once all of its packages are analyzed, as
+// packages depend on the facts of the packages they import, and before the
+// next configuration is loaded, its cached findings first.
+// Findings are not merged across build configurations: a finding seen under
+// several is passed once for each, with Builds set to that configuration.
+// Findings outside the main section have their Status set. Analyze stops at
+// the first error returned by fn, and when ctx is done.
+func Analyze(ctx context.Context, opts Options, fn func(report.Finding) error) error {
+	if opts.ReachableFrom != "" && !opts.Deep {
+		return fmt.Errorf("reachability filtering requires deep analysis")
+	}
+	builds := opts.Builds
+	if len(builds) == 0 {
+		builds = []config.BuildConfig{{}}
+	}
+
+	emit := func(f *collected) error {
+		applySuppressions(opts.Suppressions, opts.Messages, opts.Dir, opts.now(), []*collected{f})
+		applySeverityOverrides(opts.SeverityOverrides, opts.Dir, []*collected{f})
+		f.demoteUnreachable()
+		f.finding.Status = f.status()
+		return fn(f.finding)
+	}
+	for _, build := range builds {
+		pkgs, err := load(ctx, opts, build, packages.LoadAllSyntax)
+		if err != nil {
+			return err
+		}
+		loaded := loadedBuild{build: build, pkgs: pkgs}
+		err = analyzeBuild(ctx, loaded, opts, nil, func(_ analyzer.Rule, f *collected) error {
+			if len(builds) > 1 {
+				f.finding.Builds = []string{build.String()}
+			}
+			return emit(f)
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	fileFindings, err := scanFiles(opts.Dir, opts)
+	if err != nil {
+		return err
+	}
+	for _, finding := range fileFindings {
+		if err := emit(&collected{finding: finding, reachable: true}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Analyzes the packages of a build configuration and passes each of their
+// findings to fn, with its rule. Findings reported by both a package and its
+// test variant are only passed once, unless one of them was cached. A finding
+// of a per-module rule is only passed for the package of its module with the
+// smallest import path, after all the other findings. The IDs of the analyzed
+// packages are recorded in analyzed, if non-nil.
+func analyzeBuild(ctx context.Context, loaded loadedBuild, opts Options, analyzed map[string]bool, fn func(analyzer.Rule, *collected) error) error {
+	perModule := make(map[string]moduleFinding)
+	emit := func(rule analyzer.Rule, f *collected, pkgPath, module string) error {
+		if !rule.PerModule {
+			return fn(rule, f)
+		}
+		key := rule.ID + " " + module
+		if first, ok := perModule[key]; !ok || pkgPath < first.pkgPath {
+			perModule[key] = moduleFinding{rule, f, pkgPath}
+		}
+		return nil
+	}
+	if err := analyzePackages(ctx, loaded, opts, analyzed, emit); err != nil {
+		return err
+	}
+	for _, key := range slices.Sorted(maps.Keys(perModule)) {
+		if err := fn(perModule[key].rule, perModule[key].finding); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// The finding of a per-module rule kept for a module, and the import path of
+// the package it was reported for.
+type moduleFinding struct {
+	rule    analyzer.Rule
+	finding *collected
+	pkgPath string
+}
+
+// Analyzes the packages of a build configuration and passes each of their
+// findings to fn, with its rule and the import path and module path of its
+// package.
+func analyzePackages(ctx context.Context, loaded loadedBuild, opts Options, analyzed map[string]bool, fn func(rule analyzer.Rule, f *collected, pkgPath, module string) error) error {
+	for _, pkg := range loaded.cached {
+		if analyzed != nil {
+			analyzed[pkg.id] = true
+		}
+		for _, finding := range absoluteFindings(opts.Dir, pkg.findings) {
+			rule, ok := analyzer.LookupRule(finding.RuleID)
+			if !ok {
+				return fmt.Errorf("unknown rule %s in cached findings of package %s", finding.RuleID, pkg.id)
+			}
+			if err := fn(rule, finding.collected(rule, opts, true), pkg.pkgPath, pkg.module); err != nil {
+				return err
+			}
+		}
+	}
+	if len(loaded.pkgs) == 0 {
+		return nil
+	}
+
+	pkgs := loaded.pkgs
+	if opts.IncludeDeps {
+		pkgs = withDependencies(pkgs)
+	}
+	pqcAnalyzer := analyzer.New(analyzer.Options{Allow: opts.Allow, Providers: opts.Providers, Wrappers: opts.Wrappers, Messages: opts.Messages})
+	graph, err := checker.Analyze([]*analysis.Analyzer{pqcAnalyzer}, pkgs, nil)
+	if err != nil {
+		return err
+	}
+	var reach *reachability
+	if opts.ReachableFrom != "" {
+		if reach, err = newReachability(loaded.pkgs, opts.ReachableFrom); err != nil {
+			return err
+		}
+	}
+
+	// A finding in a package's files is reported again by the package's test
+	// variant, so variants are analyzed together to skip repeated findings
+	// without remembering those of every package.
+	roots := slices.Clone(graph.Roots)
+	slices.SortStableFunc(roots, func(a, b *checker.Action) int {
+		return strings.Compare(a.Package.PkgPath, b.Package.PkgPath)
+	})
+	var seen map[string]bool
+	for i, act := range roots {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		if act.Err != nil {
+			return fmt.Errorf("failed to analyze package %s: %s", act.Package.PkgPath, act.Err.Error())
+		}
+		if analyzed != nil {
+			analyzed[act.Package.ID] = true
+		}
+		if i == 0 || roots[i-1].Package.PkgPath != act.Package.PkgPath {
+			seen = make(map[string]bool)
+		}
+		var findings []packageFinding
+		dependency := dependencyOf(act.Package)
+		for _, result := range act.Result.(*analyzer.Result).Findings {
+			diag := result.Diagnostic
+			posn := act.Package.Fset.Position(diag.Pos)
+			finding := packageFinding{
+				RuleID:        result.Rule.ID,
+				File:          posn.Filename,
+				Line:          posn.Line,
+				Column:        posn.Column,
+				Message:       diag.Message,
+				HelpURI:       diag.URL,
+				Operation:     result.Operation,
+				Compat:        result.Compat,
+				Justification: result.CompatReason,
+				AcceptedUntil: result.AcceptedUntil,
+				Dependency:    dependency,
+				HighVolume:    result.HighVolume,
+			}
+			for _, related := range diag.Related {
+				posn := act.Package.Fset.Position(related.Pos)
+				finding.Related = append(finding.Related, report.Location{
+					File:    posn.Filename,
+					Line:    posn.Line,
+					Column:  posn.Column,
+					Message: related.Message,
+				})
+			}
+			if result.AcceptedUntil != "" && !result.Compat {
+				finding.Justification = result.AcceptedReason
+			}
+			findings = append(findings, finding)
+			key := fmt.Sprintf("%s:%d:%d: %s", posn.Filename, posn.Line, posn.Column, diag.Message)
+			if seen[key] {
+				continue
+			}
+			seen[key] = true
+
+			reachable := reach == nil || reach.isReachable(diag.Pos)
+			if err := fn(result.Rule, finding.collected(result.Rule, opts, reachable), act.Package.PkgPath, modulePath(act.Package)); err != nil {
+				return err
+			}
+		}
+		// Findings read from other files than those of the package would go
+		// stale in the cache, whose keys only cover Go files.
+		if key, ok := loaded.keys[act.Package.ID]; ok && loaded.cache != nil && len(act.Result.(*analyzer.Result).DataFiles) == 0 {
+			loaded.cache.store(key, relativeFindings(opts.Dir, findings))
+		}
+	}
+	return nil
+}
+
+// Returns the path of the module of the package, or "" if it has none.
+func modulePath(pkg *packages.Package) string {
+	if pkg.Module == nil {
+		return ""
+	}
+	return pkg.Module.Path
+}
+
+// Returns the packages and their dependencies outside the standard library,
+// each once.
+func withDependencies(pkgs []*packages.Package) []*packages.Package {
+	var all []*packages.Package
+	packages.Visit(pkgs, nil, func(pkg *packages.Package) {
+		if pkg.Module != nil {
+			all = append(all, pkg)
+		}
+	})
+	return all
+}
+
+// Returns the module path and version of the package, if it is in a
+// dependency rather than the main module.
+func dependencyOf(pkg *packages.Package) string {
+	module := pkg.Module
+	if module == nil || module.Main {
+		return ""
+	}
+	if module.Version == "" {
+		return module.Path
+	}
+	return module.Path + "@" + module.Version
+}
+
+// Returns the finding of the rule as collected by a scan with the options.
+func (f packageFinding) collected(rule analyzer.Rule, opts Options, reachable bool) *collected {
+	severity := rule.Severity
+	if override, ok := opts.OperationSeverities[f.Operation]; ok {
+		severity = override
+	}
+	var operation string
+	if f.Operation != analyzer.OperationUnknown {
+		operation = f.Operation.String()
+	}
+	message, accepted := f.Message, f.Compat
+	if f.AcceptedUntil != "" && !f.Compat {
+		if acceptedOn(f.AcceptedUntil, opts.now()) {
+			message = opts.Messages.Status(analyzer.StatusAcceptedUntil, message, f.AcceptedUntil)
+			accepted = true
+		} else {
+			message = opts.Messages.Status(analyzer.StatusExceptionExpired, message, f.AcceptedUntil)
+		}
+	}
+	return &collected{
+		finding: report.Finding{
+			File:          f.File,
+			Line:          f.Line,
+			Column:        f.Column,
+			RuleID:        rule.ID,
+			Category:      rule.Category,
+			Severity:      severity.String(),
+			Message:       message,
+			HelpURI:       f.HelpURI,
+			Operation:     operation,
+			Justification: f.Justification,
+			Dependency:    f.Dependency,
+			HighVolume:    f.HighVolume,
+			Related:       f.Related,
+		},
+		accepted:  accepted,
+		reachable: reachable,
+	}
+}
+
+// Returns the time exceptions are checked against.
+func (opts Options) now() time.Time {
+	if opts.Now.IsZero() {
+		return time.Now()
+	}
+	return opts.Now
+}
+
+// Reports whether an exception accepted until a date, as YYYY-MM-DD, is
+// still in effect at now. Exceptions last until the end of their day, in
+// UTC.
+func acceptedOn(until string, now time.Time) bool {
+	date, _ := time.Parse(time.DateOnly, until)
+	return now.Before(date.AddDate(0, 0, 1))
+}
+
+// Scans the schemas and the configuration files under the directory of a
+// scan, if enabled.
+func scanFiles(dir string, opts Options) ([]report.Finding, error) {
+	if dir == "" {
+		dir = "."
+	}
+	var findings []report.Finding
+	if opts.Schemas {
+		schemaFindings, err := schema.Scan(dir)
+		if err != nil {
+			return nil, err
+		}
+		findings = append(findings, schemaFindings...)
+	}
+	if opts.KeyFiles {
+		keyFindings, err := keyfiles.Scan(dir)
+		if err != nil {
+			return nil, err
+		}
+		findings = append(findings, keyFindings...)
+	}
+	return findings, nil
+}
+
+// A finding collected from the analyzer results of every build configuration.
+type collected struct {
+	finding report.Finding
+	// Whether the finding is accepted, inside a classical-compat shim or a
+	// time-boxed exception or suppression that has not expired.
+	accepted bool
+	// Whether the finding is reachable from the entrypoints in any build.
+	reachable bool
+}
+
+// Marks the finding as unreachable and demotes it to info severity, if it is
+// unreachable.
+func (c *collected) demoteUnreachable() {
+	if !c.reachable {
+		c.finding.Unreachable = true
+		c.finding.Severity = analyzer.SeverityInfo.String()
+	}
+}
+
+// Returns the report section of the finding, as a report.Finding status.
+func (c *collected) status() string {
+	switch {
+	case c.accepted:
+		return report.StatusAccepted
+	case c.finding.Category == analyzer.CategoryAgility:
+		return report.StatusInventory
+	case c.finding.Category == analyzer.CategoryAPI:
+		return report.StatusAPI
+	}
+	return ""
+}
+
+func reportRule(rule analyzer.Rule) report.Rule {
+	return report.Rule{
+		ID:       rule.ID,
+		Name:     rule.Name,
+		Category: rule.Category,
+		Severity: rule.Severity.String(),
+		Summary:  rule.Summary,
+		HelpURI:  rule.DocURL(),
+	}
+}
+
+// Loads the packages of opts under the build configuration. Analysis needs
+// packages.LoadAllSyntax: dependencies are type-checked from source rather
+// than export data, so the scan does not depend on the toolchain's export
+// data format.
+func load(ctx context.Context, opts Options, build config.BuildConfig, mode packages.LoadMode) ([]*packages.Package, error) {
+	cfg := &packages.Config{
+		Context: ctx,
+		// Modules tell first-party packages from dependencies.
+		Mode:  mode | packages.NeedModule,
+		Dir:   opts.Dir,
+		Tests: opts.Tests,
+		Env:   os.Environ(),
+	}
+	if build.GOOS != "" {
+		cfg.Env = append(cfg.Env, "GOOS="+build.GOOS)
+	}
+	if build.GOARCH != "" {
+		cfg.Env = append(cfg.Env, "GOARCH="+build.GOARCH)
+	}
+	if len(build.Tags) > 0 {
+		cfg.BuildFlags = []string{"-tags=" + strings.Join(build.Tags, ",")}
+	}
+
+	pkgs, err := packages.Load(cfg, opts.Patterns...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load packages for build %s: %s", build, err.Error())
+	}
+	if n := packages.PrintErrors(pkgs); n > 0 {
+		return nil, fmt.Errorf("failed to load packages for build %s: %d errors", build, n)
+	}
+	return pkgs, nil
+}
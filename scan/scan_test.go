@@ -0,0 +1,865 @@
+package scan_test
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"errors"
+	"fmt"
+	"maps"
+	"os"
+	"path/filepath"
+	"runtime"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ahan-adelaide/pqc-analyzer/analyzer"
+	"github.com/ahan-adelaide/pqc-analyzer/config"
+	"github.com/ahan-adelaide/pqc-analyzer/report"
+	"github.com/ahan-adelaide/pqc-analyzer/scan"
+)
+
+func TestRunMatrix(t *testing.T) {
+	rep, err := scan.Run(scan.Options{
+		Dir:      "testdata/matrix",
+		Patterns: []string{"./..."},
+		Builds: []config.BuildConfig{
+			{},
+			{Tags: []string{"legacy"}},
+		},
+	})
+	if err != nil {
+		t.Fatalf("scan failed: %s", err.Error())
+	}
+
+	// Besides the crypto of each file, the build-gated variant is reported in
+	// the build including it and noted in the build excluding it.
+	if len(rep.Findings) != 4 {
+		t.Fatalf("expected 4 findings, got %d: %v", len(rep.Findings), rep.Findings)
+	}
+	if want := map[string]int{"default": 2, "tags=legacy": 3}; !maps.Equal(rep.BuildFindings, want) {
+		t.Errorf("got findings per build %v, want %v", rep.BuildFindings, want)
+	}
+	for _, finding := range rep.Findings {
+		switch {
+		case strings.HasSuffix(finding.File, "default.go") && finding.RuleID == "PQC002":
+			if !slices.Equal(finding.Builds, []string{"default", "tags=legacy"}) {
+				t.Errorf("default.go: unexpected builds %v", finding.Builds)
+			}
+		case strings.HasSuffix(finding.File, "default.go") && finding.RuleID == "PQC031":
+			if !slices.Equal(finding.Builds, []string{"default"}) {
+				t.Errorf("default.go: unexpected builds %v of excluded variant", finding.Builds)
+			}
+		case strings.HasSuffix(finding.File, "legacy.go"):
+			if !slices.Equal(finding.Builds, []string{"tags=legacy"}) {
+				t.Errorf("legacy.go: unexpected builds %v", finding.Builds)
+			}
+		default:
+			t.Errorf("unexpected finding %s in %s", finding.RuleID, finding.File)
+		}
+	}
+}
+
+func TestRunReachableFrom(t *testing.T) {
+	rep, err := scan.Run(scan.Options{
+		Dir:           "testdata/reachable",
+		Patterns:      []string{"./..."},
+		Deep:          true,
+		ReachableFrom: scan.ReachableFromMain,
+	})
+	if err != nil {
+		t.Fatalf("scan failed: %s", err.Error())
+	}
+
+	unreachable := make(map[string]bool)
+	for _, finding := range rep.Findings {
+		unreachable[finding.Message] = finding.Unreachable
+		if finding.Unreachable && finding.Severity != "info" {
+			t.Errorf("unreachable finding has severity %s", finding.Severity)
+		}
+	}
+	want := map[string]bool{
+		`"crypto/rsa" uses quantum-vulnerable integer factorization cryptography`:   false,
+		`function "rsa.SignPSS" implements quantum-vulnerable cryptography`:         false,
+		`function "rsa.DecryptPKCS1v15" implements quantum-vulnerable cryptography`: true,
+	}
+	if !maps.Equal(unreachable, want) {
+		t.Errorf("got unreachable findings %v, want %v", unreachable, want)
+	}
+}
+
+func TestRunReachableFromRequiresDeep(t *testing.T) {
+	_, err := scan.Run(scan.Options{
+		Dir:           "testdata/reachable",
+		Patterns:      []string{"./..."},
+		ReachableFrom: scan.ReachableFromMain,
+	})
+	if err == nil {
+		t.Error("expected reachability filtering without deep analysis to fail")
+	}
+}
+
+func TestRunOperationSeverities(t *testing.T) {
+	rep, err := scan.Run(scan.Options{
+		Dir:      "testdata/operations",
+		Patterns: []string{"./..."},
+		OperationSeverities: map[analyzer.Operation]analyzer.Severity{
+			analyzer.OperationPublic: analyzer.SeverityLow,
+		},
+	})
+	if err != nil {
+		t.Fatalf("scan failed: %s", err.Error())
+	}
+
+	severities := make(map[string]string)
+	for _, finding := range rep.Findings {
+		severities[finding.Message] = finding.Operation + " " + finding.Severity
+	}
+	want := map[string]string{
+		`"crypto/rsa" uses quantum-vulnerable integer factorization cryptography`:   " medium",
+		`function "rsa.VerifyPSS" implements quantum-vulnerable cryptography`:       "public low",
+		`function "rsa.DecryptPKCS1v15" implements quantum-vulnerable cryptography`: "private high",
+	}
+	if !maps.Equal(severities, want) {
+		t.Errorf("got severities %v, want %v", severities, want)
+	}
+}
+
+func TestRunSeverityOverrides(t *testing.T) {
+	rep, err := scan.Run(scan.Options{
+		Dir:      "testdata/matrix",
+		Patterns: []string{"./..."},
+		Builds: []config.BuildConfig{
+			{Tags: []string{"legacy"}},
+		},
+		SeverityOverrides: []scan.SeverityOverride{
+			{Path: "**", Severity: analyzer.SeverityCritical},
+			{Rule: analyzer.CategoryEllipticCurve, Path: "leg*.go", Severity: analyzer.SeverityInfo},
+			{Rule: "PQC002", Path: "vendor/**", Severity: analyzer.SeverityLow},
+		},
+	})
+	if err != nil {
+		t.Fatalf("scan failed: %s", err.Error())
+	}
+
+	severities := make(map[string]string)
+	for _, finding := range rep.Findings {
+		severities[filepath.Base(finding.File)] = finding.Severity
+	}
+	if want := map[string]string{"default.go": "critical", "legacy.go": "info"}; !maps.Equal(severities, want) {
+		t.Errorf("got severities %v, want %v", severities, want)
+	}
+}
+
+func TestRunSuppressions(t *testing.T) {
+	for _, tt := range []struct {
+		now    time.Time
+		suffix string
+	}{
+		{time.Date(2026, 6, 30, 23, 0, 0, 0, time.UTC), " (accepted until 2026-06-30)"},
+		{time.Date(2026, 7, 1, 0, 0, 0, 0, time.UTC), " (exception expired on 2026-06-30)"},
+	} {
+		rep, err := scan.Run(scan.Options{
+			Dir:      "testdata/matrix",
+			Patterns: []string{"./..."},
+			Builds: []config.BuildConfig{
+				{Tags: []string{"legacy"}},
+			},
+			Suppressions: []scan.Suppression{
+				{Path: "default.go", Reason: "demo code"},
+				{Rule: analyzer.CategoryEllipticCurve, Path: "leg*.go", Reason: "retired in Q2", Until: "2026-06-30"},
+			},
+			Now: tt.now,
+		})
+		if err != nil {
+			t.Fatalf("scan failed: %s", err.Error())
+		}
+
+		accepted := make(map[string]string)
+		for _, finding := range rep.InteropDebt {
+			accepted[filepath.Base(finding.File)] = finding.Message
+			if finding.Justification == "" {
+				t.Errorf("%s: accepted finding %q has no justification", tt.now.Format(time.DateOnly), finding.Message)
+			}
+		}
+		if !strings.HasSuffix(accepted["default.go"], " (suppressed)") {
+			t.Errorf("%s: finding of default.go not suppressed: %v", tt.now.Format(time.DateOnly), accepted)
+		}
+		section := rep.Findings
+		if tt.suffix == " (accepted until 2026-06-30)" {
+			section = rep.InteropDebt
+		}
+		if !slices.ContainsFunc(section, func(finding report.Finding) bool {
+			return filepath.Base(finding.File) == "legacy.go" && strings.HasSuffix(finding.Message, tt.suffix)
+		}) {
+			t.Errorf("%s: no finding of legacy.go ending in %q in %v", tt.now.Format(time.DateOnly), tt.suffix, section)
+		}
+	}
+}
+
+func TestRunAPISurface(t *testing.T) {
+	rep, err := scan.Run(scan.Options{
+		Dir:      "testdata/apisurface",
+		Patterns: []string{"./..."},
+	})
+	if err != nil {
+		t.Fatalf("scan failed: %s", err.Error())
+	}
+
+	if len(rep.APISurface) != 1 || rep.APISurface[0].RuleID != "PQC030" {
+		t.Errorf("unexpected API surface %v", rep.APISurface)
+	}
+	for _, finding := range rep.Findings {
+		if finding.RuleID == "PQC030" {
+			t.Errorf("API surface finding %q among the findings", finding.Message)
+		}
+	}
+}
+
+func TestRunIgnoreUntil(t *testing.T) {
+	for _, tt := range []struct {
+		now      time.Time
+		accepted bool
+		suffix   string
+	}{
+		{time.Date(2026, 6, 30, 23, 0, 0, 0, time.UTC), true, " (accepted until 2026-06-30)"},
+		{time.Date(2026, 7, 1, 0, 0, 0, 0, time.UTC), false, " (exception expired on 2026-06-30)"},
+	} {
+		rep, err := scan.Run(scan.Options{
+			Dir:      "testdata/exceptions",
+			Patterns: []string{"./..."},
+			Now:      tt.now,
+		})
+		if err != nil {
+			t.Fatalf("scan failed: %s", err.Error())
+		}
+
+		section := rep.Findings
+		if tt.accepted {
+			section = rep.InteropDebt
+		}
+		idx := slices.IndexFunc(section, func(finding report.Finding) bool {
+			return strings.HasSuffix(finding.Message, tt.suffix)
+		})
+		if idx == -1 {
+			t.Errorf("%s: no finding ending in %q in %v", tt.now.Format(time.DateOnly), tt.suffix, section)
+			continue
+		}
+		if section[idx].Justification != "vendor SDK upgrade in Q2" {
+			t.Errorf("%s: unexpected justification %q", tt.now.Format(time.DateOnly), section[idx].Justification)
+		}
+	}
+}
+
+func TestRunIncludeDeps(t *testing.T) {
+	opts := scan.Options{Dir: "testdata/deps", Patterns: []string{"./..."}}
+	rep, err := scan.Run(opts)
+	if err != nil {
+		t.Fatalf("scan failed: %s", err.Error())
+	}
+	for _, finding := range rep.Findings {
+		if finding.Dependency != "" {
+			t.Errorf("dependency finding %q without -include-deps", finding.Message)
+		}
+	}
+
+	opts.IncludeDeps = true
+	rep, err = scan.Run(opts)
+	if err != nil {
+		t.Fatalf("scan failed: %s", err.Error())
+	}
+	var firstParty, dependency int
+	for _, finding := range rep.Findings {
+		switch {
+		case finding.Dependency == "":
+			firstParty++
+		case finding.Dependency == "example.com/dep@v0.0.0" && strings.HasSuffix(finding.File, "dep.go"):
+			dependency++
+		default:
+			t.Errorf("finding %q in %s tagged with dependency %q", finding.Message, finding.File, finding.Dependency)
+		}
+	}
+	if firstParty == 0 || dependency == 0 {
+		t.Errorf("got %d first-party and %d dependency findings, want both", firstParty, dependency)
+	}
+}
+
+func TestRunModules(t *testing.T) {
+	var metrics scan.Metrics
+	rep, err := scan.RunModules("testdata/deps", scan.Options{Metrics: &metrics})
+	if err != nil {
+		t.Fatalf("scan failed: %s", err.Error())
+	}
+	if !slices.Equal(rep.Modules, []string{".", "dep"}) {
+		t.Errorf("got modules %v, want the module and its nested dependency", rep.Modules)
+	}
+	if rep.ModuleFindings["."] != 1 || rep.ModuleFindings["dep"] != 2 || len(rep.Findings) != 3 {
+		t.Errorf("got %d findings, %v per module", len(rep.Findings), rep.ModuleFindings)
+	}
+	if metrics.Packages != 2 || metrics.FindingsPerRule["PQC002"] != 2 {
+		t.Errorf("unexpected metrics %+v", metrics)
+	}
+
+	if _, err := scan.RunModules(t.TempDir(), scan.Options{}); err == nil {
+		t.Error("scan of a directory without modules succeeded")
+	}
+}
+
+// The toolchain defaults of a module are reported once, for its package with
+// the smallest import path, whether or not its packages were cached.
+func TestRunPerModule(t *testing.T) {
+	opts := scan.Options{
+		Dir:      "testdata/toolchain",
+		Patterns: []string{"./..."},
+		Tests:    true,
+		CacheDir: filepath.Join(t.TempDir(), "cache"),
+	}
+	for _, run := range []string{"uncached", "cached"} {
+		rep, err := scan.Run(opts)
+		if err != nil {
+			t.Fatalf("scan failed: %s", err.Error())
+		}
+		var files []string
+		for _, finding := range rep.Findings {
+			if finding.RuleID == "PQC046" {
+				files = append(files, filepath.Base(finding.File))
+			}
+		}
+		if !slices.Equal(files, []string{"client.go"}) {
+			t.Errorf("got PQC046 findings in %v on the %s run, want one in client.go", files, run)
+		}
+	}
+}
+
+func TestRunArchive(t *testing.T) {
+	files := map[string]string{
+		"example.com/lib@v1.2.0/go.mod": "module example.com/lib\n\ngo 1.22\n",
+		"example.com/lib@v1.2.0/lib.go": `package lib
+
+import "example.com/lib/internal/sign"
+
+func Sign(digest []byte) ([]byte, error) {
+	return sign.PKCS1(digest)
+}
+`,
+		"example.com/lib@v1.2.0/internal/sign/sign.go": `package sign
+
+import (
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+)
+
+var key *rsa.PrivateKey
+
+func PKCS1(digest []byte) ([]byte, error) {
+	return rsa.SignPKCS1v15(rand.Reader, key, crypto.SHA256, digest)
+}
+`,
+		"example.com/lib@v1.2.0/testdata/ignored.go": "package ignored\n\nimport _ \"crypto/ecdsa\"\n",
+		"example.com/lib@v1.2.0/README.md":           "crypto/ecdsa\n",
+	}
+	dir := t.TempDir()
+	zipPath := filepath.Join(dir, "lib.zip")
+	var zipped bytes.Buffer
+	zw := zip.NewWriter(&zipped)
+	tarPath := filepath.Join(dir, "lib.tar.gz")
+	var tarred bytes.Buffer
+	gw := gzip.NewWriter(&tarred)
+	tw := tar.NewWriter(gw)
+	for _, name := range slices.Sorted(maps.Keys(files)) {
+		w, err := zw.Create(name)
+		if err == nil {
+			_, err = w.Write([]byte(files[name]))
+		}
+		if err == nil {
+			err = tw.WriteHeader(&tar.Header{Name: name, Mode: 0o644, Size: int64(len(files[name])), Typeflag: tar.TypeReg})
+		}
+		if err == nil {
+			_, err = tw.Write([]byte(files[name]))
+		}
+		if err != nil {
+			t.Fatalf("failed to write archive: %s", err.Error())
+		}
+	}
+	if err := errors.Join(zw.Close(), tw.Close(), gw.Close()); err != nil {
+		t.Fatalf("failed to write archive: %s", err.Error())
+	}
+	if err := errors.Join(os.WriteFile(zipPath, zipped.Bytes(), 0o644), os.WriteFile(tarPath, tarred.Bytes(), 0o644)); err != nil {
+		t.Fatalf("failed to write archive: %s", err.Error())
+	}
+
+	for _, archive := range []string{zipPath, tarPath} {
+		if !scan.IsArchive(archive) {
+			t.Errorf("%s is not recognized as an archive", archive)
+		}
+		rep, err := scan.RunArchive(archive, scan.Options{})
+		if err != nil {
+			t.Fatalf("scan of %s failed: %s", archive, err.Error())
+		}
+		var got []string
+		for _, finding := range rep.Findings {
+			got = append(got, fmt.Sprintf("%s:%s", finding.File, finding.RuleID))
+		}
+		want := []string{
+			"example.com/lib@v1.2.0/internal/sign/sign.go:PQC002",
+			"example.com/lib@v1.2.0/internal/sign/sign.go:PQC003",
+		}
+		if !slices.Equal(got, want) {
+			t.Errorf("scan of %s got findings %v, want %v", archive, got, want)
+		}
+	}
+
+	if _, err := scan.RunArchive(zipPath, scan.Options{Deep: true}); err == nil {
+		t.Error("deep scan of an archive succeeded")
+	}
+	if scan.IsArchive("./...") {
+		t.Error("package pattern recognized as an archive")
+	}
+}
+
+func TestRunArchiveLimits(t *testing.T) {
+	scan.SetArchiveLimits(t, 64, 128, 4)
+	source := "package lib\n"
+	tests := []struct {
+		name  string
+		files map[string]string
+		want  string
+	}{
+		{"oversized entry", map[string]string{"lib/lib.go": source + strings.Repeat("//\n", 32)}, "larger than 64 bytes"},
+		{"oversized total", map[string]string{"lib/a.go": source, "lib/b.go": source + strings.Repeat("//\n", 16), "lib/c.go": source + strings.Repeat("//\n", 16)}, "larger than 128 bytes in total"},
+		{"too many entries", map[string]string{"lib/a.go": source, "lib/b.go": source, "lib/c.go": source, "lib/d.go": source, "lib/README": "lib\n"}, "more than 4 entries"},
+		{"entry outside the archive", map[string]string{"../lib.go": source}, "outside the archive"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			archive := filepath.Join(t.TempDir(), "lib.tar")
+			var buf bytes.Buffer
+			tw := tar.NewWriter(&buf)
+			for _, name := range slices.Sorted(maps.Keys(tt.files)) {
+				err := tw.WriteHeader(&tar.Header{Name: name, Mode: 0o644, Size: int64(len(tt.files[name])), Typeflag: tar.TypeReg})
+				if err == nil {
+					_, err = tw.Write([]byte(tt.files[name]))
+				}
+				if err != nil {
+					t.Fatalf("failed to write archive: %s", err.Error())
+				}
+			}
+			if err := errors.Join(tw.Close(), os.WriteFile(archive, buf.Bytes(), 0o644)); err != nil {
+				t.Fatalf("failed to write archive: %s", err.Error())
+			}
+			if _, err := scan.RunArchive(archive, scan.Options{}); err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("got error %v, want %q", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestRunSource(t *testing.T) {
+	src := []byte(`package sign
+
+import (
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+
+	"example.com/keys"
+)
+
+func Sign(digest []byte) ([]byte, error) {
+	return rsa.SignPKCS1v15(rand.Reader, keys.Load(), crypto.SHA256, digest)
+}
+`)
+	rep, err := scan.RunSource("internal/sign/sign.go", src, scan.Options{})
+	if err != nil {
+		t.Fatalf("scan failed: %s", err.Error())
+	}
+	var got []string
+	for _, finding := range rep.Findings {
+		got = append(got, fmt.Sprintf("%s:%d:%s", finding.File, finding.Line, finding.RuleID))
+	}
+	want := []string{
+		"internal/sign/sign.go:6:PQC002",
+		"internal/sign/sign.go:12:PQC003",
+	}
+	if !slices.Equal(got, want) {
+		t.Errorf("got findings %v, want %v", got, want)
+	}
+
+	if _, err := scan.RunSource("sign.go", src, scan.Options{Deep: true}); err == nil {
+		t.Error("deep scan of a single file succeeded")
+	}
+	if _, err := scan.RunSource("sign.txt", src, scan.Options{}); err == nil {
+		t.Error("scan of a file without the .go suffix succeeded")
+	}
+	if _, err := scan.RunSource("sign.go", []byte("sign"), scan.Options{}); err == nil {
+		t.Error("scan of a file that does not parse succeeded")
+	}
+}
+
+func TestRunMetrics(t *testing.T) {
+	var metrics scan.Metrics
+	_, err := scan.Run(scan.Options{
+		Dir:      "testdata/matrix",
+		Patterns: []string{"./..."},
+		Builds: []config.BuildConfig{
+			{},
+			{Tags: []string{"legacy"}},
+		},
+		Metrics: &metrics,
+	})
+	if err != nil {
+		t.Fatalf("scan failed: %s", err.Error())
+	}
+
+	if metrics.Builds != 2 || metrics.Packages != 1 {
+		t.Errorf("got %d builds and %d packages, want 2 and 1", metrics.Builds, metrics.Packages)
+	}
+	if want := map[string]int{"PQC001": 1, "PQC002": 1, "PQC031": 2}; !maps.Equal(metrics.FindingsPerRule, want) {
+		t.Errorf("got findings per rule %v, want %v", metrics.FindingsPerRule, want)
+	}
+	if metrics.Duration <= 0 {
+		t.Errorf("got duration %f, want a positive duration", metrics.Duration)
+	}
+}
+
+func TestPackagesRunTwice(t *testing.T) {
+	pkgs, err := scan.Load(scan.Options{
+		Dir:      "testdata/reachable",
+		Patterns: []string{"./..."},
+	})
+	if err != nil {
+		t.Fatalf("load failed: %s", err.Error())
+	}
+
+	// The same loaded packages serve both modes.
+	shallow, err := pkgs.Run(scan.Options{})
+	if err != nil {
+		t.Fatalf("scan failed: %s", err.Error())
+	}
+	deep, err := pkgs.Run(scan.Options{Deep: true, ReachableFrom: scan.ReachableFromMain})
+	if err != nil {
+		t.Fatalf("deep scan failed: %s", err.Error())
+	}
+
+	if len(shallow.Findings) != len(deep.Findings) {
+		t.Fatalf("got %d findings, then %d", len(shallow.Findings), len(deep.Findings))
+	}
+	unreachable := 0
+	for i := range deep.Findings {
+		if shallow.Findings[i].Unreachable {
+			t.Errorf("shallow scan marked %q unreachable", shallow.Findings[i].Message)
+		}
+		if deep.Findings[i].Unreachable {
+			unreachable++
+		}
+	}
+	if unreachable != 1 {
+		t.Errorf("got %d unreachable findings in deep scan, want 1", unreachable)
+	}
+}
+
+func TestAnalyze(t *testing.T) {
+	opts := scan.Options{
+		Dir:      "testdata/matrix",
+		Patterns: []string{"./..."},
+		Builds: []config.BuildConfig{
+			{},
+			{Tags: []string{"legacy"}},
+		},
+	}
+	var streamed []string
+	err := scan.Analyze(context.Background(), opts, func(finding report.Finding) error {
+		streamed = append(streamed, filepath.Base(finding.File)+" "+strings.Join(finding.Builds, ","))
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("scan failed: %s", err.Error())
+	}
+	slices.Sort(streamed)
+	if want := []string{
+		"default.go default", "default.go default", "default.go tags=legacy",
+		"legacy.go tags=legacy", "legacy.go tags=legacy",
+	}; !slices.Equal(streamed, want) {
+		t.Errorf("streamed %v, want %v", streamed, want)
+	}
+
+	// The first error of the callback stops the scan.
+	stop := errors.New("stop")
+	calls := 0
+	err = scan.Analyze(context.Background(), opts, func(report.Finding) error {
+		calls++
+		return stop
+	})
+	if err != stop || calls != 1 {
+		t.Errorf("got error %v after %d calls, want %v after 1", err, calls, stop)
+	}
+}
+
+func TestRunCache(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.CopyFS(dir, os.DirFS("testdata/matrix")); err != nil {
+		t.Fatal(err)
+	}
+	opts := scan.Options{
+		Dir:      dir,
+		Patterns: []string{"./..."},
+		CacheDir: filepath.Join(t.TempDir(), "cache"),
+	}
+	run := func() (*report.Report, *scan.CacheMetrics) {
+		t.Helper()
+		var metrics scan.Metrics
+		opts.Metrics = &metrics
+		rep, err := scan.Run(opts)
+		if err != nil {
+			t.Fatalf("scan failed: %s", err.Error())
+		}
+		return rep, metrics.Cache
+	}
+
+	uncached, metrics := run()
+	if metrics == nil || metrics.Hits != 0 || metrics.Misses != 1 {
+		t.Fatalf("got cache metrics %+v on the first run, want 1 miss", metrics)
+	}
+	cached, metrics := run()
+	if metrics.Hits != 1 || metrics.Misses != 0 {
+		t.Errorf("got cache metrics %+v on the second run, want 1 hit", metrics)
+	}
+	if !slices.EqualFunc(uncached.Findings, cached.Findings, func(a, b report.Finding) bool {
+		return a.File == b.File && a.Line == b.Line && a.Message == b.Message && a.Severity == b.Severity
+	}) {
+		t.Errorf("cached findings %v differ from %v", cached.Findings, uncached.Findings)
+	}
+
+	// Changing a file of the package misses the cache.
+	if err := os.WriteFile(filepath.Join(dir, "default.go"), []byte("package matrix\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	changed, metrics := run()
+	if metrics.Hits != 0 || metrics.Misses != 1 {
+		t.Errorf("got cache metrics %+v after a change, want 1 miss", metrics)
+	}
+	if len(changed.Findings) != 1 || changed.Findings[0].RuleID != "PQC031" {
+		t.Errorf("got findings %v after a change, want the excluded variant only", changed.Findings)
+	}
+}
+
+// Packages whose findings depend on key files are not cached, so changing the
+// files changes the findings.
+func TestRunCacheKeyFiles(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.CopyFS(filepath.Join(dir, "certs"), os.DirFS("../analyzer/testdata/src/keypair/certs")); err != nil {
+		t.Fatal(err)
+	}
+	source := `package server
+
+import "crypto/tls"
+
+func certificate() (tls.Certificate, error) {
+	return tls.LoadX509KeyPair("certs/server.crt", "certs/server.key")
+}
+`
+	if err := os.WriteFile(filepath.Join(dir, "go.mod"), []byte("module server\n\ngo 1.24\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "server.go"), []byte(source), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	opts := scan.Options{
+		Dir:      dir,
+		Patterns: []string{"./..."},
+		CacheDir: filepath.Join(t.TempDir(), "cache"),
+	}
+	run := func() int {
+		t.Helper()
+		var metrics scan.Metrics
+		opts.Metrics = &metrics
+		rep, err := scan.Run(opts)
+		if err != nil {
+			t.Fatalf("scan failed: %s", err.Error())
+		}
+		if metrics.Cache.Hits != 0 {
+			t.Errorf("got cache metrics %+v, want no hits", metrics.Cache)
+		}
+		var n int
+		for _, finding := range rep.Findings {
+			if strings.Contains(finding.Message, "holds a quantum-vulnerable") {
+				n++
+			}
+		}
+		return n
+	}
+
+	if n := run(); n != 2 {
+		t.Errorf("got %d key file findings, want 2", n)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "certs", "server.key"), nil, 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if n := run(); n != 1 {
+		t.Errorf("got %d key file findings after emptying the key, want 1", n)
+	}
+}
+
+// Template of the files of the synthetic corpus, covering the common rules.
+// Each file declares its own functions, named after %[1]d.
+const corpusFile = `package %[2]s
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+)
+
+func sign%[1]d(key *rsa.PrivateKey, data []byte) ([]byte, error) {
+	digest := sha256.Sum256(data)
+	return rsa.SignPSS(rand.Reader, key, crypto.SHA256, digest[:], nil)
+}
+
+func generate%[1]d() (*ecdsa.PrivateKey, error) {
+	return ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+}
+
+func config%[1]d(roots *x509.CertPool) *tls.Config {
+	return &tls.Config{RootCAs: roots, MinVersion: tls.VersionTLS12}
+}
+
+func algorithm%[1]d(cert *x509.Certificate) (string, error) {
+	switch cert.PublicKeyAlgorithm {
+	case x509.RSA:
+		return "rsa", nil
+	case x509.ECDSA:
+		return "ecdsa", nil
+	}
+	return "", errors.New("unsupported algorithm")
+}
+
+func plain%[1]d(values []int) int {
+	sum := 0
+	for _, value := range values {
+		sum += value
+	}
+	return sum
+}
+`
+
+// Writes a module of the given number of packages and files per package to a
+// temporary directory, and returns the directory.
+func writeCorpus(tb testing.TB, pkgs, files int) string {
+	tb.Helper()
+	dir := tb.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "go.mod"), []byte("module corpus\n\ngo 1.24\n"), 0o644); err != nil {
+		tb.Fatal(err)
+	}
+	for p := range pkgs {
+		name := fmt.Sprintf("pkg%d", p)
+		if err := os.Mkdir(filepath.Join(dir, name), 0o755); err != nil {
+			tb.Fatal(err)
+		}
+		for f := range files {
+			src := fmt.Sprintf(corpusFile, f, name)
+			if err := os.WriteFile(filepath.Join(dir, name, fmt.Sprintf("file%d.go", f)), []byte(src), 0o644); err != nil {
+				tb.Fatal(err)
+			}
+		}
+	}
+	return dir
+}
+
+// Loads the corpus for benchmarks analyzing it.
+func loadCorpus(tb testing.TB, pkgs, files int) (*scan.Packages, scan.Options) {
+	tb.Helper()
+	opts := scan.Options{Dir: writeCorpus(tb, pkgs, files), Patterns: []string{"./..."}}
+	loaded, err := scan.Load(opts)
+	if err != nil {
+		tb.Fatalf("failed to load corpus: %s", err.Error())
+	}
+	return loaded, opts
+}
+
+// Reports the time and allocations per file of the benchmark, which stay
+// comparable as the corpus changes size.
+func reportPerFile(b *testing.B, files int, allocs uint64) {
+	perRun := float64(b.Elapsed().Nanoseconds()) / float64(b.N)
+	b.ReportMetric(perRun/float64(files), "ns/file")
+	b.ReportMetric(float64(allocs)/float64(b.N)/float64(files), "allocs/file")
+}
+
+// Returns the number of heap allocations so far.
+func mallocs() uint64 {
+	var stats runtime.MemStats
+	runtime.ReadMemStats(&stats)
+	return stats.Mallocs
+}
+
+// Budgets of analyzing a file of the synthetic corpus, failing
+// TestAnalysisBudget when new rules or passes blow up scan times. They leave
+// room for growth over the current costs, about 420 allocations and 0.7ms per
+// file; raise them deliberately when a rule is worth its cost.
+const (
+	allocsPerFileBudget = 600
+	timePerFileBudget   = 2 * time.Millisecond
+)
+
+func TestAnalysisBudget(t *testing.T) {
+	const pkgs, files = 10, 20
+	loaded, opts := loadCorpus(t, pkgs, files)
+	run := func() {
+		if _, err := loaded.Run(opts); err != nil {
+			t.Fatalf("scan failed: %s", err.Error())
+		}
+	}
+
+	if allocs := testing.AllocsPerRun(2, run) / (pkgs * files); allocs > allocsPerFileBudget {
+		t.Errorf("analysis allocates %.0f times per file, over the budget of %d", allocs, allocsPerFileBudget)
+	}
+	// Timings are noisy under the race detector and on loaded machines, so
+	// they are only checked on request, on a quiet machine.
+	if os.Getenv("PQC_TIME_BUDGET") == "" {
+		return
+	}
+	start := time.Now()
+	run()
+	if perFile := time.Since(start) / (pkgs * files); perFile > timePerFileBudget {
+		t.Errorf("analysis takes %s per file, over the budget of %s", perFile, timePerFileBudget)
+	}
+}
+
+// Benchmarks analyzing 2000 files, once loaded and type-checked, which is the
+// part of a scan the rules and their passes cost.
+func BenchmarkAnalyze(b *testing.B) {
+	const pkgs, files = 40, 50
+	loaded, opts := loadCorpus(b, pkgs, files)
+	b.ReportAllocs()
+	start := mallocs()
+	for b.Loop() {
+		if _, err := loaded.Run(opts); err != nil {
+			b.Fatalf("scan failed: %s", err.Error())
+		}
+	}
+	reportPerFile(b, pkgs*files, mallocs()-start)
+}
+
+// Benchmarks whole scans of 1000 files, including loading them.
+func BenchmarkRun(b *testing.B) {
+	const pkgs, files = 20, 50
+	opts := scan.Options{Dir: writeCorpus(b, pkgs, files), Patterns: []string{"./..."}}
+	b.ReportAllocs()
+	start := mallocs()
+	for b.Loop() {
+		if _, err := scan.Run(opts); err != nil {
+			b.Fatalf("scan failed: %s", err.Error())
+		}
+	}
+	reportPerFile(b, pkgs*files, mallocs()-start)
+}
@@ -0,0 +1,47 @@
+package scan
+
+import (
+	"fmt"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"github.com/ahan-adelaide/pqc-analyzer/config"
+	"github.com/ahan-adelaide/pqc-analyzer/report"
+)
+
+// RunSource analyzes the contents of a single Go file, such as one read from
+// standard input by an editor or a pre-commit hook, and merges the findings
+// of every build configuration into a single report, like Run. See
+// LoadSource.
+func RunSource(filename string, src []byte, opts Options) (*report.Report, error) {
+	pkgs, err := LoadSource(filename, src, opts)
+	if err != nil {
+		return nil, err
+	}
+	return pkgs.Run(opts)
+}
+
+// LoadSource loads a single Go file as a package of its own, without loading
+// the other files of its package or its module, so it returns quickly. Like
+// the packages of LoadArchive, the file is type-checked against the standard
+// library only, whose export data comes from the go command, so a Go
+// toolchain is still needed; references to other packages, including other
+// files of its own package, are left unresolved, so findings needing their
+// types are missed. Findings are reported under
+// filename, which need not exist. A file excluded from a build configuration
+// by its name or build constraints has no findings under it. Only the Tests
+// and Builds options are used, and deep analysis is not supported.
+func LoadSource(filename string, src []byte, opts Options) (*Packages, error) {
+	if opts.Deep {
+		return nil, fmt.Errorf("deep analysis is not supported for single files")
+	}
+	if !strings.HasSuffix(filename, ".go") {
+		return nil, fmt.Errorf("failed to load %s: not a Go file", filename)
+	}
+	files := map[string][]byte{path.Clean(filepath.ToSlash(filename)): src}
+	return loadBuilds(opts, func(buildConfig config.BuildConfig) (loadedBuild, error) {
+		pkgs, err := loadArchiveBuild(files, opts, buildConfig)
+		return loadedBuild{build: buildConfig, pkgs: pkgs}, err
+	})
+}
@@ -0,0 +1,3 @@
+module apisurface
+
+go 1.24
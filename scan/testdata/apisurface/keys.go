@@ -0,0 +1,7 @@
+package apisurface
+
+import "crypto/ecdsa"
+
+func Verify(key *ecdsa.PublicKey, digest, sig []byte) bool {
+	return ecdsa.VerifyASN1(key, digest, sig)
+}
@@ -0,0 +1,13 @@
+package dep
+
+import (
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+)
+
+const KeyBits = 3072
+
+func Sign(key *rsa.PrivateKey, digest []byte) ([]byte, error) {
+	return rsa.SignPSS(rand.Reader, key, crypto.SHA256, digest, nil)
+}
@@ -0,0 +1,12 @@
+package deps
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+
+	"example.com/dep"
+)
+
+func generate() (*rsa.PrivateKey, error) {
+	return rsa.GenerateKey(rand.Reader, dep.KeyBits)
+}
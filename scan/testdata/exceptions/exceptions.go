@@ -0,0 +1,14 @@
+package exceptions
+
+import (
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+)
+
+// vendorSign signs with the key of a vendor SDK being upgraded.
+//
+//pqc:ignore-until=2026-06-30 reason=vendor SDK upgrade in Q2
+func vendorSign(key *rsa.PrivateKey, digest []byte) ([]byte, error) {
+	return rsa.SignPSS(rand.Reader, key, crypto.SHA256, digest, nil)
+}
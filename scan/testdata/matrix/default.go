@@ -0,0 +1,5 @@
+package matrix
+
+import "crypto/rsa"
+
+var _ *rsa.PrivateKey
@@ -0,0 +1,7 @@
+//go:build legacy
+
+package matrix
+
+import "crypto/ecdsa"
+
+var _ *ecdsa.PrivateKey
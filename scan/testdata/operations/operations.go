@@ -0,0 +1,14 @@
+package operations
+
+import (
+	"crypto"
+	"crypto/rsa"
+)
+
+func verify(pub *rsa.PublicKey, digest, sig []byte) error {
+	return rsa.VerifyPSS(pub, crypto.SHA256, digest, sig, nil)
+}
+
+func decrypt(priv *rsa.PrivateKey, ciphertext []byte) ([]byte, error) {
+	return rsa.DecryptPKCS1v15(nil, priv, ciphertext)
+}
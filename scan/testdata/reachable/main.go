@@ -0,0 +1,20 @@
+package main
+
+import (
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+)
+
+func main() {
+	key, _ := rsa.GenerateKey(rand.Reader, 2048)
+	sign(key, make([]byte, 32))
+}
+
+func sign(key *rsa.PrivateKey, digest []byte) ([]byte, error) {
+	return rsa.SignPSS(rand.Reader, key, crypto.SHA256, digest, nil)
+}
+
+func legacyDecrypt(key *rsa.PrivateKey, ciphertext []byte) ([]byte, error) {
+	return rsa.DecryptPKCS1v15(rand.Reader, key, ciphertext)
+}
@@ -0,0 +1,10 @@
+package server
+
+import (
+	"net/http"
+	"testing"
+)
+
+func TestServe(t *testing.T) {
+	_ = serve(http.NotFoundHandler())
+}
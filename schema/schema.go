@@ -0,0 +1,210 @@
+// Package schema scans Protocol Buffers and OpenAPI schemas for fields
+// carrying classical keys, signatures and certificates. Wire formats that
+// bake in classical algorithms block a migration even when the Go code
+// handling them is agile.
+package schema
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strings"
+	"unicode"
+
+	"github.com/ahan-adelaide/pqc-analyzer/analyzer"
+	"github.com/ahan-adelaide/pqc-analyzer/report"
+)
+
+// RuleID is the ID of the analyzer rule schema findings are reported under.
+const RuleID = "PQC023"
+
+// Name tokens of classical public-key algorithms.
+var algorithmTokens = []string{"rsa", "ecdsa", "ecdh", "ed25519", "eddsa", "dsa", "x25519", "secp256k1", "p256", "p384", "p521", "ec"}
+
+// Name tokens of key material, signatures and certificates.
+var materialTokens = []string{"key", "pubkey", "pub", "priv", "private", "public", "sig", "signature", "cert", "certificate"}
+
+// Name tokens of DER-encoded X.509 blobs.
+var derTokens = []string{"der", "x509", "pkix", "spki"}
+
+var (
+	protoFieldPattern    = regexp.MustCompile(`^\s*(?:(?:repeated|optional|required)\s+)?([\w.]+)\s+(\w+)\s*=\s*\d+`)
+	openAPIKeyPattern    = regexp.MustCompile(`^\s*"?([\w-]+)"?\s*:`)
+	openAPIMarkerPattern = regexp.MustCompile(`^\s*"?(openapi|swagger)"?\s*:`)
+)
+
+// Directories never scanned.
+var skippedDirs = []string{".git", "node_modules", "vendor", "testdata"}
+
+// Scan walks the directory tree rooted at dir and returns the findings in its
+// .proto files and OpenAPI documents.
+func Scan(dir string) ([]report.Finding, error) {
+	rule, ok := analyzer.LookupRule(RuleID)
+	if !ok {
+		return nil, fmt.Errorf("unknown rule %s", RuleID)
+	}
+
+	var findings []report.Finding
+	err := filepath.WalkDir(dir, func(path string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if entry.IsDir() {
+			if path != dir && slices.Contains(skippedDirs, entry.Name()) {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+
+		switch filepath.Ext(path) {
+		case ".proto":
+			fileFindings, err := scanFile(path, rule, scanProtoLine)
+			if err != nil {
+				return err
+			}
+			findings = append(findings, fileFindings...)
+		case ".yaml", ".yml", ".json":
+			data, err := os.ReadFile(path)
+			if err != nil {
+				return fmt.Errorf("failed to read %s: %s", path, err.Error())
+			}
+			if !isOpenAPI(data) {
+				return nil
+			}
+			fileFindings, err := scanFile(path, rule, scanOpenAPILine)
+			if err != nil {
+				return err
+			}
+			findings = append(findings, fileFindings...)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to scan schemas in %s: %s", dir, err.Error())
+	}
+	return findings, nil
+}
+
+// A lineScanner returns the column and message of a finding on a line.
+type lineScanner func(line string) (int, string, bool)
+
+func scanFile(path string, rule analyzer.Rule, scanLine lineScanner) ([]report.Finding, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %s", path, err.Error())
+	}
+	abs, err := filepath.Abs(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve %s: %s", path, err.Error())
+	}
+
+	var findings []report.Finding
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	for line := 1; scanner.Scan(); line++ {
+		column, message, ok := scanLine(scanner.Text())
+		if !ok {
+			continue
+		}
+		findings = append(findings, report.Finding{
+			File:     abs,
+			Line:     line,
+			Column:   column,
+			RuleID:   rule.ID,
+			Category: rule.Category,
+			Severity: rule.Severity.String(),
+			Message:  message,
+			HelpURI:  rule.DocURL(),
+		})
+	}
+	return findings, scanner.Err()
+}
+
+func scanProtoLine(line string) (int, string, bool) {
+	match := protoFieldPattern.FindStringSubmatchIndex(line)
+	if match == nil {
+		return 0, "", false
+	}
+	fieldType, name := line[match[2]:match[3]], line[match[4]:match[5]]
+	kind, ok := classifyField(name)
+	if !ok || (kind == "an X.509 DER blob" && fieldType != "bytes") {
+		return 0, "", false
+	}
+	return match[4] + 1, fmt.Sprintf("protobuf field %s carries %s; wire formats that bake in classical algorithms block migration", name, kind), true
+}
+
+func scanOpenAPILine(line string) (int, string, bool) {
+	match := openAPIKeyPattern.FindStringSubmatchIndex(line)
+	if match == nil {
+		return 0, "", false
+	}
+	name := line[match[2]:match[3]]
+	kind, ok := classifyField(name)
+	if !ok {
+		return 0, "", false
+	}
+	return match[2] + 1, fmt.Sprintf("OpenAPI property %s carries %s; wire formats that bake in classical algorithms block migration", name, kind), true
+}
+
+// Returns what a field carries, judging by its name.
+func classifyField(name string) (string, bool) {
+	tokens := nameTokens(name)
+	hasMaterial := slices.ContainsFunc(tokens, func(token string) bool {
+		return slices.Contains(materialTokens, token)
+	})
+	if !hasMaterial {
+		return "", false
+	}
+	for _, token := range tokens {
+		if slices.Contains(algorithmTokens, token) {
+			return "classical " + strings.ToUpper(token) + " key material", true
+		}
+	}
+	if slices.ContainsFunc(tokens, func(token string) bool {
+		return slices.Contains(derTokens, token)
+	}) {
+		return "an X.509 DER blob", true
+	}
+	return "", false
+}
+
+// Splits a snake_case, kebab-case or camelCase name into lower case tokens.
+func nameTokens(name string) []string {
+	var tokens []string
+	var current []rune
+	flush := func() {
+		if len(current) > 0 {
+			tokens = append(tokens, strings.ToLower(string(current)))
+			current = nil
+		}
+	}
+	runes := []rune(name)
+	for i, r := range runes {
+		switch {
+		case r == '_' || r == '-' || r == '.':
+			flush()
+		case unicode.IsUpper(r) && i > 0 && (!unicode.IsUpper(runes[i-1]) || i+1 < len(runes) && unicode.IsLower(runes[i+1]) && unicode.IsUpper(runes[i-1])):
+			flush()
+			current = append(current, r)
+		default:
+			current = append(current, r)
+		}
+	}
+	flush()
+	return tokens
+}
+
+// Reports whether the YAML or JSON document is an OpenAPI or Swagger document.
+func isOpenAPI(data []byte) bool {
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	for i := 0; i < 20 && scanner.Scan(); i++ {
+		if openAPIMarkerPattern.MatchString(scanner.Text()) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,34 @@
+package schema_test
+
+import (
+	"fmt"
+	"path/filepath"
+	"slices"
+	"testing"
+
+	"github.com/ahan-adelaide/pqc-analyzer/schema"
+)
+
+func TestScan(t *testing.T) {
+	findings, err := schema.Scan("testdata")
+	if err != nil {
+		t.Fatalf("scan failed: %s", err.Error())
+	}
+
+	var got []string
+	for _, finding := range findings {
+		if finding.RuleID != schema.RuleID {
+			t.Errorf("unexpected rule %s", finding.RuleID)
+		}
+		got = append(got, fmt.Sprintf("%s:%d:%d: %s", filepath.Base(finding.File), finding.Line, finding.Column, finding.Message))
+	}
+	want := []string{
+		"keys.proto:7:9: protobuf field rsa_public_key carries classical RSA key material; wire formats that bake in classical algorithms block migration",
+		"keys.proto:8:9: protobuf field ecdsa_signature carries classical ECDSA key material; wire formats that bake in classical algorithms block migration",
+		"keys.proto:9:9: protobuf field cert_der carries an X.509 DER blob; wire formats that bake in classical algorithms block migration",
+		"openapi.yaml:12:9: OpenAPI property ed25519PublicKey carries classical ED25519 key material; wire formats that bake in classical algorithms block migration",
+	}
+	if !slices.Equal(got, want) {
+		t.Errorf("got findings\n%q\nwant\n%q", got, want)
+	}
+}
@@ -0,0 +1,173 @@
+// Package selftest runs the analyzer over an embedded corpus of
+// known-vulnerable code and checks that every rule fires, so operators can
+// tell that a deployed binary, and the rules database it uses, work before
+// trusting a clean report.
+package selftest
+
+import (
+	"embed"
+	"fmt"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/ahan-adelaide/pqc-analyzer/analyzer"
+	"github.com/ahan-adelaide/pqc-analyzer/report"
+	"github.com/ahan-adelaide/pqc-analyzer/scan"
+)
+
+//go:embed testdata/corpus
+var corpus embed.FS
+
+// Root of the corpus in the embedded files.
+const corpusRoot = "testdata/corpus"
+
+// Modules stubbing the third-party libraries the corpus uses, each in the
+// directory of its path below third_party. Their manifests are written when
+// the corpus is extracted, since embedded directories cannot hold modules.
+var stubModules = []string{
+	"aidanwoods.dev/go-paseto",
+	"github.com/SherClockHolmes/webpush-go",
+	"github.com/bwmarrin/discordgo",
+	"github.com/go-piv/piv-go",
+	"github.com/google/go-attestation",
+	"github.com/herumi/bls-eth-go-binary",
+	"github.com/jedisct1/go-minisign",
+	"github.com/miekg/dns",
+	"github.com/ory/fosite",
+	"github.com/roasbeef/go-go-gadget-paillier",
+	"github.com/russellhaering/goxmldsig",
+	"github.com/spiffe/go-spiffe/v2",
+	"github.com/theupdateframework/go-tuf",
+	"go.mau.fi/libsignal",
+	"go.step.sm/crypto",
+	"golang.org/x/crypto",
+	"google.golang.org/api",
+	"k8s.io/client-go",
+}
+
+// Wrappers of the corpus, added to those of the deployment so the rules of
+// configured wrappers fire.
+var corpusWrappers = []analyzer.Wrapper{{
+	Function: analyzer.QvFunction{FnName: "Sign", Package: "corpus/internal/signing"},
+	Wraps:    analyzer.QvFunction{FnName: "SignPSS", Package: "crypto/rsa"},
+}}
+
+// Go version of the corpus module. It makes the standard library replacements
+// of crypto/ecdh available.
+const corpusGoVersion = "1.24"
+
+// Directory and Go version of the second main module of the corpus, scanned
+// on its own, whose go directive keeps crypto/tls without hybrid key
+// exchange.
+const (
+	toolchainModule    = "toolchain"
+	toolchainGoVersion = "1.23"
+)
+
+// Result is the outcome of the self-test for one rule.
+type Result struct {
+	Rule analyzer.Rule
+	// Number of findings of the rule in the corpus, in any section of the
+	// report.
+	Findings int
+}
+
+// Passed reports whether the rule fired.
+func (r Result) Passed() bool {
+	return r.Findings > 0
+}
+
+// Run analyzes the corpus and returns the results of every built-in rule,
+// ordered by ID. The options are those of the deployment under test, such as the
+// wrappers of its rules database; the packages, providers, schemas and key
+// files to analyze are those of the corpus, and its wrappers are added.
+func Run(opts scan.Options) ([]Result, error) {
+	dir, err := os.MkdirTemp("", "pqc-analyzer-selftest-")
+	if err != nil {
+		return nil, fmt.Errorf("failed to create corpus directory: %s", err.Error())
+	}
+	defer os.RemoveAll(dir)
+	if err := extract(dir); err != nil {
+		return nil, err
+	}
+
+	opts.Patterns = []string{"./..."}
+	opts.Builds = nil
+	opts.Providers = []string{"crypto/..."}
+	opts.Wrappers = slices.Concat(opts.Wrappers, corpusWrappers)
+	opts.Schemas = true
+	opts.KeyFiles = true
+	counts := make(map[string]int)
+	for _, moduleDir := range []string{dir, filepath.Join(dir, toolchainModule)} {
+		opts.Dir = moduleDir
+		rep, err := scan.Run(opts)
+		if err != nil {
+			return nil, fmt.Errorf("failed to analyze corpus: %s", err.Error())
+		}
+		for _, section := range [][]report.Finding{rep.Findings, rep.InteropDebt, rep.Inventory, rep.APISurface} {
+			for _, finding := range section {
+				counts[finding.RuleID]++
+			}
+		}
+	}
+	var results []Result
+	for _, rule := range analyzer.Rules() {
+		// The corpus only exercises the built-in rules.
+		if rule.Detector != "" {
+			continue
+		}
+		results = append(results, Result{Rule: rule, Findings: counts[rule.ID]})
+	}
+	return results, nil
+}
+
+// Writes the corpus and the manifests of its modules to dir.
+func extract(dir string) error {
+	root, err := fs.Sub(corpus, corpusRoot)
+	if err != nil {
+		return fmt.Errorf("failed to read corpus: %s", err.Error())
+	}
+	if err := os.CopyFS(dir, root); err != nil {
+		return fmt.Errorf("failed to extract corpus: %s", err.Error())
+	}
+
+	var manifest strings.Builder
+	fmt.Fprintf(&manifest, "module corpus\n\ngo %s\n\nrequire (\n", corpusGoVersion)
+	for _, module := range stubModules {
+		fmt.Fprintf(&manifest, "\t%s %s\n", module, stubVersion(module))
+	}
+	manifest.WriteString(")\n")
+	for _, module := range stubModules {
+		fmt.Fprintf(&manifest, "\nreplace %s => ./third_party/%s\n", module, module)
+
+		stub := fmt.Sprintf("module %s\n\ngo %s\n", module, corpusGoVersion)
+		if err := os.WriteFile(filepath.Join(dir, "third_party", module, "go.mod"), []byte(stub), 0o644); err != nil {
+			return fmt.Errorf("failed to extract corpus: %s", err.Error())
+		}
+	}
+	if err := os.WriteFile(filepath.Join(dir, "go.mod"), []byte(manifest.String()), 0o644); err != nil {
+		return fmt.Errorf("failed to extract corpus: %s", err.Error())
+	}
+
+	toolchain := fmt.Sprintf("module corpus/%s\n\ngo %s\n", toolchainModule, toolchainGoVersion)
+	if err := os.WriteFile(filepath.Join(dir, toolchainModule, "go.mod"), []byte(toolchain), 0o644); err != nil {
+		return fmt.Errorf("failed to extract corpus: %s", err.Error())
+	}
+	return nil
+}
+
+// Returns the version the corpus requires a stub module at: v0.0.0, or the
+// first release of its major version for paths ending in one, such as v2.0.0
+// for a /v2 path.
+func stubVersion(module string) string {
+	major := path.Base(module)
+	if n, err := strconv.Atoi(strings.TrimPrefix(major, "v")); err == nil && strings.HasPrefix(major, "v") && n >= 2 {
+		return major + ".0.0"
+	}
+	return "v0.0.0"
+}
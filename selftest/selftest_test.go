@@ -0,0 +1,20 @@
+package selftest_test
+
+import (
+	"testing"
+
+	"github.com/ahan-adelaide/pqc-analyzer/scan"
+	"github.com/ahan-adelaide/pqc-analyzer/selftest"
+)
+
+func TestRun(t *testing.T) {
+	results, err := selftest.Run(scan.Options{})
+	if err != nil {
+		t.Fatalf("self-test failed: %s", err.Error())
+	}
+	for _, result := range results {
+		if !result.Passed() {
+			t.Errorf("rule %s %s did not fire on the corpus", result.Rule.ID, result.Rule.Name)
+		}
+	}
+}
@@ -0,0 +1,9 @@
+package corpus
+
+import "unsafe" // PQC050
+
+func xorWords(dst, src []byte) {
+	for i := 0; i+8 <= len(src); i += 8 {
+		*(*uint64)(unsafe.Pointer(&dst[i])) ^= *(*uint64)(unsafe.Pointer(&src[i]))
+	}
+}
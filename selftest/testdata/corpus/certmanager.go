@@ -0,0 +1,10 @@
+package corpus
+
+type certificatePrivateKey struct {
+	Algorithm string `json:"algorithm"`
+	Size      int    `json:"size"`
+}
+
+func operatorKey() certificatePrivateKey {
+	return certificatePrivateKey{Algorithm: "RSA", Size: 2048} // PQC039
+}
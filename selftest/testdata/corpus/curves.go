@@ -0,0 +1,11 @@
+package corpus
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+)
+
+func generateSmallKey() (*ecdsa.PrivateKey, error) {
+	return ecdsa.GenerateKey(elliptic.P224(), rand.Reader) // PQC045
+}
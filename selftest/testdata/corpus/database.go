@@ -0,0 +1,8 @@
+package corpus
+
+import "database/sql"
+
+func storeCardNumber(db *sql.DB, holder, number, publicKey string) error {
+	_, err := db.Exec("INSERT INTO cards (holder, number) VALUES ($1, pgp_pub_encrypt($2, dearmor($3)))", holder, number, publicKey) // PQC061
+	return err
+}
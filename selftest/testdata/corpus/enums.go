@@ -0,0 +1,11 @@
+package corpus
+
+import (
+	"crypto/rsa"
+
+	"corpus/internal/signing"
+)
+
+func signRelease(key *rsa.PrivateKey, release []byte) ([]byte, error) {
+	return signing.Sign(signing.Config{Algorithm: signing.AlgRSA3072}, key, release) // PQC056
+}
@@ -0,0 +1,7 @@
+package corpus
+
+import "net/http"
+
+func serveKeys(mux *http.ServeMux, keys http.Handler) {
+	mux.Handle("/.well-known/jwks.json", keys) // PQC033
+}
@@ -0,0 +1,26 @@
+// Package signing is an internal crypto wrapper, configured as wrapping
+// rsa.SignPSS when the corpus is analyzed.
+package signing
+
+import (
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+)
+
+type Algorithm int
+
+const (
+	AlgRSA3072 Algorithm = iota
+	AlgMLDSA65
+)
+
+type Config struct {
+	Algorithm Algorithm
+}
+
+func Sign(cfg Config, key *rsa.PrivateKey, message []byte) ([]byte, error) {
+	digest := sha256.Sum256(message)
+	return rsa.SignPSS(rand.Reader, key, crypto.SHA256, digest[:], nil)
+}
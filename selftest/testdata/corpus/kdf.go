@@ -0,0 +1,15 @@
+package corpus
+
+import (
+	"crypto/ecdh"
+	"crypto/hkdf"
+	"crypto/sha256"
+)
+
+func sessionKey(priv *ecdh.PrivateKey, peer *ecdh.PublicKey) ([]byte, error) {
+	shared, err := priv.ECDH(peer)
+	if err != nil {
+		return nil, err
+	}
+	return hkdf.Key(sha256.New, shared, nil, "session", 32) // PQC041
+}
@@ -0,0 +1,71 @@
+// Package corpus is known-vulnerable code, exercising every rule of the
+// analyzer. Each snippet is marked with the rule it triggers.
+package corpus
+
+import (
+	"crypto"
+	"crypto/ecdh"  // PQC020
+	"crypto/ecdsa" // PQC001
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/rsa" // PQC002
+	"crypto/x509"
+	"encoding/asn1"
+	"log"
+	"math/big"
+)
+
+// PQC030
+func Sign(key *rsa.PrivateKey, digest []byte) ([]byte, error) {
+	return rsa.SignPKCS1v15(rand.Reader, key, crypto.SHA256, digest) // PQC003
+}
+
+func signEd25519(key ed25519.PrivateKey, message []byte) []byte {
+	return ed25519.Sign(key, message) // PQC012
+}
+
+func verifyEd25519(key ed25519.PublicKey, message, sig []byte) bool {
+	return ed25519.Verify(key, message, sig) // PQC013
+}
+
+func debug(key *ecdsa.PrivateKey) {
+	log.Printf("loaded key %v", key) // PQC011
+}
+
+func exchange() (*ecdh.PrivateKey, error) {
+	return ecdh.X25519().GenerateKey(rand.Reader)
+}
+
+// PQC008
+var oidPublicKeyRSA = asn1.ObjectIdentifier{1, 2, 840, 113549, 1, 1, 1}
+
+// PQC009
+const modp768 = "FFFFFFFFFFFFFFFFC90FDAA22168C234C4C6628B80DC1CD129024E088A67CC74020BBEA63B139B22514A08798E3404DDEF9519B3CD3A431B302B0A6DF25F14374FE1356D6D51C245E485B576625E7EC6F44C42E9A63A3620FFFFFFFFFFFFFFFF"
+
+func encrypt(m, e, n *big.Int) *big.Int {
+	return new(big.Int).Exp(m, e, n) // PQC022
+}
+
+func sign[K crypto.Signer](key K, digest []byte) ([]byte, error) {
+	return key.Sign(rand.Reader, digest, crypto.SHA256)
+}
+
+func signECDSA(key *ecdsa.PrivateKey, digest []byte) ([]byte, error) {
+	return sign(key, digest) // PQC018
+}
+
+func keyAlgorithm(cert *x509.Certificate) string {
+	switch cert.PublicKeyAlgorithm { // PQC019, PQC032
+	case x509.RSA:
+		return "RSA"
+	}
+	return ""
+}
+
+// PQC026
+func request(key crypto.Signer) ([]byte, error) {
+	return x509.CreateCertificateRequest(rand.Reader, &x509.CertificateRequest{}, key)
+}
+
+// PQC027
+const tinkKeyType = "type.googleapis.com/google.crypto.tink.RsaSsaPssPrivateKey"
@@ -0,0 +1,8 @@
+//go:build legacy
+
+// PQC031
+package corpus
+
+import "crypto/dsa"
+
+var _ *dsa.PrivateKey
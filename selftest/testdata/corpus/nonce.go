@@ -0,0 +1,15 @@
+package corpus
+
+import (
+	"bytes"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+)
+
+func signReproducibly(priv *ecdsa.PrivateKey, digest []byte) ([]byte, error) {
+	return ecdsa.SignASN1(bytes.NewReader(digest), priv, digest) // PQC043
+}
+
+func fixtureKey(seed []byte) (*ecdsa.PrivateKey, error) {
+	return ecdsa.GenerateKey(elliptic.P256(), bytes.NewReader(seed)) // PQC059
+}
@@ -0,0 +1,15 @@
+package corpus
+
+import (
+	"crypto/rsa"
+	"encoding/json"
+)
+
+type storedAccount struct {
+	Name string
+	Key  *rsa.PrivateKey
+}
+
+func saveAccount(account storedAccount) ([]byte, error) {
+	return json.Marshal(account) // PQC042
+}
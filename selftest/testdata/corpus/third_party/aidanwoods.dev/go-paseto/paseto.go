@@ -0,0 +1,5 @@
+package paseto
+
+type V4AsymmetricSecretKey struct{}
+
+func NewV4AsymmetricSecretKey() V4AsymmetricSecretKey { return V4AsymmetricSecretKey{} }
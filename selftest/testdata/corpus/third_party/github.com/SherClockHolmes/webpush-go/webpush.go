@@ -0,0 +1,5 @@
+package webpush
+
+func GenerateVAPIDKeys() (privateKey, publicKey string, err error) {
+	return "", "", nil
+}
@@ -0,0 +1,8 @@
+package discordgo
+
+import (
+	"crypto/ed25519"
+	"net/http"
+)
+
+func VerifyInteraction(r *http.Request, key ed25519.PublicKey) bool { return false }
@@ -0,0 +1,9 @@
+package piv
+
+type Algorithm int
+
+const AlgorithmEC256 Algorithm = 1
+
+type Key struct {
+	Algorithm Algorithm
+}
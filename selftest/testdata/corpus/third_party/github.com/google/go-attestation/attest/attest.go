@@ -0,0 +1,10 @@
+package attest
+
+type Algorithm string
+
+const RSA Algorithm = "RSA"
+
+type KeyConfig struct {
+	Algorithm Algorithm
+	Size      int
+}
@@ -0,0 +1,7 @@
+package bls
+
+type SecretKey struct{}
+
+type Sign struct{}
+
+func (sec *SecretKey) SignByte(msg []byte) *Sign { return &Sign{} }
@@ -0,0 +1,9 @@
+package dns
+
+const ECDSAP256SHA256 uint8 = 13
+
+type DNSKEY struct {
+	Flags     uint16
+	Protocol  uint8
+	Algorithm uint8
+}
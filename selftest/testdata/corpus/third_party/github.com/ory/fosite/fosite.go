@@ -0,0 +1,5 @@
+package fosite
+
+type Config struct{}
+
+type OAuth2Provider interface{}
@@ -0,0 +1,7 @@
+package paillier
+
+import "io"
+
+type PrivateKey struct{}
+
+func GenerateKey(random io.Reader, bits int) (*PrivateKey, error) { return &PrivateKey{}, nil }
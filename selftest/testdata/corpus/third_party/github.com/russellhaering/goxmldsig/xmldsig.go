@@ -0,0 +1,15 @@
+package dsig
+
+type X509KeyStore interface{}
+
+type SigningContext struct{}
+
+func NewDefaultSigningContext(ks X509KeyStore) *SigningContext {
+	return nil
+}
+
+const RSASHA256SignatureMethod = "http://www.w3.org/2001/04/xmldsig-more#rsa-sha256"
+
+func (ctx *SigningContext) SetSignatureMethod(algorithmID string) error {
+	return nil
+}
@@ -0,0 +1,11 @@
+package x509svid
+
+import (
+	"crypto"
+	"crypto/x509"
+)
+
+type SVID struct {
+	Certificates []*x509.Certificate
+	PrivateKey   crypto.Signer
+}
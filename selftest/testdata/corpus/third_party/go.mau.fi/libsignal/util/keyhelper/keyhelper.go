@@ -0,0 +1,5 @@
+package keyhelper
+
+type IdentityKeyPair struct{}
+
+func GenerateIdentityKeyPair() (*IdentityKeyPair, error) { return &IdentityKeyPair{}, nil }
@@ -0,0 +1,5 @@
+package knownhosts
+
+import "golang.org/x/crypto/ssh"
+
+func New(files ...string) (ssh.HostKeyCallback, error) { return nil, nil }
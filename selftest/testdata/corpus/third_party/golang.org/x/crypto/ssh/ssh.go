@@ -0,0 +1,17 @@
+package ssh
+
+import "net"
+
+type Certificate struct{}
+
+type Signer interface{}
+
+type HostKeyCallback func(hostname string, key []byte) error
+
+func NewCertSigner(cert *Certificate, signer Signer) (Signer, error) { return nil, nil }
+
+func ParsePrivateKey(pemBytes []byte) (Signer, error) { return nil, nil }
+
+type Client struct{}
+
+func (c *Client) Dial(n, addr string) (net.Conn, error) { return nil, nil }
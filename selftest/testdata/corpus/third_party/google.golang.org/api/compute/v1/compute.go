@@ -0,0 +1,5 @@
+package compute
+
+type CustomerEncryptionKey struct {
+	RsaEncryptedKey string
+}
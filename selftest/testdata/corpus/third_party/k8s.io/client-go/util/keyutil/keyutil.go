@@ -0,0 +1,3 @@
+package keyutil
+
+func MakeEllipticPrivateKeyPEM() ([]byte, error) { return nil, nil }
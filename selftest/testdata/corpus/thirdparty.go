@@ -0,0 +1,126 @@
+package corpus
+
+import (
+	"context"
+	"crypto"
+	"crypto/rand"
+	"crypto/x509"
+	"net/http"
+
+	"aidanwoods.dev/go-paseto"
+	"github.com/SherClockHolmes/webpush-go"
+	"github.com/bwmarrin/discordgo"
+	"github.com/go-piv/piv-go/piv"
+	"github.com/google/go-attestation/attest"
+	"github.com/herumi/bls-eth-go-binary/bls"
+	"github.com/jedisct1/go-minisign"
+	"github.com/miekg/dns"
+	"github.com/ory/fosite"
+	"github.com/ory/fosite/compose"
+	paillier "github.com/roasbeef/go-go-gadget-paillier"
+	dsig "github.com/russellhaering/goxmldsig"
+	"github.com/spiffe/go-spiffe/v2/svid/x509svid"
+	"github.com/spiffe/go-spiffe/v2/workloadapi"
+	"github.com/theupdateframework/go-tuf/pkg/keys"
+	"go.mau.fi/libsignal/util/keyhelper"
+	stepkeyutil "go.step.sm/crypto/keyutil"
+	"golang.org/x/crypto/ocsp"
+	"golang.org/x/crypto/pkcs12"
+	"golang.org/x/crypto/ssh" // PQC016
+	"golang.org/x/crypto/ssh/knownhosts"
+	compute "google.golang.org/api/compute/v1"
+	"k8s.io/client-go/util/keyutil"
+)
+
+func certSigner(cert *ssh.Certificate, signer ssh.Signer) (ssh.Signer, error) {
+	return ssh.NewCertSigner(cert, signer) // PQC004
+}
+
+func hostKeys() (ssh.HostKeyCallback, error) {
+	return knownhosts.New("/etc/ssh/ssh_known_hosts") // PQC007
+}
+
+func diskKey(wrapped string) *compute.CustomerEncryptionKey {
+	return &compute.CustomerEncryptionKey{RsaEncryptedKey: wrapped} // PQC006
+}
+
+func updateKey() (minisign.PublicKey, error) {
+	return minisign.NewPublicKey("RWQf6LRCGA9i53mlYecO4IzT51TGPpvWucNSCh1CBM0QTaLn73Y7GFO3") // PQC010
+}
+
+func nodeKey() ([]byte, error) {
+	return keyutil.MakeEllipticPrivateKeyPEM() // PQC014
+}
+
+func tokenKey() paseto.V4AsymmetricSecretKey {
+	return paseto.NewV4AsymmetricSecretKey() // PQC015
+}
+
+func interaction(r *http.Request, key []byte) bool {
+	return discordgo.VerifyInteraction(r, key) // PQC024
+}
+
+func appKeyConfig() *attest.KeyConfig {
+	return &attest.KeyConfig{Algorithm: attest.RSA, Size: 2048} // PQC034
+}
+
+func zoneKey() *dns.DNSKEY {
+	return &dns.DNSKEY{Flags: 257, Protocol: 3, Algorithm: dns.ECDSAP256SHA256} // PQC035
+}
+
+func cardKey() piv.Key {
+	return piv.Key{Algorithm: piv.AlgorithmEC256} // PQC037
+}
+
+func identity() (*keyhelper.IdentityKeyPair, error) {
+	return keyhelper.GenerateIdentityKeyPair() // PQC038
+}
+
+func oauthProvider(storage interface{}, key interface{}) fosite.OAuth2Provider {
+	return compose.ComposeAllEnabled(&fosite.Config{}, storage, key) // PQC044
+}
+
+func pushKeys() (string, string, error) {
+	return webpush.GenerateVAPIDKeys() // PQC047
+}
+
+func bundle(pfx []byte) (int, error) {
+	blocks, err := pkcs12.ToPEM(pfx, "changeit") // PQC048
+	return len(blocks), err
+}
+
+func workloadIdentity(ctx context.Context) (*x509svid.SVID, error) {
+	return workloadapi.FetchX509SVID(ctx) // PQC049
+}
+
+func rootKey() (keys.Signer, error) {
+	return keys.GenerateEd25519Key() // PQC051
+}
+
+func xmlSigner(ks dsig.X509KeyStore) *dsig.SigningContext {
+	return dsig.NewDefaultSigningContext(ks) // PQC053
+}
+
+func xmlSignatureMethod(ctx *dsig.SigningContext) error {
+	return ctx.SetSignatureMethod(dsig.RSASHA256SignatureMethod) // PQC054
+}
+
+func defaultSignature(digest []byte) ([]byte, error) {
+	signer, err := stepkeyutil.GenerateDefaultSigner()
+	if err != nil {
+		return nil, err
+	}
+	return signer.Sign(rand.Reader, digest, crypto.SHA256) // PQC055
+}
+
+func ocspResponse(issuer *x509.Certificate, key crypto.Signer, template ocsp.Response) ([]byte, error) {
+	return ocsp.CreateResponse(issuer, issuer, template, key) // PQC057
+}
+
+func attestBLS(key *bls.SecretKey, root []byte) *bls.Sign {
+	return key.SignByte(root) // PQC060
+}
+
+func tallyKey() (*paillier.PrivateKey, error) {
+	return paillier.GenerateKey(rand.Reader, 2048) // PQC062
+}
@@ -0,0 +1,29 @@
+//go:build go1.21
+
+package corpus
+
+import (
+	"crypto/fips140" // PQC005
+	"crypto/tls"     // PQC021
+	"os/exec"
+)
+
+func serverConfig() (*tls.Config, error) {
+	cert, err := tls.LoadX509KeyPair("server.crt", "server.key") // PQC025
+	if err != nil {
+		return nil, err
+	}
+	return &tls.Config{
+		Certificates:       []tls.Certificate{cert},
+		MaxVersion:         tls.VersionTLS12,                 // PQC028
+		ClientSessionCache: tls.NewLRUClientSessionCache(64), // PQC017
+	}, nil
+}
+
+func fips() bool {
+	return fips140.Enabled()
+}
+
+func generate() error {
+	return exec.Command("openssl", "genrsa", "-out", "key.pem", "4096").Run() // PQC029
+}
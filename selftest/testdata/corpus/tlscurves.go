@@ -0,0 +1,13 @@
+package corpus
+
+import (
+	"crypto/ecdh" // PQC040
+	"crypto/tls"
+)
+
+func tlsCurve(config *tls.Config) ecdh.Curve {
+	if len(config.CurvePreferences) > 0 && config.CurvePreferences[0] == tls.CurveP384 {
+		return ecdh.P384()
+	}
+	return ecdh.X25519()
+}
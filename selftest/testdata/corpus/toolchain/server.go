@@ -0,0 +1,8 @@
+// PQC046
+package toolchain
+
+import "net/http"
+
+func serve(handler http.Handler) error {
+	return http.ListenAndServeTLS(":443", "cert.pem", "key.pem", handler)
+}
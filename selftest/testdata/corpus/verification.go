@@ -0,0 +1,10 @@
+package corpus
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+)
+
+func pinnedRoots(roots *x509.CertPool) *tls.Config {
+	return &tls.Config{RootCAs: roots} // PQC036
+}